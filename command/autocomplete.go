@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"unicode/utf8"
 )
 
 // Completion is the object constructed by a completer.
@@ -17,6 +18,10 @@ type Completion struct {
 	DontComplete bool
 	// CaseInsensitiveSort returns whether or not we should sort irrespective of case.
 	CaseInsensitiveSort bool
+	// PreserveOrder indicates whether the suggestions should be returned in the
+	// order they were provided (rather than being sorted). This is useful for
+	// completers that already rank their suggestions (e.g. by history or relevance).
+	PreserveOrder bool
 	// CaseInsensitve is whether or not case should be considered when filtering out suggestions.
 	CaseInsensitive bool
 	// Distinct is whether or not we should return only distinct suggestions (specifically to prevent duplicates in list arguments).
@@ -27,6 +32,22 @@ type Completion struct {
 	// SpacelessCompletion indicates that a space should *not* be added (which happens
 	// automatically if there is only one completion suggestion).
 	SpacelessCompletion bool
+	// AutofillCommonPrefix indicates that, if all of the remaining suggestions
+	// share a prefix that is longer than the argument being completed, then that
+	// prefix should be autofilled (without a trailing space) instead of
+	// displaying all of the suggestions.
+	AutofillCommonPrefix bool
+	// Hint is a descriptive, non-insertable notice (e.g. `(2 more)`) that is
+	// added to the suggestions. Since a hint is its own suggestion, it is only
+	// added if there are multiple suggestions (otherwise, it would either be
+	// inserted by the shell or prevent a single suggestion from being inserted).
+	Hint string
+	// MaxSuggestions is the maximum number of suggestions to return. If there are
+	// more suggestions than this, then the list is truncated and a non-insertable
+	// notice (e.g. `(+42 more)`) is added. If zero, the default set by the
+	// `commander.DefaultMaxSuggestions` processor is used (if any). If negative,
+	// suggestions are never truncated.
+	MaxSuggestions int
 	// DeferredCompletion will *execute* another graph before generating the actual
 	// completion object.
 	DeferredCompletion *DeferredCompletion
@@ -49,9 +70,13 @@ func (c *Completion) Clone() *Completion {
 		c.IgnoreFilter,
 		c.DontComplete,
 		c.CaseInsensitiveSort,
+		c.PreserveOrder,
 		c.CaseInsensitive,
 		c.Distinct,
 		c.SpacelessCompletion,
+		c.AutofillCommonPrefix,
+		c.Hint,
+		c.MaxSuggestions,
 		c.DeferredCompletion,
 	}
 }
@@ -68,11 +93,42 @@ type Autocompletion struct {
 
 // ProcessInput processes a `Completion` object against a given `Input` object.
 func (c *Completion) ProcessInput(input *Input) []string {
-	var lastArg string
+	return c.Process(lastInputArg(input), input.si.Delimiter, false)
+}
+
+// Autocompletion processes a `Completion` object against a given `Input` object
+// and returns the resulting `Autocompletion`.
+func (c *Completion) Autocompletion(input *Input) *Autocompletion {
+	suggestions := c.ProcessInput(input)
+	if c.AutofillCommonPrefix && len(suggestions) > 1 {
+		if prefix := commonPrefix(suggestions); len(prefix) > len(lastInputArg(input)) {
+			return &Autocompletion{[]string{prefix}, true}
+		}
+	}
+	return &Autocompletion{suggestions, c.SpacelessCompletion}
+}
+
+func lastInputArg(input *Input) string {
 	if input != nil && len(input.si.Args) > 0 {
-		lastArg = input.si.Args[len(input.si.Args)-1].Value
+		return input.si.Args[len(input.si.Args)-1].Value
 	}
-	return c.Process(lastArg, input.si.Delimiter, false)
+	return ""
+}
+
+// commonPrefix returns the longest prefix shared by all of the provided strings.
+func commonPrefix(strs []string) string {
+	prefix := strs[0]
+	for _, s := range strs[1:] {
+		i := 0
+		for ; i < len(prefix) && i < len(s) && prefix[i] == s[i]; i++ {
+		}
+		prefix = prefix[:i]
+	}
+	// Don't split a multi-byte character.
+	for !utf8.ValidString(prefix) {
+		prefix = prefix[:len(prefix)-1]
+	}
+	return prefix
 }
 
 // process processes a `Completion` object using the provided `lastArg` and `delimiter`.
@@ -96,7 +152,9 @@ func (c *Completion) Process(lastArg string, delimiter *rune, skipDelimiter bool
 		results = filteredOpts
 	}
 
-	if c.CaseInsensitiveSort {
+	if c.PreserveOrder {
+		// Don't sort
+	} else if c.CaseInsensitiveSort {
 		sort.SliceStable(results, func(i, j int) bool {
 			return strings.ToLower(results[i]) < strings.ToLower(results[j])
 		})
@@ -116,8 +174,26 @@ func (c *Completion) Process(lastArg string, delimiter *rune, skipDelimiter bool
 		}
 	}
 
+	results = c.truncate(results)
+
+	if c.Hint != "" && len(results) > 1 {
+		results = append(results, c.Hint)
+	}
+
 	if c.DontComplete {
 		results = append(results, " ")
 	}
 	return results
 }
+
+// truncate limits the number of suggestions based on `MaxSuggestions` and
+// adds a notice if any suggestions were removed.
+func (c *Completion) truncate(results []string) []string {
+	max := c.MaxSuggestions
+	if max <= 0 || len(results) <= max {
+		return results
+	}
+	// Since the notice is its own suggestion, the shell won't insert it (nor
+	// a partial completion based on only the suggestions that are shown).
+	return append(results[:max:max], fmt.Sprintf("(+%d more)", len(results)-max))
+}
@@ -15,6 +15,10 @@ func TestClone(t *testing.T) {
 		true,
 		true,
 		true,
+		true,
+		true,
+		"(2 more)",
+		5,
 		&DeferredCompletion{},
 	}
 
@@ -25,8 +29,78 @@ func TestClone(t *testing.T) {
 	if !d.Distinct {
 		t.Fatalf("Completion.Clone() resulted in objects that point to same Distinct value")
 	}
+	c.PreserveOrder = false
+	if !d.PreserveOrder {
+		t.Fatalf("Completion.Clone() resulted in objects that point to same PreserveOrder value")
+	}
 	d.DontComplete = false
 	if !c.DontComplete {
 		t.Fatalf("Completion.Clone() resulted in objects that point to same DontComplete value")
 	}
 }
+
+func TestMaxSuggestions(t *testing.T) {
+	abc := []string{"a", "b", "c", "d", "e"}
+	for _, test := range []struct {
+		name    string
+		c       *Completion
+		lastArg string
+		want    []string
+	}{
+		{
+			name: "returns all suggestions by default",
+			c:    &Completion{Suggestions: abc},
+			want: []string{"a", "b", "c", "d", "e"},
+		},
+		{
+			name: "truncates at completion cap",
+			c: &Completion{
+				Suggestions:    abc,
+				MaxSuggestions: 3,
+			},
+			want: []string{"a", "b", "c", "(+2 more)"},
+		},
+		{
+			name: "doesn't truncate when at the cap",
+			c: &Completion{
+				Suggestions:    abc,
+				MaxSuggestions: 5,
+			},
+			want: []string{"a", "b", "c", "d", "e"},
+		},
+		{
+			name: "truncates after filtering",
+			c: &Completion{
+				Suggestions:    []string{"one", "two", "three", "ten", "twenty", "thirty"},
+				MaxSuggestions: 2,
+			},
+			lastArg: "t",
+			want:    []string{"ten", "thirty", "(+3 more)"},
+		},
+		{
+			name: "negative completion cap disables truncation",
+			c: &Completion{
+				Suggestions:    abc,
+				MaxSuggestions: -1,
+			},
+			want: []string{"a", "b", "c", "d", "e"},
+		},
+		{
+			name: "truncates before DontComplete suggestion is added",
+			c: &Completion{
+				Suggestions:    abc,
+				MaxSuggestions: 2,
+				DontComplete:   true,
+			},
+			want: []string{"a", "b", "(+3 more)", " "},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			original := append([]string{}, test.c.Suggestions...)
+
+			got := test.c.Process(test.lastArg, nil, false)
+			testutil.Cmp(t, "Completion.Process() returned incorrect suggestions", test.want, got)
+			testutil.Cmp(t, "Completion.Process() modified the original suggestions", original, test.c.Suggestions)
+		})
+	}
+}
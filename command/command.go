@@ -47,4 +47,7 @@ type ExecuteData struct {
 	// FunctionWrap is whether or not to wrap the Executable contents
 	// in a function. This allows Executable to use things like "return" and "local".
 	FunctionWrap bool
+	// Cleanup is a set of functions to run (in reverse order) after execution
+	// has completed, regardless of whether or not an error occurred.
+	Cleanup []func() error
 }
@@ -1,5 +1,11 @@
 package command
 
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
 type OS interface {
 	// SetEnvVar returns a shell command that sets the environment variable
 	// `envVar` to `value`. Environment variable modifications can't and shouldn't
@@ -16,6 +22,16 @@ type OS interface {
 	// ShellCommandFileRunner(file string) (string, []string)
 }
 
+const (
+	// DeadlineKey is the key used to store the deadline returned by
+	// `Data.Deadline` (e.g. by the `commander.DeadlineFlag`).
+	DeadlineKey = "COMMAND_DEADLINE"
+
+	// ArgvKey is the key used to store the raw command line arguments
+	// (e.g. by the `commander.AuditArgv` processor).
+	ArgvKey = "COMMAND_ARGV"
+)
+
 // Data contains argument data.
 type Data struct {
 	// Values is a map from argument name to the data for that argument.
@@ -25,6 +41,42 @@ type Data struct {
 	// OS is the current operating system. It is primarily used to execute
 	// run logic in the parent shell (e.g. setting/unsetting environment variables)
 	OS OS
+
+	// metrics are the metrics recorded with `RecordMetric` (in the order in
+	// which they were recorded).
+	metrics []*Metric
+	// reads is the set of keys whose reads are being tracked (see `TrackReads`),
+	// mapped to whether or not they have been read.
+	reads map[string]bool
+	// defaults is the set of keys whose values are defaults (see `MarkDefault`).
+	defaults map[string]bool
+}
+
+// Metric is a named duration recorded with `Data.RecordMetric`.
+type Metric struct {
+	Name     string
+	Duration time.Duration
+}
+
+// RecordMetric records a named duration (e.g. how long a step of the command
+// took). Recorded metrics are returned by `Metrics` and are summarized by
+// the `commander.MetricsCollector` processor.
+func (d *Data) RecordMetric(name string, duration time.Duration) {
+	d.metrics = append(d.metrics, &Metric{name, duration})
+}
+
+// Metrics returns the metrics recorded with `RecordMetric` (in the order in
+// which they were recorded).
+func (d *Data) Metrics() []*Metric {
+	return d.metrics
+}
+
+// Deadline returns the deadline stored in the `Data` object (e.g. by the
+// `commander.DeadlineFlag`) and whether or not a deadline was set. Deadlines
+// are cooperative, so long-running executors are responsible for checking it.
+func (d *Data) Deadline() (time.Time, bool) {
+	t, ok := GetData[interface{}](d, DeadlineKey).(time.Time)
+	return t, ok
 }
 
 // Set sets the provided key-value pair in the `Data` object.
@@ -33,12 +85,52 @@ func (d *Data) Set(k string, i interface{}) {
 		d.Values = map[string]interface{}{}
 	}
 	d.Values[k] = i
+	delete(d.defaults, k)
+}
+
+// MarkDefault marks the value for the provided key as a default value (i.e.
+// one that wasn't explicitly provided). The mark is removed the next time the
+// key is set with `Set`.
+func (d *Data) MarkDefault(k string) {
+	if d.defaults == nil {
+		d.defaults = map[string]bool{}
+	}
+	d.defaults[k] = true
+}
+
+// IsDefault returns whether or not the value for the provided key is a default
+// value (see `MarkDefault`).
+func (d *Data) IsDefault(k string) bool {
+	return d.defaults[k]
+}
+
+// TrackReads starts tracking whether or not the values for the provided keys
+// are read (via `GetData` or any of the typed getters that use it).
+func (d *Data) TrackReads(keys ...string) {
+	if d.reads == nil {
+		d.reads = map[string]bool{}
+	}
+	for _, k := range keys {
+		d.reads[k] = false
+	}
+}
+
+// WasRead returns whether or not the value for the provided key has been read
+// since `TrackReads` was called for it.
+func (d *Data) WasRead(k string) bool {
+	return d.reads[k]
 }
 
 // GetData fetches the value for a given key.
 func GetData[T any](d *Data, key string) T {
 	var ret T
-	if d == nil || d.Values == nil {
+	if d == nil {
+		return ret
+	}
+	if _, ok := d.reads[key]; ok {
+		d.reads[key] = true
+	}
+	if d.Values == nil {
 		return ret
 	}
 	i, ok := d.Values[key]
@@ -48,12 +140,46 @@ func GetData[T any](d *Data, key string) T {
 	return i.(T)
 }
 
+// GetList fetches the `[]string` value for a given key and converts each
+// element with the provided `conv` function. If any element fails to convert,
+// then an error indicating which element failed is returned.
+// If the key isn't set, then a nil slice is returned.
+func GetList[T any](d *Data, key string, conv func(string) (T, error)) ([]T, error) {
+	sl := GetData[[]string](d, key)
+	if sl == nil {
+		return nil, nil
+	}
+
+	r := make([]T, 0, len(sl))
+	for i, s := range sl {
+		v, err := conv(s)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert element %d (%q) of %q: %v", i, s, key, err)
+		}
+		r = append(r, v)
+	}
+	return r, nil
+}
+
 // Has returns whether or not key has been set in the `Data` object.
 func (d *Data) Has(k string) bool {
 	_, ok := d.Values[k]
 	return ok
 }
 
+// Keys returns the sorted list of keys that are set in the `Data` object.
+func (d *Data) Keys() []string {
+	if d == nil {
+		return nil
+	}
+	keys := make([]string, 0, len(d.Values))
+	for k := range d.Values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // Removed Regexp. Callers are responsible for storing that
 
 // String returns the string data for an argument.
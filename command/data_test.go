@@ -2,7 +2,9 @@ package command
 
 import (
 	"fmt"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/leep-frog/command/internal/testutil"
 )
@@ -20,6 +22,68 @@ func TestHasArg(t *testing.T) {
 	}
 }
 
+func TestKeys(t *testing.T) {
+	d := &Data{}
+	testutil.Cmp(t, "Keys() for empty data", []string{}, d.Keys())
+
+	d.Set("charlie", 3)
+	d.Set("alpha", "one")
+	d.Set("bravo", []string{"two"})
+	d.Set("alpha", "uno")
+	testutil.Cmp(t, "Keys() after Set calls", []string{"alpha", "bravo", "charlie"}, d.Keys())
+
+	delete(d.Values, "bravo")
+	testutil.Cmp(t, "Keys() after deletion", []string{"alpha", "charlie"}, d.Keys())
+
+	var nilData *Data
+	testutil.Cmp(t, "Keys() for nil data", nil, nilData.Keys())
+}
+
+func TestRecordMetric(t *testing.T) {
+	d := &Data{}
+	d.RecordMetric("load", 3*time.Second)
+	d.RecordMetric("save", 250*time.Millisecond)
+	d.RecordMetric("load", time.Second)
+	testutil.Cmp(t, "Metrics after RecordMetric calls", []*Metric{
+		{"load", 3 * time.Second},
+		{"save", 250 * time.Millisecond},
+		{"load", time.Second},
+	}, d.Metrics())
+}
+
+func TestDeadline(t *testing.T) {
+	d := &Data{}
+	if got, ok := d.Deadline(); ok {
+		t.Errorf("Deadline() for empty data returned (%v, true); want (zero, false)", got)
+	}
+
+	d.Set(DeadlineKey, "tomorrow")
+	if got, ok := d.Deadline(); ok {
+		t.Errorf("Deadline() for non-time value returned (%v, true); want (zero, false)", got)
+	}
+
+	deadline := time.Date(2024, 3, 5, 12, 30, 0, 0, time.UTC)
+	d.Set(DeadlineKey, deadline)
+	got, ok := d.Deadline()
+	if !ok {
+		t.Errorf("Deadline() returned false; want true")
+	}
+	testutil.Cmp(t, "Deadline() returned incorrect value", deadline, got)
+}
+
+func TestMarkDefault(t *testing.T) {
+	d := &Data{}
+	d.Set("k", "v")
+	testutil.Cmp(t, "IsDefault() after Set", false, d.IsDefault("k"))
+
+	d.MarkDefault("k")
+	testutil.Cmp(t, "IsDefault() after MarkDefault", true, d.IsDefault("k"))
+	testutil.Cmp(t, "IsDefault() for other key", false, d.IsDefault("other"))
+
+	d.Set("k", "w")
+	testutil.Cmp(t, "IsDefault() after overriding Set", false, d.IsDefault("k"))
+}
+
 type getDataTest[T any] struct {
 	d    *Data
 	key  string
@@ -140,6 +204,50 @@ func TestGetData(t *testing.T) {
 	}
 }
 
+func TestGetList(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		d       *Data
+		want    []int
+		wantErr error
+	}{
+		{
+			name: "nil data returns nil",
+		},
+		{
+			name: "missing key returns nil",
+			d:    &Data{Values: map[string]interface{}{}},
+		},
+		{
+			name: "converts empty list",
+			d: &Data{Values: map[string]interface{}{
+				"some-key": []string{},
+			}},
+			want: []int{},
+		},
+		{
+			name: "converts all elements",
+			d: &Data{Values: map[string]interface{}{
+				"some-key": []string{"1", "2"},
+			}},
+			want: []int{1, 2},
+		},
+		{
+			name: "fails if an element can't be converted",
+			d: &Data{Values: map[string]interface{}{
+				"some-key": []string{"1", "two", "3"},
+			}},
+			wantErr: fmt.Errorf(`failed to convert element 1 ("two") of "some-key": strconv.Atoi: parsing "two": invalid syntax`),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := GetList(test.d, "some-key", strconv.Atoi)
+			testutil.CmpError(t, "GetList()", test.wantErr, err)
+			testutil.Cmp(t, "GetList() returned incorrect value", test.want, got)
+		})
+	}
+}
+
 type dataGetTest[T any] struct {
 	d         *Data
 	f         func(*Data) T
@@ -178,6 +178,56 @@ func (i *Input) PushFrontAt(atOffset int, sl ...string) {
 	})
 }
 
+// ReplaceRemaining replaces all of the remaining (i.e. not yet processed)
+// arguments with the provided tokens.
+func (i *Input) ReplaceRemaining(tokens []string) {
+	first := -1
+	var snapshots map[spycommand.InputSnapshot]bool
+	replaced := map[int]bool{}
+	for j, idx := range i.si.Remaining[i.si.Offset:] {
+		if j == 0 {
+			first = idx
+			snapshots = i.si.Args[idx].Snapshots
+		}
+		replaced[idx] = true
+	}
+
+	// Rebuild the args array, inserting the new tokens where the first replaced
+	// arg was (or at the end if nothing remained).
+	var args []*spycommand.InputArg
+	var inserted []int
+	insert := func() {
+		for _, t := range tokens {
+			inserted = append(inserted, len(args))
+			args = append(args, &spycommand.InputArg{
+				Value:     t,
+				Snapshots: maps.Clone(snapshots),
+			})
+		}
+	}
+	newIdx := map[int]int{}
+	for j, arg := range i.si.Args {
+		if j == first {
+			insert()
+		}
+		if replaced[j] {
+			continue
+		}
+		newIdx[j] = len(args)
+		args = append(args, arg)
+	}
+	if first < 0 {
+		insert()
+	}
+
+	remaining := make([]int, 0, i.si.Offset+len(inserted))
+	for _, idx := range i.si.Remaining[:i.si.Offset] {
+		remaining = append(remaining, newIdx[idx])
+	}
+	i.si.Args = args
+	i.si.Remaining = append(remaining, inserted...)
+}
+
 // PeekAt peeks at a specific argument and returns whether or not there are at least that many arguments.
 func (i *Input) PeekAt(idx int) (string, bool) {
 	if idx < 0 || idx >= len(i.si.Remaining) {
@@ -216,6 +266,18 @@ type InputBreaker interface {
 	DiscardBreak(string, *Data) bool
 }
 
+// InputBreakIncluder is an optional interface for `InputBreaker` objects.
+type InputBreakIncluder interface {
+	// IncludeBreak returns whether the value responsible for breaking the input
+	// should be included as the last popped value. This is ignored if `DiscardBreak` returns true.
+	IncludeBreak(string, *Data) bool
+}
+
+func includeBreak(b InputBreaker, s string, d *Data) bool {
+	ib, ok := b.(InputBreakIncluder)
+	return ok && ib.IncludeBreak(s, d)
+}
+
 // PopN pops the next `n` arguments from the input and returns whether or not there are enough arguments left.
 func (i *Input) PopN(n, optN int, breakers []InputBreaker, d *Data) ([]*string, bool) {
 	return i.PopNAt(0, n, optN, breakers, d)
@@ -247,6 +309,10 @@ func (outerInput *Input) PopNAt(atOffset, n, optN int, breakers []InputBreaker,
 				if b.Break(s, d) {
 					broken = true
 					discardBreak = b.DiscardBreak(s, d)
+					if !discardBreak && includeBreak(b, s, d) {
+						ret = append(ret, &i.get(idx+i.si.Offset).Value)
+						idx++
+					}
 					goto LOOP_END
 				}
 			}
@@ -397,14 +463,32 @@ func (qs *quoteState) delimiter() *rune {
 
 func (*quoteState) endState(w *words) {}
 
-// ParseCompLine parses the COMP_LINE value provided by the shell
-func ParseCompLine(compLine string, passthroughArgs ...string) *Input {
+// parseWords splits the provided line into words (respecting quotes and
+// escaped spaces) and returns the final parser state.
+func parseWords(line string) (*words, parserState) {
 	w := &words{}
 	state := parserState(&whitespaceState{})
-	for _, c := range compLine {
+	for _, c := range line {
 		state = state.parseChar(c, w)
 	}
 	state.endState(w)
+	return w, state
+}
+
+// ParseArgs splits the provided line into arguments the same way that
+// `ParseCompLine` does (i.e. quoted values and backslash-escaped spaces are
+// kept in a single argument).
+func ParseArgs(line string) []string {
+	w, _ := parseWords(line)
+	if w.inWord {
+		w.endWord()
+	}
+	return w.words
+}
+
+// ParseCompLine parses the COMP_LINE value provided by the shell
+func ParseCompLine(compLine string, passthroughArgs ...string) *Input {
+	w, state := parseWords(compLine)
 
 	var args []string
 	if w.inWord {
@@ -483,6 +483,133 @@ func TestPushFront(t *testing.T) {
 	}
 }
 
+func TestReplaceRemaining(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		i        *Input
+		tokens   []string
+		want     *Input
+		wantPops []string
+	}{
+		{
+			name: "replaces all args",
+			i: &Input{&spyinput.SpyInput[InputBreaker]{
+				Args:      []*spycommand.InputArg{{Value: "zero"}, {Value: "one"}, {Value: "two"}},
+				Remaining: []int{0, 1, 2},
+			}},
+			tokens: []string{"un", "deux"},
+			want: &Input{&spyinput.SpyInput[InputBreaker]{
+				Args:      []*spycommand.InputArg{{Value: "un"}, {Value: "deux"}},
+				Remaining: []int{0, 1},
+			}},
+			wantPops: []string{"un", "deux"},
+		},
+		{
+			name: "replaces only remaining args",
+			i: &Input{&spyinput.SpyInput[InputBreaker]{
+				Args:      []*spycommand.InputArg{{Value: "zero"}, {Value: "one"}, {Value: "two"}, {Value: "three"}},
+				Remaining: []int{2, 3},
+			}},
+			tokens: []string{"un", "deux", "trois"},
+			want: &Input{&spyinput.SpyInput[InputBreaker]{
+				Args:      []*spycommand.InputArg{{Value: "zero"}, {Value: "one"}, {Value: "un"}, {Value: "deux"}, {Value: "trois"}},
+				Remaining: []int{2, 3, 4},
+			}},
+			wantPops: []string{"un", "deux", "trois"},
+		},
+		{
+			name: "replaces non-contiguous remaining args",
+			i: &Input{&spyinput.SpyInput[InputBreaker]{
+				Args:      []*spycommand.InputArg{{Value: "zero"}, {Value: "one"}, {Value: "two"}, {Value: "three"}, {Value: "four"}},
+				Remaining: []int{1, 3},
+			}},
+			tokens: []string{"un"},
+			want: &Input{&spyinput.SpyInput[InputBreaker]{
+				Args:      []*spycommand.InputArg{{Value: "zero"}, {Value: "un"}, {Value: "two"}, {Value: "four"}},
+				Remaining: []int{1},
+			}},
+			wantPops: []string{"un"},
+		},
+		{
+			name: "removes remaining args if no tokens",
+			i: &Input{&spyinput.SpyInput[InputBreaker]{
+				Args:      []*spycommand.InputArg{{Value: "zero"}, {Value: "one"}, {Value: "two"}},
+				Remaining: []int{1, 2},
+			}},
+			want: &Input{&spyinput.SpyInput[InputBreaker]{
+				Args:      []*spycommand.InputArg{{Value: "zero"}},
+				Remaining: []int{},
+			}},
+		},
+		{
+			name: "adds tokens if no args remaining",
+			i: &Input{&spyinput.SpyInput[InputBreaker]{
+				Args:      []*spycommand.InputArg{{Value: "zero"}, {Value: "one"}},
+				Remaining: []int{},
+			}},
+			tokens: []string{"un", "deux"},
+			want: &Input{&spyinput.SpyInput[InputBreaker]{
+				Args:      []*spycommand.InputArg{{Value: "zero"}, {Value: "one"}, {Value: "un"}, {Value: "deux"}},
+				Remaining: []int{2, 3},
+			}},
+			wantPops: []string{"un", "deux"},
+		},
+		{
+			name: "only replaces args after offset",
+			i: &Input{&spyinput.SpyInput[InputBreaker]{
+				Args:      []*spycommand.InputArg{{Value: "zero"}, {Value: "one"}, {Value: "two"}, {Value: "three"}},
+				Remaining: []int{0, 2, 3},
+				Offset:    1,
+			}},
+			tokens: []string{"un"},
+			want: &Input{&spyinput.SpyInput[InputBreaker]{
+				Args:      []*spycommand.InputArg{{Value: "zero"}, {Value: "one"}, {Value: "un"}},
+				Remaining: []int{0, 2},
+				Offset:    1,
+			}},
+			wantPops: []string{"un"},
+		},
+		{
+			name: "new tokens inherit snapshots",
+			i: &Input{&spyinput.SpyInput[InputBreaker]{
+				Args: []*spycommand.InputArg{
+					{Value: "zero", Snapshots: map[spycommand.InputSnapshot]bool{1: true}},
+					{Value: "one", Snapshots: map[spycommand.InputSnapshot]bool{1: true, 2: true}},
+					{Value: "two", Snapshots: map[spycommand.InputSnapshot]bool{1: true, 2: true}},
+				},
+				Remaining:     []int{1, 2},
+				SnapshotCount: 2,
+			}},
+			tokens: []string{"un", "deux"},
+			want: &Input{&spyinput.SpyInput[InputBreaker]{
+				Args: []*spycommand.InputArg{
+					{Value: "zero", Snapshots: map[spycommand.InputSnapshot]bool{1: true}},
+					{Value: "un", Snapshots: map[spycommand.InputSnapshot]bool{1: true, 2: true}},
+					{Value: "deux", Snapshots: map[spycommand.InputSnapshot]bool{1: true, 2: true}},
+				},
+				Remaining:     []int{1, 2},
+				SnapshotCount: 2,
+			}},
+			wantPops: []string{"un", "deux"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			test.i.ReplaceRemaining(test.tokens)
+			if diff := cmp.Diff(test.want, test.i, cmp.AllowUnexported(Input{}, spycommand.InputArg{})); diff != "" {
+				t.Errorf("i.ReplaceRemaining(%v) resulted in incorrect Input object:\n%s", test.tokens, diff)
+			}
+
+			var gotPops []string
+			for s, ok := test.i.Pop(nil); ok; s, ok = test.i.Pop(nil) {
+				gotPops = append(gotPops, s)
+			}
+			if diff := cmp.Diff(test.wantPops, gotPops); diff != "" {
+				t.Errorf("Pop() after i.ReplaceRemaining(%v) returned incorrect values (-want, +got):\n%s", test.tokens, diff)
+			}
+		})
+	}
+}
+
 func TestPop(t *testing.T) {
 	input := NewInput([]string{
 		"one",
@@ -769,6 +896,41 @@ func TestPopN(t *testing.T) {
 				Remaining: []int{4, 5},
 			}},
 		},
+		{
+			name:  "breaks unbounded list at breaker with include",
+			input: []string{"hello", "there", "person", "how", "are", "you"},
+			optN:  UnboundedList,
+			want:  []string{"hello", "there", "person", "how"},
+			breakers: []InputBreaker{
+				&simpleListBreaker{
+					breakFunc: func(s string, d *Data) bool { return s == "how" },
+					include:   true,
+				},
+			},
+			wantOK: true,
+			wantInput: &Input{&spyinput.SpyInput[InputBreaker]{
+				Args:      []*spycommand.InputArg{{Value: "hello"}, {Value: "there"}, {Value: "person"}, {Value: "how"}, {Value: "are"}, {Value: "you"}},
+				Remaining: []int{4, 5},
+			}},
+		},
+		{
+			name:  "discard takes precedence over include",
+			input: []string{"hello", "there", "person", "how", "are", "you"},
+			optN:  UnboundedList,
+			want:  []string{"hello", "there", "person"},
+			breakers: []InputBreaker{
+				&simpleListBreaker{
+					breakFunc: func(s string, d *Data) bool { return s == "how" },
+					discard:   true,
+					include:   true,
+				},
+			},
+			wantOK: true,
+			wantInput: &Input{&spyinput.SpyInput[InputBreaker]{
+				Args:      []*spycommand.InputArg{{Value: "hello"}, {Value: "there"}, {Value: "person"}, {Value: "how"}, {Value: "are"}, {Value: "you"}},
+				Remaining: []int{4, 5},
+			}},
+		},
 		{
 			name:  "pops all when no ListBreaker breaks",
 			input: []string{"hello", "there", "person", "how", "are", "you"},
@@ -1241,6 +1403,51 @@ func TestParseCompLine(t *testing.T) {
 	}
 }
 
+func TestParseArgs(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name: "handles empty input",
+		},
+		{
+			name:  "handles whitespace input",
+			input: "   ",
+		},
+		{
+			name:  "splits on whitespace",
+			input: " one  two three ",
+			want:  []string{"one", "two", "three"},
+		},
+		{
+			name:  "keeps quoted values together",
+			input: `--name "alice bob" 'c d' e`,
+			want:  []string{"--name", "alice bob", "c d", "e"},
+		},
+		{
+			name:  "keeps empty quoted values",
+			input: `--name ""`,
+			want:  []string{"--name", ""},
+		},
+		{
+			name:  "keeps escaped spaces",
+			input: `one\ two three`,
+			want:  []string{"one two", "three"},
+		},
+		{
+			name:  "handles unterminated quote",
+			input: `one "two three`,
+			want:  []string{"one", "two three"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			testutil.Cmp(t, fmt.Sprintf("ParseArgs(%q) returned incorrect args", test.input), test.want, ParseArgs(test.input))
+		})
+	}
+}
+
 func TestPopAtAndPeekAt(t *testing.T) {
 	for _, test := range []struct {
 		name       string
@@ -1354,6 +1561,7 @@ func snapshotsMap(iss ...spycommand.InputSnapshot) map[spycommand.InputSnapshot]
 type simpleListBreaker struct {
 	breakFunc func(string, *Data) bool
 	discard   bool
+	include   bool
 }
 
 func (slb *simpleListBreaker) Break(s string, d *Data) bool {
@@ -1364,6 +1572,10 @@ func (slb *simpleListBreaker) DiscardBreak(s string, d *Data) bool {
 	return slb.discard
 }
 
+func (slb *simpleListBreaker) IncludeBreak(s string, d *Data) bool {
+	return slb.include
+}
+
 func TestExtraArgsErr(t *testing.T) {
 	ex := ExtraArgsErr(&Input{si: &spyinput.SpyInput[InputBreaker]{
 		Args: []*spycommand.InputArg{
@@ -0,0 +1,201 @@
+package command
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/leep-frog/command/color"
+	"github.com/leep-frog/command/internal/spycommand"
+)
+
+// NewJSONOutput returns an output that buffers all stdout and stderr writes
+// and, when closed, writes a single JSON object (see `JSONResult`) to the
+// provided output's stdout. This is useful for running CLIs in automation
+// where results need to be parsed programmatically.
+//
+// Color changes are ignored so the JSON doesn't contain terminal escape
+// codes. Errors are still returned as usual, so exit statuses are unaffected.
+//
+// Commands commonly fail by returning the error from `Stderr` or `Annotate`
+// functions (which can't be distinguished from warnings), so callers should
+// pass the command's final error to `CloseWithError` rather than calling
+// `Close` directly.
+func NewJSONOutput(o Output) JSONOutput {
+	return &jsonOutput{o: o}
+}
+
+// JSONOutput is the `Output` returned by `NewJSONOutput`.
+type JSONOutput interface {
+	Output
+	// CloseWithError sets the result's error to the provided error (if it
+	// isn't nil) and then closes the output (see `Close`).
+	CloseWithError(err error)
+}
+
+// JSONResult is the object written by the output returned from `NewJSONOutput`.
+type JSONResult struct {
+	// Stdout is the concatenation of all stdout writes.
+	Stdout string `json:"stdout"`
+	// Stderr is the concatenation of all stderr writes.
+	Stderr string `json:"stderr"`
+	// Error is the message of the error passed to `CloseWithError` (or, if
+	// that was nil, the last error passed to `Err` or one of the `Terminate`
+	// functions). It is nil if there wasn't one.
+	Error *string `json:"error"`
+	// Writes contains the stdout and stderr writes in the order they were made.
+	// Consecutive writes to the same stream are merged into a single entry.
+	Writes []*JSONWrite `json:"writes"`
+}
+
+// JSONWrite is a single write to stdout or stderr.
+type JSONWrite struct {
+	// Stream is either "stdout" or "stderr".
+	Stream string `json:"stream"`
+	Text   string `json:"text"`
+}
+
+type jsonOutput struct {
+	o      Output
+	mu     sync.Mutex
+	stdout strings.Builder
+	stderr strings.Builder
+	err    *string
+	writes []*JSONWrite
+}
+
+func (jo *jsonOutput) Color(fs ...color.Format)  {}
+func (jo *jsonOutput) Colerr(fs ...color.Format) {}
+
+func (jo *jsonOutput) Stdout(s string) {
+	jo.write("stdout", s)
+}
+
+func (jo *jsonOutput) Stdoutf(s string, a ...interface{}) {
+	jo.write("stdout", fmt.Sprintf(s, a...))
+}
+
+func (jo *jsonOutput) Stdoutln(a ...interface{}) {
+	jo.write("stdout", fmt.Sprintln(a...))
+}
+
+func (jo *jsonOutput) Stderr(s string) error {
+	return jo.writeStderr(s)
+}
+
+func (jo *jsonOutput) Stderrf(s string, a ...interface{}) error {
+	return jo.writeStderr(fmt.Sprintf(s, a...))
+}
+
+func (jo *jsonOutput) Stderrln(a ...interface{}) error {
+	return jo.writeStderr(fmt.Sprintln(a...))
+}
+
+func (jo *jsonOutput) Annotate(err error, s string) error {
+	if err == nil {
+		return nil
+	}
+	return jo.Stderrf("%s: %v\n", s, err)
+}
+
+func (jo *jsonOutput) Annotatef(err error, s string, a ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	return jo.Stderrf("%s: %v\n", fmt.Sprintf(s, a...), err)
+}
+
+func (jo *jsonOutput) Err(err error) error {
+	if err != nil {
+		jo.setErr(err)
+		jo.Stderrf("%s\n", err.Error())
+	}
+	return err
+}
+
+func (jo *jsonOutput) Terminate(err error) {
+	if err != nil {
+		jo.setErr(err)
+		spycommand.Terminate(jo.Stderrln(err.Error()))
+	}
+}
+
+func (jo *jsonOutput) Terminatef(s string, a ...interface{}) {
+	err := jo.Stderrf(s, a...)
+	jo.setErr(err)
+	spycommand.Terminate(err)
+}
+
+func (jo *jsonOutput) Tannotate(err error, s string) {
+	if err != nil {
+		jo.Terminate(fmt.Errorf("%s: %v", s, err))
+	}
+}
+
+func (jo *jsonOutput) Tannotatef(err error, s string, a ...interface{}) {
+	if err != nil {
+		jo.Terminate(fmt.Errorf("%s: %v", fmt.Sprintf(s, a...), err))
+	}
+}
+
+func (jo *jsonOutput) CloseWithError(err error) {
+	if err != nil {
+		jo.setErr(err)
+	}
+	jo.Close()
+}
+
+// Close writes the JSON object to the wrapped output and then closes it.
+func (jo *jsonOutput) Close() {
+	jo.mu.Lock()
+	r := &JSONResult{
+		Stdout: jo.stdout.String(),
+		Stderr: jo.stderr.String(),
+		Error:  jo.err,
+		Writes: jo.writes,
+	}
+	if r.Writes == nil {
+		r.Writes = []*JSONWrite{}
+	}
+	jo.mu.Unlock()
+
+	b, err := json.Marshal(r)
+	if err != nil {
+		// This should never happen since all fields are strings.
+		jo.o.Stderrf("failed to marshal JSON output: %v\n", err)
+	} else {
+		jo.o.Stdoutln(string(b))
+	}
+	jo.o.Close()
+}
+
+func (jo *jsonOutput) writeStderr(s string) error {
+	jo.write("stderr", s)
+	return errors.New(strings.TrimSpace(s))
+}
+
+func (jo *jsonOutput) setErr(err error) {
+	jo.mu.Lock()
+	defer jo.mu.Unlock()
+	msg := err.Error()
+	jo.err = &msg
+}
+
+func (jo *jsonOutput) write(stream, s string) {
+	jo.mu.Lock()
+	defer jo.mu.Unlock()
+	if stream == "stdout" {
+		jo.stdout.WriteString(s)
+	} else {
+		jo.stderr.WriteString(s)
+	}
+	// Merge consecutive writes to the same stream so partial writes (e.g. ones
+	// without a trailing newline) are grouped together.
+	if n := len(jo.writes); n > 0 && jo.writes[n-1].Stream == stream {
+		jo.writes[n-1].Text += s
+		return
+	}
+	jo.writes = append(jo.writes, &JSONWrite{stream, s})
+}
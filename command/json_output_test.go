@@ -0,0 +1,208 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/leep-frog/command/color"
+	"github.com/leep-frog/command/internal/spycommand"
+	"github.com/leep-frog/command/internal/testutil"
+)
+
+func TestJSONOutput(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+	for _, test := range []struct {
+		name      string
+		f         func(o Output) error
+		want      *JSONResult
+		wantErr   error
+		wantPanic interface{}
+	}{
+		{
+			name: "writes empty result",
+			f: func(o Output) error {
+				return nil
+			},
+			want: &JSONResult{
+				Writes: []*JSONWrite{},
+			},
+		},
+		{
+			name: "buffers stdout and stderr in order",
+			f: func(o Output) error {
+				o.Stdout("hello %s")
+				o.Stdoutf("hello %s", "there")
+				o.Stdoutln("!")
+				o.Stderr("general ")
+				o.Stderrf("%s\n", "kenobi")
+				o.Stdoutln("final")
+				o.Stderrln("finale")
+				return nil
+			},
+			want: &JSONResult{
+				Stdout: "hello %shello there!\nfinal\n",
+				Stderr: "general kenobi\nfinale\n",
+				Writes: []*JSONWrite{
+					{"stdout", "hello %shello there!\n"},
+					{"stderr", "general kenobi\n"},
+					{"stdout", "final\n"},
+					{"stderr", "finale\n"},
+				},
+			},
+		},
+		{
+			name: "handles partial writes and special characters",
+			f: func(o Output) error {
+				o.Stdout("no newline \"quoted\"\t")
+				o.Stderr("partial\\")
+				return nil
+			},
+			want: &JSONResult{
+				Stdout: "no newline \"quoted\"\t",
+				Stderr: "partial\\",
+				Writes: []*JSONWrite{
+					{"stdout", "no newline \"quoted\"\t"},
+					{"stderr", "partial\\"},
+				},
+			},
+		},
+		{
+			name: "ignores color changes",
+			f: func(o Output) error {
+				o.Color(color.Blue)
+				o.Stdout("one")
+				o.Colerr(color.Bold)
+				return nil
+			},
+			want: &JSONResult{
+				Stdout: "one",
+				Writes: []*JSONWrite{
+					{"stdout", "one"},
+				},
+			},
+		},
+		{
+			name: "records and returns error",
+			f: func(o Output) error {
+				o.Stdoutln("hello")
+				o.Err(nil)
+				return o.Err(fmt.Errorf("some %q", "error"))
+			},
+			wantErr: fmt.Errorf(`some "error"`),
+			want: &JSONResult{
+				Stdout: "hello\n",
+				Stderr: "some \"error\"\n",
+				Error:  strPtr(`some "error"`),
+				Writes: []*JSONWrite{
+					{"stdout", "hello\n"},
+					{"stderr", "some \"error\"\n"},
+				},
+			},
+		},
+		{
+			name: "annotates errors",
+			f: func(o Output) error {
+				if err := o.Annotate(nil, "nope"); err != nil {
+					return err
+				}
+				return o.Annotatef(fmt.Errorf("oops"), "attention %d", 101)
+			},
+			wantErr: fmt.Errorf("attention 101: oops"),
+			want: &JSONResult{
+				Stderr: "attention 101: oops\n",
+				Error:  strPtr("attention 101: oops"),
+				Writes: []*JSONWrite{
+					{"stderr", "attention 101: oops\n"},
+				},
+			},
+		},
+		{
+			name: "records stderr error returned by command",
+			f: func(o Output) error {
+				return o.Stderrf("bad input: %d\n", 7)
+			},
+			wantErr: fmt.Errorf("bad input: 7"),
+			want: &JSONResult{
+				Stderr: "bad input: 7\n",
+				Error:  strPtr("bad input: 7"),
+				Writes: []*JSONWrite{
+					{"stderr", "bad input: 7\n"},
+				},
+			},
+		},
+		{
+			name: "doesn't record stderr warnings as errors",
+			f: func(o Output) error {
+				o.Stderrln("warning: careful")
+				o.Stdoutln("done")
+				return nil
+			},
+			want: &JSONResult{
+				Stdout: "done\n",
+				Stderr: "warning: careful\n",
+				Writes: []*JSONWrite{
+					{"stderr", "warning: careful\n"},
+					{"stdout", "done\n"},
+				},
+			},
+		},
+		{
+			name: "records terminate error",
+			f: func(o Output) error {
+				o.Stdout("hello")
+				o.Terminate(nil)
+				o.Tannotatef(fmt.Errorf("donzo"), "stopping %s", "now")
+				o.Stdout("ignored")
+				return nil
+			},
+			wantPanic: spycommand.TerminationErr(fmt.Errorf("stopping now: donzo")),
+			want: &JSONResult{
+				Stdout: "hello",
+				Stderr: "stopping now: donzo\n",
+				Error:  strPtr("stopping now: donzo"),
+				Writes: []*JSONWrite{
+					{"stdout", "hello"},
+					{"stderr", "stopping now: donzo\n"},
+				},
+			},
+		},
+		{
+			name: "records terminatef error",
+			f: func(o Output) error {
+				o.Terminatef("ahoy %s", "matey")
+				return nil
+			},
+			wantPanic: spycommand.TerminationErr(fmt.Errorf("ahoy matey")),
+			want: &JSONResult{
+				Stderr: "ahoy matey",
+				Error:  strPtr("ahoy matey"),
+				Writes: []*JSONWrite{
+					{"stderr", "ahoy matey"},
+				},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var so, se []string
+			fakeO := OutputFromFuncs(func(s string) { so = append(so, s) }, func(s string) { se = append(se, s) })
+			o := NewJSONOutput(fakeO)
+
+			err := testutil.CmpPanic(t, "JSONOutput func()", func() error { return test.f(o) }, test.wantPanic, spycommand.TerminationCmpopts())
+			o.CloseWithError(err)
+			testutil.CmpError(t, "JSONOutput func()", test.wantErr, err)
+			testutil.Cmp(t, "JSONOutput wrote to stderr", []string(nil), se)
+
+			stdout := strings.Join(so, "")
+			if !strings.HasSuffix(stdout, "\n") {
+				t.Errorf("JSONOutput stdout should end with a newline: %q", stdout)
+			}
+			got := &JSONResult{}
+			if err := json.Unmarshal([]byte(stdout), got); err != nil {
+				t.Fatalf("JSONOutput produced invalid JSON (%q): %v", stdout, err)
+			}
+			testutil.Cmp(t, "JSONOutput produced incorrect result", test.want, got)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/leep-frog/command/color"
+)
+
+// Infof writes a log line prefixed with `[INFO]` (in green) to stdout.
+// A trailing newline is added if not already present. The prefix is not
+// colored if the `NO_COLOR` environment variable is set.
+func Infof(o Output, format string, a ...interface{}) {
+	o.Stdout(logLine("[INFO]", color.Green, format, a...))
+}
+
+// Warnf writes a log line prefixed with `[WARN]` (in yellow) to stderr.
+// A trailing newline is added if not already present. The prefix is not
+// colored if the `NO_COLOR` environment variable is set.
+func Warnf(o Output, format string, a ...interface{}) {
+	o.Stderr(logLine("[WARN]", color.Yellow, format, a...))
+}
+
+// Errorf writes a log line prefixed with `[ERROR]` (in red) to stderr.
+// A trailing newline is added if not already present. The prefix is not
+// colored if the `NO_COLOR` environment variable is set. The returned error
+// contains the message (without the prefix) and should be returned by the
+// caller so the command exits with an error.
+func Errorf(o Output, format string, a ...interface{}) error {
+	o.Stderr(logLine("[ERROR]", color.Red, format, a...))
+	return errors.New(strings.TrimSpace(fmt.Sprintf(format, a...)))
+}
+
+func logLine(prefix string, c color.Format, format string, a ...interface{}) string {
+	if _, ok := OSLookupEnv("NO_COLOR"); !ok {
+		prefix = color.Apply(prefix, c)
+	}
+	msg := fmt.Sprintf(format, a...)
+	if !strings.HasSuffix(msg, "\n") {
+		msg += "\n"
+	}
+	return fmt.Sprintf("%s %s", prefix, msg)
+}
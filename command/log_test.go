@@ -0,0 +1,93 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/leep-frog/command/internal/testutil"
+)
+
+func TestLogf(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		env        map[string]string
+		f          func(o Output) error
+		wantStdout string
+		wantStderr string
+		wantErr    error
+	}{
+		{
+			name: "Infof writes colored prefix to stdout",
+			f: func(o Output) error {
+				Infof(o, "hello %s", "there")
+				return nil
+			},
+			wantStdout: "\033[32m[INFO]\033[0m hello there\n",
+		},
+		{
+			name: "Warnf writes colored prefix to stderr",
+			f: func(o Output) error {
+				Warnf(o, "careful with %d\n", 123)
+				return nil
+			},
+			wantStderr: "\033[33m[WARN]\033[0m careful with 123\n",
+		},
+		{
+			name: "Errorf writes colored prefix to stderr and returns error",
+			f: func(o Output) error {
+				return Errorf(o, "failed to %s", "run")
+			},
+			wantStderr: "\033[31m[ERROR]\033[0m failed to run\n",
+			wantErr:    fmt.Errorf("failed to run"),
+		},
+		{
+			name: "Log functions route output",
+			f: func(o Output) error {
+				Infof(o, "one")
+				Warnf(o, "two")
+				Infof(o, "three")
+				return Errorf(o, "four")
+			},
+			wantStdout: strings.Join([]string{
+				"\033[32m[INFO]\033[0m one",
+				"\033[32m[INFO]\033[0m three",
+				"",
+			}, "\n"),
+			wantStderr: strings.Join([]string{
+				"\033[33m[WARN]\033[0m two",
+				"\033[31m[ERROR]\033[0m four",
+				"",
+			}, "\n"),
+			wantErr: fmt.Errorf("four"),
+		},
+		{
+			name: "Log functions don't color prefixes if NO_COLOR is set",
+			env:  map[string]string{"NO_COLOR": ""},
+			f: func(o Output) error {
+				Infof(o, "one")
+				Warnf(o, "two")
+				return Errorf(o, "three\n")
+			},
+			wantStdout: "[INFO] one\n",
+			wantStderr: "[WARN] two\n[ERROR] three\n",
+			wantErr:    fmt.Errorf("three"),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			testutil.StubValue(t, &OSLookupEnv, func(key string) (string, bool) {
+				v, ok := test.env[key]
+				return v, ok
+			})
+
+			var so, se []string
+			fo := OutputFromFuncs(func(s string) { so = append(so, s) }, func(s string) { se = append(se, s) })
+			err := test.f(fo)
+			fo.Close()
+
+			testutil.CmpError(t, "Logf()", test.wantErr, err)
+			testutil.Cmp(t, "Logf() produced incorrect stdout", test.wantStdout, strings.Join(so, ""))
+			testutil.Cmp(t, "Logf() produced incorrect stderr", test.wantStderr, strings.Join(se, ""))
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"unicode/utf8"
 
 	"github.com/leep-frog/command/color"
 	"github.com/leep-frog/command/glog"
@@ -245,3 +246,60 @@ func (ieo *ignoreErrOutput) Err(err error) error {
 	// Regular output functionality if no filter matched.
 	return ieo.Output.Err(err)
 }
+
+// NewQuietOutput is an output that discards all stdout writes (including
+// stdout color changes). Stderr writes and errors are forwarded to the
+// provided output as normal.
+func NewQuietOutput(o Output) Output {
+	return &quietOutput{o}
+}
+
+type quietOutput struct {
+	Output
+}
+
+func (qo *quietOutput) Stdout(string)                  {}
+func (qo *quietOutput) Stdoutf(string, ...interface{}) {}
+func (qo *quietOutput) Stdoutln(...interface{})        {}
+func (qo *quietOutput) Color(...color.Format)          {}
+
+// Rule writes a horizontal rule that spans the width of the terminal (as
+// determined by `TerminalWidth`) to stdout. The rule is bolded unless the
+// `NO_COLOR` environment variable is set.
+func Rule(o Output) {
+	r := strings.Repeat("─", TerminalWidth())
+	if _, ok := OSLookupEnv("NO_COLOR"); !ok {
+		r = color.Apply(r, color.Bold)
+	}
+	o.Stdoutln(r)
+}
+
+// WrapWrite writes the provided text to stdout, wrapping lines on word
+// boundaries so they fit within the width of the terminal (as determined by
+// `TerminalWidth`). Explicit newlines in the text are preserved and words
+// longer than the terminal width are written on their own line (unbroken).
+func WrapWrite(o Output, text string) {
+	for _, line := range wrapLines(strings.TrimSuffix(text, "\n"), TerminalWidth()) {
+		o.Stdoutln(line)
+	}
+}
+
+// wrapLines splits the text into lines of at most `width` characters.
+func wrapLines(text string, width int) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		var cur string
+		for _, word := range strings.Fields(paragraph) {
+			if cur == "" {
+				cur = word
+			} else if width <= 0 || utf8.RuneCountInString(cur)+1+utf8.RuneCountInString(word) <= width {
+				cur += " " + word
+			} else {
+				lines = append(lines, cur)
+				cur = word
+			}
+		}
+		lines = append(lines, cur)
+	}
+	return lines
+}
@@ -295,6 +295,183 @@ func TestOutputWriters(t *testing.T) {
 	}
 }
 
+func TestQuietOutput(t *testing.T) {
+	var so, se []string
+	fo := OutputFromFuncs(func(s string) { so = append(so, s) }, func(s string) { se = append(se, s) })
+	qo := NewQuietOutput(fo)
+
+	qo.Stdout("one")
+	qo.Stdoutf("%s", "two")
+	qo.Stdoutln("three")
+	qo.Color(color.Bold)
+	qo.Stderr("four\n")
+	qo.Stderrf("%s\n", "five")
+	gotErr := qo.Err(fmt.Errorf("six"))
+
+	fo.Close()
+	if diff := cmp.Diff([]string(nil), so); diff != "" {
+		t.Errorf("Quiet output wrote to stdout:\n%s", diff)
+	}
+	if diff := cmp.Diff("four\nfive\nsix\n", strings.Join(se, "")); diff != "" {
+		t.Errorf("Incorrect output sent to stderr:\n%s", diff)
+	}
+	if gotErr == nil || gotErr.Error() != "six" {
+		t.Errorf("NewQuietOutput(...).Err() returned %v; want %v", gotErr, "six")
+	}
+}
+
+func TestRule(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		width      int
+		env        map[string]string
+		wantStdout string
+	}{
+		{
+			name:       "Prints bolded rule",
+			width:      5,
+			wantStdout: "\033[1m─────\033[0m\n",
+		},
+		{
+			name:       "Prints plain rule if NO_COLOR is set",
+			width:      12,
+			env:        map[string]string{"NO_COLOR": ""},
+			wantStdout: "────────────\n",
+		},
+		{
+			name:       "Prints empty rule if no width",
+			env:        map[string]string{"NO_COLOR": "1"},
+			wantStdout: "\n",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			testutil.StubValue(t, &TerminalWidth, func() int { return test.width })
+			testutil.StubValue(t, &OSLookupEnv, func(key string) (string, bool) {
+				v, ok := test.env[key]
+				return v, ok
+			})
+
+			var so []string
+			fo := OutputFromFuncs(func(s string) { so = append(so, s) }, func(s string) {})
+			Rule(fo)
+			fo.Close()
+
+			testutil.Cmp(t, "Rule() produced incorrect stdout", test.wantStdout, strings.Join(so, ""))
+		})
+	}
+}
+
+func TestWrapWrite(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		width      int
+		text       string
+		wantStdout []string
+	}{
+		{
+			name:  "Wraps paragraph on word boundaries",
+			width: 20,
+			text:  "The quick brown fox jumps over the lazy dog and keeps on running",
+			wantStdout: []string{
+				"The quick brown fox",
+				"jumps over the lazy",
+				"dog and keeps on",
+				"running",
+			},
+		},
+		{
+			name:  "Writes long words on their own line",
+			width: 10,
+			text:  "a supercalifragilistic word",
+			wantStdout: []string{
+				"a",
+				"supercalifragilistic",
+				"word",
+			},
+		},
+		{
+			name:  "Preserves explicit newlines",
+			width: 12,
+			text:  "first line here\n\nsecond paragraph text\n",
+			wantStdout: []string{
+				"first line",
+				"here",
+				"",
+				"second",
+				"paragraph",
+				"text",
+			},
+		},
+		{
+			name:  "Line exactly the width isn't wrapped",
+			width: 11,
+			text:  "hello there friend",
+			wantStdout: []string{
+				"hello there",
+				"friend",
+			},
+		},
+		{
+			name: "Doesn't wrap if no width",
+			text: "no wrapping happens here",
+			wantStdout: []string{
+				"no wrapping happens here",
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			testutil.StubValue(t, &TerminalWidth, func() int { return test.width })
+
+			var so []string
+			fo := OutputFromFuncs(func(s string) { so = append(so, s) }, func(s string) {})
+			WrapWrite(fo, test.text)
+			fo.Close()
+
+			var want string
+			for _, l := range test.wantStdout {
+				want += l + "\n"
+			}
+			testutil.Cmp(t, "WrapWrite() produced incorrect stdout", want, strings.Join(so, ""))
+		})
+	}
+}
+
+func TestTerminalWidth(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		env  map[string]string
+		want int
+	}{
+		{
+			name: "Defaults to 80",
+			want: 80,
+		},
+		{
+			name: "Uses COLUMNS",
+			env:  map[string]string{"COLUMNS": "123"},
+			want: 123,
+		},
+		{
+			name: "Ignores invalid COLUMNS",
+			env:  map[string]string{"COLUMNS": "wide"},
+			want: 80,
+		},
+		{
+			name: "Ignores non-positive COLUMNS",
+			env:  map[string]string{"COLUMNS": "0"},
+			want: 80,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			testutil.StubValue(t, &OSLookupEnv, func(key string) (string, bool) {
+				v, ok := test.env[key]
+				return v, ok
+			})
+			testutil.Cmp(t, "TerminalWidth() returned incorrect value", test.want, TerminalWidth())
+		})
+	}
+}
+
 func TestMetadata(t *testing.T) {
 	t.Run("NewOutput() succeeds", func(t *testing.T) {
 		NewOutput()
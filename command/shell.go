@@ -0,0 +1,21 @@
+package command
+
+// ShellType is a type of shell for which code (e.g. shell statements or
+// autocomplete setup) can be generated.
+type ShellType string
+
+const (
+	// DefaultShell indicates that no shell was specified, in which case the
+	// shell is inferred (e.g. from the current OS or environment).
+	DefaultShell ShellType = ""
+	// BashShell is the bash shell.
+	BashShell ShellType = "bash"
+	// ZshShell is the zsh shell.
+	ZshShell ShellType = "zsh"
+	// FishShell is the fish shell.
+	FishShell ShellType = "fish"
+	// PowerShellShell is the PowerShell shell.
+	PowerShellShell ShellType = "powershell"
+	// NushellShell is the nushell (https://www.nushell.sh) shell.
+	NushellShell ShellType = "nushell"
+)
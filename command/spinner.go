@@ -0,0 +1,83 @@
+package command
+
+import "sync"
+
+var (
+	// spinnerFrames are the frames cycled through by a `Spinner` when stderr is
+	// a terminal.
+	spinnerFrames = []string{"|", "/", "-", "\\"}
+)
+
+const (
+	// clearLine returns the cursor to the start of the line and erases it.
+	clearLine = "\r\033[K"
+)
+
+// Spinner renders progress for operations that don't have a known total.
+// When stderr is a terminal (as determined by `StderrIsTerminal`), each tick
+// redraws a spinner frame in place. Otherwise, each tick prints a dot so that
+// logs don't fill up with carriage returns. All output is sent to stderr so
+// that stdout is never corrupted.
+type Spinner struct {
+	o      Output
+	label  string
+	tty    bool
+	frames []string
+
+	mu      sync.Mutex
+	ticks   int
+	stopped bool
+}
+
+// NewSpinner returns a `Spinner` that writes the provided label (and its
+// progress) to the stderr of the provided `Output`.
+func NewSpinner(o Output, label string) *Spinner {
+	return &Spinner{
+		o:      o,
+		label:  label,
+		tty:    StderrIsTerminal(),
+		frames: spinnerFrames,
+	}
+}
+
+// Tick advances the spinner by one step. It is a no-op once the spinner
+// has been stopped.
+func (s *Spinner) Tick() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		return
+	}
+
+	if s.tty {
+		if len(s.frames) > 0 {
+			s.o.Stderrf("%s%s %s", clearLine, s.frames[s.ticks%len(s.frames)], s.label)
+		}
+	} else {
+		if s.ticks == 0 {
+			s.o.Stderr(s.label)
+		}
+		s.o.Stderr(".")
+	}
+	s.ticks++
+}
+
+// Stop clears the spinner (or terminates the line of dots). Subsequent calls
+// to `Tick` and `Stop` are no-ops.
+func (s *Spinner) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		return
+	}
+	s.stopped = true
+
+	if s.ticks == 0 {
+		return
+	}
+	if s.tty {
+		s.o.Stderr(clearLine)
+	} else {
+		s.o.Stderrln()
+	}
+}
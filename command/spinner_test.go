@@ -0,0 +1,86 @@
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/leep-frog/command/internal/testutil"
+)
+
+func TestSpinner(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		tty        bool
+		frames     []string
+		f          func(s *Spinner)
+		wantStderr string
+	}{
+		{
+			name: "stop without ticks writes nothing",
+			tty:  true,
+			f: func(s *Spinner) {
+				s.Stop()
+			},
+		},
+		{
+			name:   "renders frames under a terminal",
+			tty:    true,
+			frames: []string{"a", "b", "c"},
+			f: func(s *Spinner) {
+				for i := 0; i < 4; i++ {
+					s.Tick()
+				}
+				s.Stop()
+			},
+			wantStderr: strings.Join([]string{
+				"\r\033[Ka loading",
+				"\r\033[Kb loading",
+				"\r\033[Kc loading",
+				"\r\033[Ka loading",
+				"\r\033[K",
+			}, ""),
+		},
+		{
+			name:   "ignores ticks after stop under a terminal",
+			tty:    true,
+			frames: []string{"a", "b"},
+			f: func(s *Spinner) {
+				s.Tick()
+				s.Stop()
+				s.Tick()
+				s.Stop()
+			},
+			wantStderr: "\r\033[Ka loading\r\033[K",
+		},
+		{
+			name:   "prints dots when not a terminal",
+			frames: []string{"a", "b"},
+			f: func(s *Spinner) {
+				for i := 0; i < 3; i++ {
+					s.Tick()
+				}
+				s.Stop()
+				s.Tick()
+			},
+			wantStderr: "loading...\n",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			testutil.StubValue(t, &StderrIsTerminal, func() bool { return test.tty })
+			testutil.StubValue(t, &spinnerFrames, test.frames)
+
+			var so, se []string
+			fo := OutputFromFuncs(func(s string) { so = append(so, s) }, func(s string) { se = append(se, s) })
+			test.f(NewSpinner(fo, "loading"))
+			fo.Close()
+
+			if diff := cmp.Diff([]string(nil), so); diff != "" {
+				t.Errorf("Spinner wrote to stdout:\n%s", diff)
+			}
+			if diff := cmp.Diff(test.wantStderr, strings.Join(se, "")); diff != "" {
+				t.Errorf("Spinner produced incorrect stderr output (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
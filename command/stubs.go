@@ -1,9 +1,42 @@
 package command
 
-import "os"
+import (
+	"os"
+	"strconv"
+)
 
 var (
 	// OSLookupEnv is the env lookup command used internally by the entire `command` project.
 	// It's value can be stubbed in tests by using the `commandtest.*TestCase.Env` fields.
 	OSLookupEnv = os.LookupEnv
+
+	// OSEnviron is the env listing command used internally by the entire `command` project.
+	// It's value can be stubbed in tests by using the `commandtest.*TestCase.Env` fields.
+	OSEnviron = os.Environ
+
+	// TerminalWidth returns the width (in columns) of the terminal. It uses the
+	// `COLUMNS` environment variable when available and defaults to 80 otherwise.
+	// Its value can be stubbed in tests to produce consistent output widths.
+	TerminalWidth = func() int {
+		if v, ok := OSLookupEnv("COLUMNS"); ok {
+			if w, err := strconv.Atoi(v); err == nil && w > 0 {
+				return w
+			}
+		}
+		return defaultTerminalWidth
+	}
+
+	// StderrIsTerminal returns whether or not stderr is attached to a terminal.
+	// Its value can be stubbed in tests by using `commandtest.StubSpinner`.
+	StderrIsTerminal = func() bool { return IsTerminal(os.Stderr) }
+)
+
+// IsTerminal returns whether or not the provided file is attached to a terminal.
+func IsTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+const (
+	defaultTerminalWidth = 80
 )
@@ -21,6 +21,32 @@ const (
 	SymbolSection UsageSection = "Symbols"
 )
 
+// FlagCategorySection returns the title of the usage section for flags in the
+// provided category.
+func FlagCategorySection(category string) UsageSection {
+	return UsageSection(fmt.Sprintf("%s %s", category, FlagSection))
+}
+
+func (us UsageSection) isFlagSection() bool {
+	return us == FlagSection || strings.HasSuffix(string(us), fmt.Sprintf(" %s", FlagSection))
+}
+
+// order returns the relative order of the usage section. Sections with the
+// same order are sorted alphabetically.
+func (us UsageSection) order() int {
+	switch {
+	case us == ArgSection:
+		return 0
+	case us == FlagSection:
+		return 1
+	case us.isFlagSection():
+		return 2
+	case us == SymbolSection:
+		return 3
+	}
+	return 4
+}
+
 var (
 	trailingNestedUsageRegex = regexp.MustCompile(fmt.Sprintf(`%s(\s*)$`, constants.UsageBoxUpDown))
 	trailingWhitspaceRegex   = regexp.MustCompile(`\s*$`)
@@ -116,6 +142,17 @@ func (u *Usage) AddFlag(fullFlag string, shortFlag rune, argName string, descrip
 	})
 }
 
+// FlagCategory runs the provided function and places all flags added to the
+// `Usage` object by that function under the provided category's usage section.
+func (u *Usage) FlagCategory(category string, f func() error) error {
+	start := len(u.flags)
+	err := f()
+	for _, fu := range u.flags[start:] {
+		fu.section = FlagCategorySection(category)
+	}
+	return err
+}
+
 func (u *Usage) SetBranches(branches []*BranchUsage) {
 	if u.branchArgIdx != nil {
 		panic("Currently, only one branch point is supported per line")
@@ -140,7 +177,12 @@ func (u *Usage) String() string {
 		for s := range *usageSection {
 			sections = append(sections, s)
 		}
-		slices.Sort(sections)
+		slices.SortFunc(sections, func(this, that UsageSection) int {
+			if this.order() != that.order() {
+				return this.order() - that.order()
+			}
+			return strings.Compare(string(this), string(that))
+		})
 
 		// Iterate over sections
 		for _, sk := range sections {
@@ -152,7 +194,7 @@ func (u *Usage) String() string {
 			}
 
 			// Sort by flag name or by key name
-			if sk == FlagSection {
+			if sk.isFlagSection() {
 				// We want to sort flags by full name, not short flags.
 				// So, we trim "  [c] " from each flag description.
 				sort.SliceStable(keys, func(i, j int) bool {
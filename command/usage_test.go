@@ -220,6 +220,38 @@ func TestUsage(t *testing.T) {
 				"      second-flag: 2nd",
 			},
 		},
+		{
+			name: "Usage with categorized flags",
+			yuf: func(y *Usage) {
+				y.AddSymbol("*", "Star")
+				y.FlagCategory("Zeta", func() error {
+					y.AddFlag("zeta-flag", 'z', "ZZ", "last", 1, 0)
+					return nil
+				})
+				y.AddFlag("first-flag", 'f', "FFF", "1st", 1, 0)
+				y.FlagCategory("Beta", func() error {
+					y.AddFlag("second-flag", constants.FlagNoShortName, "SS", "2nd", 1, 0)
+					y.AddFlag("another-flag", 'a', "AA", "another", 1, 0)
+					return nil
+				})
+			},
+			want: []string{
+				"* --zeta-flag|-z ZZ --first-flag|-f FFF --second-flag SS --another-flag|-a AA",
+				"",
+				"Flags:",
+				"  [f] first-flag: 1st",
+				"",
+				"Beta Flags:",
+				"  [a] another-flag: another",
+				"      second-flag: 2nd",
+				"",
+				"Zeta Flags:",
+				"  [z] zeta-flag: last",
+				"",
+				"Symbols:",
+				"  *: Star",
+			},
+		},
 		{
 			name: "Usage with desc, args, and flags",
 			yuf: func(y *Usage) {
@@ -3,10 +3,14 @@ package commander
 import (
 	"fmt"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/leep-frog/command/command"
 	"github.com/leep-frog/command/internal/operator"
+	"github.com/leep-frog/command/internal/spycommander"
+	"github.com/leep-frog/command/internal/stubs"
 )
 
 // Argument is a type that implements `command.Processor`. It can be
@@ -19,6 +23,9 @@ type Argument[T any] struct {
 	optionalN int
 	shortName rune
 	flag      bool
+	// op is the operator used to convert the argument's values. If nil,
+	// `operator.GetOperator[T]()` is used.
+	op operator.Operator[T]
 }
 
 // AddOptions adds options to an `Argument`. Although chaining isn't conventional
@@ -73,6 +80,8 @@ func (an *Argument[T]) GetOrDefaultFunc(data *command.Data, dfltFunc func(*comma
 func (an *Argument[T]) Set(v T, data *command.Data) {
 	if an.opt != nil && an.opt.customSet != nil && an.opt.customSet.F != nil {
 		an.opt.customSet.F(v, data)
+	} else if an.opt != nil && an.opt.namedSet != nil {
+		an.opt.namedSet(an.name, v, data)
 	} else {
 		data.Set(an.name, v)
 	}
@@ -113,6 +122,22 @@ func (an *Argument[T]) Usage(i *command.Input, d *command.Data, u *command.Usage
 	return nil
 }
 
+func (an *Argument[T]) getOperator() operator.Operator[T] {
+	if an.op != nil {
+		return an.op
+	}
+	return operator.GetOperator[T]()
+}
+
+// argCount returns the maximum number of values the argument can consume
+// (or `command.UnboundedList`).
+func (an *Argument[T]) argCount() int {
+	if an.optionalN == command.UnboundedList {
+		return command.UnboundedList
+	}
+	return an.minN + an.optionalN
+}
+
 func (an *Argument[T]) getDefault() (T, bool) {
 	var nill T
 	if an.opt == nil || an.opt._default == nil {
@@ -121,6 +146,15 @@ func (an *Argument[T]) getDefault() (T, bool) {
 	return an.opt._default.v, true
 }
 
+// setDefault sets the argument's default value (if it has one) and marks it
+// as a default value in `command.Data`.
+func (an *Argument[T]) setDefault(data *command.Data) {
+	if dflt, ok := an.getDefault(); ok {
+		an.Set(dflt, data)
+		data.MarkDefault(an.name)
+	}
+}
+
 func (an *Argument[T]) usageDescription() string {
 	desc := []string{an.desc}
 	if an.opt != nil {
@@ -140,14 +174,18 @@ func (an *Argument[T]) Execute(i *command.Input, o command.Output, data *command
 
 	sl, enough := i.PopN(an.minN, an.optionalN, an.opt.inputValidators(), data)
 
+	if an.opt != nil && an.opt.emptyUnset {
+		sl = dropEmptyValues(sl, false)
+		// A flag with only empty values is treated as if it wasn't provided.
+		enough = enough && ((an.flag && len(sl) == 0) || len(sl) >= an.minN)
+	}
+
 	// Don't set at all if no arguments provided for arg.
 	if len(sl) == 0 {
 		if !enough {
 			return o.Err(an.notEnoughErr(len(sl)))
 		}
-		if dflt, ok := an.getDefault(); ok {
-			an.Set(dflt, data)
-		}
+		an.setDefault(data)
 		return nil
 	}
 
@@ -159,7 +197,7 @@ func (an *Argument[T]) Execute(i *command.Input, o command.Output, data *command
 			tsl := sl[:i]
 			v, err := an.convertStringValue(tsl, data, false)
 			data.Complexecute = true
-			compl, err := RunArgumentCompleter(an.opt.completer, v, data)
+			compl, err := runArgumentCompleter(an.opt.completer, an.getOperator(), v, data)
 			data.Complexecute = false
 			if err != nil {
 				if strict {
@@ -177,6 +215,15 @@ func (an *Argument[T]) Execute(i *command.Input, o command.Output, data *command
 			suggestions := compl.Process(lastArg, nil, true)
 			if len(suggestions) == 1 || slices.Contains(suggestions, lastArg) {
 				*tsl[len(tsl)-1] = suggestions[0]
+			} else if len(suggestions) > 1 && an.opt.complexecute.Prompt && eData != nil && stubs.StdinIsTerminal() && stubs.StdoutIsTerminal() {
+				// Only prompt for actual executions (`Usage` runs `Execute` with nil
+				// `ExecuteData` and output that is ignored, so the prompt wouldn't be
+				// visible).
+				selection, err := promptSelection(o, fmt.Sprintf("Multiple matches for %q:", an.name), suggestions)
+				if err != nil {
+					return o.Annotatef(err, "[Complexecute] failed to select suggestion for %q", an.name)
+				}
+				*tsl[len(tsl)-1] = selection
 			} else if strict {
 				return o.Stderrf("[Complexecute] requires exactly one suggestion to be returned for %q, got %d: %v\n", an.name, len(suggestions), suggestions)
 			}
@@ -189,7 +236,7 @@ func (an *Argument[T]) Execute(i *command.Input, o command.Output, data *command
 	}
 
 	// Copy values into returned list (required for shortcutting)
-	newSl := operator.GetOperator[T]().ToArgs(v)
+	newSl := an.getOperator().ToArgs(v)
 	if len(newSl) != len(sl) {
 		// We enforce this for Arg transformers. The change around `command.Input` are too complicated
 		// to warrant enabling this functionality here, when users can easily just make a
@@ -210,6 +257,16 @@ func (an *Argument[T]) Execute(i *command.Input, o command.Output, data *command
 		}
 	}
 
+	// `Usage` runs `Execute` with nil `ExecuteData`, so only run these for
+	// actual executions.
+	if an.opt != nil && eData != nil {
+		for _, ex := range an.opt.executors {
+			if err := ex(v, data); err != nil {
+				return o.Err(err)
+			}
+		}
+	}
+
 	if !enough {
 		return o.Err(an.notEnoughErr(len(sl)))
 	}
@@ -219,8 +276,7 @@ func (an *Argument[T]) Execute(i *command.Input, o command.Output, data *command
 func (an *Argument[T]) convertStringValue(sl []*string, data *command.Data, transform bool) (T, error) {
 	var nill T
 	// Transform from string to value.
-	op := operator.GetOperator[T]()
-	v, err := op.FromArgs(sl)
+	v, err := an.getOperator().FromArgs(sl)
 	if err != nil {
 		return nill, err
 	}
@@ -273,6 +329,15 @@ func (an *Argument[T]) Complete(input *command.Input, data *command.Data) (*comm
 
 	sl, enough := input.PopN(an.minN, an.optionalN, an.opt.inputValidators(), data)
 
+	if an.opt != nil && an.opt.emptyUnset && len(sl) > 0 {
+		// The last value is kept if it's the one being completed.
+		sl = dropEmptyValues(sl, input.FullyProcessed())
+		if len(sl) == 0 {
+			an.setDefault(data)
+			return nil, nil
+		}
+	}
+
 	// If this is the last arg, we want the node walkthrough to stop (which
 	// doesn't happen if c and err are nil).
 	c, err := an.complete(sl, enough, input, data)
@@ -282,14 +347,32 @@ func (an *Argument[T]) Complete(input *command.Input, data *command.Data) (*comm
 	return c, err
 }
 
+// dropEmptyValues removes empty values from the provided list (for the
+// `TreatEmptyAsUnset` option). If `keepLast` is true, then the last value is
+// kept regardless.
+func dropEmptyValues(sl []*string, keepLast bool) []*string {
+	var nonEmpty []*string
+	for idx, s := range sl {
+		if *s != "" || (keepLast && idx == len(sl)-1) {
+			nonEmpty = append(nonEmpty, s)
+		}
+	}
+	return nonEmpty
+}
+
 func (an *Argument[T]) complete(sl []*string, enough bool, input *command.Input, data *command.Data) (*command.Completion, error) {
 	// Try to transform from string to value.
-	op := operator.GetOperator[T]()
-	v, err := op.FromArgs(sl)
+	v, err := an.getOperator().FromArgs(sl)
 	if err != nil {
 		// If we're on the last one, then complete it.
 		if !enough || input.FullyProcessed() {
-			return RunArgumentCompleter(an.opt.completer, v, data)
+			return an.runCompleter(len(sl), v, data)
+		}
+
+		// If running in best-effort mode, then skip this argument (without setting
+		// it in data) so later completers can still run.
+		if spycommander.BestEffortCompletion(data) {
+			return nil, nil
 		}
 
 		return nil, err
@@ -326,7 +409,23 @@ func (an *Argument[T]) complete(sl []*string, enough bool, input *command.Input,
 		return nil, nil
 	}
 
-	return RunArgumentCompleter(an.opt.completer, v, data)
+	return an.runCompleter(len(sl), v, data)
+}
+
+// runCompleter runs the argument's completer (where `got` is the number of
+// values received, including the one being completed) and adds the
+// `RemainingCountHint`, if relevant.
+func (an *Argument[T]) runCompleter(got int, v T, data *command.Data) (*command.Completion, error) {
+	if an.opt == nil {
+		return nil, nil
+	}
+	c, err := runArgumentCompleter(an.opt.completer, an.getOperator(), v, data)
+	if c == nil || !an.opt.countHint || got > an.minN {
+		return c, err
+	}
+	c = c.Clone()
+	c.Hint = fmt.Sprintf("(%d more)", an.minN-got+1)
+	return c, err
 }
 
 // Arg creates an argument `command.Processor` that requires exactly one input.
@@ -351,6 +450,73 @@ func BoolArg(name, desc string) *Argument[bool] {
 	return listArgument[bool](name, desc, 1, 0, BoolCompleter())
 }
 
+// BoolishArg creates a boolean argument that accepts "true", "false", "yes",
+// "no", "on", or "off" (case-insensitive). See `IsBoolish` for string
+// arguments that should accept the same values.
+func BoolishArg(name, desc string, opts ...ArgumentOption[bool]) *Argument[bool] {
+	an := listArgument(name, desc, 1, 0, append([]ArgumentOption[bool]{SimpleCompleter[bool](boolishValues...)}, opts...)...)
+	an.op = &boolishOperator{name}
+	return an
+}
+
+// boolishOperator converts boolean-ish strings to bools (see `parseBoolish`).
+type boolishOperator struct {
+	name string
+}
+
+func (bo *boolishOperator) ToArgs(b bool) []string {
+	return []string{strconv.FormatBool(b)}
+}
+
+func (bo *boolishOperator) FromArgs(sl []*string) (bool, error) {
+	if len(sl) == 0 {
+		return false, nil
+	}
+	b, ok := parseBoolish(*sl[0])
+	if !ok {
+		return false, &validationErr{bo.name, fmt.Errorf("[BoolishArg] value must be one of %v", boolishValues)}
+	}
+	return b, nil
+}
+
+// DurationArg creates an argument whose value is parsed into a `time.Duration`
+// (see `time.ParseDuration` for the accepted formats, e.g. `1h30m`).
+func DurationArg(name, desc string, opts ...ArgumentOption[time.Duration]) *Argument[time.Duration] {
+	return listArgument(name, desc, 1, 0, opts...)
+}
+
+// TimeArg creates an argument whose value is parsed into a `time.Time` using
+// the provided layout (see `time.Parse`). If `layout` is empty, then
+// `time.RFC3339` is used.
+func TimeArg(name, desc, layout string, opts ...ArgumentOption[time.Time]) *Argument[time.Time] {
+	if layout == "" {
+		layout = time.RFC3339
+	}
+	an := listArgument(name, desc, 1, 0, opts...)
+	an.op = &timeArgOperator{name, layout, operator.TimeOperator(layout)}
+	return an
+}
+
+// timeArgOperator wraps the time operator so parsing errors reference the
+// argument name and expected layout.
+type timeArgOperator struct {
+	name   string
+	layout string
+	op     operator.Operator[time.Time]
+}
+
+func (tao *timeArgOperator) ToArgs(t time.Time) []string {
+	return tao.op.ToArgs(t)
+}
+
+func (tao *timeArgOperator) FromArgs(sl []*string) (time.Time, error) {
+	t, err := tao.op.FromArgs(sl)
+	if err != nil {
+		return t, &validationErr{tao.name, fmt.Errorf("[TimeArg] %q does not match layout %q", *sl[0], tao.layout)}
+	}
+	return t, nil
+}
+
 func listArgument[T any](name, desc string, minN, optionalN int, opts ...ArgumentOption[T]) *Argument[T] {
 	return &Argument[T]{
 		name:      name,
@@ -0,0 +1,136 @@
+package commander
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/internal/stubs"
+)
+
+const (
+	redactedArg = "********"
+)
+
+var (
+	osOpenFile = os.OpenFile
+)
+
+// AuditOption is an option interface for modifying `AuditArgv` processors.
+type AuditOption interface {
+	modifyAudit(*audit)
+}
+
+type audit struct {
+	file   string
+	redact []FlagInterface
+}
+
+// AuditFile is an `AuditOption` that appends an audit record to the provided
+// file. Each record is a single tab-separated line containing a timestamp,
+// the current user, and the (redacted) command line arguments.
+func AuditFile(path string) AuditOption {
+	return &auditFile{path}
+}
+
+type auditFile struct {
+	path string
+}
+
+func (af *auditFile) modifyAudit(a *audit) {
+	a.file = af.path
+}
+
+// RedactFlags is an `AuditOption` that redacts the values of the provided
+// flags in audit records. Note that values are only redacted in audit
+// records (not in the arguments stored in `command.Data`).
+func RedactFlags(flags ...FlagInterface) AuditOption {
+	return &redactFlags{flags}
+}
+
+type redactFlags struct {
+	flags []FlagInterface
+}
+
+func (rf *redactFlags) modifyAudit(a *audit) {
+	a.redact = append(a.redact, rf.flags...)
+}
+
+// AuditArgv returns a `command.Processor` that stores the raw command line
+// arguments (as received before any parsing) in `command.Data` under the
+// `command.ArgvKey` key. It should be placed at the start of the command graph.
+// See `AuditFile` to also record the arguments in an audit log.
+// This processor has no effect on completion or usage.
+func AuditArgv(opts ...AuditOption) command.Processor {
+	a := &audit{}
+	for _, opt := range opts {
+		opt.modifyAudit(a)
+	}
+
+	return SimpleProcessor(func(i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
+		argv := i.ConvertedArgs()
+		d.Set(command.ArgvKey, argv)
+
+		if a.file == "" {
+			return nil
+		}
+		if err := a.write(argv); err != nil {
+			return o.Annotatef(err, "[AuditArgv] failed to write audit record to %q", a.file)
+		}
+		return nil
+	}, nil)
+}
+
+func (a *audit) write(argv []string) error {
+	f, err := osOpenFile(a.file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(f, "%s\t%s\t%q\n", stubs.TimeNow().Format(time.RFC3339), auditUser(), a.redacted(argv)); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// redacted returns a copy of the arguments with the values of redacted flags
+// replaced. All of the values that a flag can consume are redacted (which may
+// be more than the flag actually consumed, but never fewer).
+func (a *audit) redacted(argv []string) []string {
+	// Map from flag indicator to the number of values to redact.
+	counts := map[string]int{}
+	for _, f := range a.redact {
+		cnt := 1
+		if ac, ok := f.Processor().(interface{ argCount() int }); ok {
+			cnt = ac.argCount()
+		}
+		counts[flagName(f)] = cnt
+		if f.ShortName() != FlagNoShortName {
+			counts[flagShortName(f)] = cnt
+		}
+	}
+
+	r := slices.Clone(argv)
+	for j := 0; j < len(r); j++ {
+		cnt, ok := counts[r[j]]
+		if !ok {
+			continue
+		}
+		for k := 0; j+1 < len(r) && (cnt == command.UnboundedList || k < cnt); k++ {
+			j++
+			r[j] = redactedArg
+		}
+	}
+	return r
+}
+
+func auditUser() string {
+	for _, key := range []string{"USER", "USERNAME"} {
+		if u, ok := command.OSLookupEnv(key); ok && u != "" {
+			return u
+		}
+	}
+	return "unknown"
+}
@@ -0,0 +1,180 @@
+package commander
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/commandtest"
+	"github.com/leep-frog/command/internal/spycommand"
+	"github.com/leep-frog/command/internal/spycommandtest"
+	"github.com/leep-frog/command/internal/stubs"
+	"github.com/leep-frog/command/internal/testutil"
+)
+
+func TestAuditArgv(t *testing.T) {
+	now := time.Date(2024, 3, 14, 15, 9, 26, 0, time.UTC)
+	passwordFlag := Flag[string]("password", 'p', testDesc)
+	tokenFlag := Flag[string]("token", FlagNoShortName, testDesc)
+	nameFlag := Flag[string]("name", 'n', testDesc)
+	secretsFlag := ListFlag[string]("secrets", 's', testDesc, 1, 2)
+	keysFlag := ListFlag[string]("keys", 'k', testDesc, 0, command.UnboundedList)
+	quietFlag := BoolFlag("quiet", 'q', testDesc)
+	flags := FlagProcessor(passwordFlag, tokenFlag, nameFlag, secretsFlag, keysFlag, quietFlag)
+
+	for _, test := range []struct {
+		name string
+		// opts returns the options to provide to `AuditArgv`.
+		opts func(auditFile string) []AuditOption
+		// existing is the content of the audit file before the test is run.
+		existing  string
+		args      []string
+		env       map[string]string
+		wantData  map[string]interface{}
+		wantAudit string
+	}{
+		{
+			name: "stores argv in data",
+			args: []string{"--name", "alice", "--password", "hunter2"},
+			wantData: map[string]interface{}{
+				command.ArgvKey: []string{"--name", "alice", "--password", "hunter2"},
+				"name":          "alice",
+				"password":      "hunter2",
+			},
+		},
+		{
+			name: "stores empty argv in data",
+			wantData: map[string]interface{}{
+				command.ArgvKey: []string(nil),
+			},
+		},
+		{
+			name: "appends record to audit file",
+			opts: func(f string) []AuditOption {
+				return []AuditOption{AuditFile(f)}
+			},
+			existing: "previous record\n",
+			args:     []string{"-n", "bob smith"},
+			env:      map[string]string{"USER": "alice"},
+			wantData: map[string]interface{}{
+				command.ArgvKey: []string{"-n", "bob smith"},
+				"name":          "bob smith",
+			},
+			wantAudit: "previous record\n2024-03-14T15:09:26Z\talice\t[\"-n\" \"bob smith\"]\n",
+		},
+		{
+			name: "redacts sensitive flags in audit record but not in data",
+			opts: func(f string) []AuditOption {
+				return []AuditOption{AuditFile(f), RedactFlags(passwordFlag, tokenFlag)}
+			},
+			args: []string{"-p", "hunter2", "-n", "bob", "--token", "abc123"},
+			env:  map[string]string{"USERNAME": "bob"},
+			wantData: map[string]interface{}{
+				command.ArgvKey: []string{"-p", "hunter2", "-n", "bob", "--token", "abc123"},
+				"name":          "bob",
+				"password":      "hunter2",
+				"token":         "abc123",
+			},
+			wantAudit: "2024-03-14T15:09:26Z\tbob\t[\"-p\" \"********\" \"-n\" \"bob\" \"--token\" \"********\"]\n",
+		},
+		{
+			name: "redacts all values of list flags",
+			opts: func(f string) []AuditOption {
+				return []AuditOption{AuditFile(f), RedactFlags(secretsFlag)}
+			},
+			args: []string{"-n", "bob", "--secrets", "a", "b"},
+			env:  map[string]string{"USER": "bob"},
+			wantData: map[string]interface{}{
+				command.ArgvKey: []string{"-n", "bob", "--secrets", "a", "b"},
+				"name":          "bob",
+				"secrets":       []string{"a", "b"},
+			},
+			wantAudit: "2024-03-14T15:09:26Z\tbob\t[\"-n\" \"bob\" \"--secrets\" \"********\" \"********\"]\n",
+		},
+		{
+			name: "redacts all remaining values of unbounded list flags",
+			opts: func(f string) []AuditOption {
+				return []AuditOption{AuditFile(f), RedactFlags(keysFlag, quietFlag)}
+			},
+			args: []string{"-q", "-n", "bob", "-k", "a", "b", "c"},
+			env:  map[string]string{"USER": "bob"},
+			wantData: map[string]interface{}{
+				command.ArgvKey: []string{"-q", "-n", "bob", "-k", "a", "b", "c"},
+				"name":          "bob",
+				"keys":          []string{"a", "b", "c"},
+				"quiet":         true,
+			},
+			wantAudit: "2024-03-14T15:09:26Z\tbob\t[\"-q\" \"-n\" \"bob\" \"-k\" \"********\" \"********\" \"********\"]\n",
+		},
+		{
+			name: "uses unknown user if no user env variable is set",
+			opts: func(f string) []AuditOption {
+				return []AuditOption{AuditFile(f)}
+			},
+			wantData: map[string]interface{}{
+				command.ArgvKey: []string(nil),
+			},
+			wantAudit: "2024-03-14T15:09:26Z\tunknown\t[]\n",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			stubs.StubClock(t, func() time.Time { return now }, nil)
+			f := filepath.Join(t.TempDir(), "audit.log")
+			if test.existing != "" {
+				if err := os.WriteFile(f, []byte(test.existing), 0600); err != nil {
+					t.Fatalf("failed to write audit file: %v", err)
+				}
+			}
+			var opts []AuditOption
+			if test.opts != nil {
+				opts = test.opts(f)
+			}
+
+			var wantInput []*spycommand.InputArg
+			for _, a := range test.args {
+				wantInput = append(wantInput, &spycommand.InputArg{Value: a})
+			}
+			executeTest(t, &commandtest.ExecuteTestCase{
+				Node:     SerialNodes(AuditArgv(opts...), flags),
+				Args:     test.args,
+				Env:      test.env,
+				WantData: &command.Data{Values: test.wantData},
+			}, &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: wantInput,
+				},
+			})
+
+			var gotAudit string
+			if b, err := os.ReadFile(f); err == nil {
+				gotAudit = string(b)
+			} else if !os.IsNotExist(err) {
+				t.Fatalf("failed to read audit file: %v", err)
+			}
+			testutil.Cmp(t, "AuditArgv wrote incorrect audit record", test.wantAudit, gotAudit)
+		})
+	}
+}
+
+func TestAuditArgvWriteFailure(t *testing.T) {
+	testutil.StubValue(t, &osOpenFile, func(name string, flag int, perm os.FileMode) (*os.File, error) {
+		return nil, fmt.Errorf("disk is full")
+	})
+	executeTest(t, &commandtest.ExecuteTestCase{
+		Node: SerialNodes(AuditArgv(AuditFile("audit.log"))),
+		Args: []string{"hello"},
+		WantData: &command.Data{Values: map[string]interface{}{
+			command.ArgvKey: []string{"hello"},
+		}},
+		WantStderr: "[AuditArgv] failed to write audit record to \"audit.log\": disk is full\n",
+		WantErr:    fmt.Errorf(`[AuditArgv] failed to write audit record to "audit.log": disk is full`),
+	}, &spycommandtest.ExecuteTestCase{
+		WantInput: &spycommandtest.SpyInput{
+			Args:      []*spycommand.InputArg{{Value: "hello"}},
+			Remaining: []int{0},
+		},
+	})
+}
@@ -27,3 +27,26 @@ func processGraphCompletion(n command.Node, input *command.Input, data *command.
 func processOrComplete(p command.Processor, input *command.Input, data *command.Data) (*command.Completion, error) {
 	return spycommander.ProcessOrComplete(p, input, data)
 }
+
+// BestEffortCompletion returns a `command.Processor` that runs the remainder of
+// the command graph in best-effort completion mode. In this mode, arguments whose
+// values can't be converted (e.g. "two" for an `Arg[int]`) are skipped rather
+// than stopping completion, so later completers still run.
+// This processor has no effect on execution or usage.
+func BestEffortCompletion() command.Processor {
+	return SimpleProcessor(nil, func(i *command.Input, d *command.Data) (*command.Completion, error) {
+		spycommander.SetBestEffortCompletion(d)
+		return nil, nil
+	})
+}
+
+// DefaultMaxSuggestions returns a `command.Processor` that sets the maximum
+// number of suggestions returned by completions in the remainder of the graph
+// that don't set `command.Completion.MaxSuggestions` (see that field for more
+// details). This processor has no effect on execution or usage.
+func DefaultMaxSuggestions(max int) command.Processor {
+	return SimpleProcessor(nil, func(i *command.Input, d *command.Data) (*command.Completion, error) {
+		spycommander.SetDefaultMaxSuggestions(d, max)
+		return nil, nil
+	})
+}
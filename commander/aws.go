@@ -0,0 +1,119 @@
+package commander
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/leep-frog/command/command"
+)
+
+var (
+	// awsRegions is the list of AWS regions suggested by `AWSRegionCompleter`.
+	awsRegions = []string{
+		"af-south-1",
+		"ap-east-1",
+		"ap-northeast-1",
+		"ap-northeast-2",
+		"ap-northeast-3",
+		"ap-south-1",
+		"ap-south-2",
+		"ap-southeast-1",
+		"ap-southeast-2",
+		"ap-southeast-3",
+		"ap-southeast-4",
+		"ca-central-1",
+		"ca-west-1",
+		"eu-central-1",
+		"eu-central-2",
+		"eu-north-1",
+		"eu-south-1",
+		"eu-south-2",
+		"eu-west-1",
+		"eu-west-2",
+		"eu-west-3",
+		"il-central-1",
+		"me-central-1",
+		"me-south-1",
+		"sa-east-1",
+		"us-east-1",
+		"us-east-2",
+		"us-gov-east-1",
+		"us-gov-west-1",
+		"us-west-1",
+		"us-west-2",
+	}
+)
+
+// AWSProfileCompleter returns a `Completer` that suggests the profiles defined
+// in the AWS config file (`$AWS_CONFIG_FILE` or `~/.aws/config`). No
+// suggestions are returned if the config file can't be read.
+func AWSProfileCompleter() Completer[string] {
+	return CompleterFromFunc(func(string, *command.Data) (*command.Completion, error) {
+		profiles, err := readAWSProfiles()
+		if err != nil {
+			return nil, nil
+		}
+		return &command.Completion{
+			Suggestions: profiles,
+		}, nil
+	})
+}
+
+// AWSRegionCompleter returns a `Completer` that suggests AWS region names
+// (e.g. `us-east-1`).
+func AWSRegionCompleter() Completer[string] {
+	return CompleterFromFunc(func(string, *command.Data) (*command.Completion, error) {
+		return &command.Completion{
+			Suggestions: awsRegions,
+		}, nil
+	})
+}
+
+// readAWSProfiles reads the profile names from the AWS config file.
+func readAWSProfiles() ([]string, error) {
+	path, ok := command.OSLookupEnv("AWS_CONFIG_FILE")
+	if !ok || path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		path = filepath.Join(home, ".aws", "config")
+	}
+
+	lines, err := ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseAWSProfiles(lines), nil
+}
+
+// parseAWSProfiles returns the profile names from the provided AWS config
+// file lines. Profiles are defined in sections named `[default]` or
+// `[profile <name>]`. Other sections (e.g. `[sso-session <name>]`) are ignored.
+func parseAWSProfiles(lines []string) []string {
+	var profiles []string
+	seen := map[string]bool{}
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "[") || !strings.HasSuffix(line, "]") {
+			continue
+		}
+		section := strings.TrimSpace(line[1 : len(line)-1])
+
+		profile := section
+		if section != "default" {
+			name, ok := strings.CutPrefix(section, "profile ")
+			if !ok {
+				continue
+			}
+			profile = strings.TrimSpace(name)
+		}
+
+		if profile != "" && !seen[profile] {
+			seen[profile] = true
+			profiles = append(profiles, profile)
+		}
+	}
+	return profiles
+}
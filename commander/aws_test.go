@@ -0,0 +1,170 @@
+package commander
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/commandtest"
+	"github.com/leep-frog/command/internal/stubs"
+	"github.com/leep-frog/command/internal/testutil"
+)
+
+func TestAWSCompleters(t *testing.T) {
+	config := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(config, []byte(strings.Join([]string{
+		"[default]",
+		"[profile dev]",
+		"[profile dev-admin]",
+		"[profile prod]",
+	}, "\n")), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	configEnv := map[string]string{"AWS_CONFIG_FILE": config}
+
+	for _, test := range []struct {
+		name string
+		ctc  *commandtest.CompleteTestCase
+	}{
+		{
+			name: "AWSProfileCompleter suggests all profiles",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(Arg[string]("PROFILE", testDesc, AWSProfileCompleter())),
+				Args: "cmd ",
+				Env:  configEnv,
+				Want: &command.Autocompletion{
+					Suggestions: []string{"default", "dev", "dev-admin", "prod"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"PROFILE": "",
+				}},
+			},
+		},
+		{
+			name: "AWSProfileCompleter suggests profiles matching prefix",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(Arg[string]("PROFILE", testDesc, AWSProfileCompleter())),
+				Args: "cmd dev",
+				Env:  configEnv,
+				Want: &command.Autocompletion{
+					Suggestions: []string{"dev", "dev-admin"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"PROFILE": "dev",
+				}},
+			},
+		},
+		{
+			name: "AWSProfileCompleter returns nothing if config file can't be read",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(Arg[string]("PROFILE", testDesc, AWSProfileCompleter())),
+				Args: "cmd ",
+				Env: map[string]string{
+					"AWS_CONFIG_FILE": filepath.Join(t.TempDir(), "does-not-exist"),
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"PROFILE": "",
+				}},
+			},
+		},
+		{
+			name: "AWSRegionCompleter suggests all regions",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(Arg[string]("REGION", testDesc, AWSRegionCompleter())),
+				Args: "cmd ",
+				Want: &command.Autocompletion{
+					Suggestions: awsRegions,
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"REGION": "",
+				}},
+			},
+		},
+		{
+			name: "AWSRegionCompleter suggests regions matching prefix",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(Arg[string]("REGION", testDesc, AWSRegionCompleter())),
+				Args: "cmd us-e",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"us-east-1", "us-east-2"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"REGION": "us-e",
+				}},
+			},
+		},
+		{
+			name: "AWSRegionCompleter suggests regions from bundled list",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(Arg[string]("REGION", testDesc, AWSRegionCompleter())),
+				Args: "cmd ca-",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"ca-central-1", "ca-west-1"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"REGION": "ca-",
+				}},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			autocompleteTest(t, test.ctc, nil)
+		})
+	}
+}
+
+func TestReadAWSProfiles(t *testing.T) {
+	config := strings.Join([]string{
+		"[default]",
+		"region = us-east-1",
+		"",
+		"[profile dev]",
+		"region = us-west-2",
+		"",
+		"  [profile  prod ]  ",
+		"region = eu-west-1",
+		"",
+		"[sso-session my-sso]",
+		"sso_region = us-east-1",
+		"",
+		"[services my-services]",
+		"",
+		"[profile dev]",
+		"output = json",
+	}, "\n")
+
+	for _, test := range []struct {
+		name     string
+		contents *string
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name:     "reads profiles from config file",
+			contents: &config,
+			want:     []string{"default", "dev", "prod"},
+		},
+		{
+			name:    "fails if config file doesn't exist",
+			wantErr: true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config")
+			if test.contents != nil {
+				if err := os.WriteFile(path, []byte(*test.contents), 0644); err != nil {
+					t.Fatalf("failed to write config file: %v", err)
+				}
+			}
+			stubs.StubEnv(t, map[string]string{"AWS_CONFIG_FILE": path})
+
+			got, err := readAWSProfiles()
+			if (err != nil) != test.wantErr {
+				t.Fatalf("readAWSProfiles() returned error %v; want error: %v", err, test.wantErr)
+			}
+			testutil.Cmp(t, "readAWSProfiles() returned incorrect profiles", test.want, got)
+		})
+	}
+}
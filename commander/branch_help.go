@@ -0,0 +1,73 @@
+package commander
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/internal/spycommander"
+)
+
+// BranchCompleter returns a `Completer` that suggests the branch names (and
+// branch synonyms) of the provided `BranchNode`.
+func BranchCompleter(bn *BranchNode) Completer[string] {
+	return CompleterFromFunc(func(s string, d *command.Data) (*command.Completion, error) {
+		var suggestions []string
+		for name, bs := range bn.getSyns() {
+			suggestions = append(suggestions, name)
+			suggestions = append(suggestions, bs.values...)
+		}
+		return &command.Completion{
+			Suggestions:     suggestions,
+			CaseInsensitive: true,
+		}, nil
+	})
+}
+
+// BranchHelp returns a `command.Node` for a meta "help" command. It takes a
+// single branch name (or branch synonym) of the provided `BranchNode` as an
+// argument (with completion provided by `BranchCompleter`) and prints the
+// usage of that branch to stdout. For example:
+//
+//	bn := &BranchNode{Branches: map[string]command.Node{...}}
+//	bn.Branches["help"] = BranchHelp(bn)
+func BranchHelp(bn *BranchNode) command.Node {
+	arg := Arg[string](
+		"BRANCH",
+		"Subcommand for which usage should be displayed",
+		BranchCompleter(bn),
+		&ValidatorOption[string]{
+			func(s string, d *command.Data) error {
+				if !bn.IsBranch(s) {
+					return fmt.Errorf("[BranchHelp] %q is not a valid branch", s)
+				}
+				return nil
+			},
+			"BranchHelp()",
+		},
+	)
+	return SerialNodes(arg, &ExecutorProcessor{func(o command.Output, d *command.Data) error {
+		u, err := bn.branchUsage(arg.Get(d))
+		if err != nil {
+			return o.Annotatef(err, "[BranchHelp] failed to get usage")
+		}
+		o.Stdoutln(u.String())
+		return nil
+	}})
+}
+
+// branchUsage returns the usage for the branch with the provided name or synonym.
+func (bn *BranchNode) branchUsage(s string) (*command.Usage, error) {
+	for name, bs := range bn.getSyns() {
+		if name != s && !slices.Contains(bs.values, s) {
+			continue
+		}
+		u := &command.Usage{}
+		u.AddArg(name, "", 1, 0)
+		if err := spycommander.ProcessGraphUse(bs.n, command.ParseExecuteArgs(nil), &command.Data{}, u); err != nil {
+			return nil, fmt.Errorf("failed to get usage for branch %s: %v", name, err)
+		}
+		return u, nil
+	}
+	return nil, fmt.Errorf("unknown branch %q", s)
+}
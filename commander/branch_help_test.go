@@ -0,0 +1,156 @@
+package commander
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/commandtest"
+	"github.com/leep-frog/command/internal/spycommand"
+	"github.com/leep-frog/command/internal/spycommandtest"
+)
+
+func branchHelpTestNode() *BranchNode {
+	bn := &BranchNode{
+		Branches: map[string]command.Node{
+			"deploy d": SerialNodes(
+				Description("Deploys the service"),
+				FlagProcessor(BoolFlag("force", 'f', "Deploy even if checks fail")),
+				Arg[string]("ENV", "Environment to deploy to"),
+			),
+			"status": SerialNodes(
+				Description("Shows the service status"),
+			),
+		},
+		Synonyms: BranchSynonyms(map[string][]string{
+			"status": {"st"},
+		}),
+	}
+	bn.Branches["help"] = BranchHelp(bn)
+	return bn
+}
+
+func TestBranchHelp(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		etc  *commandtest.ExecuteTestCase
+		ietc *spycommandtest.ExecuteTestCase
+	}{
+		{
+			name: "prints usage for the selected branch",
+			etc: &commandtest.ExecuteTestCase{
+				Node: branchHelpTestNode(),
+				Args: []string{"help", "deploy"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"BRANCH": "deploy",
+				}},
+				WantStdout: strings.Join([]string{
+					"Deploys the service",
+					"deploy ENV --force|-f",
+					"",
+					"Arguments:",
+					"  ENV: Environment to deploy to",
+					"",
+					"Flags:",
+					"  [f] force: Deploy even if checks fail",
+					"",
+				}, "\n"),
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "help"}, {Value: "deploy"}},
+				},
+			},
+		},
+		{
+			name: "prints usage for a branch synonym",
+			etc: &commandtest.ExecuteTestCase{
+				Node: branchHelpTestNode(),
+				Args: []string{"help", "st"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"BRANCH": "st",
+				}},
+				WantStdout: "Shows the service status\nstatus\n",
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "help"}, {Value: "st"}},
+				},
+			},
+		},
+		{
+			name: "fails for an unknown branch",
+			etc: &commandtest.ExecuteTestCase{
+				Node: branchHelpTestNode(),
+				Args: []string{"help", "destroy"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"BRANCH": "destroy",
+				}},
+				WantStderr: "validation for \"BRANCH\" failed: [BranchHelp] \"destroy\" is not a valid branch\n",
+				WantErr:    fmt.Errorf(`validation for "BRANCH" failed: [BranchHelp] "destroy" is not a valid branch`),
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "help"}, {Value: "destroy"}},
+				},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			executeTest(t, test.etc, test.ietc)
+		})
+	}
+}
+
+func TestBranchHelpCompletion(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		ctc  *commandtest.CompleteTestCase
+	}{
+		{
+			name: "completes branch names and synonyms",
+			ctc: &commandtest.CompleteTestCase{
+				Node: branchHelpTestNode(),
+				Args: "cmd help ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"d", "deploy", "help", "st", "status"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"BRANCH": "",
+				}},
+			},
+		},
+		{
+			name: "completes branch names and synonyms matching prefix",
+			ctc: &commandtest.CompleteTestCase{
+				Node: branchHelpTestNode(),
+				Args: "cmd help S",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"st", "status"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"BRANCH": "S",
+				}},
+			},
+		},
+		{
+			name: "BranchCompleter works on its own",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(Arg[string]("SUBCOMMAND", testDesc, BranchCompleter(branchHelpTestNode()))),
+				Args: "cmd de",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"deploy"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"SUBCOMMAND": "de",
+				}},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			autocompleteTest(t, test.ctc, nil)
+		})
+	}
+}
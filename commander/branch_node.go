@@ -33,6 +33,11 @@ type BranchNode struct {
 	// If this is nil, then branches are sorted in alphabetical order.
 	// If this is an empty list, then no branch usage is shown.
 	BranchUsageOrder []string
+	// UnknownBranchKey is the `command.Data` key under which the branching
+	// argument is stored when it doesn't match any branch and the `Default`
+	// node is traversed instead. The argument is not consumed, so the `Default`
+	// node still processes it as well. If empty, then nothing is stored.
+	UnknownBranchKey string
 
 	next command.Node
 }
@@ -122,6 +127,7 @@ func (bn *BranchNode) getNext(input *command.Input, data *command.Data) error {
 		return nil
 	}
 
+	original := s
 	if bn.Synonyms != nil {
 		if syn, ok := bn.Synonyms[s]; ok {
 			s = syn
@@ -146,6 +152,9 @@ func (bn *BranchNode) getNext(input *command.Input, data *command.Data) error {
 	}
 
 	if bn.Default != nil {
+		if bn.UnknownBranchKey != "" {
+			data.Set(bn.UnknownBranchKey, original)
+		}
 		bn.next = bn.Default
 		return nil
 	}
@@ -5,8 +5,11 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"reflect"
 	"regexp"
+	"sort"
 	"strings"
+	"time"
 	"unicode"
 
 	"github.com/leep-frog/command/command"
@@ -79,16 +82,47 @@ type Completer[T any] interface {
 // until after the provided graph is run. See the `DeferredCompletion` object
 // for more info.
 func DeferredCompleter[T any](graph command.Node, completer Completer[T]) Completer[T] {
-	return CompleterFromFunc(func(t T, d *command.Data) (*command.Completion, error) {
-		return &command.Completion{
-			DeferredCompletion: &command.DeferredCompletion{
-				graph,
-				func(c *command.Completion, d *command.Data) (*command.Completion, error) {
-					return RunArgumentCompleter(completer, t, d)
-				},
+	return &deferredCompleter[T]{graph, completer}
+}
+
+type deferredCompleter[T any] struct {
+	graph     command.Node
+	completer Completer[T]
+}
+
+func (dc *deferredCompleter[T]) Complete(t T, d *command.Data) (*command.Completion, error) {
+	return dc.completeWithOperator(t, operator.GetOperator[T](), d)
+}
+
+func (dc *deferredCompleter[T]) completeWithOperator(t T, op operator.Operator[T], d *command.Data) (*command.Completion, error) {
+	return &command.Completion{
+		DeferredCompletion: &command.DeferredCompletion{
+			dc.graph,
+			func(c *command.Completion, d *command.Data) (*command.Completion, error) {
+				return runArgumentCompleter(dc.completer, op, t, d)
 			},
-		}, nil
-	})
+		},
+	}, nil
+}
+
+func (dc *deferredCompleter[T]) modifyArgumentOption(ao *argumentOption[T]) {
+	ao.completer = dc
+}
+
+// operatorCompleter is an optional interface for `Completer` objects that
+// convert values to or from strings. Arguments with a custom operator (e.g.
+// `TimeArg`) provide it here so completers don't fall back to the default
+// operator for the type.
+type operatorCompleter[T any] interface {
+	completeWithOperator(T, operator.Operator[T], *command.Data) (*command.Completion, error)
+}
+
+// completeWithOperator runs the completer with the provided operator, if supported.
+func completeWithOperator[T any](c Completer[T], op operator.Operator[T], t T, d *command.Data) (*command.Completion, error) {
+	if oc, ok := c.(operatorCompleter[T]); ok {
+		return oc.completeWithOperator(t, op, d)
+	}
+	return c.Complete(t, d)
 }
 
 // CompleterWithOpts sets the relevant options in the `command.Completion` object
@@ -103,7 +137,11 @@ type cmplWithOpts[T any] struct {
 }
 
 func (cwo *cmplWithOpts[T]) Complete(t T, d *command.Data) (*command.Completion, error) {
-	c, err := cwo.cr.Complete(t, d)
+	return cwo.completeWithOperator(t, operator.GetOperator[T](), d)
+}
+
+func (cwo *cmplWithOpts[T]) completeWithOperator(t T, op operator.Operator[T], d *command.Data) (*command.Completion, error) {
+	c, err := completeWithOperator(cwo.cr, op, t, d)
 	if c != nil {
 		s := c.Suggestions
 		c = cwo.cn.Clone()
@@ -143,27 +181,147 @@ func BoolCompleter() Completer[bool] {
 	return SimpleCompleter[bool](constants.BoolStringValues...)
 }
 
+// BoolishCompleter is a completer for boolean-ish strings (see `IsBoolish`).
+func BoolishCompleter() Completer[string] {
+	return SimpleCompleter[string](boolishValues...)
+}
+
+// InValuesCompleter is a completer that suggests the provided numeric values.
+// See `InValues` for the corresponding validator.
+func InValuesCompleter[T Numeric](values ...T) Completer[T] {
+	var suggestions []string
+	for _, v := range values {
+		suggestions = append(suggestions, fmt.Sprintf("%v", v))
+	}
+	return SimpleCompleter[T](suggestions...)
+}
+
+// QueryCompleter is a completer that suggests the values returned by the
+// provided query function (e.g. a lookup in a local database). The query is
+// given the (last) argument value being completed so it can filter results by
+// prefix. If the query fails, then no suggestions are returned (rather than
+// a completion error).
+func QueryCompleter[T any](query func(prefix string, d *command.Data) ([]string, error)) Completer[T] {
+	return &queryCompleter[T]{query}
+}
+
+type queryCompleter[T any] struct {
+	query func(prefix string, d *command.Data) ([]string, error)
+}
+
+func (qc *queryCompleter[T]) Complete(t T, d *command.Data) (*command.Completion, error) {
+	return qc.completeWithOperator(t, operator.GetOperator[T](), d)
+}
+
+func (qc *queryCompleter[T]) completeWithOperator(t T, op operator.Operator[T], d *command.Data) (*command.Completion, error) {
+	var prefix string
+	if args := op.ToArgs(t); len(args) > 0 {
+		prefix = args[len(args)-1]
+	}
+
+	suggestions, err := qc.query(prefix, d)
+	if err != nil {
+		return nil, nil
+	}
+	return &command.Completion{
+		Suggestions: suggestions,
+	}, nil
+}
+
+func (qc *queryCompleter[T]) modifyArgumentOption(ao *argumentOption[T]) {
+	ao.completer = qc
+}
+
+// StructFieldCompleter is a completer that suggests the (exported) field
+// paths of the provided struct (or pointer to struct), which is useful for
+// arguments that reference a field in a config struct. Nested struct fields
+// are suggested as dotted paths (e.g. `Parent.Child`) and the fields of
+// embedded structs are suggested as if they were fields of the parent struct.
+// If `tag` is provided, then the field's tag name (e.g. `json:"name"`) is used
+// instead of the field name (when set) and fields with a tag name of "-" are
+// ignored.
+func StructFieldCompleter[T any](v interface{}, tag string) Completer[T] {
+	return CompleterFromFunc(func(T, *command.Data) (*command.Completion, error) {
+		t := reflect.TypeOf(v)
+		for t != nil && t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if t == nil || t.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("[StructFieldCompleter] expected a struct type, got %T", v)
+		}
+		return &command.Completion{
+			Suggestions: structFieldPaths(t, tag, "", map[reflect.Type]bool{}),
+		}, nil
+	})
+}
+
+// structFieldPaths returns the field paths for the provided type. The visited
+// map contains the types in the current path so recursive types terminate.
+func structFieldPaths(t reflect.Type, tag, prefix string, visited map[reflect.Type]bool) []string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || visited[t] {
+		return nil
+	}
+	visited[t] = true
+	defer delete(visited, t)
+
+	var paths []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, tagged := f.Name, false
+		if tag != "" {
+			tn, _, _ := strings.Cut(f.Tag.Get(tag), ",")
+			if tn == "-" {
+				continue
+			}
+			if tn != "" {
+				name, tagged = tn, true
+			}
+		}
+
+		// Untagged embedded structs are flattened into the parent.
+		if f.Anonymous && !tagged {
+			paths = append(paths, structFieldPaths(f.Type, tag, prefix, visited)...)
+			continue
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+		paths = append(paths, prefix+name)
+		paths = append(paths, structFieldPaths(f.Type, tag, prefix+name+".", visited)...)
+	}
+	return paths
+}
+
 // RunArgumentCompleter generates a `command.Completion` object from the provided
 // `Completer` and inputs.
 func RunArgumentCompleter[T any](c Completer[T], value T, data *command.Data) (*command.Completion, error) {
+	return runArgumentCompleter(c, operator.GetOperator[T](), value, data)
+}
+
+func runArgumentCompleter[T any](c Completer[T], op operator.Operator[T], value T, data *command.Data) (*command.Completion, error) {
 	if c == nil {
 		return nil, nil
 	}
 
-	completion, err := c.Complete(value, data)
+	completion, err := completeWithOperator(c, op, value, data)
 	if completion == nil || err != nil {
 		return nil, err
 	}
 
-	return RunArgumentCompletion(completion, value, data)
+	return runArgumentCompletion(completion, op, value, data)
 }
 
 // RunArgumentCompletion generates a `command.Completion` object from the provided
 // `command.Completion` and inputs.
 func RunArgumentCompletion[T any](completion *command.Completion, value T, data *command.Data) (*command.Completion, error) {
+	return runArgumentCompletion(completion, operator.GetOperator[T](), value, data)
+}
 
-	op := operator.GetOperator[T]()
-
+func runArgumentCompletion[T any](completion *command.Completion, op operator.Operator[T], value T, data *command.Data) (*command.Completion, error) {
 	if completion.Distinct {
 		existingValues := map[string]bool{}
 		// Don't include the last element because sometimes we want to just add a
@@ -192,6 +350,12 @@ type FileCompleter[T any] struct {
 	Regexp *regexp.Regexp
 	// Directory is the directory in which to search for files.
 	Directory string
+	// BaseDir returns the directory in which to search for files. Unlike
+	// `Directory`, the base directory is determined at completion time (e.g.
+	// from a previous argument or by searching for a project root). Relative
+	// paths are completed (and suggested) relative to it. If set, then it takes
+	// precedence over `Directory`.
+	BaseDir func(*command.Data) (string, error)
 	// Distinct is whether or not each argument has to be unique.
 	// Separate from command.Completion.Distinct because file completion
 	// does more complicated custom logic (like only comparing
@@ -211,6 +375,10 @@ type FileCompleter[T any] struct {
 	// MaxDepth is the maximum depth for files allowed. If less than or equal to zero,
 	// then no limit is applied.
 	MaxDepth int
+	// SortByMTime indicates whether suggestions should be ordered by modification
+	// time (newest first) rather than alphabetically. Files whose modification
+	// time can't be determined are suggested last.
+	SortByMTime bool
 }
 
 func (ff *FileCompleter[T]) modifyArgumentOption(ao *argumentOption[T]) {
@@ -241,6 +409,14 @@ func (ff *FileCompleter[T]) Complete(value T, data *command.Data) (*command.Comp
 		lastArg = args[len(args)-1]
 	}
 
+	baseDir := ff.Directory
+	if ff.BaseDir != nil {
+		var err error
+		if baseDir, err = ff.BaseDir(data); err != nil {
+			return nil, fmt.Errorf("failed to get base directory: %v", err)
+		}
+	}
+
 	laDir, laFile := filepath.Split(filepath.FromSlash(lastArg))
 	tooDeep := ff.MaxDepth > 0 && filepathDepth(lastArg) >= ff.MaxDepth
 	var dir string
@@ -249,7 +425,7 @@ func (ff *FileCompleter[T]) Complete(value T, data *command.Data) (*command.Comp
 		dir = laDir
 	} else {
 		var err error
-		dir, err = filepathAbs(filepath.Join(ff.Directory, laDir))
+		dir, err = filepathAbs(filepath.Join(baseDir, laDir))
 		if err != nil {
 			return nil, fmt.Errorf("failed to get absolute filepath: %v", err)
 		}
@@ -287,6 +463,7 @@ func (ff *FileCompleter[T]) Complete(value T, data *command.Data) (*command.Comp
 
 	onlyDir := true
 	suggestions := make([]string, 0, len(files))
+	modTimes := map[string]time.Time{}
 	allowedFileTypes := map[string]bool{}
 	for _, ft := range ff.FileTypes {
 		allowedFileTypes[ft] = true
@@ -310,15 +487,22 @@ func (ff *FileCompleter[T]) Complete(value T, data *command.Data) (*command.Comp
 			continue
 		}
 
+		suggestion := f.Name()
 		if isDir {
-			if tooDeep {
-				suggestions = append(suggestions, f.Name())
-			} else {
-				suggestions = append(suggestions, filepath.FromSlash(fmt.Sprintf("%s/", f.Name())))
+			if !tooDeep {
+				suggestion = filepath.FromSlash(fmt.Sprintf("%s/", f.Name()))
 			}
 		} else if len(allowedFileTypes) == 0 || allowedFileTypes[filepath.Ext(f.Name())] {
 			onlyDir = false
-			suggestions = append(suggestions, f.Name())
+		} else {
+			continue
+		}
+		suggestions = append(suggestions, suggestion)
+
+		if ff.SortByMTime {
+			if fi, err := f.Info(); err == nil {
+				modTimes[suggestion] = fi.ModTime()
+			}
 		}
 	}
 
@@ -345,7 +529,7 @@ func (ff *FileCompleter[T]) Complete(value T, data *command.Data) (*command.Comp
 			continue
 		}
 
-		if absFP, err := filepathAbs(filepath.Join(ff.Directory, fullPath)); err == nil && absSet[absFP] {
+		if absFP, err := filepathAbs(filepath.Join(baseDir, fullPath)); err == nil && absSet[absFP] {
 			continue
 		}
 
@@ -365,6 +549,11 @@ func (ff *FileCompleter[T]) Complete(value T, data *command.Data) (*command.Comp
 		IgnoreFilter:        true,
 		CaseInsensitiveSort: true,
 	}
+	if ff.SortByMTime {
+		sortByMTime(suggestions, modTimes)
+		c.CaseInsensitiveSort = false
+		c.PreserveOrder = true
+	}
 
 	// If only 1 suggestion matching, then we want it to autocomplete the whole thing.
 	if len(c.Suggestions) == 1 {
@@ -409,6 +598,23 @@ func (ff *FileCompleter[T]) Complete(value T, data *command.Data) (*command.Comp
 	return c, nil
 }
 
+// sortByMTime sorts the provided suggestions by modification time (newest
+// first). Suggestions without a modification time are sorted (alphabetically)
+// after all other suggestions.
+func sortByMTime(suggestions []string, modTimes map[string]time.Time) {
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		ti, iok := modTimes[suggestions[i]]
+		tj, jok := modTimes[suggestions[j]]
+		if iok != jok {
+			return iok
+		}
+		if !iok || ti.Equal(tj) {
+			return strings.ToLower(suggestions[i]) < strings.ToLower(suggestions[j])
+		}
+		return ti.After(tj)
+	})
+}
+
 func getAutofillLetters(laFile string, suggestions []string) (string, bool) {
 	nextLetterPos := len(laFile)
 	for proceed := true; proceed; nextLetterPos++ {
@@ -460,7 +666,8 @@ func getAutofillLetters(laFile string, suggestions []string) (string, bool) {
 }
 
 // FileArgument creates an `Argument` processor for a file object. The `Argument` returned
-// by this function only relates to existing files (for execution and completion).
+// by this function only relates to existing files (for execution and completion), unless
+// the `CreateIfMissing` option is provided.
 // For more granular control of the specifics, make your own `Arg(...)` with file-relevant
 // `ArgumentOptions` (such as `FileCompleter`, `FileExists`, `IsDir`, `FileTransformer`, etc.)
 func FileArgument(argName, desc string, opts ...ArgumentOption[string]) *Argument[string] {
@@ -468,11 +675,22 @@ func FileArgument(argName, desc string, opts ...ArgumentOption[string]) *Argumen
 	// Defaults must go first so they can be overriden by provided opts
 	// For example, the last `Completer` opt in the slice will be the one
 	// set in the `ArgumentOption` object.
-	return Arg(argName, desc, append([]ArgumentOption[string]{
+	defaultOpts := []ArgumentOption[string]{
 		&FileCompleter[string]{},
 		FileTransformer(),
-		FileExists(),
-	}, opts...)...)
+	}
+
+	// The file isn't required to exist if it will be created.
+	create := false
+	for _, opt := range opts {
+		if _, ok := opt.(*createIfMissing); ok {
+			create = true
+		}
+	}
+	if !create {
+		defaultOpts = append(defaultOpts, FileExists())
+	}
+	return Arg(argName, desc, append(defaultOpts, opts...)...)
 }
 
 // FileListArgument creates an `ArgList` node for file objects.
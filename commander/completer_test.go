@@ -8,6 +8,7 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -190,6 +191,40 @@ func TestStringCompleters(t *testing.T) {
 			args: "cmd first second ",
 			want: []string{"third"},
 		},
+		{
+			name: "sorts suggestions by default",
+			c:    SimpleCompleter[[]string]("third", "first", "second"),
+			args: "cmd first ",
+			want: []string{"first", "second", "third"},
+		},
+		{
+			name: "preserves suggestion order if completer.PreserveOrder",
+			c: AsCompleter[[]string](&command.Completion{
+				PreserveOrder: true,
+				Suggestions:   []string{"third", "first", "second", "fourth"},
+			}),
+			args: "cmd first ",
+			want: []string{"third", "first", "second", "fourth"},
+		},
+		{
+			name: "preserves suggestion order after filtering",
+			c: AsCompleter[[]string](&command.Completion{
+				PreserveOrder: true,
+				Suggestions:   []string{"two", "three", "one", "twelve"},
+			}),
+			args: "cmd first t",
+			want: []string{"two", "three", "twelve"},
+		},
+		{
+			name: "preserves suggestion order with distinct completer",
+			c: AsCompleter[[]string](&command.Completion{
+				PreserveOrder: true,
+				Distinct:      true,
+				Suggestions:   []string{"third", "first", "second", "fourth"},
+			}),
+			args: "cmd first second ",
+			want: []string{"third", "fourth"},
+		},
 		// CompleterWithOpts test
 		{
 			name: "CompleterWithOpts works",
@@ -235,6 +270,112 @@ func TestStringCompleters(t *testing.T) {
 	}
 }
 
+type structFieldBase struct {
+	ID string `json:"id"`
+}
+
+type structFieldServer struct {
+	Host string `json:"host"`
+	Port int    `json:"port,omitempty"`
+}
+
+type structFieldConfig struct {
+	structFieldBase
+	Name     string             `json:"name"`
+	Server   structFieldServer  `json:"server"`
+	Backup   *structFieldServer `json:"backup"`
+	Parent   *structFieldConfig `json:"parent"`
+	Secret   string             `json:"-"`
+	Untagged bool
+	private  string
+}
+
+func TestStructFieldCompleter(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		v       interface{}
+		tag     string
+		args    string
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "suggests field names",
+			v:    structFieldServer{},
+			args: "cmd ",
+			want: []string{"Host", "Port"},
+		},
+		{
+			name: "suggests nested field paths",
+			v:    &structFieldConfig{},
+			args: "cmd ",
+			want: []string{
+				"Backup",
+				"Backup.Host",
+				"Backup.Port",
+				"ID",
+				"Name",
+				"Parent",
+				"Secret",
+				"Server",
+				"Server.Host",
+				"Server.Port",
+				"Untagged",
+			},
+		},
+		{
+			name: "suggests nested field paths with prefix",
+			v:    structFieldConfig{},
+			args: "cmd Server.",
+			want: []string{"Server.Host", "Server.Port"},
+		},
+		{
+			name: "suggests tag names",
+			v:    &structFieldConfig{},
+			tag:  "json",
+			args: "cmd ",
+			want: []string{
+				"Untagged",
+				"backup",
+				"backup.host",
+				"backup.port",
+				"id",
+				"name",
+				"parent",
+				"server",
+				"server.host",
+				"server.port",
+			},
+		},
+		{
+			name: "suggests nested tag names with prefix",
+			v:    &structFieldConfig{},
+			tag:  "json",
+			args: "cmd backup.h",
+			want: []string{"backup.host"},
+		},
+		{
+			name:    "fails for non-struct type",
+			v:       "hello",
+			args:    "cmd ",
+			wantErr: fmt.Errorf("[StructFieldCompleter] expected a struct type, got string"),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			ctc := &commandtest.CompleteTestCase{
+				Node:          SerialNodes(Arg[string]("FIELD", testDesc, StructFieldCompleter[string](test.v, test.tag))),
+				Args:          test.args,
+				WantErr:       test.wantErr,
+				SkipDataCheck: true,
+			}
+			if test.want != nil {
+				ctc.Want = &command.Autocompletion{Suggestions: test.want}
+			}
+			autocompleteTest(t, ctc, nil)
+		})
+	}
+}
+
 func TestBoolCompleter(t *testing.T) {
 	autocompleteTest(t, &commandtest.CompleteTestCase{
 		Node: SerialNodes(Arg[bool]("test", testDesc, BoolCompleter())),
@@ -871,7 +1012,7 @@ func TestTypedCompleters(t *testing.T) {
 			c: &FileCompleter[[]string]{
 				Distinct: true,
 			},
-			args: "cmd execute.go execute_test.go example-cli ex",
+			args: "cmd execute.go execute_test.go example-cli experimental.go experimental_test.go ex",
 			want: &command.Autocompletion{
 				Suggestions: []string{
 					"executor.go",
@@ -1555,6 +1696,114 @@ func TestTypedCompleters(t *testing.T) {
 				SpacelessCompletion: true,
 			},
 		},
+		// SortByMTime tests
+		&completerTest[string]{
+			name: "file completer sorts alphabetically by default",
+			c:    &FileCompleter[[]string]{},
+			setup: fakeReadDir("/",
+				fakeFileWithMTime("alpha.txt", time.Date(2024, 3, 5, 9, 0, 0, 0, time.UTC)),
+				fakeFileWithMTime("bravo.txt", time.Date(2024, 3, 5, 12, 0, 0, 0, time.UTC)),
+				fakeDirWithMTime("charlie", time.Date(2024, 3, 5, 11, 0, 0, 0, time.UTC)),
+				fakeFileWithMTime("delta.txt", time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC)),
+			),
+			args: fmt.Sprintf("cmd %s", "/"),
+			want: &command.Autocompletion{
+				Suggestions: []string{
+					"alpha.txt",
+					"bravo.txt",
+					filepath.FromSlash("charlie/"),
+					"delta.txt",
+					" ",
+				},
+			},
+		},
+		&completerTest[string]{
+			name: "file completer sorts by modification time",
+			c: &FileCompleter[[]string]{
+				SortByMTime: true,
+			},
+			setup: fakeReadDir("/",
+				fakeFileWithMTime("alpha.txt", time.Date(2024, 3, 5, 9, 0, 0, 0, time.UTC)),
+				fakeFileWithMTime("bravo.txt", time.Date(2024, 3, 5, 12, 0, 0, 0, time.UTC)),
+				fakeDirWithMTime("charlie", time.Date(2024, 3, 5, 11, 0, 0, 0, time.UTC)),
+				fakeFileWithMTime("delta.txt", time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC)),
+			),
+			args: fmt.Sprintf("cmd %s", "/"),
+			want: &command.Autocompletion{
+				Suggestions: []string{
+					"bravo.txt",
+					filepath.FromSlash("charlie/"),
+					"delta.txt",
+					"alpha.txt",
+					" ",
+				},
+			},
+		},
+		&completerTest[string]{
+			name: "file completer sorts files with same modification time alphabetically",
+			c: &FileCompleter[[]string]{
+				SortByMTime: true,
+			},
+			setup: fakeReadDir("/",
+				fakeFileWithMTime("delta.txt", time.Date(2024, 3, 5, 8, 0, 0, 0, time.UTC)),
+				fakeFileWithMTime("Charlie.txt", time.Date(2024, 3, 5, 8, 0, 0, 0, time.UTC)),
+				fakeFileWithMTime("bravo.txt", time.Date(2024, 3, 5, 8, 0, 0, 0, time.UTC)),
+				fakeFileWithMTime("alpha.txt", time.Date(2024, 3, 5, 8, 0, 0, 0, time.UTC)),
+			),
+			args: fmt.Sprintf("cmd %s", "/"),
+			want: &command.Autocompletion{
+				Suggestions: []string{
+					"alpha.txt",
+					"bravo.txt",
+					"Charlie.txt",
+					"delta.txt",
+					" ",
+				},
+			},
+		},
+		&completerTest[string]{
+			name: "file completer sorts files without modification time last",
+			c: &FileCompleter[[]string]{
+				SortByMTime: true,
+			},
+			setup: fakeReadDir("/",
+				fakeFile("alpha.txt"),
+				fakeFileWithMTime("bravo.txt", time.Date(2024, 3, 5, 9, 0, 0, 0, time.UTC)),
+				fakeFile("charlie.txt"),
+				fakeFileWithMTime("delta.txt", time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC)),
+			),
+			args: fmt.Sprintf("cmd %s", "/"),
+			want: &command.Autocompletion{
+				Suggestions: []string{
+					"delta.txt",
+					"bravo.txt",
+					"alpha.txt",
+					"charlie.txt",
+					" ",
+				},
+			},
+		},
+		&completerTest[string]{
+			name: "file completer sorts filtered suggestions by modification time",
+			c: &FileCompleter[[]string]{
+				SortByMTime: true,
+			},
+			setup: fakeReadDir("/",
+				fakeFileWithMTime("abc.txt", time.Date(2024, 3, 5, 9, 0, 0, 0, time.UTC)),
+				fakeFileWithMTime("abd.txt", time.Date(2024, 3, 5, 11, 0, 0, 0, time.UTC)),
+				fakeFileWithMTime("ab", time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC)),
+				fakeFileWithMTime("other.txt", time.Date(2024, 3, 5, 12, 0, 0, 0, time.UTC)),
+			),
+			args: fmt.Sprintf("cmd %sab", "/"),
+			want: &command.Autocompletion{
+				Suggestions: []string{
+					"abd.txt",
+					"ab",
+					"abc.txt",
+					" ",
+				},
+			},
+		},
 		// Absolute file with specified directory completion tests
 		&completerTest[string]{
 			name: "file completer works for absolute path with relative dir",
@@ -1933,11 +2182,19 @@ func TestTypedCompleters(t *testing.T) {
 }
 
 func fakeDir(name string) fs.DirEntry {
-	return &fakeFileInfo{name, true}
+	return &fakeFileInfo{name: name, isDir: true}
 }
 
 func fakeFile(name string) fs.DirEntry {
-	return &fakeFileInfo{name, false}
+	return &fakeFileInfo{name: name}
+}
+
+func fakeDirWithMTime(name string, modTime time.Time) fs.DirEntry {
+	return &fakeFileInfo{name, true, modTime}
+}
+
+func fakeFileWithMTime(name string, modTime time.Time) fs.DirEntry {
+	return &fakeFileInfo{name, false, modTime}
 }
 
 func fakeReadDir(wantDir string, files ...fs.DirEntry) func(t *testing.T) {
@@ -1952,11 +2209,21 @@ func fakeReadDir(wantDir string, files ...fs.DirEntry) func(t *testing.T) {
 }
 
 type fakeFileInfo struct {
-	name  string
-	isDir bool
+	name    string
+	isDir   bool
+	modTime time.Time
 }
 
-func (fi fakeFileInfo) Name() string               { return fi.name }
-func (fi fakeFileInfo) IsDir() bool                { return fi.isDir }
-func (fi fakeFileInfo) Type() fs.FileMode          { return 0 }
-func (fi fakeFileInfo) Info() (fs.FileInfo, error) { return nil, fmt.Errorf("unimplemented stub") }
+func (fi fakeFileInfo) Name() string       { return fi.name }
+func (fi fakeFileInfo) IsDir() bool        { return fi.isDir }
+func (fi fakeFileInfo) Type() fs.FileMode  { return 0 }
+func (fi fakeFileInfo) Size() int64        { return 0 }
+func (fi fakeFileInfo) Mode() fs.FileMode  { return 0 }
+func (fi fakeFileInfo) ModTime() time.Time { return fi.modTime }
+func (fi fakeFileInfo) Sys() any           { return nil }
+func (fi fakeFileInfo) Info() (fs.FileInfo, error) {
+	if fi.modTime.IsZero() {
+		return nil, fmt.Errorf("unimplemented stub")
+	}
+	return fi, nil
+}
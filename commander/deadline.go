@@ -0,0 +1,68 @@
+package commander
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/internal/operator"
+	"github.com/leep-frog/command/internal/stubs"
+)
+
+var (
+	// DeadlineFlag is a flag (`--deadline <duration>`) that sets a deadline
+	// relative to the current time (e.g. `--deadline 30s`). The absolute
+	// deadline (a `time.Time`) can be retrieved with `DeadlineFlag.Get` or
+	// `command.Data.Deadline`. Deadlines are cooperative, so long-running
+	// executors are responsible for checking the deadline themselves.
+	// Absolute deadlines in RFC3339 format (which is the format the resolved
+	// deadline is written back to the input in) are also accepted.
+	//
+	// The current time is determined by the clock stubbed with `commandtest.StubClock`.
+	DeadlineFlag = deadlineFlag("deadline")
+)
+
+func deadlineFlag(name string) FlagWithType[time.Time] {
+	f := listFlag[time.Time](name, FlagNoShortName, "Duration after which long-running executors should stop (e.g. 30s, 5m, 1h)", 1, 0,
+		&CustomSetter[time.Time]{func(t time.Time, d *command.Data) {
+			d.Set(name, t)
+			d.Set(command.DeadlineKey, t)
+		}},
+	)
+	f.argument.op = &deadlineOperator{name, operator.TimeOperator(time.RFC3339)}
+	return f
+}
+
+// deadlineOperator converts a duration into an absolute deadline (relative to
+// the current time). Deadlines are converted back to strings with the wrapped
+// operator.
+type deadlineOperator struct {
+	name string
+	op   operator.Operator[time.Time]
+}
+
+func (do *deadlineOperator) ToArgs(t time.Time) []string {
+	return do.op.ToArgs(t)
+}
+
+func (do *deadlineOperator) FromArgs(sl []*string) (time.Time, error) {
+	if len(sl) == 0 {
+		return time.Time{}, nil
+	}
+	if t, err := do.op.FromArgs(sl); err == nil {
+		return t, nil
+	}
+	dur, err := parseDeadlineDuration(*sl[0])
+	if err != nil {
+		return time.Time{}, &validationErr{do.name, err}
+	}
+	return stubs.TimeNow().Add(dur), nil
+}
+
+func parseDeadlineDuration(s string) (time.Duration, error) {
+	dur, err := time.ParseDuration(s)
+	if err != nil || dur <= 0 {
+		return 0, fmt.Errorf("[Deadline] value %q must be a positive duration (e.g. 30s, 5m, 1h)", s)
+	}
+	return dur, nil
+}
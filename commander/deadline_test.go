@@ -0,0 +1,173 @@
+package commander
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/commandtest"
+	"github.com/leep-frog/command/internal/spycommand"
+	"github.com/leep-frog/command/internal/spycommandtest"
+	"github.com/leep-frog/command/internal/stubs"
+)
+
+func TestDeadlineFlag(t *testing.T) {
+	now := time.Date(2024, 3, 5, 12, 30, 0, 0, time.UTC)
+	n := SerialNodes(
+		FlagProcessor(DeadlineFlag),
+		&ExecutorProcessor{func(o command.Output, d *command.Data) error {
+			deadline, ok := d.Deadline()
+			if !ok {
+				o.Stdoutln("no deadline")
+				return nil
+			}
+			o.Stdoutf("deadline: %v (in %v)\n", deadline.Format(time.RFC3339), deadline.Sub(now))
+			if !DeadlineFlag.Get(d).Equal(deadline) {
+				return o.Stderrf("DeadlineFlag.Get(d) returned %v; want %v\n", DeadlineFlag.Get(d), deadline)
+			}
+			return nil
+		}},
+	)
+
+	for _, test := range []struct {
+		name string
+		etc  *commandtest.ExecuteTestCase
+		ietc *spycommandtest.ExecuteTestCase
+	}{
+		{
+			name: "sets deadline relative to current time",
+			etc: &commandtest.ExecuteTestCase{
+				Node:       n,
+				Args:       []string{"--deadline", "90s"},
+				WantStdout: "deadline: 2024-03-05T12:31:30Z (in 1m30s)\n",
+				WantData: &command.Data{Values: map[string]interface{}{
+					"deadline":          now.Add(90 * time.Second),
+					command.DeadlineKey: now.Add(90 * time.Second),
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "--deadline"},
+						{Value: "2024-03-05T12:31:30Z"},
+					},
+				},
+			},
+		},
+		{
+			name: "sets deadline with compound duration",
+			etc: &commandtest.ExecuteTestCase{
+				Node:       n,
+				Args:       []string{"--deadline", "1h15m"},
+				WantStdout: "deadline: 2024-03-05T13:45:00Z (in 1h15m0s)\n",
+				WantData: &command.Data{Values: map[string]interface{}{
+					"deadline":          now.Add(75 * time.Minute),
+					command.DeadlineKey: now.Add(75 * time.Minute),
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "--deadline"},
+						{Value: "2024-03-05T13:45:00Z"},
+					},
+				},
+			},
+		},
+		{
+			// Execute writes the absolute deadline back to the input, so
+			// replaying those args should produce the same deadline.
+			name: "replays absolute deadline",
+			etc: &commandtest.ExecuteTestCase{
+				Node:       n,
+				Args:       []string{"--deadline", "2024-03-05T12:31:30Z"},
+				WantStdout: "deadline: 2024-03-05T12:31:30Z (in 1m30s)\n",
+				WantData: &command.Data{Values: map[string]interface{}{
+					"deadline":          now.Add(90 * time.Second),
+					command.DeadlineKey: now.Add(90 * time.Second),
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "--deadline"},
+						{Value: "2024-03-05T12:31:30Z"},
+					},
+				},
+			},
+		},
+		{
+			name: "no deadline if flag isn't provided",
+			etc: &commandtest.ExecuteTestCase{
+				Node:       n,
+				WantStdout: "no deadline\n",
+			},
+		},
+		{
+			name: "fails for invalid duration",
+			etc: &commandtest.ExecuteTestCase{
+				Node:       n,
+				Args:       []string{"--deadline", "soon"},
+				WantStderr: "validation for \"deadline\" failed: [Deadline] value \"soon\" must be a positive duration (e.g. 30s, 5m, 1h)\n",
+				WantErr:    fmt.Errorf(`validation for "deadline" failed: [Deadline] value "soon" must be a positive duration (e.g. 30s, 5m, 1h)`),
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "--deadline"},
+						{Value: "soon"},
+					},
+				},
+			},
+		},
+		{
+			name: "fails for negative duration",
+			etc: &commandtest.ExecuteTestCase{
+				Node:       n,
+				Args:       []string{"--deadline", "-5m"},
+				WantStderr: "validation for \"deadline\" failed: [Deadline] value \"-5m\" must be a positive duration (e.g. 30s, 5m, 1h)\n",
+				WantErr:    fmt.Errorf(`validation for "deadline" failed: [Deadline] value "-5m" must be a positive duration (e.g. 30s, 5m, 1h)`),
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "--deadline"},
+						{Value: "-5m"},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			stubs.StubClock(t, func() time.Time { return now }, nil)
+			executeTest(t, test.etc, test.ietc)
+		})
+	}
+}
+
+func TestDeadlineIgnoresOtherDeadlineArgs(t *testing.T) {
+	executeTest(t, &commandtest.ExecuteTestCase{
+		Node: SerialNodes(
+			Arg[string]("deadline", testDesc),
+			&ExecutorProcessor{func(o command.Output, d *command.Data) error {
+				if deadline, ok := d.Deadline(); ok {
+					return o.Stderrf("unexpected deadline: %v\n", deadline)
+				}
+				return nil
+			}},
+		),
+		Args: []string{"tomorrow"},
+		WantData: &command.Data{Values: map[string]interface{}{
+			"deadline": "tomorrow",
+		}},
+	}, &spycommandtest.ExecuteTestCase{
+		WantInput: &spycommandtest.SpyInput{
+			Args: []*spycommand.InputArg{
+				{Value: "tomorrow"},
+			},
+		},
+	})
+}
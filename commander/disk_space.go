@@ -0,0 +1,76 @@
+package commander
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/leep-frog/command/command"
+)
+
+// RequireDiskSpace returns a `command.Processor` that fails if the filesystem
+// containing `path` has fewer than `bytes` bytes available. The path doesn't
+// need to exist yet (the nearest existing ancestor is checked instead). This
+// should be placed before any nodes that write large outputs so that
+// insufficient space is detected before anything is (partially) written.
+// This processor has no effect on completion or usage.
+func RequireDiskSpace(path string, bytes int64) command.Processor {
+	return SimpleProcessor(func(i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
+		available, err := availableDiskSpace(path)
+		if err != nil {
+			return o.Annotatef(err, "[RequireDiskSpace] failed to check available disk space for %q", path)
+		}
+		if available < bytes {
+			return o.Stderrf("[RequireDiskSpace] insufficient disk space for %q: %d bytes required, but only %d bytes available\n", path, bytes, available)
+		}
+		return nil
+	}, nil)
+}
+
+// availableDiskSpace uses `df` to determine the number of bytes available on
+// the filesystem that contains the provided path.
+func availableDiskSpace(path string) (int64, error) {
+	if runtime.GOOS == "windows" {
+		return 0, fmt.Errorf("disk space lookup is not supported on windows")
+	}
+
+	// The path may not exist yet, so use the nearest existing ancestor.
+	dir := filepath.Clean(path)
+	for {
+		if _, err := os.Stat(dir); err == nil {
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	sc := &ShellCommand[[]string]{
+		CommandName: "df",
+		Args:        []string{"-Pk", dir},
+		HideStderr:  true,
+	}
+	lines, err := sc.Run(nil, &command.Data{})
+	if err != nil {
+		return 0, err
+	}
+	if len(lines) < 2 {
+		return 0, fmt.Errorf("unexpected df output: %v", lines)
+	}
+
+	// POSIX output format: Filesystem 1024-blocks Used Available Capacity Mounted-on
+	fields := strings.Fields(lines[1])
+	if len(fields) < 4 {
+		return 0, fmt.Errorf("unexpected df output: %v", lines)
+	}
+	kb, err := strconv.ParseInt(fields[3], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse available disk space from df output: %v", err)
+	}
+	return kb * 1024, nil
+}
@@ -0,0 +1,112 @@
+package commander
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/commandtest"
+)
+
+func TestRequireDiskSpace(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("disk space lookup is not supported on windows")
+	}
+
+	writer := &ExecutorProcessor{func(o command.Output, d *command.Data) error {
+		o.Stdoutln("writing output")
+		return nil
+	}}
+	dfHeader := "Filesystem 1024-blocks Used Available Capacity Mounted on"
+	// "out/data.bin" doesn't exist, so the nearest existing ancestor is checked.
+	wantDf := []*commandtest.RunContents{{
+		Name: "df",
+		Args: []string{"-Pk", "."},
+	}}
+
+	for _, test := range []struct {
+		name string
+		etc  *commandtest.ExecuteTestCase
+	}{
+		{
+			name: "succeeds if there is more than enough disk space",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(RequireDiskSpace("out/data.bin", 2048), writer),
+				RunResponses: []*commandtest.FakeRun{{
+					Stdout: []string{dfHeader, "/dev/sda1 100 96 4 96% /"},
+				}},
+				WantRunContents: wantDf,
+				WantStdout:      "writing output\n",
+			},
+		},
+		{
+			name: "succeeds if there is exactly enough disk space",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(RequireDiskSpace("out/data.bin", 2048), writer),
+				RunResponses: []*commandtest.FakeRun{{
+					Stdout: []string{dfHeader, "/dev/sda1 100 98 2 98% /"},
+				}},
+				WantRunContents: wantDf,
+				WantStdout:      "writing output\n",
+			},
+		},
+		{
+			name: "fails if there isn't enough disk space",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(RequireDiskSpace("out/data.bin", 2048), writer),
+				RunResponses: []*commandtest.FakeRun{{
+					Stdout: []string{dfHeader, "/dev/sda1 100 99 1 99% /"},
+				}},
+				WantRunContents: wantDf,
+				WantStderr:      "[RequireDiskSpace] insufficient disk space for \"out/data.bin\": 2048 bytes required, but only 1024 bytes available\n",
+				WantErr:         fmt.Errorf(`[RequireDiskSpace] insufficient disk space for "out/data.bin": 2048 bytes required, but only 1024 bytes available`),
+			},
+		},
+		{
+			name: "fails if df fails",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(RequireDiskSpace("out/data.bin", 2048), writer),
+				RunResponses: []*commandtest.FakeRun{{
+					Err: fmt.Errorf("df failed"),
+				}},
+				WantRunContents: wantDf,
+				WantStderr:      "[RequireDiskSpace] failed to check available disk space for \"out/data.bin\": failed to execute shell command: df failed\n",
+				WantErr:         fmt.Errorf(`[RequireDiskSpace] failed to check available disk space for "out/data.bin": failed to execute shell command: df failed`),
+			},
+		},
+		{
+			name: "fails if df output is unexpected",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(RequireDiskSpace("out/data.bin", 2048), writer),
+				RunResponses: []*commandtest.FakeRun{{
+					Stdout: []string{dfHeader},
+				}},
+				WantRunContents: wantDf,
+				WantStderr:      "[RequireDiskSpace] failed to check available disk space for \"out/data.bin\": unexpected df output: [Filesystem 1024-blocks Used Available Capacity Mounted on]\n",
+				WantErr:         fmt.Errorf(`[RequireDiskSpace] failed to check available disk space for "out/data.bin": unexpected df output: [Filesystem 1024-blocks Used Available Capacity Mounted on]`),
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			executeTest(t, test.etc, nil)
+		})
+	}
+}
+
+func TestRequireDiskSpaceCompletion(t *testing.T) {
+	// No run responses are provided, so the test fails if df is run.
+	autocompleteTest(t, &commandtest.CompleteTestCase{
+		Node: SerialNodes(
+			RequireDiskSpace("out/data.bin", 1024),
+			Arg[string]("S", testDesc, SimpleCompleter[string]("abc", "def")),
+		),
+		Args: "cmd ",
+		Want: &command.Autocompletion{
+			Suggestions: []string{"abc", "def"},
+		},
+		WantData: &command.Data{Values: map[string]interface{}{
+			"S": "",
+		}},
+	}, nil)
+}
@@ -0,0 +1,109 @@
+package commander
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/leep-frog/command/command"
+)
+
+// DotGraph returns a Graphviz DOT diagram of the provided node graph. Branches,
+// serial chains (`SimpleEdge`), and `NodeRepeater` processors are followed.
+// Nodes that are reachable from multiple places (including cycles) are only
+// rendered once. Edges that are determined at runtime (i.e. custom
+// `command.Edge` implementations) are not included.
+func DotGraph(n command.Node) string {
+	dg := &dotGraph{ids: map[command.Node]int{}}
+	dg.visit(n)
+	return fmt.Sprintf("digraph {\n%s}\n", strings.Join(dg.lines, ""))
+}
+
+type dotGraph struct {
+	ids   map[command.Node]int
+	lines []string
+}
+
+// visit adds the node (and all of its descendants) to the graph and returns
+// the node's id.
+func (dg *dotGraph) visit(n command.Node) int {
+	if id, ok := dg.ids[n]; ok {
+		return id
+	}
+	id := len(dg.ids)
+	dg.ids[n] = id
+	dg.lines = append(dg.lines, fmt.Sprintf("  n%d [label=%q];\n", id, dotNodeLabel(n)))
+
+	switch t := n.(type) {
+	case *BranchNode:
+		branches := make([]string, 0, len(t.Branches))
+		for b := range t.Branches {
+			branches = append(branches, b)
+		}
+		sort.Strings(branches)
+		for _, b := range branches {
+			dg.edge(id, t.Branches[b], b)
+		}
+		if t.Default != nil {
+			dg.edge(id, t.Default, "default")
+		}
+	case *SimpleNode:
+		if nr, ok := t.Processor.(*nodeRepeater); ok {
+			dg.edge(id, nr.n, "repeat")
+		}
+		if se, ok := t.Edge.(*SimpleEdge); ok && se.N != nil {
+			dg.edge(id, se.N, "")
+		}
+	}
+	return id
+}
+
+func (dg *dotGraph) edge(from int, to command.Node, label string) {
+	toID := dg.visit(to)
+	if label == "" {
+		dg.lines = append(dg.lines, fmt.Sprintf("  n%d -> n%d;\n", from, toID))
+	} else {
+		dg.lines = append(dg.lines, fmt.Sprintf("  n%d -> n%d [label=%q];\n", from, toID, label))
+	}
+}
+
+func dotNodeLabel(n command.Node) string {
+	switch t := n.(type) {
+	case *BranchNode:
+		return "BranchNode"
+	case *SimpleNode:
+		return dotProcessorLabel(t.Processor)
+	}
+	return dotTypeName(n)
+}
+
+func dotProcessorLabel(p command.Processor) string {
+	switch t := p.(type) {
+	case nil:
+		return "(empty)"
+	case *flagProcessor:
+		var flags []string
+		for _, f := range t.flagOrder {
+			flags = append(flags, flagName(f))
+		}
+		return fmt.Sprintf("FlagProcessor\n%s", strings.Join(flags, " "))
+	case *nodeRepeater:
+		return fmt.Sprintf("NodeRepeater(%d, %d)", t.minN, t.optionalN)
+	case interface{ Name() string }:
+		return fmt.Sprintf("%s %s", dotTypeName(p), t.Name())
+	}
+	return dotTypeName(p)
+}
+
+// dotTypeName returns the type name of the provided value without the package
+// name, pointer, or type parameters (e.g. `*commander.Argument[string]` -> `Argument`).
+func dotTypeName(v interface{}) string {
+	s := strings.TrimPrefix(fmt.Sprintf("%T", v), "*")
+	if i := strings.Index(s, "["); i >= 0 {
+		s = s[:i]
+	}
+	if i := strings.LastIndex(s, "."); i >= 0 {
+		s = s[i+1:]
+	}
+	return s
+}
@@ -0,0 +1,106 @@
+package commander
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/internal/testutil"
+)
+
+func TestDotGraph(t *testing.T) {
+	shared := SerialNodes(Arg[string]("SHARED", testDesc))
+
+	cyclic := &SimpleNode{Processor: Arg[string]("LOOP", testDesc)}
+	cyclic.Edge = &SimpleEdge{cyclic}
+
+	for _, test := range []struct {
+		name string
+		n    command.Node
+		want []string
+	}{
+		{
+			name: "renders empty node",
+			n:    &SimpleNode{},
+			want: []string{
+				"digraph {",
+				`  n0 [label="(empty)"];`,
+				"}",
+			},
+		},
+		{
+			name: "renders branching node with nested serial nodes",
+			n: &BranchNode{
+				Branches: map[string]command.Node{
+					"build b": SerialNodes(
+						FlagProcessor(
+							BoolFlag("release", 'r', testDesc),
+							Flag[int]("jobs", 'j', testDesc),
+						),
+						Arg[string]("TARGET", testDesc),
+						&ExecutorProcessor{},
+					),
+					"test": SerialNodes(
+						ListArg[string]("PKGS", testDesc, 0, command.UnboundedList),
+						NodeRepeater(SerialNodes(Arg[string]("KEY", testDesc), Arg[int]("VALUE", testDesc)), 0, 1),
+					),
+				},
+				Default: SerialNodes(OptionalArg[string]("CMD", testDesc)),
+			},
+			want: []string{
+				"digraph {",
+				`  n0 [label="BranchNode"];`,
+				`  n1 [label="FlagProcessor\n--release --jobs"];`,
+				`  n2 [label="Argument TARGET"];`,
+				`  n3 [label="ExecutorProcessor"];`,
+				`  n2 -> n3;`,
+				`  n1 -> n2;`,
+				`  n0 -> n1 [label="build b"];`,
+				`  n4 [label="Argument PKGS"];`,
+				`  n5 [label="NodeRepeater(0, 1)"];`,
+				`  n6 [label="Argument KEY"];`,
+				`  n7 [label="Argument VALUE"];`,
+				`  n6 -> n7;`,
+				`  n5 -> n6 [label="repeat"];`,
+				`  n4 -> n5;`,
+				`  n0 -> n4 [label="test"];`,
+				`  n8 [label="Argument CMD"];`,
+				`  n0 -> n8 [label="default"];`,
+				"}",
+			},
+		},
+		{
+			name: "renders shared nodes once",
+			n: &BranchNode{
+				Branches: map[string]command.Node{
+					"a": shared,
+					"b": shared,
+				},
+				Default: shared,
+			},
+			want: []string{
+				"digraph {",
+				`  n0 [label="BranchNode"];`,
+				`  n1 [label="Argument SHARED"];`,
+				`  n0 -> n1 [label="a"];`,
+				`  n0 -> n1 [label="b"];`,
+				`  n0 -> n1 [label="default"];`,
+				"}",
+			},
+		},
+		{
+			name: "renders cycles",
+			n:    cyclic,
+			want: []string{
+				"digraph {",
+				`  n0 [label="Argument LOOP"];`,
+				`  n0 -> n0;`,
+				"}",
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			testutil.Cmp(t, "DotGraph() returned incorrect diagram", strings.Join(test.want, "\n")+"\n", DotGraph(test.n))
+		})
+	}
+}
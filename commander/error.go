@@ -2,6 +2,7 @@ package commander
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/leep-frog/command/command"
 )
@@ -16,18 +17,51 @@ func IsNotEnoughArgsError(err error) bool {
 // IsUsageError returns whether or not the provided error
 // is a usage-related error.
 func IsUsageError(err error) bool {
-	return IsNotEnoughArgsError(err) || IsBranchingError(err) || command.IsExtraArgsError(err)
+	return IsNotEnoughArgsError(err) || IsBranchingError(err) || command.IsExtraArgsError(err) || IsMissingRequiredFlagError(err) || IsRuleViolationError(err)
+}
+
+// IsRuleViolationError returns whether or not the provided error
+// is a `Rules` violation error.
+func IsRuleViolationError(err error) bool {
+	_, ok := err.(*ruleViolations)
+	return ok
+}
+
+// IsMissingRequiredFlagError returns whether or not the provided error
+// is a `MissingRequiredFlag` error.
+func IsMissingRequiredFlagError(err error) bool {
+	_, ok := err.(*missingRequiredFlag)
+	return ok
+}
+
+// MissingRequiredFlag returns a custom error for when a flag with the
+// `Required` option isn't provided.
+func MissingRequiredFlag(name string) error {
+	return &missingRequiredFlag{name}
+}
+
+type missingRequiredFlag struct {
+	name string
+}
+
+func (mrf *missingRequiredFlag) Error() string {
+	return fmt.Sprintf("Flag %q is required", mrf.name)
 }
 
 // NotEnoughArgs returns a custom error for when not enough arguments are provided to the command.
 func NotEnoughArgs(name string, req, got int) error {
-	return &notEnoughArgs{name, req, got}
+	return &notEnoughArgs{name: name, req: req, got: got}
 }
 
 type notEnoughArgs struct {
 	name string
 	req  int
 	got  int
+
+	// signature is the expected positional signature (only set by `StrictArgs`).
+	signature *string
+	// received is the set of positional arguments that were provided (only set by `StrictArgs`).
+	received []string
 }
 
 func (ne *notEnoughArgs) Error() string {
@@ -35,5 +69,25 @@ func (ne *notEnoughArgs) Error() string {
 	if ne.req == 1 {
 		plural = ""
 	}
-	return fmt.Sprintf("Argument %q requires at least %d argument%s, got %d", ne.name, ne.req, plural, ne.got)
+	msg := fmt.Sprintf("Argument %q requires at least %d argument%s, got %d", ne.name, ne.req, plural, ne.got)
+	if ne.signature == nil {
+		return msg
+	}
+	received := ne.received
+	if received == nil {
+		received = []string{}
+	}
+	return fmt.Sprintf("Not enough arguments provided (want %q, got %q): %s", *ne.signature, received, msg)
+}
+
+type ruleViolations struct {
+	violations []string
+}
+
+func (rv *ruleViolations) Error() string {
+	plural := "s"
+	if len(rv.violations) == 1 {
+		plural = ""
+	}
+	return fmt.Sprintf("[Rules] %d rule violation%s:\n  %s", len(rv.violations), plural, strings.Join(rv.violations, "\n  "))
 }
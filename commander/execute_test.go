@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/leep-frog/command/command"
@@ -92,6 +93,8 @@ func TestExecute(t *testing.T) {
 	_ = rcNode
 	_ = rcErrNode
 
+	quietFlag := BoolFlag("quiet", 'q', testDesc)
+
 	envArgProcessor := &EnvArg{
 		Name:     "ENV_VAR",
 		Optional: true,
@@ -1952,6 +1955,74 @@ func TestExecute(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "DedupeExecutable removes all duplicate lines",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					DedupeExecutable(),
+					SimpleExecutableProcessor("cd some/dir", "echo hello"),
+					SetEnvVarProcessor("abc", "def"),
+					SetEnvVarProcessor("abc", "def"),
+					SimpleExecutableProcessor("cd some/dir", "echo hello", "echo hello"),
+					SetEnvVarProcessor("abc", "ghi"),
+					SetEnvVarProcessor("abc", "def"),
+				),
+				WantExecuteData: &command.ExecuteData{
+					Executable: []string{
+						"cd some/dir",
+						"echo hello",
+						fos.SetEnvVar("abc", "def"),
+						fos.SetEnvVar("abc", "ghi"),
+					},
+				},
+			},
+		},
+		{
+			name: "DedupeExecutable with DedupeConsecutive removes consecutive duplicate lines",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					DedupeExecutable(DedupeConsecutive()),
+					SimpleExecutableProcessor("cd some/dir", "echo hello"),
+					SetEnvVarProcessor("abc", "def"),
+					SetEnvVarProcessor("abc", "def"),
+					SimpleExecutableProcessor("cd some/dir", "echo hello", "echo hello"),
+					SetEnvVarProcessor("abc", "ghi"),
+					SetEnvVarProcessor("abc", "def"),
+				),
+				WantExecuteData: &command.ExecuteData{
+					Executable: []string{
+						"cd some/dir",
+						"echo hello",
+						fos.SetEnvVar("abc", "def"),
+						"cd some/dir",
+						"echo hello",
+						fos.SetEnvVar("abc", "ghi"),
+						fos.SetEnvVar("abc", "def"),
+					},
+				},
+			},
+		},
+		{
+			name: "DedupeExecutable does nothing if no duplicates",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					SimpleExecutableProcessor("echo one", "echo two"),
+					DedupeExecutable(),
+					SimpleExecutableProcessor("echo three"),
+				),
+				WantExecuteData: &command.ExecuteData{
+					Executable: []string{"echo one", "echo two", "echo three"},
+				},
+			},
+		},
+		{
+			name: "DedupeExecutable handles no executable lines",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					DedupeExecutable(),
+				),
+			},
+		},
 		{
 			name: "Sets executable with ExecutableProcessor",
 			etc: &commandtest.ExecuteTestCase{
@@ -2069,6 +2140,32 @@ func TestExecute(t *testing.T) {
 				},
 			},
 		},
+		// CompletionSetup tests
+		{
+			name: "CompletionSetup does nothing on execution",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					CompletionSetup(func(i *command.Input, d *command.Data) error {
+						d.Set("key", "value")
+						return fmt.Errorf("should not run")
+					}),
+					Arg[string]("s", testDesc),
+				),
+				Args: []string{"abc"},
+				WantData: &command.Data{
+					Values: map[string]interface{}{
+						"s": "abc",
+					},
+				},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "abc"},
+					},
+				},
+			},
+		},
 		// SuperSimpleProcessor tests
 		{
 			name: "sets data with SuperSimpleProcessor",
@@ -2332,6 +2429,274 @@ func TestExecute(t *testing.T) {
 				},
 			},
 		},
+		// EnvRefArg tests
+		{
+			name: "EnvRefArg resolves environment variable",
+			etc: &commandtest.ExecuteTestCase{
+				Env: map[string]string{
+					"MY_VAR": "my value",
+					"OTHER":  "other value",
+				},
+				Node: SerialNodes(
+					EnvRefArg("ref", testDesc),
+				),
+				Args: []string{"MY_VAR"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"ref": "my value",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "my value"},
+					},
+				},
+			},
+		},
+		{
+			name: "EnvRefArg resolves empty environment variable",
+			etc: &commandtest.ExecuteTestCase{
+				Env: map[string]string{
+					"MY_VAR": "",
+				},
+				Node: SerialNodes(
+					EnvRefArg("ref", testDesc),
+				),
+				Args: []string{"MY_VAR"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"ref": "",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: ""},
+					},
+				},
+			},
+		},
+		{
+			name: "EnvRefArg fails for unset environment variable",
+			etc: &commandtest.ExecuteTestCase{
+				Env: map[string]string{
+					"OTHER": "other value",
+				},
+				Node: SerialNodes(
+					EnvRefArg("ref", testDesc),
+				),
+				Args:       []string{"MY_VAR"},
+				WantStderr: "Custom transformer failed: [EnvRefArg] environment variable \"MY_VAR\" is not set\n",
+				WantErr:    fmt.Errorf("Custom transformer failed: [EnvRefArg] environment variable \"MY_VAR\" is not set"),
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "MY_VAR"},
+					},
+				},
+			},
+		},
+		{
+			name: "EnvRefArg with AllowUnsetEnvRef resolves unset environment variable",
+			etc: &commandtest.ExecuteTestCase{
+				Env: map[string]string{
+					"OTHER": "other value",
+				},
+				Node: SerialNodes(
+					EnvRefArg("ref", testDesc).AllowUnset(),
+				),
+				Args: []string{"MY_VAR"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"ref": "",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: ""},
+					},
+				},
+			},
+		},
+		{
+			name: "EnvRefArg runs validators on resolved value",
+			etc: &commandtest.ExecuteTestCase{
+				Env: map[string]string{
+					"MY_VAR": "abc",
+				},
+				Node: SerialNodes(
+					EnvRefArg("ref", testDesc, MinLength[string, string](5)),
+				),
+				Args: []string{"MY_VAR"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"ref": "abc",
+				}},
+				WantStderr: "validation for \"ref\" failed: [MinLength] length must be at least 5\n",
+				WantErr:    fmt.Errorf("validation for \"ref\" failed: [MinLength] length must be at least 5"),
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "abc"},
+					},
+				},
+			},
+		},
+		// EmitShellVars tests
+		{
+			name: "EmitShellVars emits posix statements when SHELL is unset",
+			etc: &commandtest.ExecuteTestCase{
+				Env: map[string]string{},
+				Node: SerialNodes(
+					EmitShellVars(map[string]string{
+						"SIMPLE":  "value",
+						"SPACES":  "hello there",
+						"QUOTES":  `it's "quoted"`,
+						"SPECIAL": `$HOME \n ; echo`,
+						"EMPTY":   "",
+					}),
+				),
+				WantStdout: strings.Join([]string{
+					`export EMPTY=''`,
+					`export QUOTES='it'\''s "quoted"'`,
+					`export SIMPLE='value'`,
+					`export SPACES='hello there'`,
+					`export SPECIAL='$HOME \n ; echo'`,
+					"",
+				}, "\n"),
+			},
+		},
+		{
+			name: "EmitShellVars emits posix statements for bash",
+			etc: &commandtest.ExecuteTestCase{
+				Env: map[string]string{"SHELL": "/bin/bash"},
+				Node: SerialNodes(
+					EmitShellVars(map[string]string{
+						"SIMPLE":  "value",
+						"SPACES":  "hello there",
+						"QUOTES":  `it's "quoted"`,
+						"SPECIAL": `$HOME \n ; echo`,
+						"EMPTY":   "",
+					}),
+				),
+				WantStdout: strings.Join([]string{
+					`export EMPTY=''`,
+					`export QUOTES='it'\''s "quoted"'`,
+					`export SIMPLE='value'`,
+					`export SPACES='hello there'`,
+					`export SPECIAL='$HOME \n ; echo'`,
+					"",
+				}, "\n"),
+			},
+		},
+		{
+			name: "EmitShellVars emits fish statements for fish",
+			etc: &commandtest.ExecuteTestCase{
+				Env: map[string]string{"SHELL": "/usr/bin/fish"},
+				Node: SerialNodes(
+					EmitShellVars(map[string]string{
+						"SIMPLE":  "value",
+						"SPACES":  "hello there",
+						"QUOTES":  `it's "quoted"`,
+						"SPECIAL": `$HOME \n ; echo`,
+						"EMPTY":   "",
+					}),
+				),
+				WantStdout: strings.Join([]string{
+					`set -gx EMPTY ''`,
+					`set -gx QUOTES 'it\'s "quoted"'`,
+					`set -gx SIMPLE 'value'`,
+					`set -gx SPACES 'hello there'`,
+					`set -gx SPECIAL '$HOME \\n ; echo'`,
+					"",
+				}, "\n"),
+			},
+		},
+		{
+			name: "EmitShellVars emits statements for provided posix shell",
+			etc: &commandtest.ExecuteTestCase{
+				Env: map[string]string{"SHELL": "/usr/bin/fish"},
+				Node: SerialNodes(
+					EmitShellVars(map[string]string{
+						"SIMPLE":  "value",
+						"SPACES":  "hello there",
+						"QUOTES":  `it's "quoted"`,
+						"SPECIAL": `$HOME \n ; echo`,
+						"EMPTY":   "",
+					}, ForShell(command.BashShell)),
+				),
+				WantStdout: strings.Join([]string{
+					`export EMPTY=''`,
+					`export QUOTES='it'\''s "quoted"'`,
+					`export SIMPLE='value'`,
+					`export SPACES='hello there'`,
+					`export SPECIAL='$HOME \n ; echo'`,
+					"",
+				}, "\n"),
+			},
+		},
+		{
+			name: "EmitShellVars emits statements for provided fish shell",
+			etc: &commandtest.ExecuteTestCase{
+				Env: map[string]string{"SHELL": "/bin/zsh"},
+				Node: SerialNodes(
+					EmitShellVars(map[string]string{
+						"SIMPLE":  "value",
+						"SPACES":  "hello there",
+						"QUOTES":  `it's "quoted"`,
+						"SPECIAL": `$HOME \n ; echo`,
+						"EMPTY":   "",
+					}, ForShell(command.FishShell)),
+				),
+				WantStdout: strings.Join([]string{
+					`set -gx EMPTY ''`,
+					`set -gx QUOTES 'it\'s "quoted"'`,
+					`set -gx SIMPLE 'value'`,
+					`set -gx SPACES 'hello there'`,
+					`set -gx SPECIAL '$HOME \\n ; echo'`,
+					"",
+				}, "\n"),
+			},
+		},
+		{
+			name: "EmitShellVars fails for unknown shell",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					EmitShellVars(map[string]string{
+						"SIMPLE":  "value",
+						"SPACES":  "hello there",
+						"QUOTES":  `it's "quoted"`,
+						"SPECIAL": `$HOME \n ; echo`,
+						"EMPTY":   "",
+					}, ForShell(command.ShellType("powershell"))),
+				),
+				WantStderr: "[EmitShellVars] unsupported shell: \"powershell\"\n",
+				WantErr:    fmt.Errorf("[EmitShellVars] unsupported shell: \"powershell\""),
+			},
+		},
+		{
+			name: "EmitShellVars fails for invalid variable name",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					EmitShellVars(map[string]string{
+						"OK":      "value",
+						"NOT; OK": "value",
+					}, ForShell(command.BashShell)),
+				),
+				WantStderr: "[EmitShellVars] invalid variable name: \"NOT; OK\"\n",
+				WantErr:    fmt.Errorf(`[EmitShellVars] invalid variable name: "NOT; OK"`),
+			},
+		},
+		{
+			name: "EmitShellVars does not modify executable",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					EmitShellVars(map[string]string{"abc": "def"}, ForShell(command.BashShell)),
+				),
+				WantStdout: "export abc='def'\n",
+			},
+		},
 		// PrintlnProcessor tests
 		{
 			name: "PrintlnProcessor prints output",
@@ -2606,6461 +2971,11023 @@ func TestExecute(t *testing.T) {
 				},
 			},
 		},
-		// MatchesRegex
+		// And/Or
 		{
-			name: "matches regex works",
+			name: "And succeeds if all validators succeed",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[string]("strArg", testDesc, MatchesRegex("a+b=?c")),
+					Processor: Arg[int]("i", testDesc, And(GT(0), LT(10), NEQ(5))),
 				},
-				Args: []string{"equiation: aabcdef"},
+				Args: []string{"7"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"strArg": "equiation: aabcdef",
+					"i": 7,
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "equiation: aabcdef"},
+						{Value: "7"},
 					},
 				},
 			},
 		},
 		{
-			name: "matches regex fails",
+			name: "And fails if the first validator fails",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[string]("strArg", testDesc, MatchesRegex(".*", "i+")),
+					Processor: Arg[int]("i", testDesc, And(GT(0), LT(10), NEQ(5))),
 				},
-				Args: []string{"team"},
+				Args: []string{"-3"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"strArg": "team",
+					"i": -3,
 				}},
-				WantStderr: "validation for \"strArg\" failed: [MatchesRegex] value \"team\" doesn't match regex \"i+\"\n",
-				WantErr:    fmt.Errorf(`validation for "strArg" failed: [MatchesRegex] value "team" doesn't match regex "i+"`),
+				WantStderr: "validation for \"i\" failed: [And(GT(0), LT(10), NEQ(5))] failed: [GT] value isn't greater than 0\n",
+				WantErr:    fmt.Errorf(`validation for "i" failed: [And(GT(0), LT(10), NEQ(5))] failed: [GT] value isn't greater than 0`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "team"},
+						{Value: "-3"},
 					},
 				},
 			},
 		},
-		// ListMatchesRegex
 		{
-			name: "ListMatchesRegex works",
+			name: "And fails if a later validator fails",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: ListArg[string]("slArg", testDesc, 1, command.UnboundedList, ListifyValidatorOption(MatchesRegex("a+b=?c", "^eq"))),
+					Processor: Arg[int]("i", testDesc, And(GT(0), LT(10), NEQ(5))),
 				},
-				Args: []string{"equiation: aabcdef"},
+				Args: []string{"5"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"slArg": []string{"equiation: aabcdef"},
+					"i": 5,
 				}},
+				WantStderr: "validation for \"i\" failed: [And(GT(0), LT(10), NEQ(5))] failed: [NEQ] value cannot equal 5\n",
+				WantErr:    fmt.Errorf(`validation for "i" failed: [And(GT(0), LT(10), NEQ(5))] failed: [NEQ] value cannot equal 5`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "equiation: aabcdef"},
+						{Value: "5"},
 					},
 				},
 			},
 		},
 		{
-			name: "ListMatchesRegex fails",
+			name: "And with no validators succeeds",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: ListArg[string]("slArg", testDesc, 1, command.UnboundedList, ListifyValidatorOption(MatchesRegex(".*", "i+"))),
+					Processor: Arg[int]("i", testDesc, And[int]()),
 				},
-				Args: []string{"equiation: aabcdef", "oops"},
+				Args: []string{"5"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"slArg": []string{"equiation: aabcdef", "oops"},
+					"i": 5,
 				}},
-				WantStderr: "validation for \"slArg\" failed: [MatchesRegex] value \"oops\" doesn't match regex \"i+\"\n",
-				WantErr:    fmt.Errorf(`validation for "slArg" failed: [MatchesRegex] value "oops" doesn't match regex "i+"`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
-				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "equiation: aabcdef"},
-						{Value: "oops"},
+						{Value: "5"},
 					},
 				},
 			},
 		},
-		// IsRegex
 		{
-			name: "IsRegex works",
+			name: "Or succeeds if the first validator succeeds",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[string]("strArg", testDesc, IsRegex()),
+					Processor: Arg[int]("i", testDesc, Or(LT(0), GT(100))),
 				},
-				Args: []string{".*"},
+				Args: []string{"-1"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"strArg": ".*",
+					"i": -1,
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: ".*"},
+						{Value: "-1"},
 					},
 				},
 			},
 		},
 		{
-			name: "IsRegex fails",
+			name: "Or succeeds if a later validator succeeds",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[string]("strArg", testDesc, IsRegex()),
+					Processor: Arg[int]("i", testDesc, Or(LT(0), GT(100))),
 				},
-				Args: []string{"*"},
+				Args: []string{"101"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"strArg": "*",
+					"i": 101,
 				}},
-				WantStderr: "validation for \"strArg\" failed: [IsRegex] value \"*\" isn't a valid regex: error parsing regexp: missing argument to repetition operator: `*`\n",
-				WantErr:    fmt.Errorf("validation for \"strArg\" failed: [IsRegex] value \"*\" isn't a valid regex: error parsing regexp: missing argument to repetition operator: `*`"),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
-				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "*"},
+						{Value: "101"},
 					},
 				},
 			},
 		},
-		// ListIsRegex
 		{
-			name: "ListIsRegex works",
+			name: "Or fails if no validators succeed",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: ListArg[string]("slArg", testDesc, 1, command.UnboundedList, ListifyValidatorOption(IsRegex())),
+					Processor: Arg[int]("i", testDesc, Or(LT(0), GT(100))),
 				},
-				Args: []string{".*", " +"},
+				Args: []string{"50"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"slArg": []string{".*", " +"},
+					"i": 50,
 				}},
+				WantStderr: "validation for \"i\" failed: [Or(LT(0), GT(100))] failed: [LT] value isn't less than 0; [GT] value isn't greater than 100\n",
+				WantErr:    fmt.Errorf(`validation for "i" failed: [Or(LT(0), GT(100))] failed: [LT] value isn't less than 0; [GT] value isn't greater than 100`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: ".*"},
-						{Value: " +"},
+						{Value: "50"},
 					},
 				},
 			},
 		},
 		{
-			name: "ListIsRegex fails",
+			name: "Or with no validators fails",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: ListArg[string]("slArg", testDesc, 1, command.UnboundedList, ListifyValidatorOption(IsRegex())),
+					Processor: Arg[int]("i", testDesc, Or[int]()),
 				},
-				Args: []string{".*", "+"},
+				Args: []string{"5"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"slArg": []string{".*", "+"},
+					"i": 5,
 				}},
-				WantStderr: "validation for \"slArg\" failed: [IsRegex] value \"+\" isn't a valid regex: error parsing regexp: missing argument to repetition operator: `+`\n",
-				WantErr:    fmt.Errorf("validation for \"slArg\" failed: [IsRegex] value \"+\" isn't a valid regex: error parsing regexp: missing argument to repetition operator: `+`"),
+				WantStderr: "validation for \"i\" failed: [Or()] failed: no validators provided\n",
+				WantErr:    fmt.Errorf(`validation for "i" failed: [Or()] failed: no validators provided`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: ".*"},
-						{Value: "+"},
+						{Value: "5"},
 					},
 				},
 			},
 		},
-		// FileExists, FileDoesNotExist, and FilesExist
 		{
-			name: "FileExists works",
+			name: "nested combinators succeed for inner And",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[string]("S", testDesc, FileExists()),
+					Processor: Arg[int]("i", testDesc, Or(And(GT(0), LT(10)), Not(LT(100)))),
 				},
-				Args: []string{"execute_test.go"},
+				Args: []string{"3"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"S": "execute_test.go",
+					"i": 3,
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "execute_test.go"},
+						{Value: "3"},
 					},
 				},
 			},
 		},
 		{
-			name: "FileExists fails",
+			name: "nested combinators succeed for Not",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[string]("S", testDesc, FileExists()),
+					Processor: Arg[int]("i", testDesc, Or(And(GT(0), LT(10)), Not(LT(100)))),
 				},
-				Args: []string{"execute_test.gone"},
+				Args: []string{"100"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"S": "execute_test.gone",
+					"i": 100,
 				}},
-				WantErr:    fmt.Errorf(`validation for "S" failed: [FileExists] file "execute_test.gone" does not exist`),
-				WantStderr: "validation for \"S\" failed: [FileExists] file \"execute_test.gone\" does not exist\n",
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
-				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "execute_test.gone"},
+						{Value: "100"},
 					},
 				},
 			},
 		},
 		{
-			name: "FileDoesNotExist fails",
+			name: "nested combinators fail with nested error",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[string]("S", testDesc, FileDoesNotExist()),
+					Processor: Arg[int]("i", testDesc, Or(And(GT(0), LT(10)), Not(LT(100)))),
 				},
-				Args: []string{"execute_test.go"},
+				Args: []string{"50"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"S": "execute_test.go",
+					"i": 50,
 				}},
-				WantErr:    fmt.Errorf(`validation for "S" failed: [FileDoesNotExist] file "execute_test.go" does exist`),
-				WantStderr: "validation for \"S\" failed: [FileDoesNotExist] file \"execute_test.go\" does exist\n",
+				WantStderr: "validation for \"i\" failed: [Or(And(GT(0), LT(10)), Not(LT(100)))] failed: [And(GT(0), LT(10))] failed: [LT] value isn't less than 10; [Not(LT(100))] failed\n",
+				WantErr:    fmt.Errorf(`validation for "i" failed: [Or(And(GT(0), LT(10)), Not(LT(100)))] failed: [And(GT(0), LT(10))] failed: [LT] value isn't less than 10; [Not(LT(100))] failed`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "execute_test.go"},
+						{Value: "50"},
 					},
 				},
 			},
 		},
+		// InRemoteList
 		{
-			name: "FileDoesNotExist works",
+			name: "InRemoteList works",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[string]("S", testDesc, FileDoesNotExist()),
+					Processor: Arg[string]("strArg", testDesc, InRemoteList(func(d *command.Data) ([]string, error) {
+						return []string{"abc", "def", "ghi"}, nil
+					})),
 				},
-				Args: []string{"execute_test.gone"},
+				Args: []string{"def"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"S": "execute_test.gone",
+					"strArg": "def",
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "execute_test.gone"},
+						{Value: "def"},
 					},
 				},
 			},
 		},
 		{
-			name: "FilesExist works",
+			name: "InRemoteList fetcher has access to data",
 			etc: &commandtest.ExecuteTestCase{
-				Node: &SimpleNode{
-					Processor: ListArg[string]("SL", testDesc, 1, 3, ListifyValidatorOption(FileExists())),
-				},
-				Args: []string{"execute_test.go", "execute.go"},
+				Node: SerialNodes(
+					Arg[string]("team", testDesc),
+					Arg[string]("member", testDesc, InRemoteList(func(d *command.Data) ([]string, error) {
+						return map[string][]string{
+							"red":  {"alice", "bob"},
+							"blue": {"carol"},
+						}[d.String("team")], nil
+					})),
+				),
+				Args: []string{"blue", "carol"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"SL": []string{"execute_test.go", "execute.go"},
+					"team":   "blue",
+					"member": "carol",
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "execute_test.go"},
-						{Value: "execute.go"},
+						{Value: "blue"},
+						{Value: "carol"},
 					},
 				},
 			},
 		},
 		{
-			name: "FilesExist fails",
+			name: "InRemoteList fails for value that isn't allowed",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: ListArg[string]("SL", testDesc, 1, 3, ListifyValidatorOption(FileExists())),
+					Processor: Arg[string]("strArg", testDesc, InRemoteList(func(d *command.Data) ([]string, error) {
+						return []string{"abc", "def", "ghi"}, nil
+					})),
 				},
-				Args: []string{"execute_test.go", "execute.gone"},
+				Args: []string{"jkl"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"SL": []string{"execute_test.go", "execute.gone"},
+					"strArg": "jkl",
 				}},
-				WantErr:    fmt.Errorf(`validation for "SL" failed: [FileExists] file "execute.gone" does not exist`),
-				WantStderr: "validation for \"SL\" failed: [FileExists] file \"execute.gone\" does not exist\n",
+				WantStderr: "validation for \"strArg\" failed: [InRemoteList] argument must be one of [abc def ghi]\n",
+				WantErr:    fmt.Errorf(`validation for "strArg" failed: [InRemoteList] argument must be one of [abc def ghi]`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "execute_test.go"},
-						{Value: "execute.gone"},
+						{Value: "jkl"},
 					},
 				},
 			},
 		},
-		// IsDir and AreDirs
 		{
-			name: "IsDir works",
+			name: "InRemoteList fails if fetcher fails",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[string]("S", testDesc, IsDir()),
+					Processor: Arg[string]("strArg", testDesc, InRemoteList(func(d *command.Data) ([]string, error) {
+						return []string{"abc"}, fmt.Errorf("connection refused")
+					})),
 				},
-				Args: []string{"testdata"},
+				Args: []string{"abc"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"S": "testdata",
+					"strArg": "abc",
 				}},
+				WantStderr: "validation for \"strArg\" failed: [InRemoteList] failed to fetch allowed values: connection refused\n",
+				WantErr:    fmt.Errorf(`validation for "strArg" failed: [InRemoteList] failed to fetch allowed values: connection refused`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "testdata"},
+						{Value: "abc"},
 					},
 				},
 			},
 		},
+		// MatchesRegex
 		{
-			name: "IsDir fails when does not exist",
+			name: "matches regex works",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[string]("S", testDesc, IsDir()),
+					Processor: Arg[string]("strArg", testDesc, MatchesRegex("a+b=?c")),
 				},
-				Args: []string{"tested"},
+				Args: []string{"equiation: aabcdef"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"S": "tested",
+					"strArg": "equiation: aabcdef",
 				}},
-				WantErr:    fmt.Errorf(`validation for "S" failed: [IsDir] file "tested" does not exist`),
-				WantStderr: "validation for \"S\" failed: [IsDir] file \"tested\" does not exist\n",
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
-				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "tested"},
+						{Value: "equiation: aabcdef"},
 					},
 				},
 			},
 		},
 		{
-			name: "IsDir fails when not a directory",
+			name: "matches regex fails",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[string]("S", testDesc, IsDir()),
+					Processor: Arg[string]("strArg", testDesc, MatchesRegex(".*", "i+")),
 				},
-				Args: []string{"execute_test.go"},
+				Args: []string{"team"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"S": "execute_test.go",
+					"strArg": "team",
 				}},
-				WantErr:    fmt.Errorf(`validation for "S" failed: [IsDir] argument "execute_test.go" is a file`),
-				WantStderr: "validation for \"S\" failed: [IsDir] argument \"execute_test.go\" is a file\n",
+				WantStderr: "validation for \"strArg\" failed: [MatchesRegex] value \"team\" doesn't match regex \"i+\"\n",
+				WantErr:    fmt.Errorf(`validation for "strArg" failed: [MatchesRegex] value "team" doesn't match regex "i+"`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "execute_test.go"},
+						{Value: "team"},
 					},
 				},
 			},
 		},
+		// ListMatchesRegex
 		{
-			name: "AreDirs works",
+			name: "ListMatchesRegex works",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: ListArg[string]("SL", testDesc, 1, 3, ListifyValidatorOption(IsDir())),
+					Processor: ListArg[string]("slArg", testDesc, 1, command.UnboundedList, ListifyValidatorOption(MatchesRegex("a+b=?c", "^eq"))),
 				},
-				Args: []string{"testdata", "co2test"},
+				Args: []string{"equiation: aabcdef"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"SL": []string{"testdata", "co2test"},
+					"slArg": []string{"equiation: aabcdef"},
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "testdata"},
-						{Value: "co2test"},
+						{Value: "equiation: aabcdef"},
 					},
 				},
 			},
 		},
 		{
-			name: "AreDirs fails when does not exist",
+			name: "ListMatchesRegex fails",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: ListArg[string]("SL", testDesc, 1, 3, ListifyValidatorOption(IsDir())),
+					Processor: ListArg[string]("slArg", testDesc, 1, command.UnboundedList, ListifyValidatorOption(MatchesRegex(".*", "i+"))),
 				},
-				Args: []string{"testdata", "co3test"},
+				Args: []string{"equiation: aabcdef", "oops"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"SL": []string{"testdata", "co3test"},
+					"slArg": []string{"equiation: aabcdef", "oops"},
 				}},
-				WantErr:    fmt.Errorf(`validation for "SL" failed: [IsDir] file "co3test" does not exist`),
-				WantStderr: "validation for \"SL\" failed: [IsDir] file \"co3test\" does not exist\n",
+				WantStderr: "validation for \"slArg\" failed: [MatchesRegex] value \"oops\" doesn't match regex \"i+\"\n",
+				WantErr:    fmt.Errorf(`validation for "slArg" failed: [MatchesRegex] value "oops" doesn't match regex "i+"`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "testdata"},
-						{Value: "co3test"},
+						{Value: "equiation: aabcdef"},
+						{Value: "oops"},
 					},
 				},
 			},
 		},
+		// IsRegex
 		{
-			name: "AreDirs fails when not a directory",
+			name: "IsRegex works",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: ListArg[string]("SL", testDesc, 1, 3, ListifyValidatorOption(IsDir())),
+					Processor: Arg[string]("strArg", testDesc, IsRegex()),
 				},
-				Args: []string{"testdata", "execute.go"},
+				Args: []string{".*"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"SL": []string{"testdata", "execute.go"},
+					"strArg": ".*",
 				}},
-				WantErr:    fmt.Errorf(`validation for "SL" failed: [IsDir] argument "execute.go" is a file`),
-				WantStderr: "validation for \"SL\" failed: [IsDir] argument \"execute.go\" is a file\n",
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
-				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "testdata"},
-						{Value: "execute.go"},
+						{Value: ".*"},
 					},
 				},
 			},
 		},
-		// IsFile and AreFiles
 		{
-			name: "IsFile works",
+			name: "IsRegex fails",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[string]("S", testDesc, IsFile()),
+					Processor: Arg[string]("strArg", testDesc, IsRegex()),
 				},
-				Args: []string{"execute.go"},
+				Args: []string{"*"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"S": "execute.go",
+					"strArg": "*",
 				}},
+				WantStderr: "validation for \"strArg\" failed: [IsRegex] value \"*\" isn't a valid regex: error parsing regexp: missing argument to repetition operator: `*`\n",
+				WantErr:    fmt.Errorf("validation for \"strArg\" failed: [IsRegex] value \"*\" isn't a valid regex: error parsing regexp: missing argument to repetition operator: `*`"),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "execute.go"},
+						{Value: "*"},
 					},
 				},
 			},
 		},
+		// IsGoTemplate
 		{
-			name: "IsFile fails when does not exist",
+			name: "IsGoTemplate works",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[string]("S", testDesc, IsFile()),
+					Processor: Arg[string]("strArg", testDesc, IsGoTemplate()),
 				},
-				Args: []string{"tested"},
+				Args: []string{"Hello {{ .Name }}"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"S": "tested",
+					"strArg": "Hello {{ .Name }}",
 				}},
-				WantErr:    fmt.Errorf(`validation for "S" failed: [IsFile] file "tested" does not exist`),
-				WantStderr: "validation for \"S\" failed: [IsFile] file \"tested\" does not exist\n",
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
-				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "tested"},
+						{Value: "Hello {{ .Name }}"},
 					},
 				},
 			},
 		},
 		{
-			name: "IsFile fails when not a file",
+			name: "IsGoTemplate fails for unclosed action",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[string]("S", testDesc, IsFile()),
+					Processor: Arg[string]("strArg", testDesc, IsGoTemplate()),
 				},
-				Args: []string{"testdata"},
+				Args: []string{"Hello {{ .Name"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"S": "testdata",
+					"strArg": "Hello {{ .Name",
 				}},
-				WantErr:    fmt.Errorf(`validation for "S" failed: [IsFile] argument "testdata" is a directory`),
-				WantStderr: "validation for \"S\" failed: [IsFile] argument \"testdata\" is a directory\n",
+				WantStderr: "validation for \"strArg\" failed: [IsGoTemplate] value \"Hello {{ .Name\" isn't a valid template: template: :1: unclosed action\n",
+				WantErr:    fmt.Errorf("validation for \"strArg\" failed: [IsGoTemplate] value \"Hello {{ .Name\" isn't a valid template: template: :1: unclosed action"),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "testdata"},
+						{Value: "Hello {{ .Name"},
 					},
 				},
 			},
 		},
+		// ListIsRegex
 		{
-			name: "AreFiles works",
+			name: "ListIsRegex works",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: ListArg[string]("SL", testDesc, 1, 3, ListifyValidatorOption(IsFile())),
+					Processor: ListArg[string]("slArg", testDesc, 1, command.UnboundedList, ListifyValidatorOption(IsRegex())),
 				},
-				Args: []string{"execute.go", "cache.go"},
+				Args: []string{".*", " +"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"SL": []string{"execute.go", "cache.go"},
+					"slArg": []string{".*", " +"},
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "execute.go"},
-						{Value: "cache.go"},
+						{Value: ".*"},
+						{Value: " +"},
 					},
 				},
 			},
 		},
 		{
-			name: "AreFiles fails when does not exist",
+			name: "ListIsRegex fails",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: ListArg[string]("SL", testDesc, 1, 3, ListifyValidatorOption(IsFile())),
+					Processor: ListArg[string]("slArg", testDesc, 1, command.UnboundedList, ListifyValidatorOption(IsRegex())),
 				},
-				Args: []string{"execute.go", "cash"},
+				Args: []string{".*", "+"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"SL": []string{"execute.go", "cash"},
+					"slArg": []string{".*", "+"},
 				}},
-				WantErr:    fmt.Errorf(`validation for "SL" failed: [IsFile] file "cash" does not exist`),
-				WantStderr: "validation for \"SL\" failed: [IsFile] file \"cash\" does not exist\n",
+				WantStderr: "validation for \"slArg\" failed: [IsRegex] value \"+\" isn't a valid regex: error parsing regexp: missing argument to repetition operator: `+`\n",
+				WantErr:    fmt.Errorf("validation for \"slArg\" failed: [IsRegex] value \"+\" isn't a valid regex: error parsing regexp: missing argument to repetition operator: `+`"),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "execute.go"},
-						{Value: "cash"},
+						{Value: ".*"},
+						{Value: "+"},
 					},
 				},
 			},
 		},
+		// FileExists, FileDoesNotExist, and FilesExist
 		{
-			name: "AreFiles fails when not a directory",
+			name: "FileExists works",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: ListArg[string]("SL", testDesc, 1, 3, ListifyValidatorOption(IsFile())),
+					Processor: Arg[string]("S", testDesc, FileExists()),
 				},
-				Args: []string{"execute.go", "testdata"},
+				Args: []string{"execute_test.go"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"SL": []string{"execute.go", "testdata"},
+					"S": "execute_test.go",
 				}},
-				WantErr:    fmt.Errorf(`validation for "SL" failed: [IsFile] argument "testdata" is a directory`),
-				WantStderr: "validation for \"SL\" failed: [IsFile] argument \"testdata\" is a directory\n",
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
-				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "execute.go"},
-						{Value: "testdata"},
+						{Value: "execute_test.go"},
 					},
 				},
 			},
 		},
-		// InList & string menus
 		{
-			name: "InList works",
+			name: "FileExists fails",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[string]("strArg", testDesc, InList("abc", "def", "ghi")),
+					Processor: Arg[string]("S", testDesc, FileExists()),
 				},
-				Args: []string{"def"},
+				Args: []string{"execute_test.gone"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"strArg": "def",
+					"S": "execute_test.gone",
 				}},
+				WantErr:    fmt.Errorf(`validation for "S" failed: [FileExists] file "execute_test.gone" does not exist`),
+				WantStderr: "validation for \"S\" failed: [FileExists] file \"execute_test.gone\" does not exist\n",
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "def"},
+						{Value: "execute_test.gone"},
 					},
 				},
 			},
 		},
 		{
-			name: "InList fails",
+			name: "FileDoesNotExist fails",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[string]("strArg", testDesc, InList("abc", "def", "ghi")),
+					Processor: Arg[string]("S", testDesc, FileDoesNotExist()),
 				},
-				Args: []string{"jkl"},
+				Args: []string{"execute_test.go"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"strArg": "jkl",
+					"S": "execute_test.go",
 				}},
-				WantStderr: "validation for \"strArg\" failed: [InList] argument must be one of [abc def ghi]\n",
-				WantErr:    fmt.Errorf(`validation for "strArg" failed: [InList] argument must be one of [abc def ghi]`),
+				WantErr:    fmt.Errorf(`validation for "S" failed: [FileDoesNotExist] file "execute_test.go" does exist`),
+				WantStderr: "validation for \"S\" failed: [FileDoesNotExist] file \"execute_test.go\" does exist\n",
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "jkl"},
+						{Value: "execute_test.go"},
 					},
 				},
 			},
 		},
 		{
-			name: "MenuArg works",
+			name: "FileDoesNotExist works",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: MenuArg("strArg", testDesc, "abc", "def", "ghi"),
+					Processor: Arg[string]("S", testDesc, FileDoesNotExist()),
 				},
-				Args: []string{"def"},
+				Args: []string{"execute_test.gone"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"strArg": "def",
+					"S": "execute_test.gone",
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "def"},
+						{Value: "execute_test.gone"},
 					},
 				},
 			},
 		},
 		{
-			name: "MenuArg fails if provided is not in list",
+			name: "FilesExist works",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: MenuArg("strArg", testDesc, "abc", "def", "ghi"),
+					Processor: ListArg[string]("SL", testDesc, 1, 3, ListifyValidatorOption(FileExists())),
 				},
-				Args: []string{"jkl"},
+				Args: []string{"execute_test.go", "execute.go"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"strArg": "jkl",
+					"SL": []string{"execute_test.go", "execute.go"},
 				}},
-				WantStderr: "validation for \"strArg\" failed: [InList] argument must be one of [abc def ghi]\n",
-				WantErr:    fmt.Errorf(`validation for "strArg" failed: [InList] argument must be one of [abc def ghi]`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
-				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "jkl"},
+						{Value: "execute_test.go"},
+						{Value: "execute.go"},
 					},
 				},
 			},
 		},
 		{
-			name: "MenuFlag works",
+			name: "FilesExist fails",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(
-						MenuFlag("sf", 's', testDesc, "abc", "def", "ghi"),
-					),
-				),
-				Args: []string{"--sf", "def"},
+				Node: &SimpleNode{
+					Processor: ListArg[string]("SL", testDesc, 1, 3, ListifyValidatorOption(FileExists())),
+				},
+				Args: []string{"execute_test.go", "execute.gone"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"sf": "def",
+					"SL": []string{"execute_test.go", "execute.gone"},
 				}},
+				WantErr:    fmt.Errorf(`validation for "SL" failed: [FileExists] file "execute.gone" does not exist`),
+				WantStderr: "validation for \"SL\" failed: [FileExists] file \"execute.gone\" does not exist\n",
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "--sf"},
-						{Value: "def"},
+						{Value: "execute_test.go"},
+						{Value: "execute.gone"},
 					},
 				},
 			},
 		},
+		// IsDir and AreDirs
 		{
-			name: "MenuFlag works with AddOptions(default)",
+			name: "IsDir works",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(
-						MenuFlag("sf", 's', testDesc, "abc", "def", "ghi", "xyz").AddOptions(Default("xyz")),
-					),
-				),
+				Node: &SimpleNode{
+					Processor: Arg[string]("S", testDesc, IsDir()),
+				},
+				Args: []string{"testdata"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"sf": "xyz",
+					"S": "testdata",
 				}},
 			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "testdata"},
+					},
+				},
+			},
 		},
 		{
-			name: "MenuFlag fails if provided is not in list",
+			name: "IsDir fails when does not exist",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(
-						MenuFlag("sf", 's', testDesc, "abc", "def", "ghi"),
-					),
-				),
-				Args: []string{"-s", "jkl"},
+				Node: &SimpleNode{
+					Processor: Arg[string]("S", testDesc, IsDir()),
+				},
+				Args: []string{"tested"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"sf": "jkl",
+					"S": "tested",
 				}},
-				WantStderr: "validation for \"sf\" failed: [InList] argument must be one of [abc def ghi]\n",
-				WantErr:    fmt.Errorf(`validation for "sf" failed: [InList] argument must be one of [abc def ghi]`),
+				WantErr:    fmt.Errorf(`validation for "S" failed: [IsDir] file "tested" does not exist`),
+				WantStderr: "validation for \"S\" failed: [IsDir] file \"tested\" does not exist\n",
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "-s"},
-						{Value: "jkl"},
+						{Value: "tested"},
 					},
 				},
 			},
 		},
-		// MinLength
 		{
-			name: "MinLength works",
+			name: "IsDir fails when not a directory",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[string]("strArg", testDesc, MinLength[string, string](3)),
+					Processor: Arg[string]("S", testDesc, IsDir()),
 				},
-				Args: []string{"hello"},
+				Args: []string{"execute_test.go"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"strArg": "hello",
+					"S": "execute_test.go",
 				}},
+				WantErr:    fmt.Errorf(`validation for "S" failed: [IsDir] argument "execute_test.go" is a file`),
+				WantStderr: "validation for \"S\" failed: [IsDir] argument \"execute_test.go\" is a file\n",
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "hello"},
+						{Value: "execute_test.go"},
 					},
 				},
 			},
 		},
 		{
-			name: "MinLength works for exact count match",
+			name: "AreDirs works",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[string]("strArg", testDesc, MinLength[string, string](3)),
+					Processor: ListArg[string]("SL", testDesc, 1, 3, ListifyValidatorOption(IsDir())),
 				},
-				Args: []string{"hey"},
+				Args: []string{"testdata", "co2test"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"strArg": "hey",
+					"SL": []string{"testdata", "co2test"},
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "hey"},
+						{Value: "testdata"},
+						{Value: "co2test"},
 					},
 				},
 			},
 		},
 		{
-			name: "MinLength fails",
+			name: "AreDirs fails when does not exist",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[string]("strArg", testDesc, MinLength[string, string](3)),
+					Processor: ListArg[string]("SL", testDesc, 1, 3, ListifyValidatorOption(IsDir())),
 				},
-				Args: []string{"hi"},
+				Args: []string{"testdata", "co3test"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"strArg": "hi",
+					"SL": []string{"testdata", "co3test"},
 				}},
-				WantStderr: "validation for \"strArg\" failed: [MinLength] length must be at least 3\n",
-				WantErr:    fmt.Errorf(`validation for "strArg" failed: [MinLength] length must be at least 3`),
+				WantErr:    fmt.Errorf(`validation for "SL" failed: [IsDir] file "co3test" does not exist`),
+				WantStderr: "validation for \"SL\" failed: [IsDir] file \"co3test\" does not exist\n",
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "hi"},
+						{Value: "testdata"},
+						{Value: "co3test"},
 					},
 				},
 			},
 		},
-		// MaxLength
 		{
-			name: "MaxLength works",
+			name: "AreDirs fails when not a directory",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: ListArg[int]("strArg", testDesc, 0, command.UnboundedList, MaxLength[int, []int](3)),
+					Processor: ListArg[string]("SL", testDesc, 1, 3, ListifyValidatorOption(IsDir())),
 				},
-				Args: []string{"1234", "56"},
+				Args: []string{"testdata", "execute.go"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"strArg": []int{
-						1234,
-						56,
-					},
+					"SL": []string{"testdata", "execute.go"},
 				}},
+				WantErr:    fmt.Errorf(`validation for "SL" failed: [IsDir] argument "execute.go" is a file`),
+				WantStderr: "validation for \"SL\" failed: [IsDir] argument \"execute.go\" is a file\n",
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "1234"},
-						{Value: "56"},
+						{Value: "testdata"},
+						{Value: "execute.go"},
 					},
 				},
 			},
 		},
+		// IsFile and AreFiles
 		{
-			name: "MaxLength works for exact count match",
+			name: "IsFile works",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: ListArg[int]("strArg", testDesc, 0, command.UnboundedList, MaxLength[int, []int](3)),
+					Processor: Arg[string]("S", testDesc, IsFile()),
 				},
-				Args: []string{"1234", "56", "78901"},
+				Args: []string{"execute.go"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"strArg": []int{
-						1234,
-						56,
-						78901,
-					},
+					"S": "execute.go",
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "1234"},
-						{Value: "56"},
-						{Value: "78901"},
+						{Value: "execute.go"},
 					},
 				},
 			},
 		},
 		{
-			name: "MaxLength fails",
+			name: "IsFile fails when does not exist",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: ListArg[int]("strArg", testDesc, 0, command.UnboundedList, MaxLength[int, []int](3)),
+					Processor: Arg[string]("S", testDesc, IsFile()),
 				},
-				Args: []string{"1234", "56", "78901", "234"},
+				Args: []string{"tested"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"strArg": []int{
-						1234,
-						56,
-						78901,
-						234,
-					},
+					"S": "tested",
 				}},
-				WantStderr: "validation for \"strArg\" failed: [MaxLength] length must be at most 3\n",
-				WantErr:    fmt.Errorf(`validation for "strArg" failed: [MaxLength] length must be at most 3`),
+				WantErr:    fmt.Errorf(`validation for "S" failed: [IsFile] file "tested" does not exist`),
+				WantStderr: "validation for \"S\" failed: [IsFile] file \"tested\" does not exist\n",
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "1234"},
-						{Value: "56"},
-						{Value: "78901"},
-						{Value: "234"},
+						{Value: "tested"},
 					},
 				},
 			},
 		},
-		// Length
 		{
-			name: "Length works",
+			name: "IsFile fails when not a file",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[string]("strArg", testDesc, Length[string, string](3)),
+					Processor: Arg[string]("S", testDesc, IsFile()),
 				},
-				Args: []string{"hey"},
+				Args: []string{"testdata"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"strArg": "hey",
+					"S": "testdata",
 				}},
+				WantErr:    fmt.Errorf(`validation for "S" failed: [IsFile] argument "testdata" is a directory`),
+				WantStderr: "validation for \"S\" failed: [IsFile] argument \"testdata\" is a directory\n",
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "hey"},
+						{Value: "testdata"},
 					},
 				},
 			},
 		},
+		// WithinDir
 		{
-			name: "Length fails for too few",
+			name: "WithinDir works for path inside root",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[string]("strArg", testDesc, Length[string, string](3)),
+					Processor: Arg[string]("S", testDesc, WithinDir("testdata")),
 				},
-				Args: []string{"hi"},
+				Args: []string{"testdata/one.txt"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"strArg": "hi",
+					"S": "testdata/one.txt",
 				}},
-				WantStderr: "validation for \"strArg\" failed: [Length] length must be exactly 3\n",
-				WantErr:    fmt.Errorf(`validation for "strArg" failed: [Length] length must be exactly 3`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
-				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "hi"},
+						{Value: "testdata/one.txt"},
 					},
 				},
 			},
 		},
 		{
-			name: "Length fails for too many",
+			name: "WithinDir works for root itself",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[string]("strArg", testDesc, Length[string, string](4)),
+					Processor: Arg[string]("S", testDesc, WithinDir("testdata")),
 				},
-				Args: []string{"howdy"},
+				Args: []string{"testdata"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"strArg": "howdy",
+					"S": "testdata",
 				}},
-				WantStderr: "validation for \"strArg\" failed: [Length] length must be exactly 4\n",
-				WantErr:    fmt.Errorf(`validation for "strArg" failed: [Length] length must be exactly 4`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
-				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "howdy"},
+						{Value: "testdata"},
 					},
 				},
 			},
 		},
-		// IntEQ
 		{
-			name: "IntEQ works",
+			name: "WithinDir works for non-existent path inside root",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[int]("i", testDesc, EQ(24)),
+					Processor: Arg[string]("S", testDesc, WithinDir("testdata")),
 				},
-				Args: []string{"24"},
+				Args: []string{"testdata/dir1/new-file.txt"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"i": 24,
+					"S": "testdata/dir1/new-file.txt",
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "24"},
+						{Value: "testdata/dir1/new-file.txt"},
 					},
 				},
 			},
 		},
 		{
-			name: "IntEQ fails",
+			name: "WithinDir works for path that leaves and re-enters root",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[int]("i", testDesc, EQ(24)),
+					Processor: Arg[string]("S", testDesc, WithinDir("testdata")),
 				},
-				Args: []string{"25"},
+				Args: []string{"testdata/../testdata/dir1"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"i": 25,
+					"S": "testdata/../testdata/dir1",
 				}},
-				WantStderr: "validation for \"i\" failed: [EQ] value isn't equal to 24\n",
-				WantErr:    fmt.Errorf(`validation for "i" failed: [EQ] value isn't equal to 24`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
-				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "25"},
+						{Value: "testdata/../testdata/dir1"},
 					},
 				},
 			},
 		},
-		// IntNE
 		{
-			name: "IntNE works",
+			name: "WithinDir fails for path that escapes root with ..",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[int]("i", testDesc, NEQ(24)),
+					Processor: Arg[string]("S", testDesc, WithinDir("testdata")),
 				},
-				Args: []string{"25"},
+				Args: []string{"testdata/dir1/../../validator.go"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"i": 25,
+					"S": "testdata/dir1/../../validator.go",
 				}},
+				WantStderr: "validation for \"S\" failed: [WithinDir] path \"testdata/dir1/../../validator.go\" is not inside of \"testdata\"\n",
+				WantErr:    fmt.Errorf("validation for \"S\" failed: [WithinDir] path \"testdata/dir1/../../validator.go\" is not inside of \"testdata\""),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "25"},
+						{Value: "testdata/dir1/../../validator.go"},
 					},
 				},
 			},
 		},
 		{
-			name: "IntNE fails",
+			name: "WithinDir fails for parent directory",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[int]("i", testDesc, NEQ(24)),
+					Processor: Arg[string]("S", testDesc, WithinDir("testdata")),
 				},
-				Args: []string{"24"},
+				Args: []string{".."},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"i": 24,
+					"S": "..",
 				}},
-				WantStderr: "validation for \"i\" failed: [NEQ] value cannot equal 24\n",
-				WantErr:    fmt.Errorf(`validation for "i" failed: [NEQ] value cannot equal 24`),
+				WantStderr: "validation for \"S\" failed: [WithinDir] path \"..\" is not inside of \"testdata\"\n",
+				WantErr:    fmt.Errorf("validation for \"S\" failed: [WithinDir] path \"..\" is not inside of \"testdata\""),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "24"},
+						{Value: ".."},
 					},
 				},
 			},
 		},
-		// IntLT
 		{
-			name: "IntLT works when less than",
+			name: "WithinDir fails for sibling that shares a prefix with root",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[int]("i", testDesc, LT(25)),
+					Processor: Arg[string]("S", testDesc, WithinDir("testdata")),
 				},
-				Args: []string{"24"},
+				Args: []string{"testdata2/one.txt"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"i": 24,
+					"S": "testdata2/one.txt",
 				}},
+				WantStderr: "validation for \"S\" failed: [WithinDir] path \"testdata2/one.txt\" is not inside of \"testdata\"\n",
+				WantErr:    fmt.Errorf("validation for \"S\" failed: [WithinDir] path \"testdata2/one.txt\" is not inside of \"testdata\""),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "24"},
+						{Value: "testdata2/one.txt"},
 					},
 				},
 			},
 		},
+		// Symbolic links are not resolved, so a path through a directory outside of
+		// the root fails (even if that directory links to somewhere inside the root).
 		{
-			name: "IntLT fails when equal to",
+			name: "WithinDir does not resolve symlinks",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[int]("i", testDesc, LT(25)),
+					Processor: Arg[string]("S", testDesc, WithinDir("testdata")),
 				},
-				Args: []string{"25"},
+				Args: []string{"_testdata_symlink/one.txt"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"i": 25,
+					"S": "_testdata_symlink/one.txt",
 				}},
-				WantStderr: "validation for \"i\" failed: [LT] value isn't less than 25\n",
-				WantErr:    fmt.Errorf(`validation for "i" failed: [LT] value isn't less than 25`),
+				WantStderr: "validation for \"S\" failed: [WithinDir] path \"_testdata_symlink/one.txt\" is not inside of \"testdata\"\n",
+				WantErr:    fmt.Errorf("validation for \"S\" failed: [WithinDir] path \"_testdata_symlink/one.txt\" is not inside of \"testdata\""),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "25"},
+						{Value: "_testdata_symlink/one.txt"},
 					},
 				},
 			},
 		},
 		{
-			name: "IntLT fails when greater than",
+			name: "AreFiles works",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[int]("i", testDesc, LT(25)),
+					Processor: ListArg[string]("SL", testDesc, 1, 3, ListifyValidatorOption(IsFile())),
 				},
-				Args: []string{"26"},
+				Args: []string{"execute.go", "cache.go"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"i": 26,
+					"SL": []string{"execute.go", "cache.go"},
 				}},
-				WantStderr: "validation for \"i\" failed: [LT] value isn't less than 25\n",
-				WantErr:    fmt.Errorf(`validation for "i" failed: [LT] value isn't less than 25`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
-				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "26"},
+						{Value: "execute.go"},
+						{Value: "cache.go"},
 					},
 				},
 			},
 		},
-		// IntLTE
 		{
-			name: "IntLTE works when less than",
+			name: "AreFiles fails when does not exist",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[int]("i", testDesc, LTE(25)),
+					Processor: ListArg[string]("SL", testDesc, 1, 3, ListifyValidatorOption(IsFile())),
 				},
-				Args: []string{"24"},
+				Args: []string{"execute.go", "cash"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"i": 24,
+					"SL": []string{"execute.go", "cash"},
 				}},
+				WantErr:    fmt.Errorf(`validation for "SL" failed: [IsFile] file "cash" does not exist`),
+				WantStderr: "validation for \"SL\" failed: [IsFile] file \"cash\" does not exist\n",
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "24"},
+						{Value: "execute.go"},
+						{Value: "cash"},
 					},
 				},
 			},
 		},
 		{
-			name: "IntLTE works when equal to",
+			name: "AreFiles fails when not a directory",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[int]("i", testDesc, LTE(25)),
+					Processor: ListArg[string]("SL", testDesc, 1, 3, ListifyValidatorOption(IsFile())),
 				},
-				Args: []string{"25"},
+				Args: []string{"execute.go", "testdata"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"i": 25,
+					"SL": []string{"execute.go", "testdata"},
 				}},
+				WantErr:    fmt.Errorf(`validation for "SL" failed: [IsFile] argument "testdata" is a directory`),
+				WantStderr: "validation for \"SL\" failed: [IsFile] argument \"testdata\" is a directory\n",
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "25"},
+						{Value: "execute.go"},
+						{Value: "testdata"},
 					},
 				},
 			},
 		},
+		// IsRelativeSafePath
 		{
-			name: "IntLTE fails when greater than",
+			name: "IsRelativeSafePath works for simple relative path",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[int]("i", testDesc, LTE(25)),
+					Processor: Arg[string]("S", testDesc, IsRelativeSafePath()),
 				},
-				Args: []string{"26"},
+				Args: []string{"plugins/my-plugin"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"i": 26,
+					"S": "plugins/my-plugin",
 				}},
-				WantStderr: "validation for \"i\" failed: [LTE] value isn't less than or equal to 25\n",
-				WantErr:    fmt.Errorf(`validation for "i" failed: [LTE] value isn't less than or equal to 25`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
-				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "26"},
+						{Value: "plugins/my-plugin"},
 					},
 				},
 			},
 		},
-		// IntGT
 		{
-			name: "IntGT fails when less than",
+			name: "IsRelativeSafePath works for names containing dots",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[int]("i", testDesc, GT(25)),
+					Processor: Arg[string]("S", testDesc, IsRelativeSafePath()),
 				},
-				Args: []string{"24"},
+				Args: []string{"./a/..b/c..d/.hidden"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"i": 24,
+					"S": "./a/..b/c..d/.hidden",
 				}},
-				WantStderr: "validation for \"i\" failed: [GT] value isn't greater than 25\n",
-				WantErr:    fmt.Errorf(`validation for "i" failed: [GT] value isn't greater than 25`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
-				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "24"},
+						{Value: "./a/..b/c..d/.hidden"},
 					},
 				},
 			},
 		},
 		{
-			name: "IntGT fails when equal to",
+			name: "IsRelativeSafePath fails for absolute path",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[int]("i", testDesc, GT(25)),
+					Processor: Arg[string]("S", testDesc, IsRelativeSafePath()),
 				},
-				Args: []string{"25"},
+				Args: []string{"/etc/passwd"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"i": 25,
+					"S": "/etc/passwd",
 				}},
-				WantStderr: "validation for \"i\" failed: [GT] value isn't greater than 25\n",
-				WantErr:    fmt.Errorf(`validation for "i" failed: [GT] value isn't greater than 25`),
+				WantStderr: "validation for \"S\" failed: [IsRelativeSafePath] path \"/etc/passwd\" must be relative\n",
+				WantErr:    fmt.Errorf("validation for \"S\" failed: [IsRelativeSafePath] path \"/etc/passwd\" must be relative"),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "25"},
+						{Value: "/etc/passwd"},
 					},
 				},
 			},
 		},
 		{
-			name: "IntGT works when greater than",
+			name: "IsRelativeSafePath fails for backslash-rooted path",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[int]("i", testDesc, GT(25)),
+					Processor: Arg[string]("S", testDesc, IsRelativeSafePath()),
 				},
-				Args: []string{"26"},
+				Args: []string{"\\windows\\system32"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"i": 26,
+					"S": "\\windows\\system32",
 				}},
+				WantStderr: "validation for \"S\" failed: [IsRelativeSafePath] path \"\\\\windows\\\\system32\" must be relative\n",
+				WantErr:    fmt.Errorf("validation for \"S\" failed: [IsRelativeSafePath] path \"\\\\windows\\\\system32\" must be relative"),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "26"},
+						{Value: "\\windows\\system32"},
 					},
 				},
 			},
 		},
-		// IntGTE
 		{
-			name: "IntGTE fails when less than",
+			name: "IsRelativeSafePath fails for path with .. segment",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[int]("i", testDesc, GTE(25)),
+					Processor: Arg[string]("S", testDesc, IsRelativeSafePath()),
 				},
-				Args: []string{"24"},
+				Args: []string{"plugins/../../secret"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"i": 24,
+					"S": "plugins/../../secret",
 				}},
-				WantStderr: "validation for \"i\" failed: [GTE] value isn't greater than or equal to 25\n",
-				WantErr:    fmt.Errorf(`validation for "i" failed: [GTE] value isn't greater than or equal to 25`),
+				WantStderr: "validation for \"S\" failed: [IsRelativeSafePath] path \"plugins/../../secret\" must not contain \"..\" segments\n",
+				WantErr:    fmt.Errorf("validation for \"S\" failed: [IsRelativeSafePath] path \"plugins/../../secret\" must not contain \"..\" segments"),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "24"},
+						{Value: "plugins/../../secret"},
 					},
 				},
 			},
 		},
 		{
-			name: "IntGTE works when equal to",
+			name: "IsRelativeSafePath fails for .. segment with backslashes",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[int]("i", testDesc, GTE(25)),
+					Processor: Arg[string]("S", testDesc, IsRelativeSafePath()),
 				},
-				Args: []string{"25"},
+				Args: []string{"a\\..\\b"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"i": 25,
+					"S": "a\\..\\b",
 				}},
+				WantStderr: "validation for \"S\" failed: [IsRelativeSafePath] path \"a\\\\..\\\\b\" must not contain \"..\" segments\n",
+				WantErr:    fmt.Errorf("validation for \"S\" failed: [IsRelativeSafePath] path \"a\\\\..\\\\b\" must not contain \"..\" segments"),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "25"},
+						{Value: "a\\..\\b"},
 					},
 				},
 			},
 		},
 		{
-			name: "IntGTE works when greater than",
+			name: "IsRelativeSafePath fails for ..",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[int]("i", testDesc, GTE(25)),
+					Processor: Arg[string]("S", testDesc, IsRelativeSafePath()),
 				},
-				Args: []string{"26"},
+				Args: []string{".."},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"i": 26,
+					"S": "..",
 				}},
+				WantStderr: "validation for \"S\" failed: [IsRelativeSafePath] path \"..\" must not contain \"..\" segments\n",
+				WantErr:    fmt.Errorf("validation for \"S\" failed: [IsRelativeSafePath] path \"..\" must not contain \"..\" segments"),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "26"},
+						{Value: ".."},
 					},
 				},
 			},
 		},
-		// IntPositive
 		{
-			name: "IntPositive fails when negative",
+			name: "IsRelativeSafePath fails for empty path",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[int]("i", testDesc, Positive[int]()),
+					Processor: Arg[string]("S", testDesc, IsRelativeSafePath()),
 				},
-				Args: []string{"-1"},
+				Args: []string{""},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"i": -1,
+					"S": "",
 				}},
-				WantStderr: "validation for \"i\" failed: [Positive] value isn't positive\n",
-				WantErr:    fmt.Errorf(`validation for "i" failed: [Positive] value isn't positive`),
+				WantStderr: "validation for \"S\" failed: [IsRelativeSafePath] path must not be empty\n",
+				WantErr:    fmt.Errorf("validation for \"S\" failed: [IsRelativeSafePath] path must not be empty"),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "-1"},
+						{Value: ""},
 					},
 				},
 			},
 		},
+		// HasExtension and AllHaveExtension
 		{
-			name: "IntPositive fails when zero",
+			name: "HasExtension works",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[int]("i", testDesc, Positive[int]()),
+					Processor: Arg[string]("strArg", testDesc, HasExtension("txt", ".go")),
 				},
-				Args: []string{"0"},
+				Args: []string{"main.go"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"i": 0,
+					"strArg": "main.go",
 				}},
-				WantStderr: "validation for \"i\" failed: [Positive] value isn't positive\n",
-				WantErr:    fmt.Errorf(`validation for "i" failed: [Positive] value isn't positive`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
-				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "0"},
+						{Value: "main.go"},
 					},
 				},
 			},
 		},
 		{
-			name: "IntPositive works when positive",
+			name: "HasExtension is case-insensitive",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[int]("i", testDesc, Positive[int]()),
+					Processor: Arg[string]("strArg", testDesc, HasExtension("TXT", ".go")),
 				},
-				Args: []string{"1"},
+				Args: []string{"README.Txt"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"i": 1,
+					"strArg": "README.Txt",
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "1"},
+						{Value: "README.Txt"},
 					},
 				},
 			},
 		},
-		// IntNegative
 		{
-			name: "IntNegative works when negative",
+			name: "HasExtension works with multi-part extension",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[int]("i", testDesc, Negative[int]()),
+					Processor: Arg[string]("strArg", testDesc, HasExtension("tar.gz")),
 				},
-				Args: []string{"-1"},
+				Args: []string{"archive.tar.gz"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"i": -1,
+					"strArg": "archive.tar.gz",
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "-1"},
+						{Value: "archive.tar.gz"},
 					},
 				},
 			},
 		},
 		{
-			name: "IntNegative fails when zero",
+			name: "HasExtension fails for non-matching extension",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[int]("i", testDesc, Negative[int]()),
+					Processor: Arg[string]("strArg", testDesc, HasExtension("txt", ".go")),
 				},
-				Args: []string{"0"},
+				Args: []string{"image.png"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"i": 0,
+					"strArg": "image.png",
 				}},
-				WantStderr: "validation for \"i\" failed: [Negative] value isn't negative\n",
-				WantErr:    fmt.Errorf(`validation for "i" failed: [Negative] value isn't negative`),
+				WantStderr: "validation for \"strArg\" failed: [HasExtension] file \"image.png\" has extension \".png\"; must be one of [.txt .go]\n",
+				WantErr:    fmt.Errorf("validation for \"strArg\" failed: [HasExtension] file \"image.png\" has extension \".png\"; must be one of [.txt .go]"),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "0"},
+						{Value: "image.png"},
 					},
 				},
 			},
 		},
 		{
-			name: "IntNegative fails when positive",
+			name: "HasExtension fails for partial extension match",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[int]("i", testDesc, Negative[int]()),
+					Processor: Arg[string]("strArg", testDesc, HasExtension("go")),
 				},
-				Args: []string{"1"},
+				Args: []string{"cargo"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"i": 1,
+					"strArg": "cargo",
 				}},
-				WantStderr: "validation for \"i\" failed: [Negative] value isn't negative\n",
-				WantErr:    fmt.Errorf(`validation for "i" failed: [Negative] value isn't negative`),
+				WantStderr: "validation for \"strArg\" failed: [HasExtension] file \"cargo\" has no extension; must be one of [.go]\n",
+				WantErr:    fmt.Errorf("validation for \"strArg\" failed: [HasExtension] file \"cargo\" has no extension; must be one of [.go]"),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "1"},
+						{Value: "cargo"},
 					},
 				},
 			},
 		},
-		// IntNonNegative
 		{
-			name: "IntNonNegative fails when negative",
+			name: "HasExtension fails for no extension",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[int]("i", testDesc, NonNegative[int]()),
+					Processor: Arg[string]("strArg", testDesc, HasExtension("txt", ".go")),
 				},
-				Args: []string{"-1"},
+				Args: []string{"Makefile"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"i": -1,
+					"strArg": "Makefile",
 				}},
-				WantStderr: "validation for \"i\" failed: [NonNegative] value isn't non-negative\n",
-				WantErr:    fmt.Errorf(`validation for "i" failed: [NonNegative] value isn't non-negative`),
+				WantStderr: "validation for \"strArg\" failed: [HasExtension] file \"Makefile\" has no extension; must be one of [.txt .go]\n",
+				WantErr:    fmt.Errorf("validation for \"strArg\" failed: [HasExtension] file \"Makefile\" has no extension; must be one of [.txt .go]"),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "-1"},
+						{Value: "Makefile"},
 					},
 				},
 			},
 		},
 		{
-			name: "IntNonNegative works when zero",
+			name: "AllHaveExtension works",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[int]("i", testDesc, NonNegative[int]()),
+					Processor: ListArg[string]("SL", testDesc, 1, 3, AllHaveExtension("go", "md")),
 				},
-				Args: []string{"0"},
+				Args: []string{"main.go", "README.md"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"i": 0,
+					"SL": []string{"main.go", "README.md"},
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "0"},
+						{Value: "main.go"},
+						{Value: "README.md"},
 					},
 				},
 			},
 		},
 		{
-			name: "IntNonNegative works when positive",
+			name: "AllHaveExtension fails",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[int]("i", testDesc, NonNegative[int]()),
+					Processor: ListArg[string]("SL", testDesc, 1, 3, AllHaveExtension("go", "md")),
 				},
-				Args: []string{"1"},
+				Args: []string{"main.go", "go.mod"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"i": 1,
+					"SL": []string{"main.go", "go.mod"},
 				}},
+				WantErr:    fmt.Errorf(`validation for "SL" failed: [HasExtension] file "go.mod" has extension ".mod"; must be one of [.go .md]`),
+				WantStderr: "validation for \"SL\" failed: [HasExtension] file \"go.mod\" has extension \".mod\"; must be one of [.go .md]\n",
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "1"},
+						{Value: "main.go"},
+						{Value: "go.mod"},
 					},
 				},
 			},
 		},
-		// FloatEQ
+		// NotIn
 		{
-			name: "FloatEQ works",
+			name: "NotIn works",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[float64]("flArg", testDesc, EQ(2.4)),
+					Processor: Arg[string]("strArg", testDesc, NotIn("help", "list")),
 				},
-				Args: []string{"2.4"},
+				Args: []string{"mycmd"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"flArg": 2.4,
+					"strArg": "mycmd",
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "2.4"},
+						{Value: "mycmd"},
 					},
 				},
 			},
 		},
 		{
-			name: "FloatEQ fails",
+			name: "NotIn fails for reserved value",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[float64]("flArg", testDesc, EQ(2.4)),
+					Processor: Arg[string]("strArg", testDesc, NotIn("help", "list")),
 				},
-				Args: []string{"2.5"},
+				Args: []string{"list"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"flArg": 2.5,
+					"strArg": "list",
 				}},
-				WantStderr: "validation for \"flArg\" failed: [EQ] value isn't equal to 2.4\n",
-				WantErr:    fmt.Errorf(`validation for "flArg" failed: [EQ] value isn't equal to 2.4`),
+				WantStderr: "validation for \"strArg\" failed: [NotIn] value \"list\" is reserved\n",
+				WantErr:    fmt.Errorf("validation for \"strArg\" failed: [NotIn] value \"list\" is reserved"),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "2.5"},
+						{Value: "list"},
 					},
 				},
 			},
 		},
-		// FloatNE
 		{
-			name: "FloatNE works",
+			name: "NotIn is case sensitive",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[float64]("flArg", testDesc, NEQ(2.4)),
+					Processor: Arg[string]("strArg", testDesc, NotIn("help", "list")),
 				},
-				Args: []string{"2.5"},
+				Args: []string{"LIST"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"flArg": 2.5,
+					"strArg": "LIST",
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "2.5"},
+						{Value: "LIST"},
 					},
 				},
 			},
 		},
 		{
-			name: "FloatNE fails",
+			name: "NotInFold works",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[float64]("flArg", testDesc, NEQ(2.4)),
+					Processor: Arg[string]("strArg", testDesc, NotInFold("help", "list")),
 				},
-				Args: []string{"2.4"},
+				Args: []string{"mycmd"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"flArg": 2.4,
+					"strArg": "mycmd",
 				}},
-				WantStderr: "validation for \"flArg\" failed: [NEQ] value cannot equal 2.4\n",
-				WantErr:    fmt.Errorf(`validation for "flArg" failed: [NEQ] value cannot equal 2.4`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
-				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "2.4"},
+						{Value: "mycmd"},
 					},
 				},
 			},
 		},
-		// FloatLT
 		{
-			name: "FloatLT works when less than",
+			name: "NotInFold fails for reserved value",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[float64]("flArg", testDesc, LT(2.5)),
+					Processor: Arg[string]("strArg", testDesc, NotInFold("help", "list")),
 				},
-				Args: []string{"2.4"},
+				Args: []string{"help"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"flArg": 2.4,
+					"strArg": "help",
 				}},
+				WantStderr: "validation for \"strArg\" failed: [NotInFold] value \"help\" is reserved (matches \"help\")\n",
+				WantErr:    fmt.Errorf("validation for \"strArg\" failed: [NotInFold] value \"help\" is reserved (matches \"help\")"),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "2.4"},
+						{Value: "help"},
 					},
 				},
 			},
 		},
 		{
-			name: "FloatLT fails when equal to",
+			name: "NotInFold fails for reserved value with different case",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[float64]("flArg", testDesc, LT(2.5)),
+					Processor: Arg[string]("strArg", testDesc, NotInFold("help", "list")),
 				},
-				Args: []string{"2.5"},
+				Args: []string{"LiSt"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"flArg": 2.5,
+					"strArg": "LiSt",
 				}},
-				WantStderr: "validation for \"flArg\" failed: [LT] value isn't less than 2.5\n",
-				WantErr:    fmt.Errorf(`validation for "flArg" failed: [LT] value isn't less than 2.5`),
+				WantStderr: "validation for \"strArg\" failed: [NotInFold] value \"LiSt\" is reserved (matches \"list\")\n",
+				WantErr:    fmt.Errorf("validation for \"strArg\" failed: [NotInFold] value \"LiSt\" is reserved (matches \"list\")"),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "2.5"},
+						{Value: "LiSt"},
 					},
 				},
 			},
 		},
+		// Contiguous
 		{
-			name: "FloatLT fails when greater than",
+			name: "Contiguous succeeds for a contiguous list",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[float64]("flArg", testDesc, LT(2.5)),
+					Processor: ListArg[int]("pages", testDesc, 1, command.UnboundedList, Contiguous()),
 				},
-				Args: []string{"2.6"},
+				Args: []string{"3", "1", "2", "4"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"flArg": 2.6,
+					"pages": []int{3, 1, 2, 4},
 				}},
-				WantStderr: "validation for \"flArg\" failed: [LT] value isn't less than 2.5\n",
-				WantErr:    fmt.Errorf(`validation for "flArg" failed: [LT] value isn't less than 2.5`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
-				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "2.6"},
+						{Value: "3"},
+						{Value: "1"},
+						{Value: "2"},
+						{Value: "4"},
 					},
 				},
 			},
 		},
-		// FloatLTE
 		{
-			name: "FloatLTE works when less than",
+			name: "Contiguous succeeds for a single value",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[float64]("flArg", testDesc, LTE(2.5)),
+					Processor: ListArg[int]("pages", testDesc, 1, command.UnboundedList, Contiguous()),
 				},
-				Args: []string{"2.4"},
+				Args: []string{"7"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"flArg": 2.4,
+					"pages": []int{7},
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "2.4"},
+						{Value: "7"},
 					},
 				},
 			},
 		},
 		{
-			name: "FloatLTE works when equal to",
+			name: "Contiguous fails for a list with a gap",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[float64]("flArg", testDesc, LTE(2.5)),
+					Processor: ListArg[int]("pages", testDesc, 1, command.UnboundedList, Contiguous()),
 				},
-				Args: []string{"2.5"},
+				Args: []string{"1", "2", "5", "6"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"flArg": 2.5,
+					"pages": []int{1, 2, 5, 6},
 				}},
+				WantStderr: "validation for \"pages\" failed: [Contiguous] values aren't contiguous; missing 3\n",
+				WantErr:    fmt.Errorf(`validation for "pages" failed: [Contiguous] values aren't contiguous; missing 3`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "2.5"},
+						{Value: "1"},
+						{Value: "2"},
+						{Value: "5"},
+						{Value: "6"},
 					},
 				},
 			},
 		},
 		{
-			name: "FloatLTE fails when greater than",
+			name: "Contiguous fails for a list with duplicates",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[float64]("flArg", testDesc, LTE(2.5)),
+					Processor: ListArg[int]("pages", testDesc, 1, command.UnboundedList, Contiguous()),
 				},
-				Args: []string{"2.6"},
+				Args: []string{"1", "2", "2", "3"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"flArg": 2.6,
+					"pages": []int{1, 2, 2, 3},
 				}},
-				WantStderr: "validation for \"flArg\" failed: [LTE] value isn't less than or equal to 2.5\n",
-				WantErr:    fmt.Errorf(`validation for "flArg" failed: [LTE] value isn't less than or equal to 2.5`),
+				WantStderr: "validation for \"pages\" failed: [Contiguous] value 2 is duplicated\n",
+				WantErr:    fmt.Errorf(`validation for "pages" failed: [Contiguous] value 2 is duplicated`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "2.6"},
+						{Value: "1"},
+						{Value: "2"},
+						{Value: "2"},
+						{Value: "3"},
 					},
 				},
 			},
 		},
-		// FloatGT
+		// ValidUTF8
 		{
-			name: "FloatGT fails when less than",
+			name: "ValidUTF8 succeeds for multibyte string",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[float64]("flArg", testDesc, GT(2.5)),
+					Processor: Arg[string]("S", testDesc, ValidUTF8()),
 				},
-				Args: []string{"2.4"},
+				Args: []string{"héllo 日本"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"flArg": 2.4,
+					"S": "héllo 日本",
 				}},
-				WantStderr: "validation for \"flArg\" failed: [GT] value isn't greater than 2.5\n",
-				WantErr:    fmt.Errorf(`validation for "flArg" failed: [GT] value isn't greater than 2.5`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
-				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "2.4"},
+						{Value: "héllo 日本"},
 					},
 				},
 			},
 		},
 		{
-			name: "FloatGT fails when equal to",
+			name: "ValidUTF8 fails for invalid UTF-8",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[float64]("flArg", testDesc, GT(2.5)),
+					Processor: Arg[string]("S", testDesc, ValidUTF8()),
 				},
-				Args: []string{"2.5"},
+				Args: []string{"ab\xffc"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"flArg": 2.5,
+					"S": "ab\xffc",
 				}},
-				WantStderr: "validation for \"flArg\" failed: [GT] value isn't greater than 2.5\n",
-				WantErr:    fmt.Errorf(`validation for "flArg" failed: [GT] value isn't greater than 2.5`),
+				WantStderr: "validation for \"S\" failed: [ValidUTF8] value \"ab\\xffc\" isn't valid UTF-8\n",
+				WantErr:    fmt.Errorf(`validation for "S" failed: [ValidUTF8] value "ab\xffc" isn't valid UTF-8`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "2.5"},
+						{Value: "ab\xffc"},
 					},
 				},
 			},
 		},
+		// MaxBytes
 		{
-			name: "FloatGT works when greater than",
+			name: "MaxBytes succeeds if byte length is under the limit",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[float64]("flArg", testDesc, GT(2.5)),
+					Processor: Arg[string]("S", testDesc, MaxBytes(8)),
 				},
-				Args: []string{"2.6"},
+				Args: []string{"日本"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"flArg": 2.6,
+					"S": "日本",
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "2.6"},
+						{Value: "日本"},
 					},
 				},
 			},
 		},
-		// FloatGTE
 		{
-			name: "FloatGTE fails when less than",
+			name: "MaxBytes succeeds if byte length equals the limit",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[float64]("flArg", testDesc, GTE(2.5)),
+					Processor: Arg[string]("S", testDesc, MaxBytes(6)),
 				},
-				Args: []string{"2.4"},
+				Args: []string{"日本"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"flArg": 2.4,
+					"S": "日本",
 				}},
-				WantStderr: "validation for \"flArg\" failed: [GTE] value isn't greater than or equal to 2.5\n",
-				WantErr:    fmt.Errorf(`validation for "flArg" failed: [GTE] value isn't greater than or equal to 2.5`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
-				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "2.4"},
+						{Value: "日本"},
 					},
 				},
 			},
 		},
 		{
-			name: "FloatGTE works when equal to",
+			name: "MaxBytes fails if byte length exceeds the limit even if rune count does not",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[float64]("flArg", testDesc, GTE(2.5)),
+					Processor: Arg[string]("S", testDesc, MaxBytes(5)),
 				},
-				Args: []string{"2.5"},
+				Args: []string{"héllo"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"flArg": 2.5,
+					"S": "héllo",
 				}},
+				WantStderr: "validation for \"S\" failed: [MaxBytes] value must be at most 5 bytes; got 6 bytes\n",
+				WantErr:    fmt.Errorf(`validation for "S" failed: [MaxBytes] value must be at most 5 bytes; got 6 bytes`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "2.5"},
+						{Value: "héllo"},
 					},
 				},
 			},
 		},
+		// IsMAC
 		{
-			name: "FloatGTE works when greater than",
+			name: "IsMAC succeeds for colon-separated MAC",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[float64]("flArg", testDesc, GTE(2.5)),
+					Processor: Arg[string]("S", testDesc, IsMAC()),
 				},
-				Args: []string{"2.6"},
+				Args: []string{"00:00:5e:00:53:01"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"flArg": 2.6,
+					"S": "00:00:5e:00:53:01",
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "2.6"},
+						{Value: "00:00:5e:00:53:01"},
 					},
 				},
 			},
 		},
-		// FloatPositive
 		{
-			name: "FloatPositive fails when negative",
+			name: "IsMAC succeeds for hyphen-separated MAC",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[float64]("flArg", testDesc, Positive[float64]()),
+					Processor: Arg[string]("S", testDesc, IsMAC()),
 				},
-				Args: []string{"-0.1"},
+				Args: []string{"00-00-5E-00-53-01"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"flArg": -0.1,
+					"S": "00-00-5E-00-53-01",
 				}},
-				WantStderr: "validation for \"flArg\" failed: [Positive] value isn't positive\n",
-				WantErr:    fmt.Errorf(`validation for "flArg" failed: [Positive] value isn't positive`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
-				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "-0.1"},
+						{Value: "00-00-5E-00-53-01"},
 					},
 				},
 			},
 		},
 		{
-			name: "FloatPositive fails when zero",
+			name: "IsMAC fails for invalid MAC",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[float64]("flArg", testDesc, Positive[float64]()),
+					Processor: Arg[string]("S", testDesc, IsMAC()),
 				},
-				Args: []string{"0"},
+				Args: []string{"00:00:5e:00:53"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"flArg": 0.0,
+					"S": "00:00:5e:00:53",
 				}},
-				WantStderr: "validation for \"flArg\" failed: [Positive] value isn't positive\n",
-				WantErr:    fmt.Errorf(`validation for "flArg" failed: [Positive] value isn't positive`),
+				WantStderr: "validation for \"S\" failed: [IsMAC] value \"00:00:5e:00:53\" isn't a valid MAC address: address 00:00:5e:00:53: invalid MAC address\n",
+				WantErr:    fmt.Errorf(`validation for "S" failed: [IsMAC] value "00:00:5e:00:53" isn't a valid MAC address: address 00:00:5e:00:53: invalid MAC address`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "0"},
+						{Value: "00:00:5e:00:53"},
 					},
 				},
 			},
 		},
 		{
-			name: "FloatPositive works when positive",
+			name: "NormalizeMAC stores MAC in canonical form",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[float64]("flArg", testDesc, Positive[float64]()),
+					Processor: Arg[string]("S", testDesc, IsMAC(), NormalizeMAC()),
 				},
-				Args: []string{"0.1"},
+				Args: []string{"00-00-5E-00-53-01"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"flArg": 0.1,
+					"S": "00:00:5e:00:53:01",
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "0.1"},
+						{Value: "00:00:5e:00:53:01"},
 					},
 				},
 			},
 		},
-		// FloatNegative
+		// LuhnValid
 		{
-			name: "FloatNegative works when negative",
+			name: "LuhnValid succeeds for valid number",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[float64]("flArg", testDesc, Negative[float64]()),
+					Processor: Arg[string]("S", testDesc, LuhnValid()),
 				},
-				Args: []string{"-0.1"},
+				Args: []string{"79927398713"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"flArg": -0.1,
+					"S": "79927398713",
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "-0.1"},
+						{Value: "79927398713"},
 					},
 				},
 			},
 		},
 		{
-			name: "FloatNegative fails when zero",
+			name: "LuhnValid succeeds for valid card number",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[float64]("flArg", testDesc, Negative[float64]()),
+					Processor: Arg[string]("S", testDesc, LuhnValid()),
 				},
-				Args: []string{"0"},
+				Args: []string{"4111111111111111"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"flArg": 0.0,
+					"S": "4111111111111111",
 				}},
-				WantStderr: "validation for \"flArg\" failed: [Negative] value isn't negative\n",
-				WantErr:    fmt.Errorf(`validation for "flArg" failed: [Negative] value isn't negative`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
-				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "0"},
+						{Value: "4111111111111111"},
 					},
 				},
 			},
 		},
 		{
-			name: "FloatNegative fails when positive",
+			name: "LuhnValid fails for invalid checksum",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[float64]("flArg", testDesc, Negative[float64]()),
+					Processor: Arg[string]("S", testDesc, LuhnValid()),
 				},
-				Args: []string{"0.1"},
+				Args: []string{"79927398710"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"flArg": 0.1,
+					"S": "79927398710",
 				}},
-				WantStderr: "validation for \"flArg\" failed: [Negative] value isn't negative\n",
-				WantErr:    fmt.Errorf(`validation for "flArg" failed: [Negative] value isn't negative`),
+				WantStderr: "validation for \"S\" failed: [LuhnValid] value \"79927398710\" has an invalid Luhn checksum\n",
+				WantErr:    fmt.Errorf(`validation for "S" failed: [LuhnValid] value "79927398710" has an invalid Luhn checksum`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "0.1"},
+						{Value: "79927398710"},
 					},
 				},
 			},
 		},
-		// FloatNonNegative
 		{
-			name: "FloatNonNegative fails when negative",
+			name: "LuhnValid fails for non-numeric value",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[float64]("flArg", testDesc, NonNegative[float64]()),
+					Processor: Arg[string]("S", testDesc, LuhnValid()),
 				},
-				Args: []string{"-0.1"},
+				Args: []string{"4111-1111"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"flArg": -0.1,
+					"S": "4111-1111",
 				}},
-				WantStderr: "validation for \"flArg\" failed: [NonNegative] value isn't non-negative\n",
-				WantErr:    fmt.Errorf(`validation for "flArg" failed: [NonNegative] value isn't non-negative`),
+				WantStderr: "validation for \"S\" failed: [LuhnValid] value \"4111-1111\" must only contain digits\n",
+				WantErr:    fmt.Errorf(`validation for "S" failed: [LuhnValid] value "4111-1111" must only contain digits`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "-0.1"},
+						{Value: "4111-1111"},
 					},
 				},
 			},
 		},
+		// SumBetween
 		{
-			name: "FloatNonNegative works when zero",
+			name: "SumBetween succeeds for a float sum in range",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[float64]("flArg", testDesc, NonNegative[float64]()),
+					Processor: ListArg[float64]("costs", testDesc, 1, command.UnboundedList, SumBetween(10.0, 20.5)),
 				},
-				Args: []string{"0"},
+				Args: []string{"2.5", "8", "10"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"flArg": 0.0,
+					"costs": []float64{2.5, 8, 10},
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "0"},
+						{Value: "2.5"},
+						{Value: "8"},
+						{Value: "10"},
 					},
 				},
 			},
 		},
 		{
-			name: "FloatNonNegative works when positive",
+			name: "SumBetween succeeds for an int sum equal to the bounds",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[float64]("flArg", testDesc, NonNegative[float64]()),
+					Processor: ListArg[int]("costs", testDesc, 1, command.UnboundedList, SumBetween(5, 5)),
 				},
-				Args: []string{"0.1"},
+				Args: []string{"2", "3"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"flArg": 0.1,
+					"costs": []int{2, 3},
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "0.1"},
+						{Value: "2"},
+						{Value: "3"},
 					},
 				},
 			},
 		},
-		// Between inclusive
 		{
-			name: "Between inclusive fails when less than lower bound",
+			name: "SumBetween fails for a sum below the lower bound",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[int]("iArg", testDesc, Between(-3, 4, true)),
+					Processor: ListArg[float64]("costs", testDesc, 1, command.UnboundedList, SumBetween(10.0, 20.5)),
 				},
-				Args: []string{"-4"},
+				Args: []string{"2.5", "7"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"iArg": -4,
+					"costs": []float64{2.5, 7},
 				}},
-				WantStderr: "validation for \"iArg\" failed: [Between] value is less than lower bound (-3)\n",
-				WantErr:    fmt.Errorf("validation for \"iArg\" failed: [Between] value is less than lower bound (-3)"),
+				WantStderr: "validation for \"costs\" failed: [SumBetween] sum (9.5) is less than lower bound (10)\n",
+				WantErr:    fmt.Errorf(`validation for "costs" failed: [SumBetween] sum (9.5) is less than lower bound (10)`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "-4"},
+						{Value: "2.5"},
+						{Value: "7"},
 					},
 				},
 			},
 		},
 		{
-			name: "Between inclusive succeeds when equals lower bound",
+			name: "SumBetween fails for a sum above the upper bound",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[int]("iArg", testDesc, Between(-3, 4, true)),
+					Processor: ListArg[int]("costs", testDesc, 1, command.UnboundedList, SumBetween(0, 10)),
 				},
-				Args: []string{"-3"},
+				Args: []string{"4", "5", "6"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"iArg": -3,
+					"costs": []int{4, 5, 6},
 				}},
+				WantStderr: "validation for \"costs\" failed: [SumBetween] sum (15) is greater than upper bound (10)\n",
+				WantErr:    fmt.Errorf(`validation for "costs" failed: [SumBetween] sum (15) is greater than upper bound (10)`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "-3"},
+						{Value: "4"},
+						{Value: "5"},
+						{Value: "6"},
 					},
 				},
 			},
 		},
+		// InValues
 		{
-			name: "Between inclusive succeeds when between bounds",
+			name: "InValues succeeds for an allowed int",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[int]("iArg", testDesc, Between(-3, 4, true)),
+					Processor: Arg[int]("nArg", testDesc, InValues(1, 2, 4, 8)),
 				},
-				Args: []string{"0"},
+				Args: []string{"4"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"iArg": 0,
+					"nArg": 4,
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "0"},
+						{Value: "4"},
 					},
 				},
 			},
 		},
 		{
-			name: "Between inclusive succeeds when equals upper bound",
+			name: "InValues fails for a disallowed int",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[int]("iArg", testDesc, Between(-3, 4, true)),
+					Processor: Arg[int]("nArg", testDesc, InValues(1, 2, 4, 8)),
 				},
-				Args: []string{"4"},
+				Args: []string{"3"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"iArg": 4,
+					"nArg": 3,
 				}},
+				WantStderr: "validation for \"nArg\" failed: [InValues] value must be one of [1 2 4 8]\n",
+				WantErr:    fmt.Errorf(`validation for "nArg" failed: [InValues] value must be one of [1 2 4 8]`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "4"},
+						{Value: "3"},
 					},
 				},
 			},
 		},
 		{
-			name: "Between inclusive fails when greater than upper bound",
+			name: "InValues succeeds for an allowed float",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[int]("iArg", testDesc, Between(-3, 4, true)),
+					Processor: Arg[float64]("nArg", testDesc, InValues(0.5, 1.5)),
 				},
-				Args: []string{"5"},
+				Args: []string{"1.5"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"iArg": 5,
+					"nArg": 1.5,
 				}},
-				WantStderr: "validation for \"iArg\" failed: [Between] value is greater than upper bound (4)\n",
-				WantErr:    fmt.Errorf("validation for \"iArg\" failed: [Between] value is greater than upper bound (4)"),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
-				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "5"},
+						{Value: "1.5"},
 					},
 				},
 			},
 		},
-		// Between exclusive
 		{
-			name: "Between exclusive fails when less than lower bound",
+			name: "InValues fails for a disallowed float",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[int]("iArg", testDesc, Between(-3, 4, false)),
+					Processor: Arg[float64]("nArg", testDesc, InValues(0.5, 1.5)),
 				},
-				Args: []string{"-4"},
+				Args: []string{"2.5"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"iArg": -4,
+					"nArg": 2.5,
 				}},
-				WantStderr: "validation for \"iArg\" failed: [Between] value is less than lower bound (-3)\n",
-				WantErr:    fmt.Errorf("validation for \"iArg\" failed: [Between] value is less than lower bound (-3)"),
+				WantStderr: "validation for \"nArg\" failed: [InValues] value must be one of [0.5 1.5]\n",
+				WantErr:    fmt.Errorf(`validation for "nArg" failed: [InValues] value must be one of [0.5 1.5]`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "-4"},
+						{Value: "2.5"},
 					},
 				},
 			},
 		},
+		// InList & string menus
 		{
-			name: "Between exclusive fails when equals lower bound",
+			name: "InList works",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[int]("iArg", testDesc, Between(-3, 4, false)),
+					Processor: Arg[string]("strArg", testDesc, InList("abc", "def", "ghi")),
 				},
-				Args: []string{"-3"},
+				Args: []string{"def"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"iArg": -3,
+					"strArg": "def",
 				}},
-				WantStderr: "validation for \"iArg\" failed: [Between] value equals exclusive lower bound (-3)\n",
-				WantErr:    fmt.Errorf("validation for \"iArg\" failed: [Between] value equals exclusive lower bound (-3)"),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
-				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "-3"},
+						{Value: "def"},
 					},
 				},
 			},
 		},
 		{
-			name: "Between exclusive succeeds when between bounds",
+			name: "InList fails",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[int]("iArg", testDesc, Between(-3, 4, false)),
+					Processor: Arg[string]("strArg", testDesc, InList("abc", "def", "ghi")),
 				},
-				Args: []string{"0"},
+				Args: []string{"jkl"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"iArg": 0,
+					"strArg": "jkl",
 				}},
+				WantStderr: "validation for \"strArg\" failed: [InList] argument must be one of [abc def ghi]\n",
+				WantErr:    fmt.Errorf(`validation for "strArg" failed: [InList] argument must be one of [abc def ghi]`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "0"},
+						{Value: "jkl"},
 					},
 				},
 			},
 		},
 		{
-			name: "Between exclusive fails when equals upper bound",
+			name: "MenuArg works",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[int]("iArg", testDesc, Between(-3, 4, false)),
+					Processor: MenuArg("strArg", testDesc, "abc", "def", "ghi"),
 				},
-				Args: []string{"4"},
+				Args: []string{"def"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"iArg": 4,
+					"strArg": "def",
 				}},
-				WantStderr: "validation for \"iArg\" failed: [Between] value equals exclusive upper bound (4)\n",
-				WantErr:    fmt.Errorf("validation for \"iArg\" failed: [Between] value equals exclusive upper bound (4)"),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
-				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "4"},
+						{Value: "def"},
 					},
 				},
 			},
 		},
 		{
-			name: "Between exclusive fails when greater than upper bound",
+			name: "MenuArg fails if provided is not in list",
 			etc: &commandtest.ExecuteTestCase{
 				Node: &SimpleNode{
-					Processor: Arg[int]("iArg", testDesc, Between(-3, 4, false)),
+					Processor: MenuArg("strArg", testDesc, "abc", "def", "ghi"),
 				},
-				Args: []string{"5"},
+				Args: []string{"jkl"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"iArg": 5,
+					"strArg": "jkl",
 				}},
-				WantStderr: "validation for \"iArg\" failed: [Between] value is greater than upper bound (4)\n",
-				WantErr:    fmt.Errorf("validation for \"iArg\" failed: [Between] value is greater than upper bound (4)"),
+				WantStderr: "validation for \"strArg\" failed: [InList] argument must be one of [abc def ghi]\n",
+				WantErr:    fmt.Errorf(`validation for "strArg" failed: [InList] argument must be one of [abc def ghi]`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "5"},
+						{Value: "jkl"},
 					},
 				},
 			},
 		},
-		// Flag processors
-		{
-			name: "empty flag processor works",
-			etc: &commandtest.ExecuteTestCase{
-				Node: &SimpleNode{Processor: FlagProcessor()},
-			},
-		},
-		{
-			name: "flag processor allows empty",
-			etc: &commandtest.ExecuteTestCase{
-				Node: &SimpleNode{Processor: FlagProcessor(Flag[string]("strFlag", 'f', testDesc))},
-			},
-		},
 		{
-			name: "flag processor fails if no argument",
+			name: "MenuFlag works",
 			etc: &commandtest.ExecuteTestCase{
-				Node:       &SimpleNode{Processor: FlagProcessor(Flag[string]("strFlag", 'f', testDesc))},
-				Args:       []string{"--strFlag"},
-				WantStderr: "Argument \"strFlag\" requires at least 1 argument, got 0\n",
-				WantErr:    fmt.Errorf(`Argument "strFlag" requires at least 1 argument, got 0`),
+				Node: SerialNodes(
+					FlagProcessor(
+						MenuFlag("sf", 's', testDesc, "abc", "def", "ghi"),
+					),
+				),
+				Args: []string{"--sf", "def"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"sf": "def",
+				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
-				WantIsUsageError:         true,
-				WantIsNotEnoughArgsError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "--strFlag"},
+						{Value: "--sf"},
+						{Value: "def"},
 					},
 				},
 			},
 		},
 		{
-			name: "flag processor parses flag",
+			name: "MenuFlag works with AddOptions(default)",
 			etc: &commandtest.ExecuteTestCase{
-				Node: &SimpleNode{Processor: FlagProcessor(Flag[string]("strFlag", 'f', testDesc))},
-				Args: []string{"--strFlag", "hello"},
+				Node: SerialNodes(
+					FlagProcessor(
+						MenuFlag("sf", 's', testDesc, "abc", "def", "ghi", "xyz").AddOptions(Default("xyz")),
+					),
+				),
 				WantData: &command.Data{Values: map[string]interface{}{
-					"strFlag": "hello",
+					"sf": "xyz",
 				}},
 			},
-			ietc: &spycommandtest.ExecuteTestCase{
-				WantInput: &spycommandtest.SpyInput{
-					Args: []*spycommand.InputArg{
-						{Value: "--strFlag"},
-						{Value: "hello"},
-					},
-				},
-			},
 		},
 		{
-			name: "flag processor parses short name flag",
+			name: "MenuFlag fails if provided is not in list",
 			etc: &commandtest.ExecuteTestCase{
-				Node: &SimpleNode{Processor: FlagProcessor(Flag[string]("strFlag", 'f', testDesc))},
-				Args: []string{"-f", "hello"},
+				Node: SerialNodes(
+					FlagProcessor(
+						MenuFlag("sf", 's', testDesc, "abc", "def", "ghi"),
+					),
+				),
+				Args: []string{"-s", "jkl"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"strFlag": "hello",
+					"sf": "jkl",
 				}},
+				WantStderr: "validation for \"sf\" failed: [InList] argument must be one of [abc def ghi]\n",
+				WantErr:    fmt.Errorf(`validation for "sf" failed: [InList] argument must be one of [abc def ghi]`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "-f"},
-						{Value: "hello"},
+						{Value: "-s"},
+						{Value: "jkl"},
 					},
 				},
 			},
 		},
+		// MinLength
 		{
-			name: "flag processor handles FlagNoShortName",
+			name: "MinLength works",
 			etc: &commandtest.ExecuteTestCase{
-				Node: &SimpleNode{Processor: FlagProcessor(Flag[string]("strFlag", FlagNoShortName, testDesc))},
-				Args: []string{"--strFlag", "hello"},
+				Node: &SimpleNode{
+					Processor: Arg[string]("strArg", testDesc, MinLength[string, string](3)),
+				},
+				Args: []string{"hello"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"strFlag": "hello",
+					"strArg": "hello",
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "--strFlag"},
 						{Value: "hello"},
 					},
 				},
 			},
 		},
 		{
-			name: "flag processor parses flag in the middle",
+			name: "MinLength works for exact count match",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(Flag[string]("strFlag", 'f', testDesc)),
-					ListArg[string]("filler", testDesc, 1, 2),
-				),
-				Args: []string{"un", "--strFlag", "hello", "deux"},
+				Node: &SimpleNode{
+					Processor: Arg[string]("strArg", testDesc, MinLength[string, string](3)),
+				},
+				Args: []string{"hey"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"strFlag": "hello",
-					"filler":  []string{"un", "deux"},
+					"strArg": "hey",
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "un"},
-						{Value: "--strFlag"},
-						{Value: "hello"},
-						{Value: "deux"},
+						{Value: "hey"},
 					},
 				},
 			},
 		},
 		{
-			name: "flag processor parses short name flag",
+			name: "MinLength fails",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(Flag[string]("strFlag", 'f', testDesc)),
-					ListArg[string]("filler", testDesc, 1, 2),
-				),
-				Args: []string{"uno", "dos", "-f", "hello"},
+				Node: &SimpleNode{
+					Processor: Arg[string]("strArg", testDesc, MinLength[string, string](3)),
+				},
+				Args: []string{"hi"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"filler":  []string{"uno", "dos"},
-					"strFlag": "hello",
+					"strArg": "hi",
 				}},
+				WantStderr: "validation for \"strArg\" failed: [MinLength] length must be at least 3\n",
+				WantErr:    fmt.Errorf(`validation for "strArg" failed: [MinLength] length must be at least 3`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "uno"},
-						{Value: "dos"},
-						{Value: "-f"},
-						{Value: "hello"},
+						{Value: "hi"},
 					},
 				},
 			},
 		},
-		// Int flag
+		// MaxLength
 		{
-			name: "parses int flag",
+			name: "MaxLength works",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(Flag[int]("intFlag", 'f', testDesc)),
-					ListArg[string]("filler", testDesc, 1, 2),
-				),
-				Args: []string{"un", "deux", "-f", "3", "quatre"},
+				Node: &SimpleNode{
+					Processor: ListArg[int]("strArg", testDesc, 0, command.UnboundedList, MaxLength[int, []int](3)),
+				},
+				Args: []string{"1234", "56"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"filler":  []string{"un", "deux", "quatre"},
-					"intFlag": 3,
+					"strArg": []int{
+						1234,
+						56,
+					},
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "un"},
-						{Value: "deux"},
-						{Value: "-f"},
-						{Value: "3"},
-						{Value: "quatre"},
+						{Value: "1234"},
+						{Value: "56"},
 					},
 				},
 			},
 		},
 		{
-			name: "handles invalid int flag value",
+			name: "MaxLength works for exact count match",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(Flag[int]("intFlag", 'f', testDesc)),
-					ListArg[string]("filler", testDesc, 1, 2),
-				),
-				Args:       []string{"un", "deux", "-f", "trois", "quatre"},
-				WantStderr: "strconv.Atoi: parsing \"trois\": invalid syntax\n",
-				WantErr:    fmt.Errorf(`strconv.Atoi: parsing "trois": invalid syntax`),
+				Node: &SimpleNode{
+					Processor: ListArg[int]("strArg", testDesc, 0, command.UnboundedList, MaxLength[int, []int](3)),
+				},
+				Args: []string{"1234", "56", "78901"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"strArg": []int{
+						1234,
+						56,
+						78901,
+					},
+				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "un"},
-						{Value: "deux"},
-						{Value: "-f"},
-						{Value: "trois"},
-						{Value: "quatre"},
+						{Value: "1234"},
+						{Value: "56"},
+						{Value: "78901"},
 					},
-					Remaining: []int{0, 1, 4},
 				},
 			},
 		},
-		// Float flag
 		{
-			name: "parses float flag",
+			name: "MaxLength fails",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(Flag[float64]("floatFlag", 'f', testDesc)),
-					ListArg[string]("filler", testDesc, 1, 2),
-				),
-				Args: []string{"--floatFlag", "-1.2", "three"},
+				Node: &SimpleNode{
+					Processor: ListArg[int]("strArg", testDesc, 0, command.UnboundedList, MaxLength[int, []int](3)),
+				},
+				Args: []string{"1234", "56", "78901", "234"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"filler":    []string{"three"},
-					"floatFlag": -1.2,
+					"strArg": []int{
+						1234,
+						56,
+						78901,
+						234,
+					},
 				}},
+				WantStderr: "validation for \"strArg\" failed: [MaxLength] length must be at most 3\n",
+				WantErr:    fmt.Errorf(`validation for "strArg" failed: [MaxLength] length must be at most 3`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "--floatFlag"},
-						{Value: "-1.2"},
-						{Value: "three"},
+						{Value: "1234"},
+						{Value: "56"},
+						{Value: "78901"},
+						{Value: "234"},
 					},
 				},
 			},
 		},
+		// Length
 		{
-			name: "handles invalid float flag value",
+			name: "Length works",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(Flag[float64]("floatFlag", 'f', testDesc)),
-					ListArg[string]("filler", testDesc, 1, 2),
-				),
-				Args:       []string{"--floatFlag", "twelve", "eleven"},
-				WantStderr: "strconv.ParseFloat: parsing \"twelve\": invalid syntax\n",
-				WantErr:    fmt.Errorf(`strconv.ParseFloat: parsing "twelve": invalid syntax`),
+				Node: &SimpleNode{
+					Processor: Arg[string]("strArg", testDesc, Length[string, string](3)),
+				},
+				Args: []string{"hey"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"strArg": "hey",
+				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "--floatFlag"},
-						{Value: "twelve"},
-						{Value: "eleven"},
+						{Value: "hey"},
 					},
-					Remaining: []int{2},
 				},
 			},
 		},
-		// Bool flag
 		{
-			name: "bool flag",
+			name: "Length fails for too few",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(BoolFlag("boolFlag", 'b', testDesc)),
-					ListArg[string]("filler", testDesc, 1, 2),
-				),
-				Args: []string{"okay", "--boolFlag", "then"},
+				Node: &SimpleNode{
+					Processor: Arg[string]("strArg", testDesc, Length[string, string](3)),
+				},
+				Args: []string{"hi"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"filler":   []string{"okay", "then"},
-					"boolFlag": true,
+					"strArg": "hi",
 				}},
+				WantStderr: "validation for \"strArg\" failed: [Length] length must be exactly 3\n",
+				WantErr:    fmt.Errorf(`validation for "strArg" failed: [Length] length must be exactly 3`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "okay"},
-						{Value: "--boolFlag"},
-						{Value: "then"},
+						{Value: "hi"},
 					},
 				},
 			},
 		},
 		{
-			name: "short bool flag",
+			name: "Length fails for too many",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(BoolFlag("boolFlag", 'b', testDesc)),
-					ListArg[string]("filler", testDesc, 1, 2),
-				),
-				Args: []string{"okay", "-b", "then"},
+				Node: &SimpleNode{
+					Processor: Arg[string]("strArg", testDesc, Length[string, string](4)),
+				},
+				Args: []string{"howdy"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"filler":   []string{"okay", "then"},
-					"boolFlag": true,
+					"strArg": "howdy",
 				}},
+				WantStderr: "validation for \"strArg\" failed: [Length] length must be exactly 4\n",
+				WantErr:    fmt.Errorf(`validation for "strArg" failed: [Length] length must be exactly 4`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "okay"},
-						{Value: "-b"},
-						{Value: "then"},
+						{Value: "howdy"},
 					},
 				},
 			},
 		},
-		// flag list tests
+		// IntEQ
 		{
-			name: "flag list works",
+			name: "IntEQ works",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(ListFlag[string]("slFlag", 's', testDesc, 2, 3)),
-					ListArg[string]("filler", testDesc, 1, 2),
-				),
-				Args: []string{"un", "--slFlag", "hello", "there"},
+				Node: &SimpleNode{
+					Processor: Arg[int]("i", testDesc, EQ(24)),
+				},
+				Args: []string{"24"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"filler": []string{"un"},
-					"slFlag": []string{"hello", "there"},
+					"i": 24,
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "un"},
-						{Value: "--slFlag"},
-						{Value: "hello"},
-						{Value: "there"},
+						{Value: "24"},
 					},
 				},
 			},
 		},
 		{
-			name: "flag list fails if not enough",
+			name: "IntEQ fails",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(ListFlag[string]("slFlag", 's', testDesc, 2, 3)),
-					ListArg[string]("filler", testDesc, 1, 2),
-				),
-				Args:       []string{"un", "--slFlag", "hello"},
-				WantStderr: "Argument \"slFlag\" requires at least 2 arguments, got 1\n",
-				WantErr:    fmt.Errorf(`Argument "slFlag" requires at least 2 arguments, got 1`),
+				Node: &SimpleNode{
+					Processor: Arg[int]("i", testDesc, EQ(24)),
+				},
+				Args: []string{"25"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"slFlag": []string{"hello"},
+					"i": 25,
 				}},
+				WantStderr: "validation for \"i\" failed: [EQ] value isn't equal to 24\n",
+				WantErr:    fmt.Errorf(`validation for "i" failed: [EQ] value isn't equal to 24`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
-				WantIsUsageError:         true,
-				WantIsNotEnoughArgsError: true,
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "un"},
-						{Value: "--slFlag"},
-						{Value: "hello"},
+						{Value: "25"},
 					},
-					Remaining: []int{0},
 				},
 			},
 		},
-		// Int list
+		// IntNE
 		{
-			name: "int list works",
+			name: "IntNE works",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(ListFlag[int]("ilFlag", 'i', testDesc, 2, 3)),
-					ListArg[string]("filler", testDesc, 1, 2),
-				),
-				Args: []string{"un", "-i", "2", "4", "8", "16", "32", "64"},
+				Node: &SimpleNode{
+					Processor: Arg[int]("i", testDesc, NEQ(24)),
+				},
+				Args: []string{"25"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"filler": []string{"un", "64"},
-					"ilFlag": []int{2, 4, 8, 16, 32},
+					"i": 25,
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "un"},
-						{Value: "-i"},
-						{Value: "2"},
-						{Value: "4"},
-						{Value: "8"},
-						{Value: "16"},
-						{Value: "32"},
-						{Value: "64"},
+						{Value: "25"},
 					},
 				},
 			},
 		},
 		{
-			name: "int list transform failure",
+			name: "IntNE fails",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(ListFlag[int]("ilFlag", 'i', testDesc, 2, 3)),
-					ListArg[string]("filler", testDesc, 1, 2),
-				),
-				Args:       []string{"un", "-i", "2", "4", "8", "16.0", "32", "64"},
-				WantStderr: "strconv.Atoi: parsing \"16.0\": invalid syntax\n",
-				WantErr:    fmt.Errorf(`strconv.Atoi: parsing "16.0": invalid syntax`),
+				Node: &SimpleNode{
+					Processor: Arg[int]("i", testDesc, NEQ(24)),
+				},
+				Args: []string{"24"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"i": 24,
+				}},
+				WantStderr: "validation for \"i\" failed: [NEQ] value cannot equal 24\n",
+				WantErr:    fmt.Errorf(`validation for "i" failed: [NEQ] value cannot equal 24`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "un"},
-						{Value: "-i"},
-						{Value: "2"},
-						{Value: "4"},
-						{Value: "8"},
-						{Value: "16.0"},
-						{Value: "32"},
-						{Value: "64"},
+						{Value: "24"},
 					},
-					Remaining: []int{0, 7},
 				},
 			},
 		},
-		// Float list
+		// IntLT
 		{
-			name: "float list works",
+			name: "IntLT works when less than",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(ListFlag[float64]("flFlag", 'f', testDesc, 0, 3)),
-					ListArg[string]("filler", testDesc, 1, 3),
-				),
-				Args: []string{"un", "-f", "2", "-4.4", "0.8", "16.16", "-32", "64"},
+				Node: &SimpleNode{
+					Processor: Arg[int]("i", testDesc, LT(25)),
+				},
+				Args: []string{"24"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"filler": []string{"un", "16.16", "-32", "64"},
-					"flFlag": []float64{2, -4.4, 0.8},
+					"i": 24,
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "un"},
-						{Value: "-f"},
-						{Value: "2"},
-						{Value: "-4.4"},
-						{Value: "0.8"},
-						{Value: "16.16"},
-						{Value: "-32"},
-						{Value: "64"},
+						{Value: "24"},
 					},
 				},
 			},
 		},
 		{
-			name: "float list transform failure",
+			name: "IntLT fails when equal to",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(ListFlag[float64]("flFlag", 'f', testDesc, 0, 3)),
-					ListArg[string]("filler", testDesc, 1, 2),
-				),
-				Args:       []string{"un", "--flFlag", "2", "4", "eight", "16.0", "32", "64"},
-				WantStderr: "strconv.ParseFloat: parsing \"eight\": invalid syntax\n",
-				WantErr:    fmt.Errorf(`strconv.ParseFloat: parsing "eight": invalid syntax`),
+				Node: &SimpleNode{
+					Processor: Arg[int]("i", testDesc, LT(25)),
+				},
+				Args: []string{"25"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"i": 25,
+				}},
+				WantStderr: "validation for \"i\" failed: [LT] value isn't less than 25\n",
+				WantErr:    fmt.Errorf(`validation for "i" failed: [LT] value isn't less than 25`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "un"},
-						{Value: "--flFlag"},
-						{Value: "2"},
-						{Value: "4"},
-						{Value: "eight"},
-						{Value: "16.0"},
-						{Value: "32"},
-						{Value: "64"},
+						{Value: "25"},
 					},
-					Remaining: []int{0, 5, 6, 7},
 				},
 			},
 		},
-		// Flag overlapping tests
 		{
-			name: "flags don't eat other flags",
+			name: "IntLT fails when greater than",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(
-						ListFlag[string]("alpha", 'a', testDesc, 0, command.UnboundedList),
-						ListFlag[string]("bravo", 'b', testDesc, 0, command.UnboundedList),
-						ListFlag[string]("charlie", 'c', testDesc, 0, command.UnboundedList),
-					),
-				),
-				Args: []string{"--alpha", "hey", "there", "--dude", "--bravo", "yay", "--charlie"},
+				Node: &SimpleNode{
+					Processor: Arg[int]("i", testDesc, LT(25)),
+				},
+				Args: []string{"26"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"alpha": []string{"hey", "there", "--dude"},
-					"bravo": []string{"yay"},
+					"i": 26,
 				}},
+				WantStderr: "validation for \"i\" failed: [LT] value isn't less than 25\n",
+				WantErr:    fmt.Errorf(`validation for "i" failed: [LT] value isn't less than 25`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "--alpha"},
-						{Value: "hey"},
-						{Value: "there"},
-						{Value: "--dude"},
-						{Value: "--bravo"},
-						{Value: "yay"},
-						{Value: "--charlie"},
+						{Value: "26"},
 					},
 				},
 			},
 		},
+		// IntLTE
 		{
-			name: "flags don't eat other short flags",
+			name: "IntLTE works when less than",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(
-						ListFlag[string]("alpha", 'a', testDesc, 0, command.UnboundedList),
-						ListFlag[string]("bravo", 'b', testDesc, 0, command.UnboundedList),
-						ListFlag[string]("charlie", 'c', testDesc, 0, command.UnboundedList),
-					),
-				),
-				Args: []string{"-a", "hey", "there", "-d", "-b", "yay", "-c"},
+				Node: &SimpleNode{
+					Processor: Arg[int]("i", testDesc, LTE(25)),
+				},
+				Args: []string{"24"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"alpha": []string{"hey", "there", "-d"},
-					"bravo": []string{"yay"},
+					"i": 24,
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "-a"},
-						{Value: "hey"},
-						{Value: "there"},
-						{Value: "-d"},
-						{Value: "-b"},
-						{Value: "yay"},
-						{Value: "-c"},
+						{Value: "24"},
 					},
 				},
 			},
 		},
 		{
-			name: "flags don't eat valid multi flags",
+			name: "IntLTE works when equal to",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(
-						ListFlag[string]("alpha", 'a', testDesc, 0, command.UnboundedList),
-						BoolFlag("Q", 'q', testDesc),
-						BoolFlag("W", 'w', testDesc),
-						BoolFlag("E", 'e', testDesc),
-						BoolFlag("R", 'r', testDesc),
-					),
-				),
-				Args: []string{"-a", "hey", "there", "-qwer"},
+				Node: &SimpleNode{
+					Processor: Arg[int]("i", testDesc, LTE(25)),
+				},
+				Args: []string{"25"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"alpha": []string{"hey", "there"},
-					"Q":     true,
-					"W":     true,
-					"E":     true,
-					"R":     true,
+					"i": 25,
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "-a"},
-						{Value: "hey"},
-						{Value: "there"},
-						{Value: "-qwer"},
+						{Value: "25"},
 					},
 				},
 			},
 		},
 		{
-			name: "flags eat invalid multi flags",
+			name: "IntLTE fails when greater than",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(
-						ListFlag[string]("alpha", 'a', testDesc, 0, command.UnboundedList),
-						BoolFlag("Q", 'q', testDesc),
-						BoolFlag("W", 'w', testDesc),
-						BoolFlag("E", 'e', testDesc),
-						BoolFlag("R", 'r', testDesc),
-					),
-				),
-				Args: []string{"-a", "hey", "there", "-qwert"},
+				Node: &SimpleNode{
+					Processor: Arg[int]("i", testDesc, LTE(25)),
+				},
+				Args: []string{"26"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"alpha": []string{"hey", "there", "-qwert"},
+					"i": 26,
 				}},
+				WantStderr: "validation for \"i\" failed: [LTE] value isn't less than or equal to 25\n",
+				WantErr:    fmt.Errorf(`validation for "i" failed: [LTE] value isn't less than or equal to 25`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "-a"},
-						{Value: "hey"},
-						{Value: "there"},
-						{Value: "-qwert"},
+						{Value: "26"},
 					},
 				},
 			},
 		},
-		// Misc. flag tests
+		// IntGT
 		{
-			name: "processes multiple flags",
+			name: "IntGT fails when less than",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(
-						ListFlag[float64]("coordinates", 'c', testDesc, 2, 0),
-						BoolFlag("boo", 'b', testDesc),
-						ListFlag[string]("names", 'n', testDesc, 1, 2),
-						Flag[int]("rating", 'r', testDesc),
-					),
-					ListArg[string]("extra", testDesc, 0, command.UnboundedList),
-				),
-				Args: []string{"its", "--boo", "a", "-r", "9", "secret", "-n", "greggar", "groog", "beggars", "--coordinates", "2.2", "4.4", "message."},
+				Node: &SimpleNode{
+					Processor: Arg[int]("i", testDesc, GT(25)),
+				},
+				Args: []string{"24"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"boo":         true,
-					"extra":       []string{"its", "a", "secret", "message."},
-					"names":       []string{"greggar", "groog", "beggars"},
-					"coordinates": []float64{2.2, 4.4},
-					"rating":      9,
+					"i": 24,
 				}},
+				WantStderr: "validation for \"i\" failed: [GT] value isn't greater than 25\n",
+				WantErr:    fmt.Errorf(`validation for "i" failed: [GT] value isn't greater than 25`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "its"},
-						{Value: "--boo"},
-						{Value: "a"},
-						{Value: "-r"},
-						{Value: "9"},
-						{Value: "secret"},
-						{Value: "-n"},
-						{Value: "greggar"},
-						{Value: "groog"},
-						{Value: "beggars"},
-						{Value: "--coordinates"},
-						{Value: "2.2"},
-						{Value: "4.4"},
-						{Value: "message."},
+						{Value: "24"},
 					},
 				},
 			},
 		},
-		// FlagStop tests
 		{
-			name: "stops processing flags after FlagStop",
+			name: "IntGT fails when equal to",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(
-						ListFlag[float64]("coordinates", 'c', testDesc, 2, 0),
-						BoolFlag("boo", 'b', testDesc),
-						BoolFlag("yay", 'y', testDesc),
-						ListFlag[string]("names", 'n', testDesc, 1, 2),
-						Flag[int]("rating", 'r', testDesc),
-					),
-					ListArg[string]("extra", testDesc, 0, command.UnboundedList),
-				),
-				Args: []string{"its", "-b", "a", "-r", "9", "--", "secret", "--yay", "-n", "greggar", "groog", "beggars", "--coordinates", "2.2", "4.4", "message."},
+				Node: &SimpleNode{
+					Processor: Arg[int]("i", testDesc, GT(25)),
+				},
+				Args: []string{"25"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"boo":    true,
-					"extra":  []string{"its", "a", "secret", "--yay", "-n", "greggar", "groog", "beggars", "--coordinates", "2.2", "4.4", "message."},
-					"rating": 9,
+					"i": 25,
 				}},
+				WantStderr: "validation for \"i\" failed: [GT] value isn't greater than 25\n",
+				WantErr:    fmt.Errorf(`validation for "i" failed: [GT] value isn't greater than 25`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "its"},
-						{Value: "-b"},
-						{Value: "a"},
-						{Value: "-r"},
-						{Value: "9"},
-						{Value: "--"},
-						{Value: "secret"},
-						{Value: "--yay"},
-						{Value: "-n"},
-						{Value: "greggar"},
-						{Value: "groog"},
-						{Value: "beggars"},
-						{Value: "--coordinates"},
-						{Value: "2.2"},
-						{Value: "4.4"},
-						{Value: "message."},
+						{Value: "25"},
 					},
 				},
 			},
 		},
-		// BoolValueFlag
 		{
-			name: "BoolValueFlag works with true value",
+			name: "IntGT works when greater than",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(
-						BoolValueFlag("light", 'l', testDesc, "hello there"),
-					),
-				),
-				Args: []string{"--light"},
+				Node: &SimpleNode{
+					Processor: Arg[int]("i", testDesc, GT(25)),
+				},
+				Args: []string{"26"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"light": "hello there",
+					"i": 26,
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "--light"},
+						{Value: "26"},
 					},
 				},
 			},
 		},
+		// IntGTE
 		{
-			name: "BoolValueFlag works with false value",
-			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(
-						BoolValueFlag("light", 'l', testDesc, "hello there"),
-					),
-				),
-			},
-		},
-		{
-			name: "BoolValuesFlag works with true value",
+			name: "IntGTE fails when less than",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(
-						BoolValuesFlag("light", 'l', testDesc, "hello there", "general kenobi"),
-					),
-				),
-				Args: []string{"--light"},
+				Node: &SimpleNode{
+					Processor: Arg[int]("i", testDesc, GTE(25)),
+				},
+				Args: []string{"24"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"light": "hello there",
+					"i": 24,
 				}},
+				WantStderr: "validation for \"i\" failed: [GTE] value isn't greater than or equal to 25\n",
+				WantErr:    fmt.Errorf(`validation for "i" failed: [GTE] value isn't greater than or equal to 25`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "--light"},
+						{Value: "24"},
 					},
 				},
 			},
 		},
 		{
-			name: "BoolValuesFlag works with false value",
+			name: "IntGTE works when equal to",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(
-						BoolValuesFlag("light", 'l', testDesc, "hello there", "general kenobi"),
-					),
-				),
+				Node: &SimpleNode{
+					Processor: Arg[int]("i", testDesc, GTE(25)),
+				},
+				Args: []string{"25"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"light": "general kenobi",
+					"i": 25,
 				}},
 			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "25"},
+					},
+				},
+			},
 		},
-		// Multi-flag tests
 		{
-			name: "Multiple bool flags work as a multi-flag",
+			name: "IntGTE works when greater than",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(
-						BoolFlag("everyone", 'e', testDesc),
-						BoolFlag("quick", 'q', testDesc),
-						BoolValuesFlag("run", 'r', testDesc, "hello there", "general kenobi"),
-						BoolValueFlag("to", 't', testDesc, 123),
-						BoolValueFlag("where", 'w', testDesc, 4.56),
-					),
-				),
-				Args: []string{"-qwer"},
+				Node: &SimpleNode{
+					Processor: Arg[int]("i", testDesc, GTE(25)),
+				},
+				Args: []string{"26"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"quick":    true,
-					"where":    4.56,
-					"everyone": true,
-					"run":      "hello there",
+					"i": 26,
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "-qwer"},
+						{Value: "26"},
 					},
 				},
 			},
 		},
+		// IntPositive
 		{
-			name: "Multi-flag fails if partial set of matches",
+			name: "IntPositive fails when negative",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(
-						BoolFlag("everyone", 'e', testDesc),
-						BoolFlag("quick", 'q', testDesc),
-						BoolFlag("run", 'r', testDesc),
-						BoolFlag("to", 't', testDesc),
-						BoolFlag("where", 'w', testDesc),
-					),
-				),
-				Args:       []string{"-qwy"},
-				WantStderr: "Either all or no flags in a multi-flag object must be relevant for a FlagProcessor group\n",
-				WantErr:    fmt.Errorf(`Either all or no flags in a multi-flag object must be relevant for a FlagProcessor group`),
+				Node: &SimpleNode{
+					Processor: Arg[int]("i", testDesc, Positive[int]()),
+				},
+				Args: []string{"-1"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"i": -1,
+				}},
+				WantStderr: "validation for \"i\" failed: [Positive] value isn't positive\n",
+				WantErr:    fmt.Errorf(`validation for "i" failed: [Positive] value isn't positive`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "-qwy"},
+						{Value: "-1"},
 					},
-					Remaining: []int{0},
 				},
 			},
 		},
 		{
-			name: "Multi-flags are ignored if no matches",
+			name: "IntPositive fails when zero",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(
-						BoolFlag("everyone", 'e', testDesc),
-						BoolFlag("quick", 'q', testDesc),
-						BoolFlag("run", 'r', testDesc),
-						BoolFlag("to", 't', testDesc),
-						BoolFlag("where", 'w', testDesc),
-					),
-					OptionalArg[string]("LEFTOVERS", testDesc),
-				),
-				Args: []string{"-nop"},
+				Node: &SimpleNode{
+					Processor: Arg[int]("i", testDesc, Positive[int]()),
+				},
+				Args: []string{"0"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"LEFTOVERS": "-nop",
+					"i": 0,
 				}},
+				WantStderr: "validation for \"i\" failed: [Positive] value isn't positive\n",
+				WantErr:    fmt.Errorf(`validation for "i" failed: [Positive] value isn't positive`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "-nop"},
+						{Value: "0"},
 					},
-					Remaining: []int{},
 				},
 			},
 		},
 		{
-			name: "Multi-flag fails if uncombinable flag",
+			name: "IntPositive works when positive",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(
-						BoolFlag("everyone", 'e', testDesc),
-						BoolFlag("quick", 'q', testDesc),
-						BoolFlag("run", 'r', testDesc),
-						ListFlag[int]("two", 't', testDesc, 0, command.UnboundedList),
-						BoolFlag("where", 'w', testDesc),
-					),
-				),
-				Args: []string{"-ert"},
+				Node: &SimpleNode{
+					Processor: Arg[int]("i", testDesc, Positive[int]()),
+				},
+				Args: []string{"1"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"everyone": true,
-					"run":      true,
+					"i": 1,
 				}},
-				WantStderr: "Flag \"two\" is not combinable\n",
-				WantErr:    fmt.Errorf(`Flag "two" is not combinable`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "-ert"},
+						{Value: "1"},
 					},
-					Remaining: []int{0},
 				},
 			},
 		},
-		// Duplicate flag tests
+		// IntNegative
 		{
-			name: "Duplicate flags get caught in multi-flag",
+			name: "IntNegative works when negative",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(
-						BoolFlag("everyone", 'e', testDesc),
-						BoolFlag("quick", 'q', testDesc),
-						BoolValuesFlag("run", 'r', testDesc, "hello there", "general kenobi"),
-						BoolValueFlag("to", 't', testDesc, 123),
-						BoolValueFlag("where", 'w', testDesc, 4.56),
-					),
-				),
-				Args: []string{"-qwerq"},
+				Node: &SimpleNode{
+					Processor: Arg[int]("i", testDesc, Negative[int]()),
+				},
+				Args: []string{"-1"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"quick":    true,
-					"where":    4.56,
-					"everyone": true,
-					"run":      "hello there",
+					"i": -1,
 				}},
-				WantErr:    fmt.Errorf(`Flag "quick" has already been set`),
-				WantStderr: "Flag \"quick\" has already been set\n",
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "-qwerq"},
+						{Value: "-1"},
 					},
-					Remaining: []int{0},
 				},
 			},
 		},
 		{
-			name: "Duplicate flags get caught in regular flags",
+			name: "IntNegative fails when zero",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(
-						BoolFlag("everyone", 'e', testDesc),
-						BoolFlag("quick", 'q', testDesc),
-						BoolValuesFlag("run", 'r', testDesc, "hello there", "general kenobi"),
-						BoolValueFlag("to", 't', testDesc, 123),
-						BoolValueFlag("where", 'w', testDesc, 4.56),
-					),
-				),
-				Args: []string{"-q", "--quick"},
+				Node: &SimpleNode{
+					Processor: Arg[int]("i", testDesc, Negative[int]()),
+				},
+				Args: []string{"0"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"quick": true,
+					"i": 0,
 				}},
-				WantErr:    fmt.Errorf(`Flag "quick" has already been set`),
-				WantStderr: "Flag \"quick\" has already been set\n",
+				WantStderr: "validation for \"i\" failed: [Negative] value isn't negative\n",
+				WantErr:    fmt.Errorf(`validation for "i" failed: [Negative] value isn't negative`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "-q"},
-						{Value: "--quick"},
+						{Value: "0"},
 					},
-					Remaining: []int{1},
 				},
 			},
 		},
 		{
-			name: "Duplicate flags get caught when multi, then regular flag",
+			name: "IntNegative fails when positive",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(
-						BoolFlag("everyone", 'e', testDesc),
-						BoolFlag("quick", 'q', testDesc),
-						BoolValuesFlag("run", 'r', testDesc, "hello there", "general kenobi"),
-						BoolValueFlag("to", 't', testDesc, 123),
-						BoolValueFlag("where", 'w', testDesc, 4.56),
-					),
-				),
-				Args: []string{"-qwer", "--quick"},
+				Node: &SimpleNode{
+					Processor: Arg[int]("i", testDesc, Negative[int]()),
+				},
+				Args: []string{"1"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"quick":    true,
-					"where":    4.56,
-					"everyone": true,
-					"run":      "hello there",
+					"i": 1,
 				}},
-				WantErr:    fmt.Errorf(`Flag "quick" has already been set`),
-				WantStderr: "Flag \"quick\" has already been set\n",
+				WantStderr: "validation for \"i\" failed: [Negative] value isn't negative\n",
+				WantErr:    fmt.Errorf(`validation for "i" failed: [Negative] value isn't negative`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "-qwer"},
-						{Value: "--quick"},
+						{Value: "1"},
 					},
-					Remaining: []int{1},
 				},
 			},
 		},
+		// IntNonNegative
 		{
-			name: "Duplicate flags get caught when regular, then multi flag",
+			name: "IntNonNegative fails when negative",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(
-						BoolFlag("everyone", 'e', testDesc),
-						BoolFlag("quick", 'q', testDesc),
-						BoolValuesFlag("run", 'r', testDesc, "hello there", "general kenobi"),
-						BoolValueFlag("to", 't', testDesc, 123),
-						BoolValueFlag("where", 'w', testDesc, 4.56),
-					),
-				),
-				Args: []string{"--quick", "-weqr"},
+				Node: &SimpleNode{
+					Processor: Arg[int]("i", testDesc, NonNegative[int]()),
+				},
+				Args: []string{"-1"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"quick":    true,
-					"where":    4.56,
-					"everyone": true,
+					"i": -1,
 				}},
-				WantErr:    fmt.Errorf(`Flag "quick" has already been set`),
-				WantStderr: "Flag \"quick\" has already been set\n",
+				WantStderr: "validation for \"i\" failed: [NonNegative] value isn't non-negative\n",
+				WantErr:    fmt.Errorf(`validation for "i" failed: [NonNegative] value isn't non-negative`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "--quick"},
-						{Value: "-weqr"},
+						{Value: "-1"},
 					},
-					Remaining: []int{1},
 				},
 			},
 		},
-		// OptionalFlag tests
 		{
-			name: "OptionalFlag sets if default if last argument",
+			name: "IntNonNegative works when zero",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(
-						OptionalFlag("of", 'o', testDesc, "dfltValue"),
-					),
-				),
-				Args: []string{"--of"},
+				Node: &SimpleNode{
+					Processor: Arg[int]("i", testDesc, NonNegative[int]()),
+				},
+				Args: []string{"0"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"of": "dfltValue",
+					"i": 0,
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "--of"},
+						{Value: "0"},
 					},
 				},
 			},
 		},
 		{
-			name: "OptionalFlag doesn't eat other flags",
+			name: "IntNonNegative works when positive",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(
-						OptionalFlag("of", 'o', testDesc, "dfltValue"),
-						Flag[string]("sf", 's', testDesc),
-					),
-				),
-				Args: []string{"--of", "--sf", "hello"},
+				Node: &SimpleNode{
+					Processor: Arg[int]("i", testDesc, NonNegative[int]()),
+				},
+				Args: []string{"1"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"of": "dfltValue",
-					"sf": "hello",
+					"i": 1,
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "--of"},
-						{Value: "--sf"},
-						{Value: "hello"},
+						{Value: "1"},
 					},
 				},
 			},
 		},
+		// FloatEQ
 		{
-			name: "OptionalFlag gets set",
+			name: "FloatEQ works",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(
-						OptionalFlag("of", 'o', testDesc, "dfltValue"),
-					),
-				),
-				Args: []string{"--of", "other"},
+				Node: &SimpleNode{
+					Processor: Arg[float64]("flArg", testDesc, EQ(2.4)),
+				},
+				Args: []string{"2.4"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"of": "other",
+					"flArg": 2.4,
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "--of"},
-						{Value: "other"},
+						{Value: "2.4"},
 					},
 				},
 			},
 		},
 		{
-			name: "OptionalFlag handles error",
+			name: "FloatEQ fails",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(
-						OptionalFlag("of", 'o', testDesc, 123),
-					),
-				),
-				Args:       []string{"--of", "not-a-number"},
-				WantErr:    fmt.Errorf(`strconv.Atoi: parsing "not-a-number": invalid syntax`),
-				WantStderr: "strconv.Atoi: parsing \"not-a-number\": invalid syntax\n",
+				Node: &SimpleNode{
+					Processor: Arg[float64]("flArg", testDesc, EQ(2.4)),
+				},
+				Args: []string{"2.5"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"flArg": 2.5,
+				}},
+				WantStderr: "validation for \"flArg\" failed: [EQ] value isn't equal to 2.4\n",
+				WantErr:    fmt.Errorf(`validation for "flArg" failed: [EQ] value isn't equal to 2.4`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "--of"},
-						{Value: "not-a-number"},
+						{Value: "2.5"},
 					},
 				},
 			},
 		},
-		// ItemizedListFlag tests
+		// FloatNE
 		{
-			name: "Itemized list flag requires argument",
+			name: "FloatNE works",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(
-						ItemizedListFlag[string]("ilf", 'i', testDesc),
-					),
-				),
-				Args:       []string{"--ilf"},
-				WantErr:    fmt.Errorf("Argument \"ilf\" requires at least 1 argument, got 0"),
-				WantStderr: "Argument \"ilf\" requires at least 1 argument, got 0\n",
+				Node: &SimpleNode{
+					Processor: Arg[float64]("flArg", testDesc, NEQ(2.4)),
+				},
+				Args: []string{"2.5"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"flArg": 2.5,
+				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
-				WantIsUsageError:         true,
-				WantIsNotEnoughArgsError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "--ilf"},
+						{Value: "2.5"},
 					},
 				},
 			},
 		},
 		{
-			name: "Itemized list flag only takes one argument",
+			name: "FloatNE fails",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(
-						ItemizedListFlag[string]("ilf", 'i', testDesc),
-					),
-					ListArg[string]("sl", testDesc, 0, command.UnboundedList),
-				),
-				Args: []string{"--ilf", "i1", "other"},
+				Node: &SimpleNode{
+					Processor: Arg[float64]("flArg", testDesc, NEQ(2.4)),
+				},
+				Args: []string{"2.4"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"ilf": []string{"i1"},
-					"sl":  []string{"other"},
+					"flArg": 2.4,
 				}},
+				WantStderr: "validation for \"flArg\" failed: [NEQ] value cannot equal 2.4\n",
+				WantErr:    fmt.Errorf(`validation for "flArg" failed: [NEQ] value cannot equal 2.4`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "--ilf"},
-						{Value: "i1"},
-						{Value: "other"},
+						{Value: "2.4"},
 					},
 				},
 			},
 		},
+		// FloatLT
 		{
-			name: "Mixed itemized args",
+			name: "FloatLT works when less than",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(
-						ItemizedListFlag[string]("ilf", 'i', testDesc),
-					),
-					ListArg[string]("sl", testDesc, 0, command.UnboundedList),
-				),
-				Args: []string{"--ilf", "i1", "other", "thing", "-i", "robot", "--ilf", "phone", "okay", "-i", "enough", "then"},
+				Node: &SimpleNode{
+					Processor: Arg[float64]("flArg", testDesc, LT(2.5)),
+				},
+				Args: []string{"2.4"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"ilf": []string{"i1", "robot", "phone", "enough"},
-					"sl":  []string{"other", "thing", "okay", "then"},
+					"flArg": 2.4,
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "--ilf"},
-						{Value: "i1"},
-						{Value: "other"},
-						{Value: "thing"},
-						{Value: "-i"},
-						{Value: "robot"},
-						{Value: "--ilf"},
-						{Value: "phone"},
-						{Value: "okay"},
-						{Value: "-i"},
-						{Value: "enough"},
-						{Value: "then"},
+						{Value: "2.4"},
 					},
 				},
 			},
 		},
-		// Transformer tests.
 		{
-			name: "args get transformed",
+			name: "FloatLT fails when equal to",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					Arg[string]("strArg", testDesc, &Transformer[string]{F: func(v string, d *command.Data) (string, error) {
-						return strings.ToUpper(v), nil
-					}}),
-					Arg[int]("intArg", testDesc, &Transformer[int]{F: func(v int, d *command.Data) (int, error) {
-						return 10 * v, nil
-					}}),
-				),
-				Args: []string{"hello", "12"},
+				Node: &SimpleNode{
+					Processor: Arg[float64]("flArg", testDesc, LT(2.5)),
+				},
+				Args: []string{"2.5"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"strArg": "HELLO",
-					"intArg": 120,
+					"flArg": 2.5,
 				}},
-			},
+				WantStderr: "validation for \"flArg\" failed: [LT] value isn't less than 2.5\n",
+				WantErr:    fmt.Errorf(`validation for "flArg" failed: [LT] value isn't less than 2.5`),
+			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
-					Args: []*spycommand.InputArg{{Value: "HELLO"}, {Value: "120"}},
+					Args: []*spycommand.InputArg{
+						{Value: "2.5"},
+					},
 				},
 			},
 		},
 		{
-			name: "list arg get transformed with TransformerList",
+			name: "FloatLT fails when greater than",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					ListArg[string]("sl", testDesc, 2, 3, TransformerList(&Transformer[string]{F: func(v string, d *command.Data) (string, error) {
-						return strings.ToUpper(v), nil
-					}})),
-				),
-				Args: []string{"hello", "there", "general", "kenobi"},
+				Node: &SimpleNode{
+					Processor: Arg[float64]("flArg", testDesc, LT(2.5)),
+				},
+				Args: []string{"2.6"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"sl": []string{"HELLO", "THERE", "GENERAL", "KENOBI"},
+					"flArg": 2.6,
 				}},
+				WantStderr: "validation for \"flArg\" failed: [LT] value isn't less than 2.5\n",
+				WantErr:    fmt.Errorf(`validation for "flArg" failed: [LT] value isn't less than 2.5`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "HELLO"},
-						{Value: "THERE"},
-						{Value: "GENERAL"},
-						{Value: "KENOBI"},
+						{Value: "2.6"},
 					},
 				},
 			},
 		},
+		// FloatLTE
 		{
-			name: "list arg transformer fails if number of args increases",
+			name: "FloatLTE works when less than",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					ListArg[string]("sl", testDesc, 2, 3, &Transformer[[]string]{F: func(v []string, d *command.Data) ([]string, error) {
-						return append(v, "!"), nil
-					}}),
-				),
-				Args:       []string{"hello", "there", "general", "kenobi"},
-				WantErr:    fmt.Errorf("[sl] Transformers must return a value that is the same length as the original arguments"),
-				WantStderr: "[sl] Transformers must return a value that is the same length as the original arguments\n",
+				Node: &SimpleNode{
+					Processor: Arg[float64]("flArg", testDesc, LTE(2.5)),
+				},
+				Args: []string{"2.4"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"flArg": 2.4,
+				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "hello"},
-						{Value: "there"},
-						{Value: "general"},
-						{Value: "kenobi"},
+						{Value: "2.4"},
 					},
 				},
 			},
 		},
 		{
-			name: "list arg transformer fails if number of args decreases",
+			name: "FloatLTE works when equal to",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					ListArg[string]("sl", testDesc, 2, 3, &Transformer[[]string]{F: func(v []string, d *command.Data) ([]string, error) {
-						return v[:len(v)-1], nil
-					}}),
-				),
-				Args:       []string{"hello", "there", "general", "kenobi"},
-				WantErr:    fmt.Errorf("[sl] Transformers must return a value that is the same length as the original arguments"),
-				WantStderr: "[sl] Transformers must return a value that is the same length as the original arguments\n",
+				Node: &SimpleNode{
+					Processor: Arg[float64]("flArg", testDesc, LTE(2.5)),
+				},
+				Args: []string{"2.5"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"flArg": 2.5,
+				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "hello"},
-						{Value: "there"},
-						{Value: "general"},
-						{Value: "kenobi"},
+						{Value: "2.5"},
 					},
 				},
 			},
 		},
-		// InputTransformer tests.
-		{
-			name: "InputTransformer handles no arguments",
-			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FileNumberInputTransformer(1),
-				),
-			},
-		},
 		{
-			name: "InputTransformer handles non-matching arguments",
+			name: "FloatLTE fails when greater than",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FileNumberInputTransformer(1),
-					Arg[string]("s", testDesc),
-					Arg[int]("i", testDesc),
-				),
-				Args: []string{"hello.go", "248"},
+				Node: &SimpleNode{
+					Processor: Arg[float64]("flArg", testDesc, LTE(2.5)),
+				},
+				Args: []string{"2.6"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"s": "hello.go",
-					"i": 248,
+					"flArg": 2.6,
 				}},
+				WantStderr: "validation for \"flArg\" failed: [LTE] value isn't less than or equal to 2.5\n",
+				WantErr:    fmt.Errorf(`validation for "flArg" failed: [LTE] value isn't less than or equal to 2.5`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "hello.go"},
-						{Value: "248"},
+						{Value: "2.6"},
 					},
 				},
 			},
 		},
+		// FloatGT
 		{
-			name: "InputTransformer expands matching arguments",
+			name: "FloatGT fails when less than",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FileNumberInputTransformer(1),
-					Arg[string]("s", testDesc),
-					Arg[int]("i", testDesc),
-				),
-				Args: []string{"hello.go:248"},
+				Node: &SimpleNode{
+					Processor: Arg[float64]("flArg", testDesc, GT(2.5)),
+				},
+				Args: []string{"2.4"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"s": "hello.go",
-					"i": 248,
+					"flArg": 2.4,
 				}},
+				WantStderr: "validation for \"flArg\" failed: [GT] value isn't greater than 2.5\n",
+				WantErr:    fmt.Errorf(`validation for "flArg" failed: [GT] value isn't greater than 2.5`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "hello.go"},
-						{Value: "248"},
+						{Value: "2.4"},
 					},
 				},
 			},
 		},
 		{
-			name: "InputTransformer fails",
+			name: "FloatGT fails when equal to",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FileNumberInputTransformer(1),
-				),
-				Args:       []string{"hello.go:248:extra"},
-				WantErr:    fmt.Errorf("Expected either 1 or 2 parts, got 3"),
-				WantStderr: "Expected either 1 or 2 parts, got 3\n",
+				Node: &SimpleNode{
+					Processor: Arg[float64]("flArg", testDesc, GT(2.5)),
+				},
+				Args: []string{"2.5"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"flArg": 2.5,
+				}},
+				WantStderr: "validation for \"flArg\" failed: [GT] value isn't greater than 2.5\n",
+				WantErr:    fmt.Errorf(`validation for "flArg" failed: [GT] value isn't greater than 2.5`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
-					Remaining: []int{0},
 					Args: []*spycommand.InputArg{
-						{Value: "hello.go:248:extra"},
+						{Value: "2.5"},
 					},
 				},
 			},
 		},
 		{
-			name: "InputTransformer expands multiple matching arguments",
+			name: "FloatGT works when greater than",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					FileNumberInputTransformer(2),
-					Arg[string]("s", testDesc),
-					Arg[int]("i", testDesc),
-					Arg[string]("s2", testDesc),
-					Arg[int]("i2", testDesc),
-				),
-				Args: []string{"hello.go:248", "there.txt:139"},
+				Node: &SimpleNode{
+					Processor: Arg[float64]("flArg", testDesc, GT(2.5)),
+				},
+				Args: []string{"2.6"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"s":  "hello.go",
-					"i":  248,
-					"s2": "there.txt",
-					"i2": 139,
+					"flArg": 2.6,
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "hello.go"},
-						{Value: "248"},
-						{Value: "there.txt"},
-						{Value: "139"},
+						{Value: "2.6"},
 					},
 				},
 			},
 		},
-		// Stdoutln tests
-		{
-			name: "stdoutln works",
-			etc: &commandtest.ExecuteTestCase{
-				Node:       printlnNode(true, "one", 2, 3.0),
-				WantStdout: "one 2 3\n",
-			},
-		},
-		{
-			name: "stderrln works",
-			etc: &commandtest.ExecuteTestCase{
-				Node:       printlnNode(false, "uh", 0),
-				WantStderr: "uh 0\n",
-				WantErr:    fmt.Errorf("uh 0"),
-			},
-		},
-		// BranchNode tests
+		// FloatGTE
 		{
-			name: "branch node requires branch argument",
+			name: "FloatGTE fails when less than",
 			etc: &commandtest.ExecuteTestCase{
-				Node: &BranchNode{
-					Branches: map[string]command.Node{
-						"h": printNode("hello"),
-						"b": printNode("goodbye"),
-					},
+				Node: &SimpleNode{
+					Processor: Arg[float64]("flArg", testDesc, GTE(2.5)),
 				},
-				WantStderr: "Branching argument must be one of [b h]\n",
-				WantErr:    fmt.Errorf("Branching argument must be one of [b h]"),
+				Args: []string{"2.4"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"flArg": 2.4,
+				}},
+				WantStderr: "validation for \"flArg\" failed: [GTE] value isn't greater than or equal to 2.5\n",
+				WantErr:    fmt.Errorf(`validation for "flArg" failed: [GTE] value isn't greater than or equal to 2.5`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
-				WantIsUsageError:     true,
-				WantIsBranchingError: true,
+				WantIsValidationError: true,
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "2.4"},
+					},
+				},
 			},
 		},
 		{
-			name: "branch node requires matching branch argument",
+			name: "FloatGTE works when equal to",
 			etc: &commandtest.ExecuteTestCase{
-				Node: &BranchNode{
-					Branches: map[string]command.Node{
-						"h": printNode("hello"),
-						"b": printNode("goodbye"),
-					},
+				Node: &SimpleNode{
+					Processor: Arg[float64]("flArg", testDesc, GTE(2.5)),
 				},
-				Args:       []string{"uh"},
-				WantStderr: "Branching argument must be one of [b h]\n",
-				WantErr:    fmt.Errorf("Branching argument must be one of [b h]"),
+				Args: []string{"2.5"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"flArg": 2.5,
+				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
-				WantIsUsageError:     true,
-				WantIsBranchingError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "uh"},
+						{Value: "2.5"},
 					},
-					Remaining: []int{0},
 				},
 			},
 		},
 		{
-			name: "branch node forwards to proper node",
+			name: "FloatGTE works when greater than",
 			etc: &commandtest.ExecuteTestCase{
-				Node: &BranchNode{
-					Branches: map[string]command.Node{
-						"h": printNode("hello"),
-						"b": printNode("goodbye"),
-					},
+				Node: &SimpleNode{
+					Processor: Arg[float64]("flArg", testDesc, GTE(2.5)),
 				},
-				Args:       []string{"h"},
-				WantStdout: "hello",
+				Args: []string{"2.6"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"flArg": 2.6,
+				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "h"},
+						{Value: "2.6"},
 					},
 				},
 			},
 		},
+		// FloatPositive
 		{
-			name: "branch node forwards to default if none provided",
+			name: "FloatPositive fails when negative",
 			etc: &commandtest.ExecuteTestCase{
-				Node: &BranchNode{
-					Branches: map[string]command.Node{
-						"h": printNode("hello"),
-						"b": printNode("goodbye"),
+				Node: &SimpleNode{
+					Processor: Arg[float64]("flArg", testDesc, Positive[float64]()),
+				},
+				Args: []string{"-0.1"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"flArg": -0.1,
+				}},
+				WantStderr: "validation for \"flArg\" failed: [Positive] value isn't positive\n",
+				WantErr:    fmt.Errorf(`validation for "flArg" failed: [Positive] value isn't positive`),
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "-0.1"},
 					},
-					Default: printNode("default"),
 				},
-				WantStdout: "default",
 			},
 		},
 		{
-			name: "branch node forwards to default if unknown provided",
+			name: "FloatPositive fails when zero",
 			etc: &commandtest.ExecuteTestCase{
-				Node: &BranchNode{
-					Branches: map[string]command.Node{
-						"h": printNode("hello"),
-						"b": printNode("goodbye"),
-					},
-					Default: SerialNodes(ListArg[string]("sl", testDesc, 0, command.UnboundedList), printArgsNode()),
+				Node: &SimpleNode{
+					Processor: Arg[float64]("flArg", testDesc, Positive[float64]()),
 				},
-				Args:       []string{"good", "morning"},
-				WantStdout: "sl: [good morning]\n",
+				Args: []string{"0"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"sl": []string{"good", "morning"},
+					"flArg": 0.0,
 				}},
+				WantStderr: "validation for \"flArg\" failed: [Positive] value isn't positive\n",
+				WantErr:    fmt.Errorf(`validation for "flArg" failed: [Positive] value isn't positive`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "good"},
-						{Value: "morning"},
+						{Value: "0"},
 					},
 				},
 			},
 		},
 		{
-			name: "branch node forwards to synonym",
+			name: "FloatPositive works when positive",
 			etc: &commandtest.ExecuteTestCase{
-				Args: []string{"B"},
-				Node: &BranchNode{
-					Branches: map[string]command.Node{
-						"h": printNode("hello"),
-						"b": printNode("goodbye"),
-					},
-					Default: printNode("default"),
-					Synonyms: BranchSynonyms(map[string][]string{
-						"b": {"bee", "B", "Be"},
-					}),
+				Node: &SimpleNode{
+					Processor: Arg[float64]("flArg", testDesc, Positive[float64]()),
 				},
-				WantStdout: "goodbye",
+				Args: []string{"0.1"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"flArg": 0.1,
+				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "B"},
+						{Value: "0.1"},
 					},
 				},
 			},
 		},
+		// FloatNegative
 		{
-			name: "branch node fails if synonym to unknown command",
+			name: "FloatNegative works when negative",
 			etc: &commandtest.ExecuteTestCase{
-				Args: []string{"uh"},
-				Node: &BranchNode{
-					Branches: map[string]command.Node{
-						"h": printNode("hello"),
-						"b": printNode("goodbye"),
-					},
-					Synonyms: BranchSynonyms(map[string][]string{
-						"o": {"uh"},
-					}),
+				Node: &SimpleNode{
+					Processor: Arg[float64]("flArg", testDesc, Negative[float64]()),
 				},
-				WantStderr: "Branching argument must be one of [b h]\n",
-				WantErr:    fmt.Errorf("Branching argument must be one of [b h]"),
+				Args: []string{"-0.1"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"flArg": -0.1,
+				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
-				WantIsUsageError:     true,
-				WantIsBranchingError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "uh"},
+						{Value: "-0.1"},
 					},
-					Remaining: []int{0},
 				},
 			},
 		},
 		{
-			name: "branch node forwards to default if synonym to unknown command",
+			name: "FloatNegative fails when zero",
 			etc: &commandtest.ExecuteTestCase{
-				Args: []string{"uh"},
-				Node: &BranchNode{
-					Branches: map[string]command.Node{
-						"h": printNode("hello"),
-						"b": printNode("goodbye"),
-					},
-					Default: SerialNodes(ListArg[string]("sl", testDesc, 0, command.UnboundedList), printArgsNode()),
-					Synonyms: BranchSynonyms(map[string][]string{
-						"o": {"uh"},
-					}),
-				},
-				WantData: &command.Data{
-					Values: map[string]interface{}{
-						"sl": []string{"uh"},
-					},
+				Node: &SimpleNode{
+					Processor: Arg[float64]("flArg", testDesc, Negative[float64]()),
 				},
-				WantStdout: "sl: [uh]\n",
+				Args: []string{"0"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"flArg": 0.0,
+				}},
+				WantStderr: "validation for \"flArg\" failed: [Negative] value isn't negative\n",
+				WantErr:    fmt.Errorf(`validation for "flArg" failed: [Negative] value isn't negative`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "uh"},
+						{Value: "0"},
 					},
 				},
 			},
 		},
 		{
-			name: "branch node forwards to spaced synonym",
+			name: "FloatNegative fails when positive",
 			etc: &commandtest.ExecuteTestCase{
-				Args: []string{"bee"},
-				Node: &BranchNode{
-					Branches: map[string]command.Node{
-						"h":          printNode("hello"),
-						"b bee B Be": printNode("goodbye"),
-					},
-					Default: printNode("default"),
+				Node: &SimpleNode{
+					Processor: Arg[float64]("flArg", testDesc, Negative[float64]()),
 				},
-				WantStdout: "goodbye",
+				Args: []string{"0.1"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"flArg": 0.1,
+				}},
+				WantStderr: "validation for \"flArg\" failed: [Negative] value isn't negative\n",
+				WantErr:    fmt.Errorf(`validation for "flArg" failed: [Negative] value isn't negative`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "bee"},
+						{Value: "0.1"},
 					},
 				},
 			},
 		},
-		// BranchNode synonym tests
+		// FloatNonNegative
 		{
-			name: "branch node works with branch name",
+			name: "FloatNonNegative fails when negative",
 			etc: &commandtest.ExecuteTestCase{
-				Args:       []string{"hello"},
-				Node:       branchSynNode(),
-				WantStdout: "yo",
+				Node: &SimpleNode{
+					Processor: Arg[float64]("flArg", testDesc, NonNegative[float64]()),
+				},
+				Args: []string{"-0.1"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"flArg": -0.1,
+				}},
+				WantStderr: "validation for \"flArg\" failed: [NonNegative] value isn't non-negative\n",
+				WantErr:    fmt.Errorf(`validation for "flArg" failed: [NonNegative] value isn't non-negative`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "hello"},
+						{Value: "-0.1"},
 					},
 				},
 			},
 		},
 		{
-			name: "branch node works with branch name",
+			name: "FloatNonNegative works when zero",
 			etc: &commandtest.ExecuteTestCase{
-				Args:       []string{"hello"},
-				Node:       branchSynNode(),
-				WantStdout: "yo",
+				Node: &SimpleNode{
+					Processor: Arg[float64]("flArg", testDesc, NonNegative[float64]()),
+				},
+				Args: []string{"0"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"flArg": 0.0,
+				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "hello"},
+						{Value: "0"},
 					},
 				},
 			},
 		},
 		{
-			name: "branch node works with second spaced alias",
+			name: "FloatNonNegative works when positive",
 			etc: &commandtest.ExecuteTestCase{
-				Args:       []string{"greetings"},
-				Node:       branchSynNode(),
-				WantStdout: "yo",
+				Node: &SimpleNode{
+					Processor: Arg[float64]("flArg", testDesc, NonNegative[float64]()),
+				},
+				Args: []string{"0.1"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"flArg": 0.1,
+				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "greetings"},
+						{Value: "0.1"},
 					},
 				},
 			},
 		},
+		// MultipleOf
 		{
-			name: "branch node works with first synonym",
+			name: "MultipleOf succeeds for a multiple",
 			etc: &commandtest.ExecuteTestCase{
-				Args:       []string{"hey"},
-				Node:       branchSynNode(),
-				WantStdout: "yo",
+				Node: &SimpleNode{
+					Processor: Arg[int]("iArg", testDesc, MultipleOf(4)),
+				},
+				Args: []string{"12"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"iArg": 12,
+				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "hey"},
+						{Value: "12"},
 					},
 				},
 			},
 		},
 		{
-			name: "branch node works with second synonym",
+			name: "MultipleOf succeeds for zero",
 			etc: &commandtest.ExecuteTestCase{
-				Args:       []string{"howdy"},
-				Node:       branchSynNode(),
-				WantStdout: "yo",
+				Node: &SimpleNode{
+					Processor: Arg[int]("iArg", testDesc, MultipleOf(4)),
+				},
+				Args: []string{"0"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"iArg": 0,
+				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "howdy"},
+						{Value: "0"},
 					},
 				},
 			},
 		},
-		// NodeRepeater tests
 		{
-			name: "NodeRepeater fails if not enough",
+			name: "MultipleOf succeeds for a negative multiple",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(sampleRepeaterProcessor(3, 0)),
-				Args: []string{"k1", "100", "k2", "200"},
+				Node: &SimpleNode{
+					Processor: Arg[int]("iArg", testDesc, MultipleOf(4)),
+				},
+				Args: []string{"-8"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"keys":   []string{"k1", "k2"},
-					"values": []int{100, 200},
+					"iArg": -8,
 				}},
-				WantErr:    fmt.Errorf(`Argument "KEY" requires at least 1 argument, got 0`),
-				WantStderr: "Argument \"KEY\" requires at least 1 argument, got 0\n",
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
-				WantIsUsageError:         true,
-				WantIsNotEnoughArgsError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "k1"},
-						{Value: "100"},
-						{Value: "k2"},
-						{Value: "200"},
+						{Value: "-8"},
 					},
 				},
 			},
 		},
 		{
-			name: "NodeRepeater fails if middle node doen't have enough",
+			name: "MultipleOf fails for a non-multiple",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(sampleRepeaterProcessor(1, 1)),
-				Args: []string{"k1", "100", "k2"},
+				Node: &SimpleNode{
+					Processor: Arg[int]("iArg", testDesc, MultipleOf(4)),
+				},
+				Args: []string{"10"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"keys":   []string{"k1", "k2"},
-					"values": []int{100},
+					"iArg": 10,
 				}},
-				WantErr:    fmt.Errorf(`Argument "VALUE" requires at least 1 argument, got 0`),
-				WantStderr: "Argument \"VALUE\" requires at least 1 argument, got 0\n",
+				WantStderr: "validation for \"iArg\" failed: [MultipleOf] value isn't a multiple of 4\n",
+				WantErr:    fmt.Errorf("validation for \"iArg\" failed: [MultipleOf] value isn't a multiple of 4"),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
-				WantIsUsageError:         true,
-				WantIsNotEnoughArgsError: true,
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "k1"},
-						{Value: "100"},
-						{Value: "k2"},
+						{Value: "10"},
 					},
 				},
 			},
 		},
 		{
-			name: "NodeRepeater fails if too many",
+			name: "MultipleOf fails if divisor is zero",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(sampleRepeaterProcessor(1, 0)),
-				Args: []string{"k1", "100", "k2", "200"},
+				Node: &SimpleNode{
+					Processor: Arg[int]("iArg", testDesc, MultipleOf(0)),
+				},
+				Args: []string{"10"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"keys":   []string{"k1"},
-					"values": []int{100},
+					"iArg": 10,
 				}},
-				WantErr: fmt.Errorf(`Unprocessed extra args: [k2 200]`),
-				WantStderr: strings.Join([]string{
-					`Unprocessed extra args: [k2 200]`,
-					``,
-					`======= Command Usage =======`,
-					`KEY VALUE`,
-					``,
-					`Arguments:`,
-					`  KEY: test desc`,
-					`  VALUE: test desc`,
-					``,
-				}, "\n"),
+				WantStderr: "validation for \"iArg\" failed: [MultipleOf] divisor cannot be zero\n",
+				WantErr:    fmt.Errorf("validation for \"iArg\" failed: [MultipleOf] divisor cannot be zero"),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
-				WantIsExtraArgsError: true,
-				WantIsUsageError:     true,
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "k1"},
-						{Value: "100"},
-						{Value: "k2"},
-						{Value: "200"},
+						{Value: "10"},
 					},
-					Remaining: []int{2, 3},
 				},
 			},
 		},
 		{
-			name: "NodeRepeater accepts minimum when no optional",
+			name: "MultipleOf works with list validator",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(sampleRepeaterProcessor(2, 0)),
-				Args: []string{"k1", "100", "k2", "200"},
+				Node: &SimpleNode{
+					Processor: ListArg[int]("iArg", testDesc, 1, 2, ListifyValidatorOption(MultipleOf(3))),
+				},
+				Args: []string{"3", "6", "7"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"keys":   []string{"k1", "k2"},
-					"values": []int{100, 200},
+					"iArg": []int{3, 6, 7},
 				}},
+				WantStderr: "validation for \"iArg\" failed: [MultipleOf] value isn't a multiple of 3\n",
+				WantErr:    fmt.Errorf("validation for \"iArg\" failed: [MultipleOf] value isn't a multiple of 3"),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "k1"},
-						{Value: "100"},
-						{Value: "k2"},
-						{Value: "200"},
+						{Value: "3"},
+						{Value: "6"},
+						{Value: "7"},
 					},
 				},
 			},
 		},
+		// BoolishArg
 		{
-			name: "NodeRepeater accepts minimum when optional",
+			name: "BoolishArg converts yes to true",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(sampleRepeaterProcessor(2, 3)),
-				Args: []string{"k1", "100", "k2", "200"},
+				Node: &SimpleNode{
+					Processor: BoolishArg("bArg", testDesc),
+				},
+				Args: []string{"yes"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"keys":   []string{"k1", "k2"},
-					"values": []int{100, 200},
+					"bArg": true,
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "k1"},
-						{Value: "100"},
-						{Value: "k2"},
-						{Value: "200"},
+						{Value: "true"},
 					},
 				},
 			},
 		},
 		{
-			name: "NodeRepeater accepts minimum when unlimited optional",
+			name: "BoolishArg converts on to true",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(sampleRepeaterProcessor(2, 3)),
-				Args: []string{"k1", "100", "k2", "200"},
+				Node: &SimpleNode{
+					Processor: BoolishArg("bArg", testDesc),
+				},
+				Args: []string{"on"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"keys":   []string{"k1", "k2"},
-					"values": []int{100, 200},
+					"bArg": true,
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "k1"},
-						{Value: "100"},
-						{Value: "k2"},
-						{Value: "200"},
+						{Value: "true"},
 					},
 				},
 			},
 		},
 		{
-			name: "NodeRepeater accepts maximum when no optional",
+			name: "BoolishArg converts case-insensitive OFF to false",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(sampleRepeaterProcessor(2, 0)),
-				Args: []string{"k1", "100", "k2", "200"},
+				Node: &SimpleNode{
+					Processor: BoolishArg("bArg", testDesc),
+				},
+				Args: []string{"OFF"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"keys":   []string{"k1", "k2"},
-					"values": []int{100, 200},
+					"bArg": false,
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "k1"},
-						{Value: "100"},
-						{Value: "k2"},
-						{Value: "200"},
+						{Value: "false"},
 					},
 				},
 			},
 		},
 		{
-			name: "NodeRepeater accepts maximum when optional",
+			name: "BoolishArg converts no to false",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(sampleRepeaterProcessor(1, 1)),
-				Args: []string{"k1", "100", "k2", "200"},
+				Node: &SimpleNode{
+					Processor: BoolishArg("bArg", testDesc),
+				},
+				Args: []string{"no"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"keys":   []string{"k1", "k2"},
-					"values": []int{100, 200},
+					"bArg": false,
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "k1"},
-						{Value: "100"},
-						{Value: "k2"},
-						{Value: "200"},
+						{Value: "false"},
 					},
 				},
 			},
 		},
 		{
-			name: "NodeRepeater with unlimited optional accepts a bunch",
+			name: "BoolishArg converts true to true",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(sampleRepeaterProcessor(1, command.UnboundedList)),
-				Args: []string{"k1", "100", "k2", "200", "k3", "300", "k4", "400", "...", "0", "kn", "999"},
+				Node: &SimpleNode{
+					Processor: BoolishArg("bArg", testDesc),
+				},
+				Args: []string{"true"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"keys":   []string{"k1", "k2", "k3", "k4", "...", "kn"},
-					"values": []int{100, 200, 300, 400, 0, 999},
+					"bArg": true,
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "k1"},
-						{Value: "100"},
-						{Value: "k2"},
-						{Value: "200"},
-						{Value: "k3"},
-						{Value: "300"},
-						{Value: "k4"},
-						{Value: "400"},
-						{Value: "..."},
-						{Value: "0"},
-						{Value: "kn"},
-						{Value: "999"},
+						{Value: "true"},
 					},
 				},
 			},
 		},
-		// ListBreaker tests
 		{
-			name: "Handles broken list",
+			name: "BoolishArg fails for non-boolish value",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					ListArg[string]("SL", testDesc, 1, command.UnboundedList, ListUntilSymbol("ghi")),
-					ListArg[string]("SL2", testDesc, 0, command.UnboundedList),
-				),
-				Args: []string{"abc", "def", "ghi", "jkl"},
-				WantData: &command.Data{Values: map[string]interface{}{
-					"SL":  []string{"abc", "def"},
-					"SL2": []string{"ghi", "jkl"},
-				}},
+				Node: &SimpleNode{
+					Processor: BoolishArg("bArg", testDesc),
+				},
+				Args:       []string{"maybe"},
+				WantStderr: "validation for \"bArg\" failed: [BoolishArg] value must be one of [true false yes no on off]\n",
+				WantErr:    fmt.Errorf("validation for \"bArg\" failed: [BoolishArg] value must be one of [true false yes no on off]"),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "abc"},
-						{Value: "def"},
-						{Value: "ghi"},
-						{Value: "jkl"},
+						{Value: "maybe"},
 					},
 				},
 			},
 		},
+		// IsBoolish
 		{
-			name: "List breaker before min value",
+			name: "IsBoolish accepts boolish value",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					ListArg[string]("SL", testDesc, 3, command.UnboundedList, ListUntilSymbol("ghi")),
-				),
-				Args: []string{"abc", "def", "ghi", "jkl"},
+				Node: &SimpleNode{
+					Processor: Arg[string]("bArg", testDesc, IsBoolish()),
+				},
+				Args: []string{"Yes"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"SL": []string{"abc", "def"},
+					"bArg": "Yes",
 				}},
-				WantErr:    fmt.Errorf(`Argument "SL" requires at least 3 arguments, got 2`),
-				WantStderr: "Argument \"SL\" requires at least 3 arguments, got 2\n",
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
-				WantIsUsageError:         true,
-				WantIsNotEnoughArgsError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "abc"},
-						{Value: "def"},
-						{Value: "ghi"},
-						{Value: "jkl"},
+						{Value: "Yes"},
 					},
-					Remaining: []int{2, 3},
 				},
 			},
 		},
 		{
-			name: "Handles broken list with discard",
+			name: "IsBoolish fails for non-boolish value",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					ListArg[string]("SL", testDesc, 1, command.UnboundedList, func() *ListBreaker[[]string] {
-						li := ListUntilSymbol("ghi")
-						li.Discard = true
-						return li
-					}()),
-					ListArg[string]("SL2", testDesc, 0, command.UnboundedList),
-				),
-				Args: []string{"abc", "def", "ghi", "jkl"},
+				Node: &SimpleNode{
+					Processor: Arg[string]("bArg", testDesc, IsBoolish()),
+				},
+				Args:       []string{"maybe"},
+				WantStderr: "validation for \"bArg\" failed: [IsBoolish] value must be one of [true false yes no on off]\n",
+				WantErr:    fmt.Errorf("validation for \"bArg\" failed: [IsBoolish] value must be one of [true false yes no on off]"),
 				WantData: &command.Data{Values: map[string]interface{}{
-					"SL":  []string{"abc", "def"},
-					"SL2": []string{"jkl"},
+					"bArg": "maybe",
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "abc"},
-						{Value: "def"},
-						{Value: "ghi"},
-						{Value: "jkl"},
+						{Value: "maybe"},
 					},
 				},
 			},
 		},
+		// Between inclusive
 		{
-			name: "Handles unbroken list",
+			name: "Between inclusive fails when less than lower bound",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					ListArg[string]("SL", testDesc, 1, command.UnboundedList, ListUntilSymbol("ghi")),
-					ListArg[string]("SL2", testDesc, 0, command.UnboundedList),
-				),
-				Args: []string{"abc", "def", "ghif", "jkl"},
+				Node: &SimpleNode{
+					Processor: Arg[int]("iArg", testDesc, Between(-3, 4, true)),
+				},
+				Args: []string{"-4"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"SL": []string{"abc", "def", "ghif", "jkl"},
+					"iArg": -4,
 				}},
+				WantStderr: "validation for \"iArg\" failed: [Between] value is less than lower bound (-3)\n",
+				WantErr:    fmt.Errorf("validation for \"iArg\" failed: [Between] value is less than lower bound (-3)"),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "abc"},
-						{Value: "def"},
-						{Value: "ghif"},
-						{Value: "jkl"},
+						{Value: "-4"},
 					},
 				},
 			},
 		},
 		{
-			name: "Fails if arguments required after broken list",
+			name: "Between inclusive succeeds when equals lower bound",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					ListArg[string]("SL", testDesc, 1, command.UnboundedList, ListUntilSymbol("ghi")),
-					ListArg[string]("SL2", testDesc, 1, command.UnboundedList),
-				),
-				Args: []string{"abc", "def", "ghif", "jkl"},
+				Node: &SimpleNode{
+					Processor: Arg[int]("iArg", testDesc, Between(-3, 4, true)),
+				},
+				Args: []string{"-3"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"SL": []string{"abc", "def", "ghif", "jkl"},
+					"iArg": -3,
 				}},
-				WantErr:    fmt.Errorf(`Argument "SL2" requires at least 1 argument, got 0`),
-				WantStderr: "Argument \"SL2\" requires at least 1 argument, got 0\n",
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
-				WantIsUsageError:         true,
-				WantIsNotEnoughArgsError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "abc"},
-						{Value: "def"},
-						{Value: "ghif"},
-						{Value: "jkl"},
+						{Value: "-3"},
 					},
 				},
 			},
 		},
-		// StringListListProcessor tests
 		{
-			name: "StringListListProcessor works if no breakers",
+			name: "Between inclusive succeeds when between bounds",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					StringListListProcessor("SLL", testDesc, "|", 1, command.UnboundedList),
-				),
-				Args: []string{"abc", "def", "ghi", "jkl"},
+				Node: &SimpleNode{
+					Processor: Arg[int]("iArg", testDesc, Between(-3, 4, true)),
+				},
+				Args: []string{"0"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"SLL": [][]string{
-						{"abc", "def", "ghi", "jkl"},
-					},
+					"iArg": 0,
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "abc"},
-						{Value: "def"},
-						{Value: "ghi"},
-						{Value: "jkl"},
+						{Value: "0"},
 					},
 				},
 			},
 		},
 		{
-			name: "StringListListProcessor works with unbounded list",
+			name: "Between inclusive succeeds when equals upper bound",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					StringListListProcessor("SLL", testDesc, "|", 1, command.UnboundedList),
-				),
-				Args: []string{"abc", "def", "|", "ghi", "||", "|", "jkl"},
+				Node: &SimpleNode{
+					Processor: Arg[int]("iArg", testDesc, Between(-3, 4, true)),
+				},
+				Args: []string{"4"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"SLL": [][]string{
-						{"abc", "def"},
-						{"ghi", "||"},
-						{"jkl"},
-					},
+					"iArg": 4,
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "abc"},
-						{Value: "def"},
-						{Value: "|"},
-						{Value: "ghi"},
-						{Value: "||"},
-						{Value: "|"},
-						{Value: "jkl"},
+						{Value: "4"},
 					},
 				},
 			},
 		},
 		{
-			name: "StringListListProcessor works with bounded list",
+			name: "Between inclusive fails when greater than upper bound",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					StringListListProcessor("SLL", testDesc, "|", 1, 2),
-				),
-				Args: []string{"abc", "def", "|", "ghi", "||", "|", "jkl"},
+				Node: &SimpleNode{
+					Processor: Arg[int]("iArg", testDesc, Between(-3, 4, true)),
+				},
+				Args: []string{"5"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"SLL": [][]string{
-						{"abc", "def"},
-						{"ghi", "||"},
-						{"jkl"},
-					},
+					"iArg": 5,
 				}},
+				WantStderr: "validation for \"iArg\" failed: [Between] value is greater than upper bound (4)\n",
+				WantErr:    fmt.Errorf("validation for \"iArg\" failed: [Between] value is greater than upper bound (4)"),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "abc"},
-						{Value: "def"},
-						{Value: "|"},
-						{Value: "ghi"},
-						{Value: "||"},
-						{Value: "|"},
-						{Value: "jkl"},
+						{Value: "5"},
 					},
 				},
 			},
 		},
+		// Between exclusive
 		{
-			name: "StringListListProcessor works if ends with operator",
+			name: "Between exclusive fails when less than lower bound",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					StringListListProcessor("SLL", testDesc, "|", 1, 2),
-				),
-				Args: []string{"abc", "def", "|", "ghi", "||", "|", "jkl", "|"},
+				Node: &SimpleNode{
+					Processor: Arg[int]("iArg", testDesc, Between(-3, 4, false)),
+				},
+				Args: []string{"-4"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"SLL": [][]string{
-						{"abc", "def"},
-						{"ghi", "||"},
-						{"jkl"},
-					},
+					"iArg": -4,
 				}},
+				WantStderr: "validation for \"iArg\" failed: [Between] value is less than lower bound (-3)\n",
+				WantErr:    fmt.Errorf("validation for \"iArg\" failed: [Between] value is less than lower bound (-3)"),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "abc"},
-						{Value: "def"},
-						{Value: "|"},
-						{Value: "ghi"},
-						{Value: "||"},
-						{Value: "|"},
-						{Value: "jkl"},
-						{Value: "|"},
+						{Value: "-4"},
 					},
 				},
 			},
 		},
 		{
-			name: "StringListListProcessor fails if extra args",
+			name: "Between exclusive fails when equals lower bound",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					StringListListProcessor("SLL", testDesc, "|", 1, 2),
-				),
-				Args: []string{"abc", "def", "|", "ghi", "||", "|", "jkl", "|", "other", "stuff"},
+				Node: &SimpleNode{
+					Processor: Arg[int]("iArg", testDesc, Between(-3, 4, false)),
+				},
+				Args: []string{"-3"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"SLL": [][]string{
-						{"abc", "def"},
-						{"ghi", "||"},
-						{"jkl"},
-					},
+					"iArg": -3,
 				}},
-				WantErr: fmt.Errorf("Unprocessed extra args: [other stuff]"),
-				WantStderr: strings.Join([]string{
-					`Unprocessed extra args: [other stuff]`,
-					``,
-					`======= Command Usage =======`,
-					`[ SLL ... ] | { [ SLL ... ] | [ SLL ... ] | }`,
-					``,
-					`Arguments:`,
-					`  SLL: test desc`,
-					``,
-					`Symbols:`,
-					`  |: List breaker`,
-					``,
-				}, "\n"),
+				WantStderr: "validation for \"iArg\" failed: [Between] value equals exclusive lower bound (-3)\n",
+				WantErr:    fmt.Errorf("validation for \"iArg\" failed: [Between] value equals exclusive lower bound (-3)"),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
-				WantIsExtraArgsError: true,
-				WantIsUsageError:     true,
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "abc"},
-						{Value: "def"},
-						{Value: "|"},
-						{Value: "ghi"},
-						{Value: "||"},
-						{Value: "|"},
-						{Value: "jkl"},
-						{Value: "|"},
-						{Value: "other"},
-						{Value: "stuff"},
+						{Value: "-3"},
 					},
-					Remaining: []int{8, 9},
 				},
 			},
 		},
-		// FileContents test
 		{
-			name: "file gets read properly",
+			name: "Between exclusive succeeds when between bounds",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(FileContents("FILE", testDesc)),
-				Args: []string{filepath.Join("testdata", "one.txt")},
-				WantData: &command.Data{
-					Values: map[string]interface{}{
-						"FILE": []string{"hello", "there"},
-					},
+				Node: &SimpleNode{
+					Processor: Arg[int]("iArg", testDesc, Between(-3, 4, false)),
 				},
+				Args: []string{"0"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"iArg": 0,
+				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: testutil.FilepathAbs(t, "testdata", "one.txt")},
+						{Value: "0"},
 					},
 				},
 			},
 		},
 		{
-			name: "FileContents fails for unknown file",
+			name: "Between exclusive fails when equals upper bound",
 			etc: &commandtest.ExecuteTestCase{
-				Node:       SerialNodes(FileContents("FILE", testDesc)),
-				Args:       []string{filepath.Join("uh")},
-				WantStderr: fmt.Sprintf("validation for \"FILE\" failed: [FileExists] file %q does not exist\n", testutil.FilepathAbs(t, "uh")),
-				WantErr:    fmt.Errorf(`validation for "FILE" failed: [FileExists] file %q does not exist`, testutil.FilepathAbs(t, "uh")),
-				WantData: &command.Data{
-					Values: map[string]interface{}{
-						"FILE": testutil.FilepathAbs(t, "uh"),
-					},
+				Node: &SimpleNode{
+					Processor: Arg[int]("iArg", testDesc, Between(-3, 4, false)),
 				},
+				Args: []string{"4"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"iArg": 4,
+				}},
+				WantStderr: "validation for \"iArg\" failed: [Between] value equals exclusive upper bound (4)\n",
+				WantErr:    fmt.Errorf("validation for \"iArg\" failed: [Between] value equals exclusive upper bound (4)"),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: testutil.FilepathAbs(t, "uh")},
+						{Value: "4"},
 					},
 				},
 			},
 		},
-		// If tests
 		{
-			name: "If runs if function returns true",
+			name: "Between exclusive fails when greater than upper bound",
 			etc: &commandtest.ExecuteTestCase{
-				Args: []string{"abc", "def"},
-				Node: SerialNodes(
-					Arg[string]("s", testDesc),
-					If(
-						printArgsNode(),
-						func(i *command.Input, d *command.Data) bool {
-							return true
-						},
-					),
-					Arg[string]("s2", testDesc),
-				),
-				WantStdout: strings.Join([]string{
-					"s: abc",
-					"s2: def",
-					"",
-				}, "\n"),
+				Node: &SimpleNode{
+					Processor: Arg[int]("iArg", testDesc, Between(-3, 4, false)),
+				},
+				Args: []string{"5"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"s":  "abc",
-					"s2": "def",
+					"iArg": 5,
 				}},
+				WantStderr: "validation for \"iArg\" failed: [Between] value is greater than upper bound (4)\n",
+				WantErr:    fmt.Errorf("validation for \"iArg\" failed: [Between] value is greater than upper bound (4)"),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "abc"},
-						{Value: "def"},
+						{Value: "5"},
 					},
 				},
 			},
 		},
+		// Flag processors
 		{
-			name: "If does not run if function returns false",
+			name: "empty flag processor works",
 			etc: &commandtest.ExecuteTestCase{
-				Args: []string{"abc", "def"},
-				Node: SerialNodes(
-					Arg[string]("s", testDesc),
-					If(
-						printArgsNode(),
-						func(i *command.Input, d *command.Data) bool {
-							return false
-						},
-					),
-					Arg[string]("s2", testDesc),
-				),
-				WantData: &command.Data{Values: map[string]interface{}{
-					"s":  "abc",
-					"s2": "def",
-				}},
+				Node: &SimpleNode{Processor: FlagProcessor()},
+			},
+		},
+		{
+			name: "flag processor allows empty",
+			etc: &commandtest.ExecuteTestCase{
+				Node: &SimpleNode{Processor: FlagProcessor(Flag[string]("strFlag", 'f', testDesc))},
+			},
+		},
+		{
+			name: "flag processor fails if no argument",
+			etc: &commandtest.ExecuteTestCase{
+				Node:       &SimpleNode{Processor: FlagProcessor(Flag[string]("strFlag", 'f', testDesc))},
+				Args:       []string{"--strFlag"},
+				WantStderr: "Argument \"strFlag\" requires at least 1 argument, got 0\n",
+				WantErr:    fmt.Errorf(`Argument "strFlag" requires at least 1 argument, got 0`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsUsageError:         true,
+				WantIsNotEnoughArgsError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "abc"},
-						{Value: "def"},
+						{Value: "--strFlag"},
 					},
 				},
 			},
 		},
-		// IfData tests
 		{
-			name: "IfData runs if variable is present",
+			name: "flag processor parses flag",
 			etc: &commandtest.ExecuteTestCase{
-				Args: []string{"abc"},
-				Node: SerialNodes(
-					OptionalArg[string]("s", testDesc),
-					IfData("s", printlnNode(true, "hello")),
-				),
-				WantStdout: "hello\n",
+				Node: &SimpleNode{Processor: FlagProcessor(Flag[string]("strFlag", 'f', testDesc))},
+				Args: []string{"--strFlag", "hello"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"s": "abc",
+					"strFlag": "hello",
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "abc"},
+						{Value: "--strFlag"},
+						{Value: "hello"},
 					},
 				},
 			},
 		},
 		{
-			name: "IfData runs if bool variable is present and true",
+			name: "flag processor parses short name flag",
 			etc: &commandtest.ExecuteTestCase{
-				Args: []string{"true"},
-				Node: SerialNodes(
-					OptionalArg[bool]("b", testDesc),
-					IfData("b", printlnNode(true, "hello")),
-				),
-				WantStdout: "hello\n",
+				Node: &SimpleNode{Processor: FlagProcessor(Flag[string]("strFlag", 'f', testDesc))},
+				Args: []string{"-f", "hello"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"b": true,
+					"strFlag": "hello",
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "true"},
+						{Value: "-f"},
+						{Value: "hello"},
 					},
 				},
 			},
 		},
 		{
-			name: "IfData does not run if variable is not present",
+			name: "flag processor handles FlagNoShortName",
 			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					OptionalArg[string]("s", testDesc),
-					IfData("s", printlnNode(true, "hello")),
-				),
+				Node: &SimpleNode{Processor: FlagProcessor(Flag[string]("strFlag", FlagNoShortName, testDesc))},
+				Args: []string{"--strFlag", "hello"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"strFlag": "hello",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "--strFlag"},
+						{Value: "hello"},
+					},
+				},
 			},
 		},
 		{
-			name: "IfData does not run if bool variable is present and false",
+			name: "flag processor parses flag in the middle",
 			etc: &commandtest.ExecuteTestCase{
-				Args: []string{"false"},
 				Node: SerialNodes(
-					OptionalArg[bool]("b", testDesc),
-					IfData("b", printlnNode(true, "hello")),
+					FlagProcessor(Flag[string]("strFlag", 'f', testDesc)),
+					ListArg[string]("filler", testDesc, 1, 2),
 				),
+				Args: []string{"un", "--strFlag", "hello", "deux"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"b": false,
+					"strFlag": "hello",
+					"filler":  []string{"un", "deux"},
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "false"},
+						{Value: "un"},
+						{Value: "--strFlag"},
+						{Value: "hello"},
+						{Value: "deux"},
 					},
 				},
 			},
 		},
-		// IfElseData tests
 		{
-			name: "IfElseData runs t if variable is present",
+			name: "flag processor parses short name flag",
 			etc: &commandtest.ExecuteTestCase{
-				Args: []string{"abc"},
 				Node: SerialNodes(
-					OptionalArg[string]("s", testDesc),
-					IfElseData(
-						"s",
-						printlnNode(true, "hello"),
-						printlnNode(true, "goodbye"),
-					),
+					FlagProcessor(Flag[string]("strFlag", 'f', testDesc)),
+					ListArg[string]("filler", testDesc, 1, 2),
 				),
-				WantStdout: "hello\n",
+				Args: []string{"uno", "dos", "-f", "hello"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"s": "abc",
+					"filler":  []string{"uno", "dos"},
+					"strFlag": "hello",
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "abc"},
+						{Value: "uno"},
+						{Value: "dos"},
+						{Value: "-f"},
+						{Value: "hello"},
 					},
 				},
 			},
 		},
+		// Int flag
 		{
-			name: "IfElseData runs t if bool variable is present and true",
+			name: "parses int flag",
 			etc: &commandtest.ExecuteTestCase{
-				Args: []string{"true"},
 				Node: SerialNodes(
-					OptionalArg[bool]("b", testDesc),
-					IfElseData(
-						"b",
-						printlnNode(true, "hello"),
-						printlnNode(true, "goodbye"),
-					),
+					FlagProcessor(Flag[int]("intFlag", 'f', testDesc)),
+					ListArg[string]("filler", testDesc, 1, 2),
 				),
-				WantStdout: "hello\n",
+				Args: []string{"un", "deux", "-f", "3", "quatre"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"b": true,
+					"filler":  []string{"un", "deux", "quatre"},
+					"intFlag": 3,
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "true"},
+						{Value: "un"},
+						{Value: "deux"},
+						{Value: "-f"},
+						{Value: "3"},
+						{Value: "quatre"},
 					},
 				},
 			},
 		},
 		{
-			name: "IfElseData runs f if variable is not present",
+			name: "handles invalid int flag value",
 			etc: &commandtest.ExecuteTestCase{
 				Node: SerialNodes(
-					OptionalArg[string]("s", testDesc),
-					IfElseData(
-						"s",
-						printlnNode(true, "hello"),
-						printlnNode(true, "goodbye"),
-					),
+					FlagProcessor(Flag[int]("intFlag", 'f', testDesc)),
+					ListArg[string]("filler", testDesc, 1, 2),
 				),
-				WantStdout: "goodbye\n",
+				Args:       []string{"un", "deux", "-f", "trois", "quatre"},
+				WantStderr: "strconv.Atoi: parsing \"trois\": invalid syntax\n",
+				WantErr:    fmt.Errorf(`strconv.Atoi: parsing "trois": invalid syntax`),
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "un"},
+						{Value: "deux"},
+						{Value: "-f"},
+						{Value: "trois"},
+						{Value: "quatre"},
+					},
+					Remaining: []int{0, 1, 4},
+				},
 			},
 		},
+		// Float flag
 		{
-			name: "IfData runs f if bool variable is present and false",
+			name: "parses float flag",
 			etc: &commandtest.ExecuteTestCase{
-				Args: []string{"false"},
 				Node: SerialNodes(
-					OptionalArg[bool]("b", testDesc),
-					IfElseData(
-						"b",
-						printlnNode(true, "hello"),
-						printlnNode(true, "goodbye"),
-					),
+					FlagProcessor(Flag[float64]("floatFlag", 'f', testDesc)),
+					ListArg[string]("filler", testDesc, 1, 2),
 				),
-				WantStdout: "goodbye\n",
+				Args: []string{"--floatFlag", "-1.2", "three"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"b": false,
+					"filler":    []string{"three"},
+					"floatFlag": -1.2,
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "false"},
+						{Value: "--floatFlag"},
+						{Value: "-1.2"},
+						{Value: "three"},
 					},
 				},
 			},
 		},
-		// EchoExecuteData
-		{
-			name: "EchoExecuteData ignores empty command.ExecuteData.Executable",
-			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					EchoExecuteData(),
-				),
-			},
-		},
 		{
-			name: "EchoExecuteData outputs command.ExecuteData.Executable",
+			name: "handles invalid float flag value",
 			etc: &commandtest.ExecuteTestCase{
 				Node: SerialNodes(
-					SimpleExecutableProcessor("un", "deux", "trois"),
-					EchoExecuteData(),
+					FlagProcessor(Flag[float64]("floatFlag", 'f', testDesc)),
+					ListArg[string]("filler", testDesc, 1, 2),
 				),
-				WantExecuteData: &command.ExecuteData{
-					Executable: []string{"un", "deux", "trois"},
-				},
-				WantStdout: "un\ndeux\ntrois\n",
+				Args:       []string{"--floatFlag", "twelve", "eleven"},
+				WantStderr: "strconv.ParseFloat: parsing \"twelve\": invalid syntax\n",
+				WantErr:    fmt.Errorf(`strconv.ParseFloat: parsing "twelve": invalid syntax`),
 			},
-		},
-		{
-			name: "EchoExecuteData outputs command.ExecuteData.Executable to stderr",
-			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					SimpleExecutableProcessor("un", "deux", "trois"),
-					&EchoExecuteDataProcessor{
-						Stderr: true,
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "--floatFlag"},
+						{Value: "twelve"},
+						{Value: "eleven"},
 					},
-				),
-				WantExecuteData: &command.ExecuteData{
-					Executable: []string{"un", "deux", "trois"},
+					Remaining: []int{2},
 				},
-				WantStderr: "un\ndeux\ntrois\n",
-			},
-		},
-		{
-			name: "EchoExecuteDataf ignores empty command.ExecuteData.Executable",
-			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					EchoExecuteDataf("RUNNING CODE:\n%s\nDONE CODE\n"),
-				),
 			},
 		},
+		// Bool flag
 		{
-			name: "EchoExecuteData outputs command.ExecuteData.Executable",
+			name: "bool flag",
 			etc: &commandtest.ExecuteTestCase{
 				Node: SerialNodes(
-					SimpleExecutableProcessor("un", "deux", "trois"),
-					EchoExecuteDataf("RUNNING CODE:\n%s\nDONE CODE\n"),
+					FlagProcessor(BoolFlag("boolFlag", 'b', testDesc)),
+					ListArg[string]("filler", testDesc, 1, 2),
 				),
-				WantExecuteData: &command.ExecuteData{
-					Executable: []string{"un", "deux", "trois"},
-				},
-				WantStdout: strings.Join([]string{
-					"RUNNING CODE:",
-					"un",
-					"deux",
-					"trois",
-					"DONE CODE",
-					"",
-				}, "\n"),
+				Args: []string{"okay", "--boolFlag", "then"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"filler":   []string{"okay", "then"},
+					"boolFlag": true,
+				}},
 			},
-		},
-		{
-			name: "EchoExecuteData outputs command.ExecuteData.Executable to stderr",
-			etc: &commandtest.ExecuteTestCase{
-				Node: SerialNodes(
-					SimpleExecutableProcessor("un", "deux", "trois"),
-					&EchoExecuteDataProcessor{
-						Stderr: true,
-						Format: "RUNNING CODE:\n%s\nDONE CODE\n",
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "okay"},
+						{Value: "--boolFlag"},
+						{Value: "then"},
 					},
-				),
-				WantExecuteData: &command.ExecuteData{
-					Executable: []string{"un", "deux", "trois"},
 				},
-				WantStderr: strings.Join([]string{
-					"RUNNING CODE:",
-					"un",
-					"deux",
-					"trois",
-					"DONE CODE",
-					"",
-				}, "\n"),
 			},
 		},
-		// MapArg tests
 		{
-			name: "MapArg converts a value with allowMissing=true",
+			name: "short bool flag",
 			etc: &commandtest.ExecuteTestCase{
 				Node: SerialNodes(
-					MapArg("m", testDesc, map[string]int{
-						"one":   1,
-						"two":   2,
-						"three": 3,
-					}, true),
+					FlagProcessor(BoolFlag("boolFlag", 'b', testDesc)),
+					ListArg[string]("filler", testDesc, 1, 2),
 				),
-				Args: []string{"two"},
+				Args: []string{"okay", "-b", "then"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"m": 2,
+					"filler":   []string{"okay", "then"},
+					"boolFlag": true,
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "two"},
+						{Value: "okay"},
+						{Value: "-b"},
+						{Value: "then"},
 					},
 				},
 			},
 		},
+		// flag list tests
 		{
-			name: "MapArg converts a value with allowMissing=false",
+			name: "flag list works",
 			etc: &commandtest.ExecuteTestCase{
 				Node: SerialNodes(
-					MapArg("m", testDesc, map[string]int{
-						"one":   1,
-						"two":   2,
-						"three": 3,
-					}, false),
+					FlagProcessor(ListFlag[string]("slFlag", 's', testDesc, 2, 3)),
+					ListArg[string]("filler", testDesc, 1, 2),
 				),
-				Args: []string{"two"},
+				Args: []string{"un", "--slFlag", "hello", "there"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"m": 2,
+					"filler": []string{"un"},
+					"slFlag": []string{"hello", "there"},
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "two"},
+						{Value: "un"},
+						{Value: "--slFlag"},
+						{Value: "hello"},
+						{Value: "there"},
 					},
 				},
 			},
 		},
 		{
-			name: "MapArg converts to default value if allow missing",
+			name: "flag list fails if not enough",
 			etc: &commandtest.ExecuteTestCase{
 				Node: SerialNodes(
-					MapArg("m", testDesc, map[string]int{
-						"one":   1,
-						"two":   2,
-						"three": 3,
-					}, true),
+					FlagProcessor(ListFlag[string]("slFlag", 's', testDesc, 2, 3)),
+					ListArg[string]("filler", testDesc, 1, 2),
 				),
-				Args: []string{"four"},
+				Args:       []string{"un", "--slFlag", "hello"},
+				WantStderr: "Argument \"slFlag\" requires at least 2 arguments, got 1\n",
+				WantErr:    fmt.Errorf(`Argument "slFlag" requires at least 2 arguments, got 1`),
 				WantData: &command.Data{Values: map[string]interface{}{
-					"m": 0,
+					"slFlag": []string{"hello"},
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsUsageError:         true,
+				WantIsNotEnoughArgsError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "four"},
+						{Value: "un"},
+						{Value: "--slFlag"},
+						{Value: "hello"},
 					},
+					Remaining: []int{0},
 				},
 			},
 		},
+		// Int list
 		{
-			name: "MapArg fails if allow missing is false",
+			name: "int list works",
 			etc: &commandtest.ExecuteTestCase{
 				Node: SerialNodes(
-					MapArg("m", testDesc, map[string]int{
-						"one":   1,
-						"two":   2,
-						"three": 3,
-					}, false),
+					FlagProcessor(ListFlag[int]("ilFlag", 'i', testDesc, 2, 3)),
+					ListArg[string]("filler", testDesc, 1, 2),
 				),
-				Args:       []string{"four"},
-				WantStderr: "validation for \"m\" failed: [MapArg] key (four) is not in map; expected one of [one three two]\n",
-				WantErr:    fmt.Errorf("validation for \"m\" failed: [MapArg] key (four) is not in map; expected one of [one three two]"),
+				Args: []string{"un", "-i", "2", "4", "8", "16", "32", "64"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"m": 0,
+					"filler": []string{"un", "64"},
+					"ilFlag": []int{2, 4, 8, 16, 32},
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
-				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "four"},
+						{Value: "un"},
+						{Value: "-i"},
+						{Value: "2"},
+						{Value: "4"},
+						{Value: "8"},
+						{Value: "16"},
+						{Value: "32"},
+						{Value: "64"},
 					},
 				},
 			},
 		},
 		{
-			name: "MapArg fails if allow missing is false",
+			name: "int list transform failure",
 			etc: &commandtest.ExecuteTestCase{
 				Node: SerialNodes(
-					MapArg("m", testDesc, map[string]int{
-						"one":   1,
-						"two":   2,
-						"three": 3,
-					}, false),
+					FlagProcessor(ListFlag[int]("ilFlag", 'i', testDesc, 2, 3)),
+					ListArg[string]("filler", testDesc, 1, 2),
 				),
-				Args:       []string{"four"},
-				WantStderr: "validation for \"m\" failed: [MapArg] key (four) is not in map; expected one of [one three two]\n",
-				WantErr:    fmt.Errorf("validation for \"m\" failed: [MapArg] key (four) is not in map; expected one of [one three two]"),
-				WantData: &command.Data{Values: map[string]interface{}{
-					"m": 0,
-				}},
+				Args:       []string{"un", "-i", "2", "4", "8", "16.0", "32", "64"},
+				WantStderr: "strconv.Atoi: parsing \"16.0\": invalid syntax\n",
+				WantErr:    fmt.Errorf(`strconv.Atoi: parsing "16.0": invalid syntax`),
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
-				WantIsValidationError: true,
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "four"},
+						{Value: "un"},
+						{Value: "-i"},
+						{Value: "2"},
+						{Value: "4"},
+						{Value: "8"},
+						{Value: "16.0"},
+						{Value: "32"},
+						{Value: "64"},
 					},
+					Remaining: []int{0, 7},
 				},
 			},
 		},
+		// Float list
 		{
-			name: "MapArg works with custom types",
+			name: "float list works",
 			etc: &commandtest.ExecuteTestCase{
 				Node: SerialNodes(
-					MapArg("m", testDesc, map[string]struct {
-						A int
-						B float64
-					}{
-						"one":   {1, 1.0},
-						"two":   {2, 2.0},
-						"three": {3, 3.0},
-					}, true),
+					FlagProcessor(ListFlag[float64]("flFlag", 'f', testDesc, 0, 3)),
+					ListArg[string]("filler", testDesc, 1, 3),
 				),
-				Args: []string{"three"},
+				Args: []string{"un", "-f", "2", "-4.4", "0.8", "16.16", "-32", "64"},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"m": struct {
-						A int
-						B float64
-					}{3, 3.0},
+					"filler": []string{"un", "16.16", "-32", "64"},
+					"flFlag": []float64{2, -4.4, 0.8},
 				}},
 			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "three"},
+						{Value: "un"},
+						{Value: "-f"},
+						{Value: "2"},
+						{Value: "-4.4"},
+						{Value: "0.8"},
+						{Value: "16.16"},
+						{Value: "-32"},
+						{Value: "64"},
 					},
 				},
 			},
 		},
 		{
-			name: "MapArg.Get, GetOrDefault, and GetKey works",
-			etc: func() *commandtest.ExecuteTestCase {
-				ma := MapArg("m", testDesc, map[string]int{
-					"one":   1,
-					"two":   2,
-					"three": 3,
-				}, true)
-				otherMa := MapArg("m2", testDesc, map[string]int{
-					"one":   1,
-					"two":   2,
-					"three": 3,
-				}, true)
-
-				return &commandtest.ExecuteTestCase{
-					Node: SerialNodes(
-						ma,
-						SimpleProcessor(func(i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
-							o.Stdoutln(ma.GetKey())
-							o.Stdoutln(otherMa.GetKey())
-							o.Stdoutln(ma.Get(d))
-							o.Stdoutln(ma.GetOrDefault(d, 7))
-							o.Stdoutln(otherMa.Get(d))
-							o.Stdoutln(otherMa.GetOrDefault(d, 7))
-							return nil
-						}, nil),
-					),
-					Args: []string{"three"},
-					WantData: &command.Data{Values: map[string]interface{}{
-						"m": 3,
-					}},
-					WantStdout: strings.Join([]string{
-						"three", // ma.GetKey
-						"",      // otherMa.GetKey
-						"3",     // ma.Get
-						"3",     // ma.GetOrDefault
-						"0",     // otherMa.Get
-						"7",     // otherMa.GetOrDefault
-						"",
-					}, "\n"),
-				}
-			}(),
+			name: "float list transform failure",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(ListFlag[float64]("flFlag", 'f', testDesc, 0, 3)),
+					ListArg[string]("filler", testDesc, 1, 2),
+				),
+				Args:       []string{"un", "--flFlag", "2", "4", "eight", "16.0", "32", "64"},
+				WantStderr: "strconv.ParseFloat: parsing \"eight\": invalid syntax\n",
+				WantErr:    fmt.Errorf(`strconv.ParseFloat: parsing "eight": invalid syntax`),
+			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "three"},
+						{Value: "un"},
+						{Value: "--flFlag"},
+						{Value: "2"},
+						{Value: "4"},
+						{Value: "eight"},
+						{Value: "16.0"},
+						{Value: "32"},
+						{Value: "64"},
 					},
+					Remaining: []int{0, 5, 6, 7},
 				},
 			},
 		},
+		// Flag overlapping tests
 		{
-			name: "MapArg.Get, GetOrDefault, and GetKey works with custom type",
-			etc: func() *commandtest.ExecuteTestCase {
-				type vType struct {
-					A int
-					B float64
-				}
-				ma := MapArg("m", testDesc, map[string]*vType{
-					"one":   {1, 1.1},
-					"two":   {2, 2.2},
-					"three": {3, 3.3},
-				}, true)
-				otherMa := MapArg("m2", testDesc, map[string]*vType{
-					"one":   {1, 1.1},
-					"two":   {2, 2.2},
-					"three": {3, 3.3},
-				}, true)
-				missingMa := MapArg("m3", testDesc, map[string]*vType{
-					"one":   {1, 1.1},
-					"two":   {2, 2.2},
-					"three": {3, 3.3},
-				}, true)
-
-				return &commandtest.ExecuteTestCase{
-					Node: SerialNodes(
-						ma,
-						missingMa,
-						SimpleProcessor(func(i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
-							o.Stdoutln("GetKey", ma.GetKey())
-							o.Stdoutln("Provided", ma.Provided(d))
-							o.Stdoutln("Get", ma.Get(d))
-							o.Stdoutln("GetOrDefault", ma.GetOrDefault(d, &vType{7, 7.7}))
-							o.Stdoutln("Hit", ma.Hit())
-							o.Stdoutln("===")
-							o.Stdoutln("GetKey", otherMa.GetKey())
-							o.Stdoutln("Provided", otherMa.Provided(d))
-							o.Stdoutln("Get", otherMa.Get(d))
-							o.Stdoutln("GetOrDefault", otherMa.GetOrDefault(d, &vType{7, 7.7}))
-							o.Stdoutln("Hit", otherMa.Hit())
-							o.Stdoutln("===")
-							o.Stdoutln("GetKey", missingMa.GetKey())
-							o.Stdoutln("Provided", missingMa.Provided(d))
-							o.Stdoutln("Get", missingMa.Get(d))
-							o.Stdoutln("GetOrDefault", missingMa.GetOrDefault(d, &vType{7, 7.7}))
-							o.Stdoutln("Hit", missingMa.Hit())
-							return nil
-						}, nil),
+			name: "flags don't eat other flags",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						ListFlag[string]("alpha", 'a', testDesc, 0, command.UnboundedList),
+						ListFlag[string]("bravo", 'b', testDesc, 0, command.UnboundedList),
+						ListFlag[string]("charlie", 'c', testDesc, 0, command.UnboundedList),
 					),
-					Args: []string{"three", "eleven"},
-					WantData: &command.Data{Values: map[string]interface{}{
-						"m":  &vType{3, 3.3},
-						"m3": (*vType)(nil),
-					}},
-					WantStdout: strings.Join([]string{
-						"GetKey three",
-						"Provided true",
-						"Get &{3 3.3}",
-						"GetOrDefault &{3 3.3}",
-						"Hit true",
-						"===",
-						"GetKey ",
-						"Provided false",
-						"Get <nil>",
-						"GetOrDefault &{7 7.7}",
-						"Hit false",
-						"===",
-						"GetKey eleven",
-						"Provided true",
-						"Get <nil>",
-						"GetOrDefault <nil>",
-						"Hit false",
-						"",
-					}, "\n"),
-				}
-			}(),
+				),
+				Args: []string{"--alpha", "hey", "there", "--dude", "--bravo", "yay", "--charlie"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"alpha": []string{"hey", "there", "--dude"},
+					"bravo": []string{"yay"},
+				}},
+			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "three"},
-						{Value: "eleven"},
+						{Value: "--alpha"},
+						{Value: "hey"},
+						{Value: "there"},
+						{Value: "--dude"},
+						{Value: "--bravo"},
+						{Value: "yay"},
+						{Value: "--charlie"},
 					},
 				},
 			},
 		},
-		// MapFlag
 		{
-			name: "MapFlag.Get, Provided, GetOrDefault, and GetKey works with custom type for flag",
-			etc: func() *commandtest.ExecuteTestCase {
-				type vType struct {
-					A int
-					B float64
-				}
-				ma := MapFlag("m1", 'm', testDesc, map[string]*vType{
-					"one":   {1, 1.1},
-					"two":   {2, 2.2},
-					"three": {3, 3.3},
-				}, true)
-				otherMa := MapFlag("m2", 'd', testDesc, map[string]*vType{
-					"one":   {1, 1.1},
-					"two":   {2, 2.2},
-					"three": {3, 3.3},
-				}, true)
-				missingMa := MapFlag("m3", FlagNoShortName, testDesc, map[string]*vType{
-					"one":   {1, 1.1},
-					"two":   {2, 2.2},
-					"three": {3, 3.3},
-				}, true)
-
-				return &commandtest.ExecuteTestCase{
-					Node: SerialNodes(
-						FlagProcessor(
-							ma,
-							otherMa,
-							missingMa,
-						),
-						SimpleProcessor(func(i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
-							o.Stdoutln("GetKey", ma.GetKey())
-							o.Stdoutln("Provided", ma.Provided(d))
-							o.Stdoutln("Get", ma.Get(d))
-							o.Stdoutln("GetOrDefault", ma.GetOrDefault(d, &vType{7, 7.7}))
-							o.Stdoutln("Hit", ma.Hit())
-							o.Stdoutln("===")
-							o.Stdoutln("GetKey", otherMa.GetKey())
-							o.Stdoutln("Get", otherMa.Get(d))
-							o.Stdoutln("Provided", otherMa.Provided(d))
-							o.Stdoutln("GetOrDefault", otherMa.GetOrDefault(d, &vType{7, 7.7}))
-							o.Stdoutln("Hit", otherMa.Hit())
-							o.Stdoutln("===")
-							o.Stdoutln("GetKey", missingMa.GetKey())
-							o.Stdoutln("Get", missingMa.Get(d))
-							o.Stdoutln("Provided", missingMa.Provided(d))
-							o.Stdoutln("GetOrDefault", missingMa.GetOrDefault(d, &vType{7, 7.7}))
-							o.Stdoutln("Hit", missingMa.Hit())
-							return nil
-						}, nil),
+			name: "flags don't eat other short flags",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						ListFlag[string]("alpha", 'a', testDesc, 0, command.UnboundedList),
+						ListFlag[string]("bravo", 'b', testDesc, 0, command.UnboundedList),
+						ListFlag[string]("charlie", 'c', testDesc, 0, command.UnboundedList),
 					),
-					Args: []string{"-m", "three", "--m3", "eleven"},
-					WantData: &command.Data{Values: map[string]interface{}{
-						"m1": &vType{3, 3.3},
-						"m3": (*vType)(nil),
-					}},
-					WantStdout: strings.Join([]string{
-						"GetKey three",
-						"Provided true",
-						"Get &{3 3.3}",
-						"GetOrDefault &{3 3.3}",
-						"Hit true",
-						"===",
-						"GetKey ",
-						"Get <nil>",
-						"Provided false",
-						"GetOrDefault &{7 7.7}",
-						"Hit false",
-						"===",
-						"GetKey eleven",
-						"Get <nil>",
-						"Provided true",
-						"GetOrDefault <nil>",
-						"Hit false",
-						"",
-					}, "\n"),
-				}
-			}(),
+				),
+				Args: []string{"-a", "hey", "there", "-d", "-b", "yay", "-c"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"alpha": []string{"hey", "there", "-d"},
+					"bravo": []string{"yay"},
+				}},
+			},
 			ietc: &spycommandtest.ExecuteTestCase{
 				WantInput: &spycommandtest.SpyInput{
 					Args: []*spycommand.InputArg{
-						{Value: "-m"},
-						{Value: "three"},
-						{Value: "--m3"},
-						{Value: "eleven"},
+						{Value: "-a"},
+						{Value: "hey"},
+						{Value: "there"},
+						{Value: "-d"},
+						{Value: "-b"},
+						{Value: "yay"},
+						{Value: "-c"},
 					},
 				},
 			},
 		},
-		// command.Usage tests
 		{
-			name: "works with single arg",
+			name: "flags don't eat valid multi flags",
 			etc: &commandtest.ExecuteTestCase{
-				Args: []string{"--help"},
-				Node: SerialNodes(Arg[string]("SARG", "desc")),
-				WantStdout: strings.Join([]string{
-					"SARG",
-					"",
-					"Arguments:",
-					"  SARG: desc",
-					"",
-				}, "\n"),
+				Node: SerialNodes(
+					FlagProcessor(
+						ListFlag[string]("alpha", 'a', testDesc, 0, command.UnboundedList),
+						BoolFlag("Q", 'q', testDesc),
+						BoolFlag("W", 'w', testDesc),
+						BoolFlag("E", 'e', testDesc),
+						BoolFlag("R", 'r', testDesc),
+					),
+				),
+				Args: []string{"-a", "hey", "there", "-qwer"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"alpha": []string{"hey", "there"},
+					"Q":     true,
+					"W":     true,
+					"E":     true,
+					"R":     true,
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "-a"},
+						{Value: "hey"},
+						{Value: "there"},
+						{Value: "-qwer"},
+					},
+				},
 			},
 		},
-		// Panic tests
 		{
-			name: "forwards panic",
+			name: "flags eat invalid multi flags",
 			etc: &commandtest.ExecuteTestCase{
 				Node: SerialNodes(
-					&ExecutorProcessor{func(o command.Output, d *command.Data) error {
-						panic("oh no!")
-					}},
+					FlagProcessor(
+						ListFlag[string]("alpha", 'a', testDesc, 0, command.UnboundedList),
+						BoolFlag("Q", 'q', testDesc),
+						BoolFlag("W", 'w', testDesc),
+						BoolFlag("E", 'e', testDesc),
+						BoolFlag("R", 'r', testDesc),
+					),
 				),
-				WantPanic: "oh no!",
+				Args: []string{"-a", "hey", "there", "-qwert"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"alpha": []string{"hey", "there", "-qwert"},
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "-a"},
+						{Value: "hey"},
+						{Value: "there"},
+						{Value: "-qwert"},
+					},
+				},
 			},
 		},
-		// MutableProcessor tests
+		// Misc. flag tests
 		{
-			name: "Reference does not update underlying processor",
-			etc: func() *commandtest.ExecuteTestCase {
-				hi := PrintlnProcessor("hi")
-				hello := PrintlnProcessor("hello")
-
-				return &commandtest.ExecuteTestCase{
-					Node: SerialNodes(
-						SuperSimpleProcessor(func(i *command.Input, d *command.Data) error {
-							hi = hello
-							return nil
-						}),
-						hi,
-						SuperSimpleProcessor(func(i *command.Input, d *command.Data) error {
-							fo := commandtest.NewOutput()
-							if err := hi.Execute(nil, fo, nil, nil); err != nil {
-								return err
-							}
-							fo.Close()
-							d.Set("FINAL", fo.GetStdout())
-							return nil
-						}),
+			name: "processes multiple flags",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						ListFlag[float64]("coordinates", 'c', testDesc, 2, 0),
+						BoolFlag("boo", 'b', testDesc),
+						ListFlag[string]("names", 'n', testDesc, 1, 2),
+						Flag[int]("rating", 'r', testDesc),
 					),
-					WantStdout: "hi\n",
-					WantData: &command.Data{Values: map[string]interface{}{
-						"FINAL": "hello\n",
-					}},
-				}
-			}(),
+					ListArg[string]("extra", testDesc, 0, command.UnboundedList),
+				),
+				Args: []string{"its", "--boo", "a", "-r", "9", "secret", "-n", "greggar", "groog", "beggars", "--coordinates", "2.2", "4.4", "message."},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"boo":         true,
+					"extra":       []string{"its", "a", "secret", "message."},
+					"names":       []string{"greggar", "groog", "beggars"},
+					"coordinates": []float64{2.2, 4.4},
+					"rating":      9,
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "its"},
+						{Value: "--boo"},
+						{Value: "a"},
+						{Value: "-r"},
+						{Value: "9"},
+						{Value: "secret"},
+						{Value: "-n"},
+						{Value: "greggar"},
+						{Value: "groog"},
+						{Value: "beggars"},
+						{Value: "--coordinates"},
+						{Value: "2.2"},
+						{Value: "4.4"},
+						{Value: "message."},
+					},
+				},
+			},
 		},
+		// FlagStop tests
 		{
-			name: "MutableProcessor DOES update underlying processor",
-			etc: func() *commandtest.ExecuteTestCase {
-				hi := NewMutableProcessor[command.Processor](PrintlnProcessor("hi"))
-				hello := PrintlnProcessor("hello")
-
-				return &commandtest.ExecuteTestCase{
-					Node: SerialNodes(
-						SuperSimpleProcessor(func(i *command.Input, d *command.Data) error {
-							hi.Processor = &hello
-							return nil
-						}),
-						hi,
-						SuperSimpleProcessor(func(i *command.Input, d *command.Data) error {
-							fo := commandtest.NewOutput()
-							if err := hi.Execute(nil, fo, nil, nil); err != nil {
-								return err
-							}
-							fo.Close()
-							d.Set("FINAL", fo.GetStdout())
-							return nil
-						}),
+			name: "stops processing flags after FlagStop",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						ListFlag[float64]("coordinates", 'c', testDesc, 2, 0),
+						BoolFlag("boo", 'b', testDesc),
+						BoolFlag("yay", 'y', testDesc),
+						ListFlag[string]("names", 'n', testDesc, 1, 2),
+						Flag[int]("rating", 'r', testDesc),
 					),
-					WantStdout: "hello\n",
-					WantData: &command.Data{Values: map[string]interface{}{
-						"FINAL": "hello\n",
-					}},
-				}
-			}(),
+					ListArg[string]("extra", testDesc, 0, command.UnboundedList),
+				),
+				Args: []string{"its", "-b", "a", "-r", "9", "--", "secret", "--yay", "-n", "greggar", "groog", "beggars", "--coordinates", "2.2", "4.4", "message."},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"boo":    true,
+					"extra":  []string{"its", "a", "secret", "--yay", "-n", "greggar", "groog", "beggars", "--coordinates", "2.2", "4.4", "message."},
+					"rating": 9,
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "its"},
+						{Value: "-b"},
+						{Value: "a"},
+						{Value: "-r"},
+						{Value: "9"},
+						{Value: "--"},
+						{Value: "secret"},
+						{Value: "--yay"},
+						{Value: "-n"},
+						{Value: "greggar"},
+						{Value: "groog"},
+						{Value: "beggars"},
+						{Value: "--coordinates"},
+						{Value: "2.2"},
+						{Value: "4.4"},
+						{Value: "message."},
+					},
+				},
+			},
+		},
+		// BoolValueFlag
+		{
+			name: "BoolValueFlag works with true value",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						BoolValueFlag("light", 'l', testDesc, "hello there"),
+					),
+				),
+				Args: []string{"--light"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"light": "hello there",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "--light"},
+					},
+				},
+			},
+		},
+		{
+			name: "BoolValueFlag works with false value",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						BoolValueFlag("light", 'l', testDesc, "hello there"),
+					),
+				),
+			},
+		},
+		{
+			name: "BoolValuesFlag works with true value",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						BoolValuesFlag("light", 'l', testDesc, "hello there", "general kenobi"),
+					),
+				),
+				Args: []string{"--light"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"light": "hello there",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "--light"},
+					},
+				},
+			},
+		},
+		{
+			name: "BoolValuesFlag works with false value",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						BoolValuesFlag("light", 'l', testDesc, "hello there", "general kenobi"),
+					),
+				),
+				WantData: &command.Data{Values: map[string]interface{}{
+					"light": "general kenobi",
+				}},
+			},
+		},
+		// Multi-flag tests
+		{
+			name: "Multiple bool flags work as a multi-flag",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						BoolFlag("everyone", 'e', testDesc),
+						BoolFlag("quick", 'q', testDesc),
+						BoolValuesFlag("run", 'r', testDesc, "hello there", "general kenobi"),
+						BoolValueFlag("to", 't', testDesc, 123),
+						BoolValueFlag("where", 'w', testDesc, 4.56),
+					),
+				),
+				Args: []string{"-qwer"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"quick":    true,
+					"where":    4.56,
+					"everyone": true,
+					"run":      "hello there",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "-qwer"},
+					},
+				},
+			},
+		},
+		{
+			name: "Multi-flag fails if partial set of matches",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						BoolFlag("everyone", 'e', testDesc),
+						BoolFlag("quick", 'q', testDesc),
+						BoolFlag("run", 'r', testDesc),
+						BoolFlag("to", 't', testDesc),
+						BoolFlag("where", 'w', testDesc),
+					),
+				),
+				Args:       []string{"-qwy"},
+				WantStderr: "Either all or no flags in a multi-flag object must be relevant for a FlagProcessor group\n",
+				WantErr:    fmt.Errorf(`Either all or no flags in a multi-flag object must be relevant for a FlagProcessor group`),
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "-qwy"},
+					},
+					Remaining: []int{0},
+				},
+			},
+		},
+		{
+			name: "Multi-flags are ignored if no matches",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						BoolFlag("everyone", 'e', testDesc),
+						BoolFlag("quick", 'q', testDesc),
+						BoolFlag("run", 'r', testDesc),
+						BoolFlag("to", 't', testDesc),
+						BoolFlag("where", 'w', testDesc),
+					),
+					OptionalArg[string]("LEFTOVERS", testDesc),
+				),
+				Args: []string{"-nop"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"LEFTOVERS": "-nop",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "-nop"},
+					},
+					Remaining: []int{},
+				},
+			},
+		},
+		{
+			name: "Multi-flag fails if uncombinable flag",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						BoolFlag("everyone", 'e', testDesc),
+						BoolFlag("quick", 'q', testDesc),
+						BoolFlag("run", 'r', testDesc),
+						ListFlag[int]("two", 't', testDesc, 0, command.UnboundedList),
+						BoolFlag("where", 'w', testDesc),
+					),
+				),
+				Args: []string{"-ert"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"everyone": true,
+					"run":      true,
+				}},
+				WantStderr: "Flag \"two\" is not combinable\n",
+				WantErr:    fmt.Errorf(`Flag "two" is not combinable`),
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "-ert"},
+					},
+					Remaining: []int{0},
+				},
+			},
+		},
+		// Duplicate flag tests
+		{
+			name: "Duplicate flags get caught in multi-flag",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						BoolFlag("everyone", 'e', testDesc),
+						BoolFlag("quick", 'q', testDesc),
+						BoolValuesFlag("run", 'r', testDesc, "hello there", "general kenobi"),
+						BoolValueFlag("to", 't', testDesc, 123),
+						BoolValueFlag("where", 'w', testDesc, 4.56),
+					),
+				),
+				Args: []string{"-qwerq"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"quick":    true,
+					"where":    4.56,
+					"everyone": true,
+					"run":      "hello there",
+				}},
+				WantErr:    fmt.Errorf(`Flag "quick" has already been set`),
+				WantStderr: "Flag \"quick\" has already been set\n",
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "-qwerq"},
+					},
+					Remaining: []int{0},
+				},
+			},
+		},
+		{
+			name: "Duplicate flags get caught in regular flags",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						BoolFlag("everyone", 'e', testDesc),
+						BoolFlag("quick", 'q', testDesc),
+						BoolValuesFlag("run", 'r', testDesc, "hello there", "general kenobi"),
+						BoolValueFlag("to", 't', testDesc, 123),
+						BoolValueFlag("where", 'w', testDesc, 4.56),
+					),
+				),
+				Args: []string{"-q", "--quick"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"quick": true,
+				}},
+				WantErr:    fmt.Errorf(`Flag "quick" has already been set`),
+				WantStderr: "Flag \"quick\" has already been set\n",
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "-q"},
+						{Value: "--quick"},
+					},
+					Remaining: []int{1},
+				},
+			},
+		},
+		{
+			name: "Duplicate flags get caught when multi, then regular flag",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						BoolFlag("everyone", 'e', testDesc),
+						BoolFlag("quick", 'q', testDesc),
+						BoolValuesFlag("run", 'r', testDesc, "hello there", "general kenobi"),
+						BoolValueFlag("to", 't', testDesc, 123),
+						BoolValueFlag("where", 'w', testDesc, 4.56),
+					),
+				),
+				Args: []string{"-qwer", "--quick"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"quick":    true,
+					"where":    4.56,
+					"everyone": true,
+					"run":      "hello there",
+				}},
+				WantErr:    fmt.Errorf(`Flag "quick" has already been set`),
+				WantStderr: "Flag \"quick\" has already been set\n",
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "-qwer"},
+						{Value: "--quick"},
+					},
+					Remaining: []int{1},
+				},
+			},
+		},
+		{
+			name: "Duplicate flags get caught when regular, then multi flag",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						BoolFlag("everyone", 'e', testDesc),
+						BoolFlag("quick", 'q', testDesc),
+						BoolValuesFlag("run", 'r', testDesc, "hello there", "general kenobi"),
+						BoolValueFlag("to", 't', testDesc, 123),
+						BoolValueFlag("where", 'w', testDesc, 4.56),
+					),
+				),
+				Args: []string{"--quick", "-weqr"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"quick":    true,
+					"where":    4.56,
+					"everyone": true,
+				}},
+				WantErr:    fmt.Errorf(`Flag "quick" has already been set`),
+				WantStderr: "Flag \"quick\" has already been set\n",
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "--quick"},
+						{Value: "-weqr"},
+					},
+					Remaining: []int{1},
+				},
+			},
+		},
+		// OptionalFlag tests
+		{
+			name: "OptionalFlag sets if default if last argument",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						OptionalFlag("of", 'o', testDesc, "dfltValue"),
+					),
+				),
+				Args: []string{"--of"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"of": "dfltValue",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "--of"},
+					},
+				},
+			},
+		},
+		{
+			name: "OptionalFlag doesn't eat other flags",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						OptionalFlag("of", 'o', testDesc, "dfltValue"),
+						Flag[string]("sf", 's', testDesc),
+					),
+				),
+				Args: []string{"--of", "--sf", "hello"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"of": "dfltValue",
+					"sf": "hello",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "--of"},
+						{Value: "--sf"},
+						{Value: "hello"},
+					},
+				},
+			},
+		},
+		{
+			name: "OptionalFlag gets set",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						OptionalFlag("of", 'o', testDesc, "dfltValue"),
+					),
+				),
+				Args: []string{"--of", "other"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"of": "other",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "--of"},
+						{Value: "other"},
+					},
+				},
+			},
+		},
+		{
+			name: "OptionalFlag handles error",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						OptionalFlag("of", 'o', testDesc, 123),
+					),
+				),
+				Args:       []string{"--of", "not-a-number"},
+				WantErr:    fmt.Errorf(`strconv.Atoi: parsing "not-a-number": invalid syntax`),
+				WantStderr: "strconv.Atoi: parsing \"not-a-number\": invalid syntax\n",
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "--of"},
+						{Value: "not-a-number"},
+					},
+				},
+			},
+		},
+		// Required flag tests
+		{
+			name: "Required flag succeeds when provided",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						Flag[string]("name", 'n', testDesc, Required[string]()),
+						Flag[int]("count", 'c', testDesc, Default(2)),
+					),
+				),
+				Args: []string{"-n", "alice"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"name":  "alice",
+					"count": 2,
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "-n"},
+						{Value: "alice"},
+					},
+				},
+			},
+		},
+		{
+			name: "Required flag fails when missing",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						Flag[string]("name", 'n', testDesc, Required[string]()),
+						Flag[int]("count", 'c', testDesc, Default(2)),
+					),
+				),
+				Args:       []string{"-c", "3"},
+				WantStderr: "Flag \"name\" is required\n",
+				WantErr:    fmt.Errorf(`Flag "name" is required`),
+				WantData: &command.Data{Values: map[string]interface{}{
+					"count": 3,
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsUsageError: true,
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "-c"},
+						{Value: "3"},
+					},
+				},
+			},
+		},
+		{
+			name: "Required option added to existing flag",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						ListFlag[string]("tags", 't', testDesc, 1, command.UnboundedList).AddOptions(Required[[]string]()),
+					),
+				),
+				WantStderr: "Flag \"tags\" is required\n",
+				WantErr:    fmt.Errorf(`Flag "tags" is required`),
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsUsageError: true,
+			},
+		},
+		{
+			name: "Required itemized list flag fails when missing",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						ItemizedListFlag[string]("ilf", 'i', testDesc, Required[[]string]()),
+					),
+				),
+				WantStderr: "Flag \"ilf\" is required\n",
+				WantErr:    fmt.Errorf(`Flag "ilf" is required`),
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsUsageError: true,
+			},
+		},
+		// Time tests
+		{
+			name: "TimeArg parses RFC3339 by default",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(TimeArg("when", testDesc, "")),
+				Args: []string{"2023-04-05T06:07:08Z"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"when": time.Date(2023, 4, 5, 6, 7, 8, 0, time.UTC),
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "2023-04-05T06:07:08Z"}},
+				},
+			},
+		},
+		{
+			name: "TimeArg parses with custom layout",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(TimeArg("when", testDesc, time.DateOnly)),
+				Args: []string{"2023-04-05"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"when": time.Date(2023, 4, 5, 0, 0, 0, 0, time.UTC),
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "2023-04-05"}},
+				},
+			},
+		},
+		{
+			name: "TimeArg fails if value does not match layout",
+			etc: &commandtest.ExecuteTestCase{
+				Node:       SerialNodes(TimeArg("when", testDesc, time.DateOnly)),
+				Args:       []string{"April 5"},
+				WantErr:    fmt.Errorf(`validation for "when" failed: [TimeArg] "April 5" does not match layout "2006-01-02"`),
+				WantStderr: "validation for \"when\" failed: [TimeArg] \"April 5\" does not match layout \"2006-01-02\"\n",
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "April 5"}},
+				},
+			},
+		},
+		{
+			name: "TimeArg works with Before and After validators",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(TimeArg("when", testDesc, time.DateOnly,
+					After(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)),
+					Before(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+				)),
+				Args: []string{"2023-04-05"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"when": time.Date(2023, 4, 5, 0, 0, 0, 0, time.UTC),
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "2023-04-05"}},
+				},
+			},
+		},
+		{
+			name: "TimeArg fails Before validator",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(TimeArg("when", testDesc, time.DateOnly, Before(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)))),
+				Args: []string{"2023-01-01"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"when": time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+				}},
+				WantErr:    fmt.Errorf(`validation for "when" failed: [Before] value isn't before 2023-01-01 00:00:00 +0000 UTC`),
+				WantStderr: "validation for \"when\" failed: [Before] value isn't before 2023-01-01 00:00:00 +0000 UTC\n",
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "2023-01-01"}},
+				},
+			},
+		},
+		{
+			name: "TimeArg fails After validator",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(TimeArg("when", testDesc, time.DateOnly, After(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)))),
+				Args: []string{"2022-12-31"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"when": time.Date(2022, 12, 31, 0, 0, 0, 0, time.UTC),
+				}},
+				WantErr:    fmt.Errorf(`validation for "when" failed: [After] value isn't after 2023-01-01 00:00:00 +0000 UTC`),
+				WantStderr: "validation for \"when\" failed: [After] value isn't after 2023-01-01 00:00:00 +0000 UTC\n",
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "2022-12-31"}},
+				},
+			},
+		},
+		// Duration tests
+		{
+			name: "DurationArg parses duration",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(DurationArg("timeout", testDesc)),
+				Args: []string{"1h30m"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"timeout": 90 * time.Minute,
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "1h30m0s"}},
+				},
+			},
+		},
+		{
+			name: "DurationArg fails for invalid duration",
+			etc: &commandtest.ExecuteTestCase{
+				Node:       SerialNodes(DurationArg("timeout", testDesc)),
+				Args:       []string{"soon"},
+				WantErr:    fmt.Errorf(`time: invalid duration "soon"`),
+				WantStderr: "time: invalid duration \"soon\"\n",
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "soon"}},
+				},
+			},
+		},
+		{
+			name: "DurationArg works with numeric validators",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(DurationArg("timeout", testDesc, Positive[time.Duration](), LTE(time.Hour))),
+				Args: []string{"45m"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"timeout": 45 * time.Minute,
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "45m0s"}},
+				},
+			},
+		},
+		{
+			name: "DurationArg fails numeric validators",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(DurationArg("timeout", testDesc, Positive[time.Duration]())),
+				Args: []string{"-5s"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"timeout": -5 * time.Second,
+				}},
+				WantErr:    fmt.Errorf(`validation for "timeout" failed: [Positive] value isn't positive`),
+				WantStderr: "validation for \"timeout\" failed: [Positive] value isn't positive\n",
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "-5s"}},
+				},
+			},
+		},
+		{
+			name: "DurationFlag parses duration",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(FlagProcessor(
+					DurationFlag("interval", 'i', testDesc),
+					DurationFlag("timeout", 't', testDesc, Default(time.Minute)),
+				)),
+				Args: []string{"-i", "250ms"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"interval": 250 * time.Millisecond,
+					"timeout":  time.Minute,
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "-i"}, {Value: "250ms"}},
+				},
+			},
+		},
+		{
+			name: "DurationFlag fails for invalid duration",
+			etc: &commandtest.ExecuteTestCase{
+				Node:       SerialNodes(FlagProcessor(DurationFlag("interval", 'i', testDesc))),
+				Args:       []string{"--interval", "5"},
+				WantErr:    fmt.Errorf(`time: missing unit in duration "5"`),
+				WantStderr: "time: missing unit in duration \"5\"\n",
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "--interval"}, {Value: "5"}},
+				},
+			},
+		},
+		// LastWins tests
+		{
+			name: "repeated scalar flag fails without LastWins",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						Flag[string]("opt", 'o', testDesc),
+					),
+				),
+				Args: []string{"--opt", "a", "--opt", "b"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"opt": "a",
+				}},
+				WantErr:    fmt.Errorf(`Flag "opt" has already been set`),
+				WantStderr: "Flag \"opt\" has already been set\n",
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "--opt"},
+						{Value: "a"},
+						{Value: "--opt"},
+						{Value: "b"},
+					},
+					Remaining: []int{2, 3},
+				},
+			},
+		},
+		{
+			name: "repeated scalar flag uses last value with LastWins",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						Flag[string]("opt", 'o', testDesc, LastWins[string]()),
+						Flag[int]("num", 'n', testDesc),
+					),
+					OptionalArg[string]("pos", testDesc),
+				),
+				Args: []string{"--opt", "a", "-n", "3", "-o", "b", "positional", "--opt", "c"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"opt": "c",
+					"num": 3,
+					"pos": "positional",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "--opt"},
+						{Value: "a"},
+						{Value: "-n"},
+						{Value: "3"},
+						{Value: "-o"},
+						{Value: "b"},
+						{Value: "positional"},
+						{Value: "--opt"},
+						{Value: "c"},
+					},
+				},
+			},
+		},
+		{
+			name: "LastWins doesn't affect duplicate detection for other flags",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						Flag[string]("opt", 'o', testDesc, LastWins[string]()),
+						Flag[int]("num", 'n', testDesc),
+					),
+				),
+				Args: []string{"--opt", "a", "-n", "3", "--opt", "b", "-n", "4"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"opt": "b",
+					"num": 3,
+				}},
+				WantErr:    fmt.Errorf(`Flag "num" has already been set`),
+				WantStderr: "Flag \"num\" has already been set\n",
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "--opt"},
+						{Value: "a"},
+						{Value: "-n"},
+						{Value: "3"},
+						{Value: "--opt"},
+						{Value: "b"},
+						{Value: "-n"},
+						{Value: "4"},
+					},
+					Remaining: []int{6, 7},
+				},
+			},
+		},
+		// TreatEmptyAsUnset tests
+		{
+			name: "empty optional arg is stored without TreatEmptyAsUnset",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					OptionalArg[string]("name", testDesc, Default("bob")),
+				),
+				Args: []string{""},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"name": "",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: ""}},
+				},
+			},
+		},
+		{
+			name: "empty optional arg uses default with TreatEmptyAsUnset",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					OptionalArg[string]("name", testDesc, Default("bob"), TreatEmptyAsUnset[string]()),
+				),
+				Args: []string{""},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"name": "bob",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: ""}},
+				},
+			},
+		},
+		{
+			name: "empty optional arg is unset with TreatEmptyAsUnset and no default",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					OptionalArg[string]("name", testDesc, TreatEmptyAsUnset[string]()),
+				),
+				Args: []string{""},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: ""}},
+				},
+			},
+		},
+		{
+			name: "non-empty optional arg is stored with TreatEmptyAsUnset",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					OptionalArg[string]("name", testDesc, Default("bob"), TreatEmptyAsUnset[string]()),
+				),
+				Args: []string{"alice"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"name": "alice",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "alice"}},
+				},
+			},
+		},
+		{
+			name: "empty required arg fails with TreatEmptyAsUnset",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					Arg[string]("name", testDesc, TreatEmptyAsUnset[string]()),
+				),
+				Args:       []string{""},
+				WantStderr: "Argument \"name\" requires at least 1 argument, got 0\n",
+				WantErr:    fmt.Errorf(`Argument "name" requires at least 1 argument, got 0`),
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsUsageError:         true,
+				WantIsNotEnoughArgsError: true,
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: ""}},
+				},
+			},
+		},
+		{
+			name: "empty values are dropped from list args with TreatEmptyAsUnset",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					ListArg[string]("names", testDesc, 1, 3, TreatEmptyAsUnset[[]string]()),
+				),
+				Args: []string{"alice", "", "bob", ""},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"names": []string{"alice", "bob"},
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "alice"}, {Value: ""}, {Value: "bob"}, {Value: ""}},
+				},
+			},
+		},
+		{
+			name: "empty flag value is stored without TreatEmptyAsUnset",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						Flag[string]("name", 'n', testDesc, Default("bob")),
+					),
+				),
+				Args: []string{"-n", ""},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"name": "",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "-n"}, {Value: ""}},
+				},
+			},
+		},
+		{
+			name: "empty flag value uses default with TreatEmptyAsUnset",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						Flag[string]("name", 'n', testDesc, Default("bob"), TreatEmptyAsUnset[string]()),
+					),
+				),
+				Args: []string{"-n", ""},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"name": "bob",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "-n"}, {Value: ""}},
+				},
+			},
+		},
+		// WarnUnread tests
+		{
+			name: "WarnUnread warns about provided flags that are never read",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						Flag[string]("name", 'n', testDesc),
+						Flag[int]("count", 'c', testDesc),
+						BoolFlag("quiet", 'q', testDesc),
+					).WarnUnread(),
+					&ExecutorProcessor{func(o command.Output, d *command.Data) error {
+						o.Stdoutf("name: %s\n", d.String("name"))
+						return nil
+					}},
+				),
+				Args:       []string{"-n", "alice", "-c", "3", "-q"},
+				WantStdout: "name: alice\n",
+				WantStderr: strings.Join([]string{
+					`[WarnUnread] flag "count" was provided, but its value was never read`,
+					`[WarnUnread] flag "quiet" was provided, but its value was never read`,
+					"",
+				}, "\n"),
+				WantData: &command.Data{
+					Values: map[string]interface{}{
+						"name":  "alice",
+						"count": 3,
+						"quiet": true,
+					},
+				},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "-n"},
+						{Value: "alice"},
+						{Value: "-c"},
+						{Value: "3"},
+						{Value: "-q"},
+					},
+				},
+			},
+		},
+		{
+			name: "WarnUnread is silent if all provided flags are read",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						Flag[string]("name", 'n', testDesc),
+						Flag[int]("count", 'c', testDesc, Default(2)),
+						BoolFlag("quiet", 'q', testDesc),
+					).WarnUnread(),
+					&ExecutorProcessor{func(o command.Output, d *command.Data) error {
+						o.Stdoutf("name: %s\n", d.String("name"))
+						return nil
+					}},
+				),
+				Args:       []string{"-n", "alice"},
+				WantStdout: "name: alice\n",
+				WantData: &command.Data{
+					Values: map[string]interface{}{
+						"name":  "alice",
+						"count": 2,
+					},
+				},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "-n"},
+						{Value: "alice"},
+					},
+				},
+			},
+		},
+		{
+			name: "unread flags are ignored if WarnUnread isn't set",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						Flag[string]("name", 'n', testDesc),
+					),
+				),
+				Args: []string{"-n", "alice"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"name": "alice",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "-n"},
+						{Value: "alice"},
+					},
+				},
+			},
+		},
+		// PairListFlag tests
+		{
+			name: "PairListFlag stores pairs",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						PairListFlag("pairs", 'p', testDesc),
+						BoolFlag("verbose", 'v', testDesc),
+					),
+					&ExecutorProcessor{func(o command.Output, d *command.Data) error {
+						for _, p := range PairListFlag("pairs", 'p', testDesc).Get(d) {
+							o.Stdoutf("%s=%s\n", p[0], p[1])
+						}
+						return nil
+					}},
+				),
+				Args:       []string{"--pairs", "k1", "v1", "k2", "v2", "-v"},
+				WantStdout: "k1=v1\nk2=v2\n",
+				WantData: &command.Data{Values: map[string]interface{}{
+					"pairs":   [][2]string{{"k1", "v1"}, {"k2", "v2"}},
+					"verbose": true,
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "--pairs"},
+						{Value: "k1"},
+						{Value: "v1"},
+						{Value: "k2"},
+						{Value: "v2"},
+						{Value: "-v"},
+					},
+				},
+			},
+		},
+		{
+			name: "PairListFlag fails for an odd number of values",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						PairListFlag("pairs", 'p', testDesc),
+					),
+				),
+				Args:       []string{"-p", "k1", "v1", "k2"},
+				WantStderr: "validation for \"pairs\" failed: [PairListFlag] requires an even number of values; got 3\n",
+				WantErr:    fmt.Errorf(`validation for "pairs" failed: [PairListFlag] requires an even number of values; got 3`),
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "-p"},
+						{Value: "k1"},
+						{Value: "v1"},
+						{Value: "k2"},
+					},
+				},
+			},
+		},
+		{
+			name: "PairListFlag fails for a single value",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						PairListFlag("pairs", 'p', testDesc),
+					),
+				),
+				Args:       []string{"--pairs", "k1"},
+				WantStderr: "validation for \"pairs\" failed: [PairListFlag] requires an even number of values; got 1\n",
+				WantErr:    fmt.Errorf(`validation for "pairs" failed: [PairListFlag] requires an even number of values; got 1`),
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "--pairs"},
+						{Value: "k1"},
+					},
+				},
+			},
+		},
+		{
+			name: "PairListFlag supports options",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						PairListFlag("pairs", 'p', testDesc, Default([][2]string{{"dk", "dv"}})),
+					),
+				),
+				WantData: &command.Data{Values: map[string]interface{}{
+					"pairs": [][2]string{{"dk", "dv"}},
+				}},
+			},
+		},
+		{
+			name: "PairListFlag supports added options",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						PairListFlag("pairs", 'p', testDesc).AddOptions(
+							&ValidatorOption[[][2]string]{
+								func(pairs [][2]string, d *command.Data) error {
+									if len(pairs) > 1 {
+										return fmt.Errorf("at most one pair allowed")
+									}
+									return nil
+								},
+								"MaxOnePair()",
+							},
+						),
+					),
+				),
+				Args:       []string{"-p", "k1", "v1", "k2", "v2"},
+				WantStderr: "validation for \"pairs\" failed: at most one pair allowed\n",
+				WantErr:    fmt.Errorf(`validation for "pairs" failed: at most one pair allowed`),
+				WantData: &command.Data{Values: map[string]interface{}{
+					"pairs": [][2]string{{"k1", "v1"}, {"k2", "v2"}},
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "-p"},
+						{Value: "k1"},
+						{Value: "v1"},
+						{Value: "k2"},
+						{Value: "v2"},
+					},
+				},
+			},
+		},
+		// ItemizedListFlag tests
+		{
+			name: "Itemized list flag requires argument",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						ItemizedListFlag[string]("ilf", 'i', testDesc),
+					),
+				),
+				Args:       []string{"--ilf"},
+				WantErr:    fmt.Errorf("Argument \"ilf\" requires at least 1 argument, got 0"),
+				WantStderr: "Argument \"ilf\" requires at least 1 argument, got 0\n",
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsUsageError:         true,
+				WantIsNotEnoughArgsError: true,
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "--ilf"},
+					},
+				},
+			},
+		},
+		{
+			name: "Itemized list flag only takes one argument",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						ItemizedListFlag[string]("ilf", 'i', testDesc),
+					),
+					ListArg[string]("sl", testDesc, 0, command.UnboundedList),
+				),
+				Args: []string{"--ilf", "i1", "other"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"ilf": []string{"i1"},
+					"sl":  []string{"other"},
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "--ilf"},
+						{Value: "i1"},
+						{Value: "other"},
+					},
+				},
+			},
+		},
+		{
+			name: "Mixed itemized args",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						ItemizedListFlag[string]("ilf", 'i', testDesc),
+					),
+					ListArg[string]("sl", testDesc, 0, command.UnboundedList),
+				),
+				Args: []string{"--ilf", "i1", "other", "thing", "-i", "robot", "--ilf", "phone", "okay", "-i", "enough", "then"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"ilf": []string{"i1", "robot", "phone", "enough"},
+					"sl":  []string{"other", "thing", "okay", "then"},
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "--ilf"},
+						{Value: "i1"},
+						{Value: "other"},
+						{Value: "thing"},
+						{Value: "-i"},
+						{Value: "robot"},
+						{Value: "--ilf"},
+						{Value: "phone"},
+						{Value: "okay"},
+						{Value: "-i"},
+						{Value: "enough"},
+						{Value: "then"},
+					},
+				},
+			},
+		},
+		// Transformer tests.
+		{
+			name: "args get transformed",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					Arg[string]("strArg", testDesc, &Transformer[string]{F: func(v string, d *command.Data) (string, error) {
+						return strings.ToUpper(v), nil
+					}}),
+					Arg[int]("intArg", testDesc, &Transformer[int]{F: func(v int, d *command.Data) (int, error) {
+						return 10 * v, nil
+					}}),
+				),
+				Args: []string{"hello", "12"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"strArg": "HELLO",
+					"intArg": 120,
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "HELLO"}, {Value: "120"}},
+				},
+			},
+		},
+		{
+			name: "list arg get transformed with TransformerList",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					ListArg[string]("sl", testDesc, 2, 3, TransformerList(&Transformer[string]{F: func(v string, d *command.Data) (string, error) {
+						return strings.ToUpper(v), nil
+					}})),
+				),
+				Args: []string{"hello", "there", "general", "kenobi"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"sl": []string{"HELLO", "THERE", "GENERAL", "KENOBI"},
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "HELLO"},
+						{Value: "THERE"},
+						{Value: "GENERAL"},
+						{Value: "KENOBI"},
+					},
+				},
+			},
+		},
+		{
+			name: "list arg transformer fails if number of args increases",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					ListArg[string]("sl", testDesc, 2, 3, &Transformer[[]string]{F: func(v []string, d *command.Data) ([]string, error) {
+						return append(v, "!"), nil
+					}}),
+				),
+				Args:       []string{"hello", "there", "general", "kenobi"},
+				WantErr:    fmt.Errorf("[sl] Transformers must return a value that is the same length as the original arguments"),
+				WantStderr: "[sl] Transformers must return a value that is the same length as the original arguments\n",
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "hello"},
+						{Value: "there"},
+						{Value: "general"},
+						{Value: "kenobi"},
+					},
+				},
+			},
+		},
+		{
+			name: "list arg transformer fails if number of args decreases",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					ListArg[string]("sl", testDesc, 2, 3, &Transformer[[]string]{F: func(v []string, d *command.Data) ([]string, error) {
+						return v[:len(v)-1], nil
+					}}),
+				),
+				Args:       []string{"hello", "there", "general", "kenobi"},
+				WantErr:    fmt.Errorf("[sl] Transformers must return a value that is the same length as the original arguments"),
+				WantStderr: "[sl] Transformers must return a value that is the same length as the original arguments\n",
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "hello"},
+						{Value: "there"},
+						{Value: "general"},
+						{Value: "kenobi"},
+					},
+				},
+			},
+		},
+		// Trim and Unquote tests.
+		{
+			name: "Trim removes surrounding whitespace",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					Arg[string]("strArg", testDesc, Trim()),
+				),
+				Args: []string{"  \t padded value \n "},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"strArg": "padded value",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "padded value"}},
+				},
+			},
+		},
+		{
+			name: "Trim does nothing if no surrounding whitespace",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					Arg[string]("strArg", testDesc, Trim()),
+				),
+				Args: []string{"in ner"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"strArg": "in ner",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "in ner"}},
+				},
+			},
+		},
+		{
+			name: "Unquote removes double quotes",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					Arg[string]("strArg", testDesc, Unquote()),
+				),
+				Args: []string{`"quoted value"`},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"strArg": "quoted value",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "quoted value"}},
+				},
+			},
+		},
+		{
+			name: "Unquote removes single quotes",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					Arg[string]("strArg", testDesc, Unquote()),
+				),
+				Args: []string{`'quoted value'`},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"strArg": "quoted value",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "quoted value"}},
+				},
+			},
+		},
+		{
+			name: "Unquote only removes outer quotes",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					Arg[string]("strArg", testDesc, Unquote()),
+				),
+				Args: []string{`"'nested'"`},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"strArg": "'nested'",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "'nested'"}},
+				},
+			},
+		},
+		{
+			name: "Unquote removes empty quotes",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					Arg[string]("strArg", testDesc, Unquote()),
+				),
+				Args: []string{`""`},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"strArg": "",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: ""}},
+				},
+			},
+		},
+		{
+			name: "Unquote ignores mismatched quotes",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					Arg[string]("strArg", testDesc, Unquote()),
+				),
+				Args: []string{`"mismatched'`},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"strArg": `"mismatched'`,
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: `"mismatched'`}},
+				},
+			},
+		},
+		{
+			name: "Unquote ignores unbalanced quotes",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					Arg[string]("strArg", testDesc, Unquote()),
+				),
+				Args: []string{`"unbalanced`},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"strArg": `"unbalanced`,
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: `"unbalanced`}},
+				},
+			},
+		},
+		{
+			name: "Unquote ignores single quote character",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					Arg[string]("strArg", testDesc, Unquote()),
+				),
+				Args: []string{`"`},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"strArg": `"`,
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: `"`}},
+				},
+			},
+		},
+		{
+			name: "Trim and Unquote can be combined",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					Arg[string]("strArg", testDesc, Trim(), Unquote()),
+				),
+				Args: []string{`  "padded and quoted" `},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"strArg": "padded and quoted",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "padded and quoted"}},
+				},
+			},
+		},
+		{
+			name: "Trim and Unquote run before validators",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					Arg[string]("strArg", testDesc, Trim(), Unquote(), MinLength[string, string](3), MaxLength[string, string](3)),
+				),
+				Args: []string{` "abc"`},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"strArg": "abc",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "abc"}},
+				},
+			},
+		},
+		{
+			name: "URLEncode encodes spaces and special characters",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					Arg[string]("strArg", testDesc, URLEncode()),
+				),
+				Args: []string{`a b&c=d/e?f#g+h%`},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"strArg": `a+b%26c%3Dd%2Fe%3Ff%23g%2Bh%25`,
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: `a+b%26c%3Dd%2Fe%3Ff%23g%2Bh%25`}},
+				},
+			},
+		},
+		{
+			name: "URLEncode leaves unreserved characters",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					Arg[string]("strArg", testDesc, URLEncode()),
+				),
+				Args: []string{"abc-123_.~"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"strArg": "abc-123_.~",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "abc-123_.~"}},
+				},
+			},
+		},
+		{
+			name: "URLDecode decodes spaces and special characters",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					Arg[string]("strArg", testDesc, URLDecode()),
+				),
+				Args: []string{`a+b%26c%3Dd%2Fe%3Ff%23g%2Bh%25`},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"strArg": `a b&c=d/e?f#g+h%`,
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: `a b&c=d/e?f#g+h%`}},
+				},
+			},
+		},
+		{
+			name: "URLDecode decodes percent-encoded spaces",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					Arg[string]("strArg", testDesc, URLDecode()),
+				),
+				Args: []string{"hello%20there"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"strArg": "hello there",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "hello there"}},
+				},
+			},
+		},
+		{
+			name: "URLEncode and URLDecode round trip",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					Arg[string]("strArg", testDesc, URLEncode(), URLDecode()),
+				),
+				Args: []string{`a b&c=d/e?f#g+h%`},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"strArg": `a b&c=d/e?f#g+h%`,
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: `a b&c=d/e?f#g+h%`}},
+				},
+			},
+		},
+		{
+			name: "URLDecode and URLEncode round trip",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					Arg[string]("strArg", testDesc, URLDecode(), URLEncode()),
+				),
+				Args: []string{`a+b%26c%3Dd`},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"strArg": `a+b%26c%3Dd`,
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: `a+b%26c%3Dd`}},
+				},
+			},
+		},
+		{
+			name: "URLDecode fails for invalid escape",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					Arg[string]("strArg", testDesc, URLDecode()),
+				),
+				Args:       []string{"100%zz"},
+				WantStderr: "Custom transformer failed: [URLDecode] failed to decode \"100%zz\": invalid URL escape \"%zz\"\n",
+				WantErr:    fmt.Errorf(`Custom transformer failed: [URLDecode] failed to decode "100%%zz": invalid URL escape "%%zz"`),
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "100%zz"}},
+				},
+			},
+		},
+		{
+			name: "ToLower lowercases the argument",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					Arg[string]("strArg", testDesc, ToLower()),
+				),
+				Args: []string{"HeLLo WoRLD"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"strArg": "hello world",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "hello world"}},
+				},
+			},
+		},
+		{
+			name: "ToUpper uppercases the argument",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					Arg[string]("strArg", testDesc, ToUpper()),
+				),
+				Args: []string{"HeLLo WoRLD"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"strArg": "HELLO WORLD",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "HELLO WORLD"}},
+				},
+			},
+		},
+		{
+			name: "ToTitle titlecases the argument",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					Arg[string]("strArg", testDesc, ToTitle()),
+				),
+				Args: []string{"hELLO  wORLD\tÉCOLE"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"strArg": "Hello  World\tÉcole",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "Hello  World\tÉcole"}},
+				},
+			},
+		},
+		{
+			name: "ToLower runs before InList validation",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					Arg[string]("strArg", testDesc, InList("abc", "def"), ToLower()),
+				),
+				Args: []string{"DeF"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"strArg": "def",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "def"}},
+				},
+			},
+		},
+		{
+			name: "InList validates the normalized value",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					Arg[string]("strArg", testDesc, ToUpper(), InList("abc", "def")),
+				),
+				Args: []string{"abc"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"strArg": "ABC",
+				}},
+				WantStderr: "validation for \"strArg\" failed: [InList] argument must be one of [abc def]\n",
+				WantErr:    fmt.Errorf(`validation for "strArg" failed: [InList] argument must be one of [abc def]`),
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "ABC"}},
+				},
+			},
+		},
+		{
+			name: "ToLowerList lowercases all list arguments",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					ListArg[string]("slArg", testDesc, 1, 2, ToLowerList(), ListifyValidatorOption(InList("abc", "def", "ghi"))),
+				),
+				Args: []string{"ABC", "dEf", "Ghi"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"slArg": []string{"abc", "def", "ghi"},
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "abc"}, {Value: "def"}, {Value: "ghi"}},
+				},
+			},
+		},
+		{
+			name: "ToUpperList and ToTitleList transform all list arguments",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					ListArg[string]("upper", testDesc, 1, 0, ToUpperList()),
+					ListArg[string]("title", testDesc, 1, 1, ToTitleList()),
+				),
+				Args: []string{"ab", "cD", "eF gh"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"upper": []string{"AB"},
+					"title": []string{"Cd", "Ef Gh"},
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "AB"}, {Value: "Cd"}, {Value: "Ef Gh"}},
+				},
+			},
+		},
+		// Quiet tests
+		{
+			name: "Quiet outputs stdout if flag is not set",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(quietFlag),
+					Quiet(quietFlag,
+						PrintlnProcessor("processor stdout"),
+						SimpleProcessor(func(i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
+							o.Stderrln("processor stderr")
+							return nil
+						}, nil),
+						&ExecutorProcessor{func(o command.Output, d *command.Data) error {
+							o.Stdoutln("executor stdout")
+							o.Stderrln("executor stderr")
+							return nil
+						}},
+					),
+					PrintlnProcessor("not quieted"),
+				),
+				WantStdout: strings.Join([]string{
+					"processor stdout",
+					"not quieted",
+					"executor stdout",
+					"",
+				}, "\n"),
+				WantStderr: strings.Join([]string{
+					"processor stderr",
+					"executor stderr",
+					"",
+				}, "\n"),
+			},
+		},
+		{
+			name: "Quiet suppresses stdout if flag is set",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(quietFlag),
+					Quiet(quietFlag,
+						PrintlnProcessor("processor stdout"),
+						SimpleProcessor(func(i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
+							o.Stderrln("processor stderr")
+							return nil
+						}, nil),
+						&ExecutorProcessor{func(o command.Output, d *command.Data) error {
+							o.Stdoutln("executor stdout")
+							o.Stderrln("executor stderr")
+							return nil
+						}},
+					),
+					PrintlnProcessor("not quieted"),
+				),
+				Args: []string{"--quiet"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"quiet": true,
+				}},
+				WantStdout: "not quieted\n",
+				WantStderr: strings.Join([]string{
+					"processor stderr",
+					"executor stderr",
+					"",
+				}, "\n"),
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "--quiet"},
+					},
+				},
+			},
+		},
+		{
+			name: "Quiet still outputs errors if flag is set",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(quietFlag),
+					Quiet(quietFlag,
+						PrintlnProcessor("processor stdout"),
+						SimpleProcessor(func(i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
+							o.Stderrln("processor stderr")
+							return nil
+						}, nil),
+						&ExecutorProcessor{func(o command.Output, d *command.Data) error {
+							o.Stdoutln("executor stdout")
+							o.Stderrln("executor stderr")
+							return o.Stderrf("executor failed\n")
+						}},
+					),
+					PrintlnProcessor("not quieted"),
+				),
+				Args: []string{"-q"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"quiet": true,
+				}},
+				WantStdout: "not quieted\n",
+				WantStderr: strings.Join([]string{
+					"processor stderr",
+					"executor stderr",
+					"executor failed",
+					"",
+				}, "\n"),
+				WantErr: fmt.Errorf("executor failed"),
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "-q"},
+					},
+				},
+			},
+		},
+		// StrictArgs tests
+		{
+			name: "StrictArgs succeeds if all args provided",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(StrictArgs(
+					Arg[string]("service", testDesc),
+					Arg[string]("env", testDesc),
+					Arg[int]("replicas", testDesc),
+				)),
+				Args: []string{"api", "prod", "3"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"service":  "api",
+					"env":      "prod",
+					"replicas": 3,
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "api"},
+						{Value: "prod"},
+						{Value: "3"},
+					},
+				},
+			},
+		},
+		{
+			name: "StrictArgs fails with full signature if too few args provided",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(StrictArgs(
+					Arg[string]("service", testDesc),
+					Arg[string]("env", testDesc),
+					Arg[int]("replicas", testDesc),
+				)),
+				Args:       []string{"api"},
+				WantErr:    fmt.Errorf(`Not enough arguments provided (want "service env replicas", got ["api"]): Argument "env" requires at least 1 argument, got 0`),
+				WantStderr: "Not enough arguments provided (want \"service env replicas\", got [\"api\"]): Argument \"env\" requires at least 1 argument, got 0\n",
+				WantData: &command.Data{Values: map[string]interface{}{
+					"service": "api",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsUsageError:         true,
+				WantIsNotEnoughArgsError: true,
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "api"},
+					},
+				},
+			},
+		},
+		{
+			name: "StrictArgs fails with full signature if no args provided",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(StrictArgs(
+					Arg[string]("service", testDesc),
+					ListArg[string]("envs", testDesc, 1, 2),
+					OptionalArg[int]("replicas", testDesc),
+				)),
+				WantErr:    fmt.Errorf(`Not enough arguments provided (want "service envs [ envs envs ] [ replicas ]", got []): Argument "service" requires at least 1 argument, got 0`),
+				WantStderr: "Not enough arguments provided (want \"service envs [ envs envs ] [ replicas ]\", got []): Argument \"service\" requires at least 1 argument, got 0\n",
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsUsageError:         true,
+				WantIsNotEnoughArgsError: true,
+			},
+		},
+		{
+			name: "StrictArgs returns other errors as is",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(StrictArgs(
+					Arg[string]("service", testDesc),
+					Arg[int]("replicas", testDesc),
+				)),
+				Args:       []string{"api", "three"},
+				WantErr:    fmt.Errorf(`strconv.Atoi: parsing "three": invalid syntax`),
+				WantStderr: "strconv.Atoi: parsing \"three\": invalid syntax\n",
+				WantData: &command.Data{Values: map[string]interface{}{
+					"service": "api",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "api"},
+						{Value: "three"},
+					},
+				},
+			},
+		},
+		// InputTransformer tests.
+		{
+			name: "InputTransformer handles no arguments",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FileNumberInputTransformer(1),
+				),
+			},
+		},
+		{
+			name: "InputTransformer handles non-matching arguments",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FileNumberInputTransformer(1),
+					Arg[string]("s", testDesc),
+					Arg[int]("i", testDesc),
+				),
+				Args: []string{"hello.go", "248"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"s": "hello.go",
+					"i": 248,
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "hello.go"},
+						{Value: "248"},
+					},
+				},
+			},
+		},
+		{
+			name: "InputTransformer expands matching arguments",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FileNumberInputTransformer(1),
+					Arg[string]("s", testDesc),
+					Arg[int]("i", testDesc),
+				),
+				Args: []string{"hello.go:248"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"s": "hello.go",
+					"i": 248,
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "hello.go"},
+						{Value: "248"},
+					},
+				},
+			},
+		},
+		{
+			name: "InputTransformer fails",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FileNumberInputTransformer(1),
+				),
+				Args:       []string{"hello.go:248:extra"},
+				WantErr:    fmt.Errorf("Expected either 1 or 2 parts, got 3"),
+				WantStderr: "Expected either 1 or 2 parts, got 3\n",
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Remaining: []int{0},
+					Args: []*spycommand.InputArg{
+						{Value: "hello.go:248:extra"},
+					},
+				},
+			},
+		},
+		{
+			name: "InputTransformer expands multiple matching arguments",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FileNumberInputTransformer(2),
+					Arg[string]("s", testDesc),
+					Arg[int]("i", testDesc),
+					Arg[string]("s2", testDesc),
+					Arg[int]("i2", testDesc),
+				),
+				Args: []string{"hello.go:248", "there.txt:139"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"s":  "hello.go",
+					"i":  248,
+					"s2": "there.txt",
+					"i2": 139,
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "hello.go"},
+						{Value: "248"},
+						{Value: "there.txt"},
+						{Value: "139"},
+					},
+				},
+			},
+		},
+		// Stdoutln tests
+		{
+			name: "stdoutln works",
+			etc: &commandtest.ExecuteTestCase{
+				Node:       printlnNode(true, "one", 2, 3.0),
+				WantStdout: "one 2 3\n",
+			},
+		},
+		{
+			name: "stderrln works",
+			etc: &commandtest.ExecuteTestCase{
+				Node:       printlnNode(false, "uh", 0),
+				WantStderr: "uh 0\n",
+				WantErr:    fmt.Errorf("uh 0"),
+			},
+		},
+		// BranchNode tests
+		{
+			name: "branch node requires branch argument",
+			etc: &commandtest.ExecuteTestCase{
+				Node: &BranchNode{
+					Branches: map[string]command.Node{
+						"h": printNode("hello"),
+						"b": printNode("goodbye"),
+					},
+				},
+				WantStderr: "Branching argument must be one of [b h]\n",
+				WantErr:    fmt.Errorf("Branching argument must be one of [b h]"),
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsUsageError:     true,
+				WantIsBranchingError: true,
+			},
+		},
+		{
+			name: "branch node requires matching branch argument",
+			etc: &commandtest.ExecuteTestCase{
+				Node: &BranchNode{
+					Branches: map[string]command.Node{
+						"h": printNode("hello"),
+						"b": printNode("goodbye"),
+					},
+				},
+				Args:       []string{"uh"},
+				WantStderr: "Branching argument must be one of [b h]\n",
+				WantErr:    fmt.Errorf("Branching argument must be one of [b h]"),
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsUsageError:     true,
+				WantIsBranchingError: true,
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "uh"},
+					},
+					Remaining: []int{0},
+				},
+			},
+		},
+		{
+			name: "branch node forwards to proper node",
+			etc: &commandtest.ExecuteTestCase{
+				Node: &BranchNode{
+					Branches: map[string]command.Node{
+						"h": printNode("hello"),
+						"b": printNode("goodbye"),
+					},
+				},
+				Args:       []string{"h"},
+				WantStdout: "hello",
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "h"},
+					},
+				},
+			},
+		},
+		{
+			name: "branch node forwards to default if none provided",
+			etc: &commandtest.ExecuteTestCase{
+				Node: &BranchNode{
+					Branches: map[string]command.Node{
+						"h": printNode("hello"),
+						"b": printNode("goodbye"),
+					},
+					Default: printNode("default"),
+				},
+				WantStdout: "default",
+			},
+		},
+		{
+			name: "branch node forwards to default if unknown provided",
+			etc: &commandtest.ExecuteTestCase{
+				Node: &BranchNode{
+					Branches: map[string]command.Node{
+						"h": printNode("hello"),
+						"b": printNode("goodbye"),
+					},
+					Default: SerialNodes(ListArg[string]("sl", testDesc, 0, command.UnboundedList), printArgsNode()),
+				},
+				Args:       []string{"good", "morning"},
+				WantStdout: "sl: [good morning]\n",
+				WantData: &command.Data{Values: map[string]interface{}{
+					"sl": []string{"good", "morning"},
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "good"},
+						{Value: "morning"},
+					},
+				},
+			},
+		},
+		{
+			name: "branch node forwards to synonym",
+			etc: &commandtest.ExecuteTestCase{
+				Args: []string{"B"},
+				Node: &BranchNode{
+					Branches: map[string]command.Node{
+						"h": printNode("hello"),
+						"b": printNode("goodbye"),
+					},
+					Default: printNode("default"),
+					Synonyms: BranchSynonyms(map[string][]string{
+						"b": {"bee", "B", "Be"},
+					}),
+				},
+				WantStdout: "goodbye",
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "B"},
+					},
+				},
+			},
+		},
+		{
+			name: "branch node fails if synonym to unknown command",
+			etc: &commandtest.ExecuteTestCase{
+				Args: []string{"uh"},
+				Node: &BranchNode{
+					Branches: map[string]command.Node{
+						"h": printNode("hello"),
+						"b": printNode("goodbye"),
+					},
+					Synonyms: BranchSynonyms(map[string][]string{
+						"o": {"uh"},
+					}),
+				},
+				WantStderr: "Branching argument must be one of [b h]\n",
+				WantErr:    fmt.Errorf("Branching argument must be one of [b h]"),
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsUsageError:     true,
+				WantIsBranchingError: true,
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "uh"},
+					},
+					Remaining: []int{0},
+				},
+			},
+		},
+		{
+			name: "branch node forwards to default if synonym to unknown command",
+			etc: &commandtest.ExecuteTestCase{
+				Args: []string{"uh"},
+				Node: &BranchNode{
+					Branches: map[string]command.Node{
+						"h": printNode("hello"),
+						"b": printNode("goodbye"),
+					},
+					Default: SerialNodes(ListArg[string]("sl", testDesc, 0, command.UnboundedList), printArgsNode()),
+					Synonyms: BranchSynonyms(map[string][]string{
+						"o": {"uh"},
+					}),
+				},
+				WantData: &command.Data{
+					Values: map[string]interface{}{
+						"sl": []string{"uh"},
+					},
+				},
+				WantStdout: "sl: [uh]\n",
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "uh"},
+					},
+				},
+			},
+		},
+		{
+			name: "branch node stores unknown branch argument in data",
+			etc: &commandtest.ExecuteTestCase{
+				Args: []string{"good", "morning"},
+				Node: &BranchNode{
+					Branches: map[string]command.Node{
+						"h": printNode("hello"),
+						"b": printNode("goodbye"),
+					},
+					Default: SerialNodes(
+						ListArg[string]("sl", testDesc, 0, command.UnboundedList),
+						SimpleProcessor(func(i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
+							o.Stdoutf("unknown: %q, sl: %v\n", d.String("UNKNOWN"), d.StringList("sl"))
+							return nil
+						}, nil),
+					),
+					UnknownBranchKey: "UNKNOWN",
+				},
+				WantStdout: "unknown: \"good\", sl: [good morning]\n",
+				WantData: &command.Data{Values: map[string]interface{}{
+					"UNKNOWN": "good",
+					"sl":      []string{"good", "morning"},
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "good"},
+						{Value: "morning"},
+					},
+				},
+			},
+		},
+		{
+			name: "branch node stores original unknown branch argument if synonym to unknown command",
+			etc: &commandtest.ExecuteTestCase{
+				Args: []string{"uh"},
+				Node: &BranchNode{
+					Branches: map[string]command.Node{
+						"h": printNode("hello"),
+						"b": printNode("goodbye"),
+					},
+					Default: SerialNodes(
+						ListArg[string]("sl", testDesc, 0, command.UnboundedList),
+						SimpleProcessor(func(i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
+							o.Stdoutf("unknown: %q, sl: %v\n", d.String("UNKNOWN"), d.StringList("sl"))
+							return nil
+						}, nil),
+					),
+					Synonyms: BranchSynonyms(map[string][]string{
+						"o": {"uh"},
+					}),
+					UnknownBranchKey: "UNKNOWN",
+				},
+				WantStdout: "unknown: \"uh\", sl: [uh]\n",
+				WantData: &command.Data{Values: map[string]interface{}{
+					"UNKNOWN": "uh",
+					"sl":      []string{"uh"},
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "uh"},
+					},
+				},
+			},
+		},
+		{
+			name: "branch node doesn't store unknown branch argument if none provided",
+			etc: &commandtest.ExecuteTestCase{
+				Node: &BranchNode{
+					Branches: map[string]command.Node{
+						"h": printNode("hello"),
+						"b": printNode("goodbye"),
+					},
+					Default: SerialNodes(
+						ListArg[string]("sl", testDesc, 0, command.UnboundedList),
+						SimpleProcessor(func(i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
+							o.Stdoutf("unknown: %q, sl: %v\n", d.String("UNKNOWN"), d.StringList("sl"))
+							return nil
+						}, nil),
+					),
+					UnknownBranchKey: "UNKNOWN",
+				},
+				WantStdout: "unknown: \"\", sl: []\n",
+			},
+		},
+		{
+			name: "branch node doesn't store unknown branch argument if branch matches",
+			etc: &commandtest.ExecuteTestCase{
+				Args: []string{"h"},
+				Node: &BranchNode{
+					Branches: map[string]command.Node{
+						"h": printNode("hello"),
+						"b": printNode("goodbye"),
+					},
+					Default: SerialNodes(
+						ListArg[string]("sl", testDesc, 0, command.UnboundedList),
+						SimpleProcessor(func(i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
+							o.Stdoutf("unknown: %q, sl: %v\n", d.String("UNKNOWN"), d.StringList("sl"))
+							return nil
+						}, nil),
+					),
+					UnknownBranchKey: "UNKNOWN",
+				},
+				WantStdout: "hello",
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "h"},
+					},
+				},
+			},
+		},
+		{
+			name: "branch node forwards to spaced synonym",
+			etc: &commandtest.ExecuteTestCase{
+				Args: []string{"bee"},
+				Node: &BranchNode{
+					Branches: map[string]command.Node{
+						"h":          printNode("hello"),
+						"b bee B Be": printNode("goodbye"),
+					},
+					Default: printNode("default"),
+				},
+				WantStdout: "goodbye",
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "bee"},
+					},
+				},
+			},
+		},
+		// BranchNode synonym tests
+		{
+			name: "branch node works with branch name",
+			etc: &commandtest.ExecuteTestCase{
+				Args:       []string{"hello"},
+				Node:       branchSynNode(),
+				WantStdout: "yo",
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "hello"},
+					},
+				},
+			},
+		},
+		{
+			name: "branch node works with branch name",
+			etc: &commandtest.ExecuteTestCase{
+				Args:       []string{"hello"},
+				Node:       branchSynNode(),
+				WantStdout: "yo",
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "hello"},
+					},
+				},
+			},
+		},
+		{
+			name: "branch node works with second spaced alias",
+			etc: &commandtest.ExecuteTestCase{
+				Args:       []string{"greetings"},
+				Node:       branchSynNode(),
+				WantStdout: "yo",
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "greetings"},
+					},
+				},
+			},
+		},
+		{
+			name: "branch node works with first synonym",
+			etc: &commandtest.ExecuteTestCase{
+				Args:       []string{"hey"},
+				Node:       branchSynNode(),
+				WantStdout: "yo",
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "hey"},
+					},
+				},
+			},
+		},
+		{
+			name: "branch node works with second synonym",
+			etc: &commandtest.ExecuteTestCase{
+				Args:       []string{"howdy"},
+				Node:       branchSynNode(),
+				WantStdout: "yo",
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "howdy"},
+					},
+				},
+			},
+		},
+		// NodeRepeater tests
+		{
+			name: "NodeRepeater fails if not enough",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(sampleRepeaterProcessor(3, 0)),
+				Args: []string{"k1", "100", "k2", "200"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"keys":   []string{"k1", "k2"},
+					"values": []int{100, 200},
+				}},
+				WantErr:    fmt.Errorf(`Argument "KEY" requires at least 1 argument, got 0`),
+				WantStderr: "Argument \"KEY\" requires at least 1 argument, got 0\n",
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsUsageError:         true,
+				WantIsNotEnoughArgsError: true,
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "k1"},
+						{Value: "100"},
+						{Value: "k2"},
+						{Value: "200"},
+					},
+				},
+			},
+		},
+		{
+			name: "NodeRepeater fails if middle node doen't have enough",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(sampleRepeaterProcessor(1, 1)),
+				Args: []string{"k1", "100", "k2"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"keys":   []string{"k1", "k2"},
+					"values": []int{100},
+				}},
+				WantErr:    fmt.Errorf(`Argument "VALUE" requires at least 1 argument, got 0`),
+				WantStderr: "Argument \"VALUE\" requires at least 1 argument, got 0\n",
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsUsageError:         true,
+				WantIsNotEnoughArgsError: true,
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "k1"},
+						{Value: "100"},
+						{Value: "k2"},
+					},
+				},
+			},
+		},
+		{
+			name: "NodeRepeater fails if too many",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(sampleRepeaterProcessor(1, 0)),
+				Args: []string{"k1", "100", "k2", "200"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"keys":   []string{"k1"},
+					"values": []int{100},
+				}},
+				WantErr: fmt.Errorf(`Unprocessed extra args: [k2 200]`),
+				WantStderr: strings.Join([]string{
+					`Unprocessed extra args: [k2 200]`,
+					``,
+					`======= Command Usage =======`,
+					`KEY VALUE`,
+					``,
+					`Arguments:`,
+					`  KEY: test desc`,
+					`  VALUE: test desc`,
+					``,
+				}, "\n"),
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsExtraArgsError: true,
+				WantIsUsageError:     true,
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "k1"},
+						{Value: "100"},
+						{Value: "k2"},
+						{Value: "200"},
+					},
+					Remaining: []int{2, 3},
+				},
+			},
+		},
+		{
+			name: "NodeRepeater accepts minimum when no optional",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(sampleRepeaterProcessor(2, 0)),
+				Args: []string{"k1", "100", "k2", "200"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"keys":   []string{"k1", "k2"},
+					"values": []int{100, 200},
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "k1"},
+						{Value: "100"},
+						{Value: "k2"},
+						{Value: "200"},
+					},
+				},
+			},
+		},
+		{
+			name: "NodeRepeater accepts minimum when optional",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(sampleRepeaterProcessor(2, 3)),
+				Args: []string{"k1", "100", "k2", "200"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"keys":   []string{"k1", "k2"},
+					"values": []int{100, 200},
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "k1"},
+						{Value: "100"},
+						{Value: "k2"},
+						{Value: "200"},
+					},
+				},
+			},
+		},
+		{
+			name: "NodeRepeater accepts minimum when unlimited optional",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(sampleRepeaterProcessor(2, 3)),
+				Args: []string{"k1", "100", "k2", "200"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"keys":   []string{"k1", "k2"},
+					"values": []int{100, 200},
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "k1"},
+						{Value: "100"},
+						{Value: "k2"},
+						{Value: "200"},
+					},
+				},
+			},
+		},
+		{
+			name: "NodeRepeater accepts maximum when no optional",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(sampleRepeaterProcessor(2, 0)),
+				Args: []string{"k1", "100", "k2", "200"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"keys":   []string{"k1", "k2"},
+					"values": []int{100, 200},
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "k1"},
+						{Value: "100"},
+						{Value: "k2"},
+						{Value: "200"},
+					},
+				},
+			},
+		},
+		{
+			name: "NodeRepeater accepts maximum when optional",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(sampleRepeaterProcessor(1, 1)),
+				Args: []string{"k1", "100", "k2", "200"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"keys":   []string{"k1", "k2"},
+					"values": []int{100, 200},
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "k1"},
+						{Value: "100"},
+						{Value: "k2"},
+						{Value: "200"},
+					},
+				},
+			},
+		},
+		{
+			name: "NodeRepeater with unlimited optional accepts a bunch",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(sampleRepeaterProcessor(1, command.UnboundedList)),
+				Args: []string{"k1", "100", "k2", "200", "k3", "300", "k4", "400", "...", "0", "kn", "999"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"keys":   []string{"k1", "k2", "k3", "k4", "...", "kn"},
+					"values": []int{100, 200, 300, 400, 0, 999},
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "k1"},
+						{Value: "100"},
+						{Value: "k2"},
+						{Value: "200"},
+						{Value: "k3"},
+						{Value: "300"},
+						{Value: "k4"},
+						{Value: "400"},
+						{Value: "..."},
+						{Value: "0"},
+						{Value: "kn"},
+						{Value: "999"},
+					},
+				},
+			},
+		},
+		// ListBreaker tests
+		{
+			name: "Handles broken list",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					ListArg[string]("SL", testDesc, 1, command.UnboundedList, ListUntilSymbol("ghi")),
+					ListArg[string]("SL2", testDesc, 0, command.UnboundedList),
+				),
+				Args: []string{"abc", "def", "ghi", "jkl"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"SL":  []string{"abc", "def"},
+					"SL2": []string{"ghi", "jkl"},
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "abc"},
+						{Value: "def"},
+						{Value: "ghi"},
+						{Value: "jkl"},
+					},
+				},
+			},
+		},
+		{
+			name: "List breaker before min value",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					ListArg[string]("SL", testDesc, 3, command.UnboundedList, ListUntilSymbol("ghi")),
+				),
+				Args: []string{"abc", "def", "ghi", "jkl"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"SL": []string{"abc", "def"},
+				}},
+				WantErr:    fmt.Errorf(`Argument "SL" requires at least 3 arguments, got 2`),
+				WantStderr: "Argument \"SL\" requires at least 3 arguments, got 2\n",
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsUsageError:         true,
+				WantIsNotEnoughArgsError: true,
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "abc"},
+						{Value: "def"},
+						{Value: "ghi"},
+						{Value: "jkl"},
+					},
+					Remaining: []int{2, 3},
+				},
+			},
+		},
+		{
+			name: "Handles broken list with discard",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					ListArg[string]("SL", testDesc, 1, command.UnboundedList, func() *ListBreaker[[]string] {
+						li := ListUntilSymbol("ghi")
+						li.Discard = true
+						return li
+					}()),
+					ListArg[string]("SL2", testDesc, 0, command.UnboundedList),
+				),
+				Args: []string{"abc", "def", "ghi", "jkl"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"SL":  []string{"abc", "def"},
+					"SL2": []string{"jkl"},
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "abc"},
+						{Value: "def"},
+						{Value: "ghi"},
+						{Value: "jkl"},
+					},
+				},
+			},
+		},
+		{
+			name: "Handles broken list with IncludeInFirst",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					ListArg[string]("SL", testDesc, 1, command.UnboundedList, func() *ListBreaker[[]string] {
+						li := ListUntilSymbol("ghi")
+						li.IncludeInFirst = true
+						return li
+					}()),
+					ListArg[string]("SL2", testDesc, 0, command.UnboundedList),
+				),
+				Args: []string{"abc", "def", "ghi", "jkl"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"SL":  []string{"abc", "def", "ghi"},
+					"SL2": []string{"jkl"},
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "abc"},
+						{Value: "def"},
+						{Value: "ghi"},
+						{Value: "jkl"},
+					},
+				},
+			},
+		},
+		{
+			name: "Handles broken list with Discard and IncludeInFirst",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					ListArg[string]("SL", testDesc, 1, command.UnboundedList, func() *ListBreaker[[]string] {
+						li := ListUntilSymbol("ghi")
+						li.Discard = true
+						li.IncludeInFirst = true
+						return li
+					}()),
+					ListArg[string]("SL2", testDesc, 0, command.UnboundedList),
+				),
+				Args: []string{"abc", "def", "ghi", "jkl"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"SL":  []string{"abc", "def"},
+					"SL2": []string{"jkl"},
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "abc"},
+						{Value: "def"},
+						{Value: "ghi"},
+						{Value: "jkl"},
+					},
+				},
+			},
+		},
+		{
+			name: "Handles unbroken list",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					ListArg[string]("SL", testDesc, 1, command.UnboundedList, ListUntilSymbol("ghi")),
+					ListArg[string]("SL2", testDesc, 0, command.UnboundedList),
+				),
+				Args: []string{"abc", "def", "ghif", "jkl"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"SL": []string{"abc", "def", "ghif", "jkl"},
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "abc"},
+						{Value: "def"},
+						{Value: "ghif"},
+						{Value: "jkl"},
+					},
+				},
+			},
+		},
+		{
+			name: "Fails if arguments required after broken list",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					ListArg[string]("SL", testDesc, 1, command.UnboundedList, ListUntilSymbol("ghi")),
+					ListArg[string]("SL2", testDesc, 1, command.UnboundedList),
+				),
+				Args: []string{"abc", "def", "ghif", "jkl"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"SL": []string{"abc", "def", "ghif", "jkl"},
+				}},
+				WantErr:    fmt.Errorf(`Argument "SL2" requires at least 1 argument, got 0`),
+				WantStderr: "Argument \"SL2\" requires at least 1 argument, got 0\n",
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsUsageError:         true,
+				WantIsNotEnoughArgsError: true,
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "abc"},
+						{Value: "def"},
+						{Value: "ghif"},
+						{Value: "jkl"},
+					},
+				},
+			},
+		},
+		// StringListListProcessor tests
+		{
+			name: "StringListListProcessor works if no breakers",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					StringListListProcessor("SLL", testDesc, "|", 1, command.UnboundedList),
+				),
+				Args: []string{"abc", "def", "ghi", "jkl"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"SLL": [][]string{
+						{"abc", "def", "ghi", "jkl"},
+					},
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "abc"},
+						{Value: "def"},
+						{Value: "ghi"},
+						{Value: "jkl"},
+					},
+				},
+			},
+		},
+		{
+			name: "StringListListProcessor works with unbounded list",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					StringListListProcessor("SLL", testDesc, "|", 1, command.UnboundedList),
+				),
+				Args: []string{"abc", "def", "|", "ghi", "||", "|", "jkl"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"SLL": [][]string{
+						{"abc", "def"},
+						{"ghi", "||"},
+						{"jkl"},
+					},
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "abc"},
+						{Value: "def"},
+						{Value: "|"},
+						{Value: "ghi"},
+						{Value: "||"},
+						{Value: "|"},
+						{Value: "jkl"},
+					},
+				},
+			},
+		},
+		{
+			name: "StringListListProcessor works with bounded list",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					StringListListProcessor("SLL", testDesc, "|", 1, 2),
+				),
+				Args: []string{"abc", "def", "|", "ghi", "||", "|", "jkl"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"SLL": [][]string{
+						{"abc", "def"},
+						{"ghi", "||"},
+						{"jkl"},
+					},
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "abc"},
+						{Value: "def"},
+						{Value: "|"},
+						{Value: "ghi"},
+						{Value: "||"},
+						{Value: "|"},
+						{Value: "jkl"},
+					},
+				},
+			},
+		},
+		{
+			name: "StringListListProcessor works if ends with operator",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					StringListListProcessor("SLL", testDesc, "|", 1, 2),
+				),
+				Args: []string{"abc", "def", "|", "ghi", "||", "|", "jkl", "|"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"SLL": [][]string{
+						{"abc", "def"},
+						{"ghi", "||"},
+						{"jkl"},
+					},
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "abc"},
+						{Value: "def"},
+						{Value: "|"},
+						{Value: "ghi"},
+						{Value: "||"},
+						{Value: "|"},
+						{Value: "jkl"},
+						{Value: "|"},
+					},
+				},
+			},
+		},
+		{
+			name: "StringListListProcessor fails if extra args",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					StringListListProcessor("SLL", testDesc, "|", 1, 2),
+				),
+				Args: []string{"abc", "def", "|", "ghi", "||", "|", "jkl", "|", "other", "stuff"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"SLL": [][]string{
+						{"abc", "def"},
+						{"ghi", "||"},
+						{"jkl"},
+					},
+				}},
+				WantErr: fmt.Errorf("Unprocessed extra args: [other stuff]"),
+				WantStderr: strings.Join([]string{
+					`Unprocessed extra args: [other stuff]`,
+					``,
+					`======= Command Usage =======`,
+					`[ SLL ... ] | { [ SLL ... ] | [ SLL ... ] | }`,
+					``,
+					`Arguments:`,
+					`  SLL: test desc`,
+					``,
+					`Symbols:`,
+					`  |: List breaker`,
+					``,
+				}, "\n"),
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsExtraArgsError: true,
+				WantIsUsageError:     true,
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "abc"},
+						{Value: "def"},
+						{Value: "|"},
+						{Value: "ghi"},
+						{Value: "||"},
+						{Value: "|"},
+						{Value: "jkl"},
+						{Value: "|"},
+						{Value: "other"},
+						{Value: "stuff"},
+					},
+					Remaining: []int{8, 9},
+				},
+			},
+		},
+		// FileContents test
+		{
+			name: "file gets read properly",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(FileContents("FILE", testDesc)),
+				Args: []string{filepath.Join("testdata", "one.txt")},
+				WantData: &command.Data{
+					Values: map[string]interface{}{
+						"FILE": []string{"hello", "there"},
+					},
+				},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: testutil.FilepathAbs(t, "testdata", "one.txt")},
+					},
+				},
+			},
+		},
+		{
+			name: "FileContents fails for unknown file",
+			etc: &commandtest.ExecuteTestCase{
+				Node:       SerialNodes(FileContents("FILE", testDesc)),
+				Args:       []string{filepath.Join("uh")},
+				WantStderr: fmt.Sprintf("validation for \"FILE\" failed: [FileExists] file %q does not exist\n", testutil.FilepathAbs(t, "uh")),
+				WantErr:    fmt.Errorf(`validation for "FILE" failed: [FileExists] file %q does not exist`, testutil.FilepathAbs(t, "uh")),
+				WantData: &command.Data{
+					Values: map[string]interface{}{
+						"FILE": testutil.FilepathAbs(t, "uh"),
+					},
+				},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: testutil.FilepathAbs(t, "uh")},
+					},
+				},
+			},
+		},
+		// If tests
+		{
+			name: "If runs if function returns true",
+			etc: &commandtest.ExecuteTestCase{
+				Args: []string{"abc", "def"},
+				Node: SerialNodes(
+					Arg[string]("s", testDesc),
+					If(
+						printArgsNode(),
+						func(i *command.Input, d *command.Data) bool {
+							return true
+						},
+					),
+					Arg[string]("s2", testDesc),
+				),
+				WantStdout: strings.Join([]string{
+					"s: abc",
+					"s2: def",
+					"",
+				}, "\n"),
+				WantData: &command.Data{Values: map[string]interface{}{
+					"s":  "abc",
+					"s2": "def",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "abc"},
+						{Value: "def"},
+					},
+				},
+			},
+		},
+		{
+			name: "If does not run if function returns false",
+			etc: &commandtest.ExecuteTestCase{
+				Args: []string{"abc", "def"},
+				Node: SerialNodes(
+					Arg[string]("s", testDesc),
+					If(
+						printArgsNode(),
+						func(i *command.Input, d *command.Data) bool {
+							return false
+						},
+					),
+					Arg[string]("s2", testDesc),
+				),
+				WantData: &command.Data{Values: map[string]interface{}{
+					"s":  "abc",
+					"s2": "def",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "abc"},
+						{Value: "def"},
+					},
+				},
+			},
+		},
+		// IfData tests
+		{
+			name: "IfData runs if variable is present",
+			etc: &commandtest.ExecuteTestCase{
+				Args: []string{"abc"},
+				Node: SerialNodes(
+					OptionalArg[string]("s", testDesc),
+					IfData("s", printlnNode(true, "hello")),
+				),
+				WantStdout: "hello\n",
+				WantData: &command.Data{Values: map[string]interface{}{
+					"s": "abc",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "abc"},
+					},
+				},
+			},
+		},
+		{
+			name: "IfData runs if bool variable is present and true",
+			etc: &commandtest.ExecuteTestCase{
+				Args: []string{"true"},
+				Node: SerialNodes(
+					OptionalArg[bool]("b", testDesc),
+					IfData("b", printlnNode(true, "hello")),
+				),
+				WantStdout: "hello\n",
+				WantData: &command.Data{Values: map[string]interface{}{
+					"b": true,
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "true"},
+					},
+				},
+			},
+		},
+		{
+			name: "IfData does not run if variable is not present",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					OptionalArg[string]("s", testDesc),
+					IfData("s", printlnNode(true, "hello")),
+				),
+			},
+		},
+		{
+			name: "IfData does not run if bool variable is present and false",
+			etc: &commandtest.ExecuteTestCase{
+				Args: []string{"false"},
+				Node: SerialNodes(
+					OptionalArg[bool]("b", testDesc),
+					IfData("b", printlnNode(true, "hello")),
+				),
+				WantData: &command.Data{Values: map[string]interface{}{
+					"b": false,
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "false"},
+					},
+				},
+			},
+		},
+		// IfElseData tests
+		{
+			name: "IfElseData runs t if variable is present",
+			etc: &commandtest.ExecuteTestCase{
+				Args: []string{"abc"},
+				Node: SerialNodes(
+					OptionalArg[string]("s", testDesc),
+					IfElseData(
+						"s",
+						printlnNode(true, "hello"),
+						printlnNode(true, "goodbye"),
+					),
+				),
+				WantStdout: "hello\n",
+				WantData: &command.Data{Values: map[string]interface{}{
+					"s": "abc",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "abc"},
+					},
+				},
+			},
+		},
+		{
+			name: "IfElseData runs t if bool variable is present and true",
+			etc: &commandtest.ExecuteTestCase{
+				Args: []string{"true"},
+				Node: SerialNodes(
+					OptionalArg[bool]("b", testDesc),
+					IfElseData(
+						"b",
+						printlnNode(true, "hello"),
+						printlnNode(true, "goodbye"),
+					),
+				),
+				WantStdout: "hello\n",
+				WantData: &command.Data{Values: map[string]interface{}{
+					"b": true,
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "true"},
+					},
+				},
+			},
+		},
+		{
+			name: "IfElseData runs f if variable is not present",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					OptionalArg[string]("s", testDesc),
+					IfElseData(
+						"s",
+						printlnNode(true, "hello"),
+						printlnNode(true, "goodbye"),
+					),
+				),
+				WantStdout: "goodbye\n",
+			},
+		},
+		{
+			name: "IfData runs f if bool variable is present and false",
+			etc: &commandtest.ExecuteTestCase{
+				Args: []string{"false"},
+				Node: SerialNodes(
+					OptionalArg[bool]("b", testDesc),
+					IfElseData(
+						"b",
+						printlnNode(true, "hello"),
+						printlnNode(true, "goodbye"),
+					),
+				),
+				WantStdout: "goodbye\n",
+				WantData: &command.Data{Values: map[string]interface{}{
+					"b": false,
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "false"},
+					},
+				},
+			},
+		},
+		// EchoExecuteData
+		{
+			name: "EchoExecuteData ignores empty command.ExecuteData.Executable",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					EchoExecuteData(),
+				),
+			},
+		},
+		{
+			name: "EchoExecuteData outputs command.ExecuteData.Executable",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					SimpleExecutableProcessor("un", "deux", "trois"),
+					EchoExecuteData(),
+				),
+				WantExecuteData: &command.ExecuteData{
+					Executable: []string{"un", "deux", "trois"},
+				},
+				WantStdout: "un\ndeux\ntrois\n",
+			},
+		},
+		{
+			name: "EchoExecuteData outputs command.ExecuteData.Executable to stderr",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					SimpleExecutableProcessor("un", "deux", "trois"),
+					&EchoExecuteDataProcessor{
+						Stderr: true,
+					},
+				),
+				WantExecuteData: &command.ExecuteData{
+					Executable: []string{"un", "deux", "trois"},
+				},
+				WantStderr: "un\ndeux\ntrois\n",
+			},
+		},
+		{
+			name: "EchoExecuteDataf ignores empty command.ExecuteData.Executable",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					EchoExecuteDataf("RUNNING CODE:\n%s\nDONE CODE\n"),
+				),
+			},
+		},
+		{
+			name: "EchoExecuteData outputs command.ExecuteData.Executable",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					SimpleExecutableProcessor("un", "deux", "trois"),
+					EchoExecuteDataf("RUNNING CODE:\n%s\nDONE CODE\n"),
+				),
+				WantExecuteData: &command.ExecuteData{
+					Executable: []string{"un", "deux", "trois"},
+				},
+				WantStdout: strings.Join([]string{
+					"RUNNING CODE:",
+					"un",
+					"deux",
+					"trois",
+					"DONE CODE",
+					"",
+				}, "\n"),
+			},
+		},
+		{
+			name: "EchoExecuteData outputs command.ExecuteData.Executable to stderr",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					SimpleExecutableProcessor("un", "deux", "trois"),
+					&EchoExecuteDataProcessor{
+						Stderr: true,
+						Format: "RUNNING CODE:\n%s\nDONE CODE\n",
+					},
+				),
+				WantExecuteData: &command.ExecuteData{
+					Executable: []string{"un", "deux", "trois"},
+				},
+				WantStderr: strings.Join([]string{
+					"RUNNING CODE:",
+					"un",
+					"deux",
+					"trois",
+					"DONE CODE",
+					"",
+				}, "\n"),
+			},
+		},
+		// MapArg tests
+		{
+			name: "MapArg converts a value with allowMissing=true",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					MapArg("m", testDesc, map[string]int{
+						"one":   1,
+						"two":   2,
+						"three": 3,
+					}, true),
+				),
+				Args: []string{"two"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"m": 2,
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "two"},
+					},
+				},
+			},
+		},
+		{
+			name: "MapArg converts a value with allowMissing=false",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					MapArg("m", testDesc, map[string]int{
+						"one":   1,
+						"two":   2,
+						"three": 3,
+					}, false),
+				),
+				Args: []string{"two"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"m": 2,
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "two"},
+					},
+				},
+			},
+		},
+		{
+			name: "MapArg converts to default value if allow missing",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					MapArg("m", testDesc, map[string]int{
+						"one":   1,
+						"two":   2,
+						"three": 3,
+					}, true),
+				),
+				Args: []string{"four"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"m": 0,
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "four"},
+					},
+				},
+			},
+		},
+		{
+			name: "MapArg fails if allow missing is false",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					MapArg("m", testDesc, map[string]int{
+						"one":   1,
+						"two":   2,
+						"three": 3,
+					}, false),
+				),
+				Args:       []string{"four"},
+				WantStderr: "validation for \"m\" failed: [MapArg] key (four) is not in map; expected one of [one three two]\n",
+				WantErr:    fmt.Errorf("validation for \"m\" failed: [MapArg] key (four) is not in map; expected one of [one three two]"),
+				WantData: &command.Data{Values: map[string]interface{}{
+					"m": 0,
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "four"},
+					},
+				},
+			},
+		},
+		{
+			name: "MapArg fails if allow missing is false",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					MapArg("m", testDesc, map[string]int{
+						"one":   1,
+						"two":   2,
+						"three": 3,
+					}, false),
+				),
+				Args:       []string{"four"},
+				WantStderr: "validation for \"m\" failed: [MapArg] key (four) is not in map; expected one of [one three two]\n",
+				WantErr:    fmt.Errorf("validation for \"m\" failed: [MapArg] key (four) is not in map; expected one of [one three two]"),
+				WantData: &command.Data{Values: map[string]interface{}{
+					"m": 0,
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "four"},
+					},
+				},
+			},
+		},
+		{
+			name: "MapArg works with custom types",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					MapArg("m", testDesc, map[string]struct {
+						A int
+						B float64
+					}{
+						"one":   {1, 1.0},
+						"two":   {2, 2.0},
+						"three": {3, 3.0},
+					}, true),
+				),
+				Args: []string{"three"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"m": struct {
+						A int
+						B float64
+					}{3, 3.0},
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "three"},
+					},
+				},
+			},
+		},
+		{
+			name: "MapArg.Get, GetOrDefault, and GetKey works",
+			etc: func() *commandtest.ExecuteTestCase {
+				ma := MapArg("m", testDesc, map[string]int{
+					"one":   1,
+					"two":   2,
+					"three": 3,
+				}, true)
+				otherMa := MapArg("m2", testDesc, map[string]int{
+					"one":   1,
+					"two":   2,
+					"three": 3,
+				}, true)
+
+				return &commandtest.ExecuteTestCase{
+					Node: SerialNodes(
+						ma,
+						SimpleProcessor(func(i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
+							o.Stdoutln(ma.GetKey())
+							o.Stdoutln(otherMa.GetKey())
+							o.Stdoutln(ma.Get(d))
+							o.Stdoutln(ma.GetOrDefault(d, 7))
+							o.Stdoutln(otherMa.Get(d))
+							o.Stdoutln(otherMa.GetOrDefault(d, 7))
+							return nil
+						}, nil),
+					),
+					Args: []string{"three"},
+					WantData: &command.Data{Values: map[string]interface{}{
+						"m": 3,
+					}},
+					WantStdout: strings.Join([]string{
+						"three", // ma.GetKey
+						"",      // otherMa.GetKey
+						"3",     // ma.Get
+						"3",     // ma.GetOrDefault
+						"0",     // otherMa.Get
+						"7",     // otherMa.GetOrDefault
+						"",
+					}, "\n"),
+				}
+			}(),
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "three"},
+					},
+				},
+			},
+		},
+		{
+			name: "MapArg.Get, GetOrDefault, and GetKey works with custom type",
+			etc: func() *commandtest.ExecuteTestCase {
+				type vType struct {
+					A int
+					B float64
+				}
+				ma := MapArg("m", testDesc, map[string]*vType{
+					"one":   {1, 1.1},
+					"two":   {2, 2.2},
+					"three": {3, 3.3},
+				}, true)
+				otherMa := MapArg("m2", testDesc, map[string]*vType{
+					"one":   {1, 1.1},
+					"two":   {2, 2.2},
+					"three": {3, 3.3},
+				}, true)
+				missingMa := MapArg("m3", testDesc, map[string]*vType{
+					"one":   {1, 1.1},
+					"two":   {2, 2.2},
+					"three": {3, 3.3},
+				}, true)
+
+				return &commandtest.ExecuteTestCase{
+					Node: SerialNodes(
+						ma,
+						missingMa,
+						SimpleProcessor(func(i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
+							o.Stdoutln("GetKey", ma.GetKey())
+							o.Stdoutln("Provided", ma.Provided(d))
+							o.Stdoutln("Get", ma.Get(d))
+							o.Stdoutln("GetOrDefault", ma.GetOrDefault(d, &vType{7, 7.7}))
+							o.Stdoutln("Hit", ma.Hit())
+							o.Stdoutln("===")
+							o.Stdoutln("GetKey", otherMa.GetKey())
+							o.Stdoutln("Provided", otherMa.Provided(d))
+							o.Stdoutln("Get", otherMa.Get(d))
+							o.Stdoutln("GetOrDefault", otherMa.GetOrDefault(d, &vType{7, 7.7}))
+							o.Stdoutln("Hit", otherMa.Hit())
+							o.Stdoutln("===")
+							o.Stdoutln("GetKey", missingMa.GetKey())
+							o.Stdoutln("Provided", missingMa.Provided(d))
+							o.Stdoutln("Get", missingMa.Get(d))
+							o.Stdoutln("GetOrDefault", missingMa.GetOrDefault(d, &vType{7, 7.7}))
+							o.Stdoutln("Hit", missingMa.Hit())
+							return nil
+						}, nil),
+					),
+					Args: []string{"three", "eleven"},
+					WantData: &command.Data{Values: map[string]interface{}{
+						"m":  &vType{3, 3.3},
+						"m3": (*vType)(nil),
+					}},
+					WantStdout: strings.Join([]string{
+						"GetKey three",
+						"Provided true",
+						"Get &{3 3.3}",
+						"GetOrDefault &{3 3.3}",
+						"Hit true",
+						"===",
+						"GetKey ",
+						"Provided false",
+						"Get <nil>",
+						"GetOrDefault &{7 7.7}",
+						"Hit false",
+						"===",
+						"GetKey eleven",
+						"Provided true",
+						"Get <nil>",
+						"GetOrDefault <nil>",
+						"Hit false",
+						"",
+					}, "\n"),
+				}
+			}(),
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "three"},
+						{Value: "eleven"},
+					},
+				},
+			},
+		},
+		// MapFlag
+		{
+			name: "MapFlag.Get, Provided, GetOrDefault, and GetKey works with custom type for flag",
+			etc: func() *commandtest.ExecuteTestCase {
+				type vType struct {
+					A int
+					B float64
+				}
+				ma := MapFlag("m1", 'm', testDesc, map[string]*vType{
+					"one":   {1, 1.1},
+					"two":   {2, 2.2},
+					"three": {3, 3.3},
+				}, true)
+				otherMa := MapFlag("m2", 'd', testDesc, map[string]*vType{
+					"one":   {1, 1.1},
+					"two":   {2, 2.2},
+					"three": {3, 3.3},
+				}, true)
+				missingMa := MapFlag("m3", FlagNoShortName, testDesc, map[string]*vType{
+					"one":   {1, 1.1},
+					"two":   {2, 2.2},
+					"three": {3, 3.3},
+				}, true)
+
+				return &commandtest.ExecuteTestCase{
+					Node: SerialNodes(
+						FlagProcessor(
+							ma,
+							otherMa,
+							missingMa,
+						),
+						SimpleProcessor(func(i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
+							o.Stdoutln("GetKey", ma.GetKey())
+							o.Stdoutln("Provided", ma.Provided(d))
+							o.Stdoutln("Get", ma.Get(d))
+							o.Stdoutln("GetOrDefault", ma.GetOrDefault(d, &vType{7, 7.7}))
+							o.Stdoutln("Hit", ma.Hit())
+							o.Stdoutln("===")
+							o.Stdoutln("GetKey", otherMa.GetKey())
+							o.Stdoutln("Get", otherMa.Get(d))
+							o.Stdoutln("Provided", otherMa.Provided(d))
+							o.Stdoutln("GetOrDefault", otherMa.GetOrDefault(d, &vType{7, 7.7}))
+							o.Stdoutln("Hit", otherMa.Hit())
+							o.Stdoutln("===")
+							o.Stdoutln("GetKey", missingMa.GetKey())
+							o.Stdoutln("Get", missingMa.Get(d))
+							o.Stdoutln("Provided", missingMa.Provided(d))
+							o.Stdoutln("GetOrDefault", missingMa.GetOrDefault(d, &vType{7, 7.7}))
+							o.Stdoutln("Hit", missingMa.Hit())
+							return nil
+						}, nil),
+					),
+					Args: []string{"-m", "three", "--m3", "eleven"},
+					WantData: &command.Data{Values: map[string]interface{}{
+						"m1": &vType{3, 3.3},
+						"m3": (*vType)(nil),
+					}},
+					WantStdout: strings.Join([]string{
+						"GetKey three",
+						"Provided true",
+						"Get &{3 3.3}",
+						"GetOrDefault &{3 3.3}",
+						"Hit true",
+						"===",
+						"GetKey ",
+						"Get <nil>",
+						"Provided false",
+						"GetOrDefault &{7 7.7}",
+						"Hit false",
+						"===",
+						"GetKey eleven",
+						"Get <nil>",
+						"Provided true",
+						"GetOrDefault <nil>",
+						"Hit false",
+						"",
+					}, "\n"),
+				}
+			}(),
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "-m"},
+						{Value: "three"},
+						{Value: "--m3"},
+						{Value: "eleven"},
+					},
+				},
+			},
+		},
+		// command.Usage tests
+		{
+			name: "works with single arg",
+			etc: &commandtest.ExecuteTestCase{
+				Args: []string{"--help"},
+				Node: SerialNodes(Arg[string]("SARG", "desc")),
+				WantStdout: strings.Join([]string{
+					"SARG",
+					"",
+					"Arguments:",
+					"  SARG: desc",
+					"",
+				}, "\n"),
+			},
+		},
+		// Panic tests
+		{
+			name: "forwards panic",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					&ExecutorProcessor{func(o command.Output, d *command.Data) error {
+						panic("oh no!")
+					}},
+				),
+				WantPanic: "oh no!",
+			},
+		},
+		// MutableProcessor tests
+		{
+			name: "Reference does not update underlying processor",
+			etc: func() *commandtest.ExecuteTestCase {
+				hi := PrintlnProcessor("hi")
+				hello := PrintlnProcessor("hello")
+
+				return &commandtest.ExecuteTestCase{
+					Node: SerialNodes(
+						SuperSimpleProcessor(func(i *command.Input, d *command.Data) error {
+							hi = hello
+							return nil
+						}),
+						hi,
+						SuperSimpleProcessor(func(i *command.Input, d *command.Data) error {
+							fo := commandtest.NewOutput()
+							if err := hi.Execute(nil, fo, nil, nil); err != nil {
+								return err
+							}
+							fo.Close()
+							d.Set("FINAL", fo.GetStdout())
+							return nil
+						}),
+					),
+					WantStdout: "hi\n",
+					WantData: &command.Data{Values: map[string]interface{}{
+						"FINAL": "hello\n",
+					}},
+				}
+			}(),
+		},
+		{
+			name: "MutableProcessor DOES update underlying processor",
+			etc: func() *commandtest.ExecuteTestCase {
+				hi := NewMutableProcessor[command.Processor](PrintlnProcessor("hi"))
+				hello := PrintlnProcessor("hello")
+
+				return &commandtest.ExecuteTestCase{
+					Node: SerialNodes(
+						SuperSimpleProcessor(func(i *command.Input, d *command.Data) error {
+							hi.Processor = &hello
+							return nil
+						}),
+						hi,
+						SuperSimpleProcessor(func(i *command.Input, d *command.Data) error {
+							fo := commandtest.NewOutput()
+							if err := hi.Execute(nil, fo, nil, nil); err != nil {
+								return err
+							}
+							fo.Close()
+							d.Set("FINAL", fo.GetStdout())
+							return nil
+						}),
+					),
+					WantStdout: "hello\n",
+					WantData: &command.Data{Values: map[string]interface{}{
+						"FINAL": "hello\n",
+					}},
+				}
+			}(),
+		},
+		/* Useful for commenting out tests. */
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			stubs.StubGetwd(t, test.osGetwd, test.osGetwdErr)
+
+			if test.etc == nil {
+				test.etc = &commandtest.ExecuteTestCase{}
+			}
+			if test.etc.OS == nil {
+				test.etc.OS = fos
+			}
+			if test.ietc == nil {
+				test.ietc = &spycommandtest.ExecuteTestCase{}
+			}
+			executeTest(t, test.etc, test.ietc)
+		})
+	}
+}
+
+func abc() command.Node {
+	return &BranchNode{
+		Branches: map[string]command.Node{
+			"t": ShortcutNode("TEST_SHORTCUT", nil,
+				CacheNode("TEST_CACHE", nil, SerialNodes(
+					&tt{},
+					Arg[string]("PATH", testDesc, SimpleCompleter[string]("clh111", "abcd111")),
+					Arg[string]("TARGET", testDesc, SimpleCompleter[string]("clh222", "abcd222")),
+					Arg[string]("FUNC", testDesc, SimpleCompleter[string]("clh333", "abcd333")),
+				))),
+		},
+		DefaultCompletion: true,
+	}
+}
+
+type tt struct{}
+
+func (t *tt) Usage(*command.Input, *command.Data, *command.Usage) error { return nil }
+func (t *tt) Execute(input *command.Input, output command.Output, data *command.Data, e *command.ExecuteData) error {
+	t.do(input, data)
+	return nil
+}
+
+func (t *tt) do(input *command.Input, data *command.Data) {
+	if s, ok := input.Peek(); ok && strings.Contains(s, ":") {
+		if ss := strings.Split(s, ":"); len(ss) == 2 {
+			input.Pop(data)
+			input.PushFront(ss...)
+		}
+	}
+}
+
+func (t *tt) Complete(input *command.Input, data *command.Data) (*command.Completion, error) {
+	t.do(input, data)
+	return nil, nil
+}
+
+func TestComplete(t *testing.T) {
+	breakerFlagProcessor := FlagProcessor(
+		Flag[string]("greeting", 'h', testDesc, SimpleCompleter[string]("hey", "hi")),
+		ListFlag[string]("names", 'n', testDesc, 1, 2, SimpleCompleter[[]string]("ralph", "johnny", "renee")),
+		BoolFlag("good", 'g', testDesc),
+	)
+	for _, test := range []struct {
+		name           string
+		ctc            *commandtest.CompleteTestCase
+		ictc           *spycommandtest.CompleteTestCase
+		filepathAbs    string
+		filepathAbsErr error
+		osGetwd        string
+		osGetwdErr     error
+	}{
+		{
+			name: "stuff",
+			ctc: &commandtest.CompleteTestCase{
+				Node: abc(),
+				Args: "cmd t clh:abc",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"abcd222"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"PATH":   "clh",
+					"TARGET": "abc",
+				}},
+			},
+		},
+		// Basic tests
+		{
+			name: "empty graph",
+			ctc: &commandtest.CompleteTestCase{
+				Node:    &SimpleNode{},
+				WantErr: fmt.Errorf("Unprocessed extra args: []"),
+			},
+			ictc: &spycommandtest.CompleteTestCase{
+				WantIsExtraArgsError: true,
+				WantIsUsageError:     true,
+			},
+		},
+		{
+			name: "returns suggestions of first node if empty",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(
+					Arg[string]("s", testDesc, SimpleCompleter[string]("un", "deux", "trois")),
+					OptionalArg[int]("i", testDesc, SimpleCompleter[int]("2", "1")),
+					ListArg[string]("sl", testDesc, 0, 2, SimpleCompleter[[]string]("uno", "dos")),
+				),
+				Want: &command.Autocompletion{
+					Suggestions: []string{"deux", "trois", "un"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"s": "",
+				}},
+			},
+		},
+		{
+			name: "returns suggestions of first node if up to first arg",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(
+					Arg[string]("s", testDesc, SimpleCompleter[string]("one", "two", "three")),
+					OptionalArg[int]("i", testDesc, SimpleCompleter[int]("2", "1")),
+					ListArg[string]("sl", testDesc, 0, 2, SimpleCompleter[[]string]("uno", "dos")),
+				),
+				Args: "cmd t",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"three", "two"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"s": "t",
+				}},
+			},
+		},
+		{
+			name: "returns suggestions of middle node if that's where we're at",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(
+					Arg[string]("s", testDesc, SimpleCompleter[string]("one", "two", "three")),
+					ListArg[string]("sl", testDesc, 0, 2, SimpleCompleter[[]string]("uno", "dos")),
+					OptionalArg[int]("i", testDesc, SimpleCompleter[int]("2", "1")),
+				),
+				Args: "cmd three ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"dos", "uno"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"s":  "three",
+					"sl": []string{""},
+				}},
+			},
+		},
+		{
+			name: "returns suggestions of middle node if partial",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(
+					Arg[string]("s", testDesc, SimpleCompleter[string]("one", "two", "three")),
+					ListArg[string]("sl", testDesc, 0, 2, SimpleCompleter[[]string]("uno", "dos")),
+					OptionalArg[int]("i", testDesc, SimpleCompleter[int]("2", "1")),
+				),
+				Args: "cmd three d",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"dos"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"s":  "three",
+					"sl": []string{"d"},
+				}},
+			},
+		},
+		{
+			name: "returns suggestions in list",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(
+					Arg[string]("s", testDesc, SimpleCompleter[string]("one", "two", "three")),
+					ListArg[string]("sl", testDesc, 0, 2, SimpleCompleter[[]string]("uno", "dos")),
+					OptionalArg[int]("i", testDesc, SimpleCompleter[int]("2", "1")),
+				),
+				Args: "cmd three dos ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"dos", "uno"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"s":  "three",
+					"sl": []string{"dos", ""},
+				}},
+			},
+		},
+		{
+			name: "returns suggestions for last arg",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(
+					Arg[string]("s", testDesc, SimpleCompleter[string]("one", "two", "three")),
+					ListArg[string]("sl", testDesc, 0, 2, SimpleCompleter[[]string]("uno", "dos")),
+					OptionalArg[int]("i", testDesc, SimpleCompleter[int]("2", "1")),
+				),
+				Args: "cmd three uno dos ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"1", "2"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"s":  "three",
+					"sl": []string{"uno", "dos"},
+				}},
+			},
+		},
+		{
+			name: "returns nothing if iterate through all nodes",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(
+					Arg[string]("s", testDesc, SimpleCompleter[string]("one", "two", "three")),
+					ListArg[string]("sl", testDesc, 0, 2, SimpleCompleter[[]string]("uno", "dos")),
+					OptionalArg[int]("i", testDesc, SimpleCompleter[int]("2", "1")),
+				),
+				Args: "cmd three uno dos 1 what now",
+				WantData: &command.Data{Values: map[string]interface{}{
+					"s":  "three",
+					"sl": []string{"uno", "dos"},
+					"i":  1,
+				}},
+				WantErr: fmt.Errorf("Unprocessed extra args: [what now]"),
+			},
+			ictc: &spycommandtest.CompleteTestCase{
+				WantIsExtraArgsError: true,
+				WantIsUsageError:     true,
+			},
+		},
+		{
+			name: "works if empty and list starts",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(
+					ListArg[string]("sl", testDesc, 1, 2, SimpleCompleter[[]string]("uno", "dos")),
+				),
+				Want: &command.Autocompletion{
+					Suggestions: []string{"dos", "uno"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"sl": []string{""},
+				}},
+			},
+		},
+		{
+			name: "only returns suggestions matching prefix",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(
+					ListArg[string]("sl", testDesc, 1, 2, SimpleCompleter[[]string]("zzz-1", "zzz-2", "yyy-3", "zzz-4")),
+				),
+				Args: "cmd zz",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"zzz-1", "zzz-2", "zzz-4"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"sl": []string{"zz"},
+				}},
+			},
+		},
+		{
+			name: "if fail to convert arg, then don't complete",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(
+					Arg[string]("s1", testDesc, SimpleCompleter[string]("one", "two", "three")),
+					Arg[int]("i", testDesc),
+					Arg[string]("s2", testDesc, SimpleCompleter[string]("abc", "alpha")),
+				),
+				Args:    "cmd three two a",
+				WantErr: fmt.Errorf(`strconv.Atoi: parsing "two": invalid syntax`),
+				WantData: &command.Data{Values: map[string]interface{}{
+					"s1": "three",
+				}},
+			},
+		},
+		{
+			name: "if fail to convert arg in best-effort mode, then complete later arg",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(
+					BestEffortCompletion(),
+					Arg[string]("s1", testDesc, SimpleCompleter[string]("one", "two", "three")),
+					Arg[int]("i", testDesc),
+					Arg[string]("s2", testDesc, SimpleCompleter[string]("abc", "alpha", "bravo")),
+				),
+				Args: "cmd three two a",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"abc", "alpha"},
+				},
+				WantData: &command.Data{
+					Values: map[string]interface{}{
+						"s1": "three",
+						"s2": "a",
+					},
+				},
+			},
+		},
+		{
+			name: "if fail to convert list arg in best-effort mode, then complete later flag",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(
+					BestEffortCompletion(),
+					FlagProcessor(
+						Flag[float64]("float", 'f', testDesc),
+						Flag[string]("str", 's', testDesc, SimpleCompleter[string]("xyz", "xylophone", "zebra")),
+					),
+				),
+				Args: "cmd --float nope --str xy",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"xylophone", "xyz"},
+				},
+				WantData: &command.Data{
+					Values: map[string]interface{}{
+						"str": "xy",
+					},
+				},
+			},
+		},
+		// Ensure completion iteration stops if necessary.
+		{
+			name: "stop iterating if a completion returns nil",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(
+					Arg[string]("PATH", "dd", SimpleCompleter[string]()),
+					ListArg[string]("SUB_PATH", "stc", 0, command.UnboundedList, SimpleCompleter[[]string]("un", "deux", "trois")),
+				),
+				Args: "cmd p",
+				WantData: &command.Data{Values: map[string]interface{}{
+					"PATH": "p",
+				}},
+			},
+		},
+		{
+			name: "stop iterating if a completion returns an error",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(
+					Arg[string]("PATH", "dd", CompleterFromFunc(func(string, *command.Data) (*command.Completion, error) {
+						return nil, fmt.Errorf("ruh-roh")
+					})),
+					ListArg[string]("SUB_PATH", "stc", 0, command.UnboundedList, SimpleCompleter[[]string]("un", "deux", "trois")),
+				),
+				Args:    "cmd p",
+				WantErr: fmt.Errorf("ruh-roh"),
+				WantData: &command.Data{Values: map[string]interface{}{
+					"PATH": "p",
+				}},
+			},
+		},
+		{
+			name: "fails if edge returns an error",
+			ctc: &commandtest.CompleteTestCase{
+				Node: &SimpleNode{
+					Edge: &errorEdge{fmt.Errorf("whoops")},
+				},
+				Args:    "cmd p",
+				WantErr: fmt.Errorf("whoops"),
+			},
+		},
+		// Flag completion
+		{
+			name: "bool flag gets set if not last one",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						Flag[string]("greeting", 'h', testDesc, SimpleCompleter[string]("hey", "hi")),
+						ListFlag[string]("names", 'n', testDesc, 1, 2, SimpleCompleter[[]string]("ralph", "johnny", "renee")),
+						BoolFlag("good", 'g', testDesc),
+					),
+					Arg[int]("i", testDesc, SimpleCompleter[int]("1", "2")),
+				),
+				Args: "cmd -g ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"1", "2"},
+				},
+				WantData: &command.Data{
+					Values: map[string]interface{}{
+						"good": true,
+					},
+				},
+			},
+		},
+		{
+			name: "arg flag gets set if not last one",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						Flag[string]("greeting", 'h', testDesc, SimpleCompleter[string]("hey", "hi")),
+						ListFlag[string]("names", 'n', testDesc, 1, 2, SimpleCompleter[[]string]("ralph", "johnny", "renee")),
+						BoolFlag("good", 'g', testDesc),
+					),
+					Arg[int]("i", testDesc, SimpleCompleter[int]("1", "2")),
+				),
+				Args: "cmd --greeting howdy ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"1", "2"},
+				},
+				WantData: &command.Data{
+					Values: map[string]interface{}{
+						"greeting": "howdy",
+					},
+				},
+			},
+		},
+		{
+			name: "list arg flag gets set if not last one",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						Flag[string]("greeting", 'h', testDesc, SimpleCompleter[string]("hey", "hi")),
+						ListFlag[string]("names", 'n', testDesc, 1, 2, SimpleCompleter[[]string]("ralph", "johnny", "renee")),
+						BoolFlag("good", 'g', testDesc),
+					),
+					Arg[int]("i", testDesc, SimpleCompleter[int]("1", "2")),
+				),
+				Args: "cmd --names alice bob charlie ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"1", "2"},
+				},
+				WantData: &command.Data{
+					Values: map[string]interface{}{
+						"names": []string{"alice", "bob", "charlie"},
+					},
+				},
+			},
+		},
+		{
+			name: "multiple flags get set if not last one",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						Flag[string]("greeting", 'h', testDesc, SimpleCompleter[string]("hey", "hi")),
+						ListFlag[string]("names", 'n', testDesc, 1, 2, SimpleCompleter[[]string]("ralph", "johnny", "renee")),
+						BoolFlag("good", 'g', testDesc),
+					),
+					Arg[int]("i", testDesc, SimpleCompleter[int]("1", "2")),
+				),
+				Args: "cmd -n alice bob charlie --good -h howdy ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"1", "2"},
+				},
+				WantData: &command.Data{
+					Values: map[string]interface{}{
+						"names":    []string{"alice", "bob", "charlie"},
+						"good":     true,
+						"greeting": "howdy",
+					},
+				},
+			},
+		},
+		{
+			name: "flag name gets completed if single hyphen at end",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						Flag[string]("greeting", 'h', testDesc, SimpleCompleter[string]("hey", "hi")),
+						ListFlag[string]("names", 'n', testDesc, 1, 2, SimpleCompleter[[]string]("ralph", "johnny", "renee")),
+						BoolFlag("good", 'g', testDesc),
+					),
+					Arg[int]("i", testDesc, SimpleCompleter[int]("1", "2")),
+				),
+				Args: "cmd -",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"--good", "--greeting", "--names"},
+				},
+			},
+		},
+		{
+			name: "flag name gets completed if double hyphen at end",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						Flag[string]("greeting", 'h', testDesc, SimpleCompleter[string]("hey", "hi")),
+						ListFlag[string]("names", 'n', testDesc, 1, 2, SimpleCompleter[[]string]("ralph", "johnny", "renee")),
+						BoolFlag("good", 'g', testDesc),
+					),
+					Arg[int]("i", testDesc, SimpleCompleter[int]("1", "2")),
+				),
+				Args: "cmd --",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"--good", "--greeting", "--names"},
+				},
+			},
+		},
+		{
+			name: "flag name gets completed if it's the only arg",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						Flag[string]("greeting", 'h', testDesc, SimpleCompleter[string]("hey", "hi")),
+						ListFlag[string]("names", 'n', testDesc, 1, 2, SimpleCompleter[[]string]("ralph", "johnny", "renee")),
+						BoolFlag("good", 'g', testDesc),
+					),
+					Arg[int]("i", testDesc, SimpleCompleter[int]("1", "2")),
+				),
+				Args: "cmd 1 -",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"--good", "--greeting", "--names"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"i": 1,
+				}},
+			},
+		},
+		{
+			name: "partial flag name gets completed",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						Flag[string]("greeting", 'h', testDesc, SimpleCompleter[string]("hey", "hi")),
+						ListFlag[string]("names", 'n', testDesc, 1, 2, SimpleCompleter[[]string]("ralph", "johnny", "renee")),
+						BoolFlag("good", 'g', testDesc),
+					),
+					Arg[int]("i", testDesc, SimpleCompleter[int]("1", "2")),
+				),
+				Args: "cmd --gr",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"--greeting"},
+				},
+			},
+		},
+		{
+			name: "full flag name gets completed",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						Flag[string]("greeting", 'h', testDesc, SimpleCompleter[string]("hey", "hi")),
+						ListFlag[string]("names", 'n', testDesc, 1, 2, SimpleCompleter[[]string]("ralph", "johnny", "renee")),
+						BoolFlag("good", 'g', testDesc),
+					),
+					Arg[int]("i", testDesc, SimpleCompleter[int]("1", "2")),
+				),
+				Args: "cmd --names",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"--names"},
+				},
+			},
+		},
+		// Flag value completions
+		{
+			name: "completes for single flag",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						Flag[string]("greeting", 'h', testDesc, SimpleCompleter[string]("hey", "hi")),
+						ListFlag[string]("names", 'n', testDesc, 1, 2, SimpleCompleter[[]string]("ralph", "johnny", "renee")),
+						BoolFlag("good", 'g', testDesc),
+					),
+					Arg[int]("i", testDesc, SimpleCompleter[int]("1", "2")),
+				),
+				Args: "cmd 1 --greeting h",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"hey", "hi"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"greeting": "h",
+				}},
+			},
+		},
+		{
+			name: "completes for single short flag",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						Flag[string]("greeting", 'h', testDesc, SimpleCompleter[string]("hey", "hi")),
+						ListFlag[string]("names", 'n', testDesc, 1, 2, SimpleCompleter[[]string]("ralph", "johnny", "renee")),
+						BoolFlag("good", 'g', testDesc),
+					),
+					Arg[int]("i", testDesc, SimpleCompleter[int]("1", "2")),
+				),
+				Args: "cmd 1 -h he",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"hey"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"greeting": "he",
+				}},
+			},
+		},
+		{
+			name: "completes for list flag",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						Flag[string]("greeting", 'h', testDesc, SimpleCompleter[string]("hey", "hi")),
+						ListFlag[string]("names", 'n', testDesc, 1, 2, SimpleCompleter[[]string]("ralph", "johnny", "renee")),
+						BoolFlag("good", 'g', testDesc),
+					),
+					Arg[int]("i", testDesc, SimpleCompleter[int]("1", "2")),
+				),
+				Args: "cmd 1 -h hey other --names ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"johnny", "ralph", "renee"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"greeting": "hey",
+					"names":    []string{""},
+				}},
+			},
+		},
+		{
+			name: "completes distinct secondary for list flag",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						Flag[string]("greeting", 'h', testDesc, SimpleCompleter[string]("hey", "hi")),
+						ListFlag[string]("names", 'n', testDesc, 1, 2, SimpleDistinctCompleter[[]string]("ralph", "johnny", "renee")),
+						BoolFlag("good", 'g', testDesc),
+					),
+					Arg[int]("i", testDesc, SimpleCompleter[int]("1", "2")),
+				),
+				Args: "cmd 1 -h hey other --names ralph ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"johnny", "renee"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"greeting": "hey",
+					"names":    []string{"ralph", ""},
+				}},
+			},
+		},
+		{
+			name: "completes last flag",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						Flag[string]("greeting", 'h', testDesc, SimpleCompleter[string]("hey", "hi")),
+						ListFlag[string]("names", 'n', testDesc, 1, 2, SimpleDistinctCompleter[[]string]("ralph", "johnny", "renee")),
+						BoolFlag("good", 'g', testDesc),
+						Flag[float64]("float", 'f', testDesc, SimpleCompleter[float64]("1.23", "12.3", "123.4")),
+					),
+					Arg[int]("i", testDesc, SimpleCompleter[int]("1", "2")),
+				),
+				Args: "cmd 1 -h hey other --names ralph renee johnny -f ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"1.23", "12.3", "123.4"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"greeting": "hey",
+					"names":    []string{"ralph", "renee", "johnny"},
+				}},
+			},
+		},
+		{
+			name: "completes arg if flag arg isn't at the end",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						Flag[string]("greeting", 'h', testDesc, SimpleCompleter[string]("hey", "hi")),
+						ListFlag[string]("names", 'n', testDesc, 1, 2, SimpleDistinctCompleter[[]string]("ralph", "johnny", "renee")),
+						BoolFlag("good", 'g', testDesc),
+					),
+					ListArg[string]("i", testDesc, 1, 2, SimpleCompleter[[]string]("hey", "ooo")),
+				),
+				Args: "cmd 1 -h hello bravo --names ralph renee johnny ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"hey", "ooo"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"i":        []string{"1", "bravo", ""},
+					"greeting": "hello",
+					"names":    []string{"ralph", "renee", "johnny"},
+				}},
+			},
+		},
+		// Duration completion tests
+		{
+			name: "DurationArg completion is a no-op without a completer",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(DurationArg("timeout", testDesc)),
+				Args: "cmd 5",
+			},
+		},
+		{
+			name: "DurationFlag uses provided completer",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(FlagProcessor(
+					DurationFlag("timeout", 't', testDesc, SimpleCompleter[time.Duration]("30s", "1m", "5m")),
+				)),
+				Args: "cmd -t ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"1m", "30s", "5m"},
+				},
+			},
+		},
+		// Mid-list flag completion tests
+		{
+			name: "completes second element for list flag",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						ListFlag[string]("names", 'n', testDesc, 1, command.UnboundedList, SimpleCompleter[[]string]("ralph", "johnny", "renee")),
+					),
+				),
+				Args: "cmd --names ralph ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"johnny", "ralph", "renee"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"names": []string{"ralph", ""},
+				}},
+			},
+		},
+		{
+			name: "completes remaining distinct elements for list flag",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						ListFlag[string]("names", 'n', testDesc, 1, command.UnboundedList, SimpleDistinctCompleter[[]string]("ralph", "johnny", "renee", "rhonda")),
+					),
+				),
+				Args: "cmd --names ralph ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"johnny", "renee", "rhonda"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"names": []string{"ralph", ""},
+				}},
+			},
+		},
+		{
+			name: "completes partial later element for list flag",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						ListFlag[string]("names", 'n', testDesc, 1, command.UnboundedList, SimpleDistinctCompleter[[]string]("ralph", "johnny", "renee", "rhonda")),
+					),
+				),
+				Args: "cmd -n ralph johnny r",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"renee", "rhonda"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"names": []string{"ralph", "johnny", "r"},
+				}},
+			},
+		},
+		{
+			name: "list flag completer gets previous elements as context",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						ListFlag[string]("names", 'n', testDesc, 1, command.UnboundedList, CompleterFromFunc(func(names []string, d *command.Data) (*command.Completion, error) {
+							prev := names[:len(names)-1]
+							return &command.Completion{
+								Suggestions: []string{fmt.Sprintf("after-%d", len(prev)), fmt.Sprintf("after-%s", strings.Join(prev, "-"))},
+							}, nil
+						})),
+					),
+				),
+				Args: "cmd --names ralph johnny ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"after-2", "after-ralph-johnny"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"names": []string{"ralph", "johnny", ""},
+				}},
+			},
+		},
+		{
+			name: "completes later list flag element before other args",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						ListFlag[string]("names", 'n', testDesc, 1, command.UnboundedList, SimpleDistinctCompleter[[]string]("ralph", "johnny", "renee")),
+						BoolFlag("good", 'g', testDesc),
+					),
+					Arg[string]("s", testDesc, SimpleCompleter[string]("un", "deux")),
+				),
+				Args: "cmd un -g --names renee ralph ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"johnny"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"good":  true,
+					"names": []string{"renee", "ralph", ""},
+				}},
+			},
+		},
+		// Multi-flag tests
+		{
+			name: "Multi-flags get completed with remaining combinable flags",
+			ctc: &commandtest.CompleteTestCase{
+				Args: "cmd -qwer",
+				Node: SerialNodes(
+					FlagProcessor(
+						BoolFlag("everyone", 'e', testDesc),
+						BoolFlag("quick", 'q', testDesc),
+						BoolFlag("run", 'r', testDesc),
+						BoolFlag("to", 't', testDesc),
+						BoolFlag("where", 'w', testDesc),
+					),
+				),
+				Want: &command.Autocompletion{
+					Suggestions: []string{"-qwer", "-qwert"},
+				},
+			},
+		},
+		{
+			name: "Partial multi-flag suggests remaining combinable bool flags",
+			ctc: &commandtest.CompleteTestCase{
+				Args: "cmd -qw",
+				Node: SerialNodes(
+					FlagProcessor(
+						BoolFlag("everyone", 'e', testDesc),
+						BoolFlag("quick", 'q', testDesc),
+						BoolFlag("run", 'r', testDesc),
+						BoolFlag("to", 't', testDesc),
+						BoolFlag("where", 'w', testDesc),
+						Flag[string]("greeting", 'g', testDesc),
+						BoolFlag("one", '1', testDesc),
+						BoolFlag("no-short", FlagNoShortName, testDesc),
+					),
+				),
+				Want: &command.Autocompletion{
+					Suggestions: []string{"-qw", "-qwe", "-qwr", "-qwt"},
+				},
+			},
+		},
+		{
+			name: "Single short flag suggests combinable bool flags",
+			ctc: &commandtest.CompleteTestCase{
+				Args: "cmd -q",
+				Node: SerialNodes(
+					FlagProcessor(
+						BoolFlag("everyone", 'e', testDesc),
+						BoolFlag("quick", 'q', testDesc),
+						BoolFlag("run", 'r', testDesc),
+						BoolFlag("to", 't', testDesc),
+						BoolFlag("where", 'w', testDesc),
+						Flag[string]("greeting", 'g', testDesc),
+						BoolFlag("one", '1', testDesc),
+						BoolFlag("no-short", FlagNoShortName, testDesc),
+					),
+				),
+				Want: &command.Autocompletion{
+					Suggestions: []string{"-q", "-qe", "-qr", "-qt", "-qw"},
+				},
+			},
 		},
-		/* Useful for commenting out tests. */
-	} {
-		t.Run(test.name, func(t *testing.T) {
-			stubs.StubGetwd(t, test.osGetwd, test.osGetwdErr)
-
-			if test.etc == nil {
-				test.etc = &commandtest.ExecuteTestCase{}
-			}
-			if test.etc.OS == nil {
-				test.etc.OS = fos
-			}
-			if test.ietc == nil {
-				test.ietc = &spycommandtest.ExecuteTestCase{}
-			}
-			executeTest(t, test.etc, test.ietc)
-		})
-	}
-}
-
-func abc() command.Node {
-	return &BranchNode{
-		Branches: map[string]command.Node{
-			"t": ShortcutNode("TEST_SHORTCUT", nil,
-				CacheNode("TEST_CACHE", nil, SerialNodes(
-					&tt{},
-					Arg[string]("PATH", testDesc, SimpleCompleter[string]("clh111", "abcd111")),
-					Arg[string]("TARGET", testDesc, SimpleCompleter[string]("clh222", "abcd222")),
-					Arg[string]("FUNC", testDesc, SimpleCompleter[string]("clh333", "abcd333")),
-				))),
+		{
+			name: "Partial multi-flag doesn't suggest flags that were already provided",
+			ctc: &commandtest.CompleteTestCase{
+				Args: "cmd -e -qw",
+				Node: SerialNodes(
+					FlagProcessor(
+						BoolFlag("everyone", 'e', testDesc),
+						BoolFlag("quick", 'q', testDesc),
+						BoolFlag("run", 'r', testDesc),
+						BoolFlag("to", 't', testDesc),
+						BoolFlag("where", 'w', testDesc),
+						Flag[string]("greeting", 'g', testDesc),
+						BoolFlag("one", '1', testDesc),
+						BoolFlag("no-short", FlagNoShortName, testDesc),
+					),
+				),
+				Want: &command.Autocompletion{
+					Suggestions: []string{"-qw", "-qwr", "-qwt"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"everyone": true,
+				}},
+			},
 		},
-		DefaultCompletion: true,
-	}
-}
-
-type tt struct{}
-
-func (t *tt) Usage(*command.Input, *command.Data, *command.Usage) error { return nil }
-func (t *tt) Execute(input *command.Input, output command.Output, data *command.Data, e *command.ExecuteData) error {
-	t.do(input, data)
-	return nil
-}
-
-func (t *tt) do(input *command.Input, data *command.Data) {
-	if s, ok := input.Peek(); ok && strings.Contains(s, ":") {
-		if ss := strings.Split(s, ":"); len(ss) == 2 {
-			input.Pop(data)
-			input.PushFront(ss...)
-		}
-	}
-}
-
-func (t *tt) Complete(input *command.Input, data *command.Data) (*command.Completion, error) {
-	t.do(input, data)
-	return nil, nil
-}
-
-func TestComplete(t *testing.T) {
-	breakerFlagProcessor := FlagProcessor(
-		Flag[string]("greeting", 'h', testDesc, SimpleCompleter[string]("hey", "hi")),
-		ListFlag[string]("names", 'n', testDesc, 1, 2, SimpleCompleter[[]string]("ralph", "johnny", "renee")),
-		BoolFlag("good", 'g', testDesc),
-	)
-	for _, test := range []struct {
-		name           string
-		ctc            *commandtest.CompleteTestCase
-		ictc           *spycommandtest.CompleteTestCase
-		filepathAbs    string
-		filepathAbsErr error
-		osGetwd        string
-		osGetwdErr     error
-	}{
 		{
-			name: "stuff",
+			name: "Partial multi-flag isn't completed if it includes an uncombinable flag",
 			ctc: &commandtest.CompleteTestCase{
-				Node: abc(),
-				Args: "cmd t clh:abc",
+				Args: "cmd -qg",
+				Node: SerialNodes(
+					FlagProcessor(
+						BoolFlag("everyone", 'e', testDesc),
+						BoolFlag("quick", 'q', testDesc),
+						BoolFlag("run", 'r', testDesc),
+						BoolFlag("to", 't', testDesc),
+						BoolFlag("where", 'w', testDesc),
+						Flag[string]("greeting", 'g', testDesc),
+						BoolFlag("one", '1', testDesc),
+						BoolFlag("no-short", FlagNoShortName, testDesc),
+					),
+				),
+			},
+		},
+		{
+			name: "Partial multi-flag isn't completed if it includes an unknown flag",
+			ctc: &commandtest.CompleteTestCase{
+				Args: "cmd -qz",
+				Node: SerialNodes(
+					FlagProcessor(
+						BoolFlag("everyone", 'e', testDesc),
+						BoolFlag("quick", 'q', testDesc),
+						BoolFlag("run", 'r', testDesc),
+						BoolFlag("to", 't', testDesc),
+						BoolFlag("where", 'w', testDesc),
+						Flag[string]("greeting", 'g', testDesc),
+						BoolFlag("one", '1', testDesc),
+						BoolFlag("no-short", FlagNoShortName, testDesc),
+					),
+				),
+			},
+		},
+		{
+			name: "Args after multi-flags get completed",
+			ctc: &commandtest.CompleteTestCase{
+				Args: "cmd -qwer ",
+				Node: SerialNodes(
+					FlagProcessor(
+						BoolFlag("everyone", 'e', testDesc),
+						BoolFlag("quick", 'q', testDesc),
+						BoolFlag("run", 'r', testDesc),
+						BoolFlag("to", 't', testDesc),
+						BoolFlag("where", 'w', testDesc),
+					),
+					Arg[string]("s", testDesc, SimpleCompleter[string]("abc", "def", "ghi")),
+				),
 				Want: &command.Autocompletion{
-					Suggestions: []string{"abcd222"},
+					Suggestions: []string{"abc", "def", "ghi"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"quick":    true,
+					"where":    true,
+					"everyone": true,
+					"run":      true,
+					"s":        "",
+				}},
+			},
+		},
+		{
+			name: "Args after multi-flags get completed, even if unknown flag included",
+			ctc: &commandtest.CompleteTestCase{
+				Args: "cmd -qwertyuiop ",
+				Node: SerialNodes(
+					FlagProcessor(
+						BoolFlag("everyone", 'e', testDesc),
+						BoolFlag("quick", 'q', testDesc),
+						BoolFlag("run", 'r', testDesc),
+						BoolFlag("to", 't', testDesc),
+						BoolFlag("where", 'w', testDesc),
+					),
+					Arg[string]("s", testDesc, SimpleCompleter[string]("abc", "def", "ghi")),
+				),
+				Want: &command.Autocompletion{
+					Suggestions: []string{"abc", "def", "ghi"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"quick":    true,
+					"where":    true,
+					"everyone": true,
+					"run":      true,
+					"to":       true,
+					"s":        "",
+				}},
+			},
+		},
+		{
+			name: "Args after multi-flags get completed, even if uncombinable flag is included",
+			ctc: &commandtest.CompleteTestCase{
+				Args: "cmd -qwz ",
+				Node: SerialNodes(
+					FlagProcessor(
+						BoolFlag("everyone", 'e', testDesc),
+						BoolFlag("quick", 'q', testDesc),
+						BoolFlag("run", 'r', testDesc),
+						BoolFlag("to", 't', testDesc),
+						Flag[string]("zf", 'z', testDesc),
+						BoolFlag("where", 'w', testDesc),
+					),
+					Arg[string]("s", testDesc, SimpleCompleter[string]("abc", "def", "ghi")),
+				),
+				Want: &command.Autocompletion{
+					Suggestions: []string{"abc", "def", "ghi"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"quick": true,
+					"where": true,
+					"s":     "",
+				}},
+			},
+		},
+		// Duplicate flag tests
+		{
+			name: "Repeated flag still gets completed",
+			ctc: &commandtest.CompleteTestCase{
+				Args: "cmd -z firstZ -z ",
+				Node: SerialNodes(
+					FlagProcessor(
+						BoolFlag("everyone", 'e', testDesc),
+						BoolFlag("quick", 'q', testDesc),
+						BoolFlag("run", 'r', testDesc),
+						BoolFlag("to", 't', testDesc),
+						Flag[string]("zf", 'z', testDesc, SimpleCompleter[string]("zyx", "wvu", "tsr")),
+						BoolFlag("where", 'w', testDesc),
+					),
+				),
+				Want: &command.Autocompletion{
+					Suggestions: []string{"tsr", "wvu", "zyx"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"zf": "",
+				}},
+			},
+		},
+		{
+			name: "Repeated flag still gets completed even if other repetition in multi-flags",
+			ctc: &commandtest.CompleteTestCase{
+				Args: "cmd --quick -qwrqw --where -z firstZ -z ",
+				Node: SerialNodes(
+					FlagProcessor(
+						BoolFlag("everyone", 'e', testDesc),
+						BoolFlag("quick", 'q', testDesc),
+						BoolFlag("run", 'r', testDesc),
+						BoolFlag("to", 't', testDesc),
+						Flag[string]("zf", 'z', testDesc, SimpleCompleter[string]("zyx", "wvu", "tsr")),
+						BoolFlag("where", 'w', testDesc),
+					),
+				),
+				Want: &command.Autocompletion{
+					Suggestions: []string{"tsr", "wvu", "zyx"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"quick": true,
+					"where": true,
+					"run":   true,
+					"zf":    "",
+				}},
+			},
+		},
+		{
+			name: "Don't suggest already seen flag names",
+			ctc: &commandtest.CompleteTestCase{
+				Args: "cmd -z firstZ --everyone --ilf heyo --run -",
+				Node: SerialNodes(
+					FlagProcessor(
+						BoolFlag("everyone", 'e', testDesc),
+						BoolFlag("quick", 'q', testDesc),
+						BoolFlag("run", 'r', testDesc),
+						BoolFlag("to", 't', testDesc),
+						Flag[string]("zf", 'z', testDesc, SimpleCompleter[string]("zyx", "wvu", "tsr")),
+						ItemizedListFlag[string]("ilf", 'i', testDesc),
+						BoolFlag("where", 'w', testDesc),
+					),
+				),
+				Want: &command.Autocompletion{
+					Suggestions: []string{
+						// ilf still gets completed because it allows multiple.
+						"--ilf",
+						"--quick",
+						"--to",
+						"--where",
+					},
 				},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"PATH":   "clh",
-					"TARGET": "abc",
+					"everyone": true,
+					"run":      true,
+					"zf":       "firstZ",
 				}},
 			},
 		},
-		// Basic tests
+		// OptionalFlag tests
 		{
-			name: "empty graph",
+			name: "OptionalFlag gets completed",
 			ctc: &commandtest.CompleteTestCase{
-				Node:    &SimpleNode{},
-				WantErr: fmt.Errorf("Unprocessed extra args: []"),
-			},
-			ictc: &spycommandtest.CompleteTestCase{
-				WantIsExtraArgsError: true,
-				WantIsUsageError:     true,
+				Node: SerialNodes(
+					FlagProcessor(
+						OptionalFlag[string]("of", 'o', testDesc, "dfltValue", SimpleDistinctCompleter[string]("abc", "def", "ghi")),
+					),
+				),
+				Args: "cmd --of",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"--of"},
+				},
 			},
 		},
 		{
-			name: "returns suggestions of first node if empty",
+			name: "OptionalFlag arg gets completed",
 			ctc: &commandtest.CompleteTestCase{
 				Node: SerialNodes(
-					Arg[string]("s", testDesc, SimpleCompleter[string]("un", "deux", "trois")),
-					OptionalArg[int]("i", testDesc, SimpleCompleter[int]("2", "1")),
-					ListArg[string]("sl", testDesc, 0, 2, SimpleCompleter[[]string]("uno", "dos")),
+					FlagProcessor(
+						OptionalFlag[string]("of", 'o', testDesc, "dfltValue", SimpleDistinctCompleter[string]("abc", "def", "ghi")),
+					),
 				),
+				Args: "cmd --of ",
 				Want: &command.Autocompletion{
-					Suggestions: []string{"deux", "trois", "un"},
+					Suggestions: []string{"abc", "def", "ghi"},
 				},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"s": "",
+					"of": "",
 				}},
 			},
 		},
 		{
-			name: "returns suggestions of first node if up to first arg",
+			name: "Eats partial flag completion",
+			// Eats partial flag completion because there's no great way
+			// to know if the value is for this flag or not.
 			ctc: &commandtest.CompleteTestCase{
 				Node: SerialNodes(
-					Arg[string]("s", testDesc, SimpleCompleter[string]("one", "two", "three")),
-					OptionalArg[int]("i", testDesc, SimpleCompleter[int]("2", "1")),
-					ListArg[string]("sl", testDesc, 0, 2, SimpleCompleter[[]string]("uno", "dos")),
+					FlagProcessor(
+						OptionalFlag[string]("of", 'o', testDesc, "dfltValue", SimpleDistinctCompleter[string]("abc", "def", "ghi")),
+						BoolFlag("bf", 'b', testDesc),
+					),
 				),
-				Args: "cmd t",
+				Args: "cmd --of -",
 				Want: &command.Autocompletion{
-					Suggestions: []string{"three", "two"},
+					Suggestions: []string{
+						"--bf",
+					},
 				},
+			},
+		},
+		{
+			name: "Eats optional flag completion",
+			// Eats partial flag completion because there's no great way
+			// to know if the value is for this flag or not.
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						OptionalFlag[string]("of", 'o', testDesc, "dfltValue", SimpleDistinctCompleter[string]("abc", "def", "ghi")),
+						BoolFlag("bf", 'b', testDesc),
+					),
+				),
+				Args: "cmd --of provided -",
 				WantData: &command.Data{Values: map[string]interface{}{
-					"s": "t",
+					"of": "provided",
 				}},
+				Want: &command.Autocompletion{
+					Suggestions: []string{
+						"--bf",
+					},
+				},
 			},
 		},
+		// ItemizedListFlag tests
 		{
-			name: "returns suggestions of middle node if that's where we're at",
+			name: "Itemized list flag gets completed",
 			ctc: &commandtest.CompleteTestCase{
 				Node: SerialNodes(
-					Arg[string]("s", testDesc, SimpleCompleter[string]("one", "two", "three")),
-					ListArg[string]("sl", testDesc, 0, 2, SimpleCompleter[[]string]("uno", "dos")),
-					OptionalArg[int]("i", testDesc, SimpleCompleter[int]("2", "1")),
+					FlagProcessor(
+						ItemizedListFlag[string]("ilf", 'i', testDesc, SimpleDistinctCompleter[[]string]("abc", "def", "ghi")),
+					),
 				),
-				Args: "cmd three ",
+				Args: "cmd --ilf",
 				Want: &command.Autocompletion{
-					Suggestions: []string{"dos", "uno"},
+					Suggestions: []string{"--ilf"},
 				},
-				WantData: &command.Data{Values: map[string]interface{}{
-					"s":  "three",
-					"sl": []string{""},
-				}},
 			},
 		},
 		{
-			name: "returns suggestions of middle node if partial",
+			name: "Completes itemized list flag value",
 			ctc: &commandtest.CompleteTestCase{
 				Node: SerialNodes(
-					Arg[string]("s", testDesc, SimpleCompleter[string]("one", "two", "three")),
-					ListArg[string]("sl", testDesc, 0, 2, SimpleCompleter[[]string]("uno", "dos")),
-					OptionalArg[int]("i", testDesc, SimpleCompleter[int]("2", "1")),
+					FlagProcessor(
+						ItemizedListFlag[string]("ilf", 'i', testDesc, SimpleDistinctCompleter[[]string]("abc", "def", "ghi")),
+					),
 				),
-				Args: "cmd three d",
+				Args: "cmd --ilf ",
 				Want: &command.Autocompletion{
-					Suggestions: []string{"dos"},
+					Suggestions: []string{"abc", "def", "ghi"},
 				},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"s":  "three",
-					"sl": []string{"d"},
+					"ilf": []string{""},
 				}},
 			},
 		},
 		{
-			name: "returns suggestions in list",
+			name: "Completes later itemized list flag value",
 			ctc: &commandtest.CompleteTestCase{
 				Node: SerialNodes(
-					Arg[string]("s", testDesc, SimpleCompleter[string]("one", "two", "three")),
-					ListArg[string]("sl", testDesc, 0, 2, SimpleCompleter[[]string]("uno", "dos")),
-					OptionalArg[int]("i", testDesc, SimpleCompleter[int]("2", "1")),
+					FlagProcessor(
+						ItemizedListFlag[string]("ilf", 'i', testDesc, SimpleDistinctCompleter[[]string]("abc", "def", "ghi")),
+					),
 				),
-				Args: "cmd three dos ",
+				Args: "cmd --ilf un -i d",
+				WantData: &command.Data{Values: map[string]interface{}{
+					"ilf": []string{"un", "d"},
+				}},
 				Want: &command.Autocompletion{
-					Suggestions: []string{"dos", "uno"},
+					Suggestions: []string{"def"},
 				},
+			},
+		},
+		{
+			name: "Completes distinct itemized list flag value",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						ItemizedListFlag[string]("ilf", 'i', testDesc, SimpleDistinctCompleter[[]string]("abc", "def", "ghi")),
+					),
+				),
+				Args: "cmd --ilf def -i ",
 				WantData: &command.Data{Values: map[string]interface{}{
-					"s":  "three",
-					"sl": []string{"dos", ""},
+					"ilf": []string{"def", ""},
 				}},
+				Want: &command.Autocompletion{
+					Suggestions: []string{"abc", "ghi"},
+				},
 			},
 		},
+		// Flag completion with list breaker
 		{
-			name: "returns suggestions for last arg",
+			name: "completes flag argument when flag processor's list breaker is provided as arg option",
 			ctc: &commandtest.CompleteTestCase{
 				Node: SerialNodes(
-					Arg[string]("s", testDesc, SimpleCompleter[string]("one", "two", "three")),
-					ListArg[string]("sl", testDesc, 0, 2, SimpleCompleter[[]string]("uno", "dos")),
-					OptionalArg[int]("i", testDesc, SimpleCompleter[int]("2", "1")),
+					ListArg[string]("lst", testDesc, 0, command.UnboundedList, breakerFlagProcessor.ListBreaker()),
+					breakerFlagProcessor,
 				),
-				Args: "cmd three uno dos ",
+				Args: "cmd v1 v2 other --names ",
 				Want: &command.Autocompletion{
-					Suggestions: []string{"1", "2"},
+					Suggestions: []string{"johnny", "ralph", "renee"},
 				},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"s":  "three",
-					"sl": []string{"uno", "dos"},
+					"lst":   []string{"v1", "v2", "other"},
+					"names": []string{""},
 				}},
 			},
 		},
 		{
-			name: "returns nothing if iterate through all nodes",
+			name: "second flag is recognized and completed",
 			ctc: &commandtest.CompleteTestCase{
 				Node: SerialNodes(
-					Arg[string]("s", testDesc, SimpleCompleter[string]("one", "two", "three")),
-					ListArg[string]("sl", testDesc, 0, 2, SimpleCompleter[[]string]("uno", "dos")),
-					OptionalArg[int]("i", testDesc, SimpleCompleter[int]("2", "1")),
+					ListArg[string]("lst", testDesc, 0, command.UnboundedList, breakerFlagProcessor.ListBreaker()),
+					breakerFlagProcessor,
 				),
-				Args: "cmd three uno dos 1 what now",
+				Args: "cmd v1 v2 other --names un --greeting ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"hey", "hi"},
+				},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"s":  "three",
-					"sl": []string{"uno", "dos"},
-					"i":  1,
+					"lst":      []string{"v1", "v2", "other"},
+					"names":    []string{"un"},
+					"greeting": "",
 				}},
-				WantErr: fmt.Errorf("Unprocessed extra args: [what now]"),
 			},
-			ictc: &spycommandtest.CompleteTestCase{
-				WantIsExtraArgsError: true,
-				WantIsUsageError:     true,
+		},
+		{
+			name: "InValuesCompleter suggests int values",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(Arg[int]("n", testDesc, InValuesCompleter(1, 2, 4, 8, 16), InValues(1, 2, 4, 8, 16))),
+				Args: "cmd ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"1", "16", "2", "4", "8"},
+				},
 			},
 		},
 		{
-			name: "works if empty and list starts",
+			name: "InValuesCompleter filters by prefix",
 			ctc: &commandtest.CompleteTestCase{
-				Node: SerialNodes(
-					ListArg[string]("sl", testDesc, 1, 2, SimpleCompleter[[]string]("uno", "dos")),
-				),
+				Node: SerialNodes(Arg[int]("n", testDesc, InValuesCompleter(1, 2, 4, 8, 16))),
+				Args: "cmd 1",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"1", "16"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"n": 1,
+				}},
+			},
+		},
+		{
+			name: "InValuesCompleter suggests float values",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(Arg[float64]("f", testDesc, InValuesCompleter(0.5, 1.25))),
+				Args: "cmd ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"0.5", "1.25"},
+				},
+			},
+		},
+		{
+			name: "QueryCompleter suggests rows returned by query",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(Arg[string]("s", testDesc, QueryCompleter[string](func(prefix string, d *command.Data) ([]string, error) {
+					if prefix != "" {
+						return nil, fmt.Errorf("unexpected prefix %q", prefix)
+					}
+					return []string{"alpha", "beta", "bravo"}, nil
+				}))),
+				Args: "cmd ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"alpha", "beta", "bravo"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"s": "",
+				}},
+			},
+		},
+		{
+			name: "QueryCompleter passes prefix to query",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(Arg[string]("s", testDesc, QueryCompleter[string](func(prefix string, d *command.Data) ([]string, error) {
+					if prefix != "b" {
+						return nil, fmt.Errorf("unexpected prefix %q", prefix)
+					}
+					return []string{"beta", "bravo"}, nil
+				}))),
+				Args: "cmd b",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"beta", "bravo"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"s": "b",
+				}},
+			},
+		},
+		{
+			name: "QueryCompleter passes last list value as prefix",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(ListArg[string]("sl", testDesc, 1, 2, QueryCompleter[[]string](func(prefix string, d *command.Data) ([]string, error) {
+					if prefix != "br" {
+						return nil, fmt.Errorf("unexpected prefix %q", prefix)
+					}
+					return []string{"bravo", "brown"}, nil
+				}))),
+				Args: "cmd alpha br",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"bravo", "brown"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"sl": []string{"alpha", "br"},
+				}},
+			},
+		},
+		{
+			name: "QueryCompleter uses argument's operator for prefix",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(TimeArg("when", testDesc, time.DateOnly, QueryCompleter[time.Time](func(prefix string, d *command.Data) ([]string, error) {
+					if prefix != "2024-04-05" {
+						return nil, fmt.Errorf("unexpected prefix %q", prefix)
+					}
+					return []string{"2024-04-05", "2024-04-15"}, nil
+				}))),
+				Args: "cmd 2024-04-05",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"2024-04-05"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"when": time.Date(2024, 4, 5, 0, 0, 0, 0, time.UTC),
+				}},
+			},
+		},
+		{
+			name: "QueryCompleter returns no suggestions if query fails",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(Arg[string]("s", testDesc, QueryCompleter[string](func(prefix string, d *command.Data) ([]string, error) {
+					return []string{"alpha"}, fmt.Errorf("database is locked")
+				}))),
+				Args: "cmd a",
+				WantData: &command.Data{Values: map[string]interface{}{
+					"s": "a",
+				}},
+			},
+		},
+		{
+			name: "BoolishCompleter suggests all values",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(Arg[string]("b", testDesc, BoolishCompleter(), IsBoolish())),
+				Args: "cmd ",
 				Want: &command.Autocompletion{
-					Suggestions: []string{"dos", "uno"},
+					Suggestions: []string{"false", "no", "off", "on", "true", "yes"},
 				},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"sl": []string{""},
+					"b": "",
 				}},
 			},
 		},
 		{
-			name: "only returns suggestions matching prefix",
+			name: "BoolishCompleter filters by prefix",
 			ctc: &commandtest.CompleteTestCase{
-				Node: SerialNodes(
-					ListArg[string]("sl", testDesc, 1, 2, SimpleCompleter[[]string]("zzz-1", "zzz-2", "yyy-3", "zzz-4")),
-				),
-				Args: "cmd zz",
+				Node: SerialNodes(Arg[string]("b", testDesc, BoolishCompleter(), IsBoolish())),
+				Args: "cmd o",
 				Want: &command.Autocompletion{
-					Suggestions: []string{"zzz-1", "zzz-2", "zzz-4"},
+					Suggestions: []string{"off", "on"},
 				},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"sl": []string{"zz"},
+					"b": "o",
 				}},
 			},
 		},
 		{
-			name: "if fail to convert arg, then don't complete",
+			name: "BoolishCompleter completes single prefix match",
 			ctc: &commandtest.CompleteTestCase{
-				Node: SerialNodes(
-					Arg[string]("s1", testDesc, SimpleCompleter[string]("one", "two", "three")),
-					Arg[int]("i", testDesc),
-					Arg[string]("s2", testDesc, SimpleCompleter[string]("abc", "alpha")),
-				),
-				Args:    "cmd three two a",
-				WantErr: fmt.Errorf(`strconv.Atoi: parsing "two": invalid syntax`),
+				Node: SerialNodes(Arg[string]("b", testDesc, BoolishCompleter())),
+				Args: "cmd y",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"yes"},
+				},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"s1": "three",
+					"b": "y",
 				}},
 			},
 		},
-		// Ensure completion iteration stops if necessary.
 		{
-			name: "stop iterating if a completion returns nil",
+			name: "BoolishCompleter after BoolishArg",
 			ctc: &commandtest.CompleteTestCase{
 				Node: SerialNodes(
-					Arg[string]("PATH", "dd", SimpleCompleter[string]()),
-					ListArg[string]("SUB_PATH", "stc", 0, command.UnboundedList, SimpleCompleter[[]string]("un", "deux", "trois")),
+					BoolishArg("b", testDesc),
+					Arg[string]("c", testDesc, BoolishCompleter()),
 				),
-				Args: "cmd p",
+				Args: "cmd YES f",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"false"},
+				},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"PATH": "p",
+					"b": true,
+					"c": "f",
 				}},
 			},
 		},
 		{
-			name: "stop iterating if a completion returns an error",
+			name: "BoolishArg completes boolish values",
 			ctc: &commandtest.CompleteTestCase{
-				Node: SerialNodes(
-					Arg[string]("PATH", "dd", CompleterFromFunc(func(string, *command.Data) (*command.Completion, error) {
-						return nil, fmt.Errorf("ruh-roh")
-					})),
-					ListArg[string]("SUB_PATH", "stc", 0, command.UnboundedList, SimpleCompleter[[]string]("un", "deux", "trois")),
-				),
-				Args:    "cmd p",
-				WantErr: fmt.Errorf("ruh-roh"),
-				WantData: &command.Data{Values: map[string]interface{}{
-					"PATH": "p",
-				}},
+				Node: SerialNodes(BoolishArg("b", testDesc)),
+				Args: "cmd o",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"off", "on"},
+				},
 			},
 		},
 		{
-			name: "fails if edge returns an error",
+			name: "StrictArgs completes wrapped args",
 			ctc: &commandtest.CompleteTestCase{
-				Node: &SimpleNode{
-					Edge: &errorEdge{fmt.Errorf("whoops")},
+				Node: SerialNodes(StrictArgs(
+					Arg[string]("service", testDesc, SimpleCompleter[string]("api", "web")),
+					Arg[string]("env", testDesc, SimpleCompleter[string]("dev", "prod")),
+				)),
+				Args: "cmd api ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"dev", "prod"},
 				},
-				Args:    "cmd p",
-				WantErr: fmt.Errorf("whoops"),
+				WantData: &command.Data{Values: map[string]interface{}{
+					"service": "api",
+					"env":     "",
+				}},
 			},
 		},
-		// Flag completion
 		{
-			name: "bool flag gets set if not last one",
+			name: "flag completer has access to earlier flag value",
 			ctc: &commandtest.CompleteTestCase{
 				Node: SerialNodes(
 					FlagProcessor(
-						Flag[string]("greeting", 'h', testDesc, SimpleCompleter[string]("hey", "hi")),
-						ListFlag[string]("names", 'n', testDesc, 1, 2, SimpleCompleter[[]string]("ralph", "johnny", "renee")),
-						BoolFlag("good", 'g', testDesc),
+						Flag[string]("env", 'e', testDesc),
+						Flag[string]("region", 'r', testDesc, CompleterFromFunc(func(s string, d *command.Data) (*command.Completion, error) {
+							return &command.Completion{Suggestions: []string{d.String("env") + "-east", d.String("env") + "-west"}}, nil
+						})),
 					),
-					Arg[int]("i", testDesc, SimpleCompleter[int]("1", "2")),
 				),
-				Args: "cmd -g ",
+				Args: "cmd --env prod --region ",
 				Want: &command.Autocompletion{
-					Suggestions: []string{"1", "2"},
-				},
-				WantData: &command.Data{
-					Values: map[string]interface{}{
-						"good": true,
-					},
+					Suggestions: []string{"prod-east", "prod-west"},
 				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"env":    "prod",
+					"region": "",
+				}},
 			},
 		},
 		{
-			name: "arg flag gets set if not last one",
+			name: "flag completer has access to earlier multi-flag value",
 			ctc: &commandtest.CompleteTestCase{
 				Node: SerialNodes(
 					FlagProcessor(
-						Flag[string]("greeting", 'h', testDesc, SimpleCompleter[string]("hey", "hi")),
-						ListFlag[string]("names", 'n', testDesc, 1, 2, SimpleCompleter[[]string]("ralph", "johnny", "renee")),
-						BoolFlag("good", 'g', testDesc),
+						BoolValuesFlag("loud", 'l', testDesc, "HEY", "hey"),
+						BoolFlag("quick", 'q', testDesc),
+						Flag[string]("greeting", 'g', testDesc, CompleterFromFunc(func(s string, d *command.Data) (*command.Completion, error) {
+							return &command.Completion{Suggestions: []string{d.String("loud")}}, nil
+						})),
 					),
-					Arg[int]("i", testDesc, SimpleCompleter[int]("1", "2")),
 				),
-				Args: "cmd --greeting howdy ",
+				Args: "cmd -ql --greeting ",
 				Want: &command.Autocompletion{
-					Suggestions: []string{"1", "2"},
-				},
-				WantData: &command.Data{
-					Values: map[string]interface{}{
-						"greeting": "howdy",
-					},
+					Suggestions: []string{"HEY"},
 				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"loud":     "HEY",
+					"quick":    true,
+					"greeting": "",
+				}},
 			},
 		},
 		{
-			name: "list arg flag gets set if not last one",
+			name: "flag completer has access to default values of flags that aren't provided",
 			ctc: &commandtest.CompleteTestCase{
 				Node: SerialNodes(
 					FlagProcessor(
-						Flag[string]("greeting", 'h', testDesc, SimpleCompleter[string]("hey", "hi")),
-						ListFlag[string]("names", 'n', testDesc, 1, 2, SimpleCompleter[[]string]("ralph", "johnny", "renee")),
-						BoolFlag("good", 'g', testDesc),
+						Flag[string]("env", 'e', testDesc, Default("dev")),
+						BoolValuesFlag("loud", 'l', testDesc, "HEY", "hey"),
+						Flag[string]("region", 'r', testDesc, CompleterFromFunc(func(s string, d *command.Data) (*command.Completion, error) {
+							return &command.Completion{Suggestions: []string{d.String("env") + "-" + d.String("loud")}}, nil
+						})),
 					),
-					Arg[int]("i", testDesc, SimpleCompleter[int]("1", "2")),
 				),
-				Args: "cmd --names alice bob charlie ",
+				Args: "cmd --region ",
 				Want: &command.Autocompletion{
-					Suggestions: []string{"1", "2"},
-				},
-				WantData: &command.Data{
-					Values: map[string]interface{}{
-						"names": []string{"alice", "bob", "charlie"},
-					},
+					Suggestions: []string{"dev-hey"},
 				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"env":    "dev",
+					"loud":   "hey",
+					"region": "",
+				}},
 			},
 		},
 		{
-			name: "multiple flags get set if not last one",
+			name: "flag completer has access to earlier flag value that is provided after the default",
 			ctc: &commandtest.CompleteTestCase{
 				Node: SerialNodes(
 					FlagProcessor(
-						Flag[string]("greeting", 'h', testDesc, SimpleCompleter[string]("hey", "hi")),
-						ListFlag[string]("names", 'n', testDesc, 1, 2, SimpleCompleter[[]string]("ralph", "johnny", "renee")),
-						BoolFlag("good", 'g', testDesc),
+						Flag[string]("env", 'e', testDesc, Default("dev")),
+						Flag[string]("region", 'r', testDesc, CompleterFromFunc(func(s string, d *command.Data) (*command.Completion, error) {
+							return &command.Completion{Suggestions: []string{d.String("env") + "-east"}}, nil
+						})),
 					),
-					Arg[int]("i", testDesc, SimpleCompleter[int]("1", "2")),
 				),
-				Args: "cmd -n alice bob charlie --good -h howdy ",
+				Args: "cmd -e prod -r ",
 				Want: &command.Autocompletion{
-					Suggestions: []string{"1", "2"},
-				},
-				WantData: &command.Data{
-					Values: map[string]interface{}{
-						"names":    []string{"alice", "bob", "charlie"},
-						"good":     true,
-						"greeting": "howdy",
-					},
+					Suggestions: []string{"prod-east"},
 				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"env":    "prod",
+					"region": "",
+				}},
 			},
 		},
 		{
-			name: "flag name gets completed if single hyphen at end",
+			name: "flag completer has access to earlier positional args",
 			ctc: &commandtest.CompleteTestCase{
 				Node: SerialNodes(
+					Arg[string]("service", testDesc),
 					FlagProcessor(
-						Flag[string]("greeting", 'h', testDesc, SimpleCompleter[string]("hey", "hi")),
-						ListFlag[string]("names", 'n', testDesc, 1, 2, SimpleCompleter[[]string]("ralph", "johnny", "renee")),
-						BoolFlag("good", 'g', testDesc),
+						Flag[string]("env", 'e', testDesc, Default("dev")),
+						Flag[string]("instance", 'i', testDesc, CompleterFromFunc(func(s string, d *command.Data) (*command.Completion, error) {
+							return &command.Completion{Suggestions: []string{
+								fmt.Sprintf("%s-%s-1", d.String("service"), d.String("env")),
+								fmt.Sprintf("%s-%s-2", d.String("service"), d.String("env")),
+							}}, nil
+						})),
 					),
-					Arg[int]("i", testDesc, SimpleCompleter[int]("1", "2")),
 				),
-				Args: "cmd -",
+				Args: "cmd api --env prod --instance api",
 				Want: &command.Autocompletion{
-					Suggestions: []string{"--good", "--greeting", "--names"},
+					Suggestions: []string{"api-prod-1", "api-prod-2"},
 				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"service":  "api",
+					"env":      "prod",
+					"instance": "api",
+				}},
 			},
 		},
 		{
-			name: "flag name gets completed if double hyphen at end",
+			name: "shared flag names are completed along with branch flag names",
 			ctc: &commandtest.CompleteTestCase{
 				Node: SerialNodes(
 					FlagProcessor(
-						Flag[string]("greeting", 'h', testDesc, SimpleCompleter[string]("hey", "hi")),
-						ListFlag[string]("names", 'n', testDesc, 1, 2, SimpleCompleter[[]string]("ralph", "johnny", "renee")),
-						BoolFlag("good", 'g', testDesc),
+						Flag[string]("env", 'e', testDesc, SimpleCompleter[string]("dev", "prod")),
+						BoolFlag("verbose", 'v', testDesc),
 					),
-					Arg[int]("i", testDesc, SimpleCompleter[int]("1", "2")),
+					&BranchNode{
+						Branches: map[string]command.Node{
+							"deploy": SerialNodes(
+								FlagProcessor(
+									BoolFlag("force", 'f', testDesc),
+									BoolFlag("verbose", 'v', testDesc),
+								),
+								Arg[string]("svc", testDesc, SimpleCompleter[string]("api", "web")),
+							),
+							"status": SerialNodes(
+								Arg[string]("svc", testDesc, SimpleCompleter[string]("api", "web")),
+							),
+						},
+					},
 				),
-				Args: "cmd --",
+				Args: "cmd deploy -",
 				Want: &command.Autocompletion{
-					Suggestions: []string{"--good", "--greeting", "--names"},
+					Suggestions: []string{"--env", "--force", "--verbose"},
 				},
 			},
 		},
 		{
-			name: "flag name gets completed if it's the only arg",
+			name: "shared flag names are completed in branch without flags",
 			ctc: &commandtest.CompleteTestCase{
 				Node: SerialNodes(
 					FlagProcessor(
-						Flag[string]("greeting", 'h', testDesc, SimpleCompleter[string]("hey", "hi")),
-						ListFlag[string]("names", 'n', testDesc, 1, 2, SimpleCompleter[[]string]("ralph", "johnny", "renee")),
-						BoolFlag("good", 'g', testDesc),
+						Flag[string]("env", 'e', testDesc, SimpleCompleter[string]("dev", "prod")),
+						BoolFlag("verbose", 'v', testDesc),
 					),
-					Arg[int]("i", testDesc, SimpleCompleter[int]("1", "2")),
+					&BranchNode{
+						Branches: map[string]command.Node{
+							"deploy": SerialNodes(
+								FlagProcessor(
+									BoolFlag("force", 'f', testDesc),
+									BoolFlag("verbose", 'v', testDesc),
+								),
+								Arg[string]("svc", testDesc, SimpleCompleter[string]("api", "web")),
+							),
+							"status": SerialNodes(
+								Arg[string]("svc", testDesc, SimpleCompleter[string]("api", "web")),
+							),
+						},
+					},
 				),
-				Args: "cmd 1 -",
+				Args: "cmd status --",
 				Want: &command.Autocompletion{
-					Suggestions: []string{"--good", "--greeting", "--names"},
+					Suggestions: []string{"--env", "--verbose"},
 				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"svc": "--",
+				}},
 			},
 		},
 		{
-			name: "partial flag name gets completed",
+			name: "shared flag names that were already provided aren't completed in branch",
 			ctc: &commandtest.CompleteTestCase{
 				Node: SerialNodes(
 					FlagProcessor(
-						Flag[string]("greeting", 'h', testDesc, SimpleCompleter[string]("hey", "hi")),
-						ListFlag[string]("names", 'n', testDesc, 1, 2, SimpleCompleter[[]string]("ralph", "johnny", "renee")),
-						BoolFlag("good", 'g', testDesc),
+						Flag[string]("env", 'e', testDesc, SimpleCompleter[string]("dev", "prod")),
+						BoolFlag("verbose", 'v', testDesc),
 					),
-					Arg[int]("i", testDesc, SimpleCompleter[int]("1", "2")),
+					&BranchNode{
+						Branches: map[string]command.Node{
+							"deploy": SerialNodes(
+								FlagProcessor(
+									BoolFlag("force", 'f', testDesc),
+									BoolFlag("verbose", 'v', testDesc),
+								),
+								Arg[string]("svc", testDesc, SimpleCompleter[string]("api", "web")),
+							),
+							"status": SerialNodes(
+								Arg[string]("svc", testDesc, SimpleCompleter[string]("api", "web")),
+							),
+						},
+					},
 				),
-				Args: "cmd --gr",
+				Args: "cmd deploy --env prod --",
 				Want: &command.Autocompletion{
-					Suggestions: []string{"--greeting"},
+					Suggestions: []string{"--force", "--verbose"},
 				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"env": "prod",
+				}},
 			},
 		},
 		{
-			name: "full flag name gets completed",
+			name: "shared flag value is completed inside branch",
 			ctc: &commandtest.CompleteTestCase{
 				Node: SerialNodes(
 					FlagProcessor(
-						Flag[string]("greeting", 'h', testDesc, SimpleCompleter[string]("hey", "hi")),
-						ListFlag[string]("names", 'n', testDesc, 1, 2, SimpleCompleter[[]string]("ralph", "johnny", "renee")),
-						BoolFlag("good", 'g', testDesc),
+						Flag[string]("env", 'e', testDesc, SimpleCompleter[string]("dev", "prod")),
+						BoolFlag("verbose", 'v', testDesc),
 					),
-					Arg[int]("i", testDesc, SimpleCompleter[int]("1", "2")),
+					&BranchNode{
+						Branches: map[string]command.Node{
+							"deploy": SerialNodes(
+								FlagProcessor(
+									BoolFlag("force", 'f', testDesc),
+									BoolFlag("verbose", 'v', testDesc),
+								),
+								Arg[string]("svc", testDesc, SimpleCompleter[string]("api", "web")),
+							),
+							"status": SerialNodes(
+								Arg[string]("svc", testDesc, SimpleCompleter[string]("api", "web")),
+							),
+						},
+					},
 				),
-				Args: "cmd --names",
+				Args: "cmd deploy --force --env ",
 				Want: &command.Autocompletion{
-					Suggestions: []string{"--names"},
+					Suggestions: []string{"dev", "prod"},
 				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"env": "",
+				}},
 			},
 		},
-		// Flag value completions
 		{
-			name: "completes for single flag",
+			name: "branch arg is completed after shared flag inside branch",
 			ctc: &commandtest.CompleteTestCase{
 				Node: SerialNodes(
 					FlagProcessor(
-						Flag[string]("greeting", 'h', testDesc, SimpleCompleter[string]("hey", "hi")),
-						ListFlag[string]("names", 'n', testDesc, 1, 2, SimpleCompleter[[]string]("ralph", "johnny", "renee")),
-						BoolFlag("good", 'g', testDesc),
+						Flag[string]("env", 'e', testDesc, SimpleCompleter[string]("dev", "prod")),
+						BoolFlag("verbose", 'v', testDesc),
 					),
-					Arg[int]("i", testDesc, SimpleCompleter[int]("1", "2")),
+					&BranchNode{
+						Branches: map[string]command.Node{
+							"deploy": SerialNodes(
+								FlagProcessor(
+									BoolFlag("force", 'f', testDesc),
+									BoolFlag("verbose", 'v', testDesc),
+								),
+								Arg[string]("svc", testDesc, SimpleCompleter[string]("api", "web")),
+							),
+							"status": SerialNodes(
+								Arg[string]("svc", testDesc, SimpleCompleter[string]("api", "web")),
+							),
+						},
+					},
 				),
-				Args: "cmd 1 --greeting h",
+				Args: "cmd deploy --env prod -f ",
 				Want: &command.Autocompletion{
-					Suggestions: []string{"hey", "hi"},
+					Suggestions: []string{"api", "web"},
 				},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"greeting": "h",
+					"env":   "prod",
+					"force": true,
+					"svc":   "",
 				}},
 			},
 		},
+		// DeferredCompletion tests
 		{
-			name: "completes for single short flag",
+			name: "DeferredCompletion handles nil graph",
 			ctc: &commandtest.CompleteTestCase{
 				Node: SerialNodes(
 					FlagProcessor(
-						Flag[string]("greeting", 'h', testDesc, SimpleCompleter[string]("hey", "hi")),
-						ListFlag[string]("names", 'n', testDesc, 1, 2, SimpleCompleter[[]string]("ralph", "johnny", "renee")),
-						BoolFlag("good", 'g', testDesc),
+						ListFlag[string]("lf", 'f', testDesc, 0, command.UnboundedList, DeferredCompleter(nil, SimpleCompleter[[]string]("abc", "def"))),
 					),
-					Arg[int]("i", testDesc, SimpleCompleter[int]("1", "2")),
 				),
-				Args: "cmd 1 -h he",
+				Args: "cmd --lf ab ",
 				Want: &command.Autocompletion{
-					Suggestions: []string{"hey"},
+					Suggestions: []string{"abc", "def"},
 				},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"greeting": "he",
+					"lf": []string{"ab", ""},
 				}},
 			},
 		},
 		{
-			name: "completes for list flag",
+			name: "DeferredCompletion handles error",
 			ctc: &commandtest.CompleteTestCase{
 				Node: SerialNodes(
 					FlagProcessor(
-						Flag[string]("greeting", 'h', testDesc, SimpleCompleter[string]("hey", "hi")),
-						ListFlag[string]("names", 'n', testDesc, 1, 2, SimpleCompleter[[]string]("ralph", "johnny", "renee")),
-						BoolFlag("good", 'g', testDesc),
+						ListFlag[string]("lf", 'f', testDesc, 0, command.UnboundedList, DeferredCompleter(nil, CompleterFromFunc(func([]string, *command.Data) (*command.Completion, error) {
+							return &command.Completion{Suggestions: []string{"abc", "def"}}, fmt.Errorf("oh well")
+						}))),
 					),
-					Arg[int]("i", testDesc, SimpleCompleter[int]("1", "2")),
 				),
-				Args: "cmd 1 -h hey other --names ",
-				Want: &command.Autocompletion{
-					Suggestions: []string{"johnny", "ralph", "renee"},
-				},
+				Args:    "cmd --lf ab ",
+				WantErr: fmt.Errorf("oh well"),
 				WantData: &command.Data{Values: map[string]interface{}{
-					"greeting": "hey",
-					"names":    []string{""},
+					"lf": []string{"ab", ""},
 				}},
 			},
 		},
 		{
-			name: "completes distinct secondary for list flag",
+			name: "DeferredCompletion executes sub graph",
 			ctc: &commandtest.CompleteTestCase{
 				Node: SerialNodes(
 					FlagProcessor(
-						Flag[string]("greeting", 'h', testDesc, SimpleCompleter[string]("hey", "hi")),
-						ListFlag[string]("names", 'n', testDesc, 1, 2, SimpleDistinctCompleter[[]string]("ralph", "johnny", "renee")),
-						BoolFlag("good", 'g', testDesc),
+						ListFlag[string]("lf", 'f', testDesc, 0, command.UnboundedList, DeferredCompleter(
+							SerialNodes(
+								ListArg[string]("la", testDesc, 3, command.UnboundedList, SimpleCompleter[[]string]("un", "deux")),
+							),
+							CompleterFromFunc(func([]string, *command.Data) (*command.Completion, error) {
+								return &command.Completion{Suggestions: []string{"abc", "def"}}, fmt.Errorf("oh well")
+							}))),
 					),
-					Arg[int]("i", testDesc, SimpleCompleter[int]("1", "2")),
 				),
-				Args: "cmd 1 -h hey other --names ralph ",
-				Want: &command.Autocompletion{
-					Suggestions: []string{"johnny", "renee"},
-				},
+				Args:    "cmd v1 v2 other --lf ab ",
+				WantErr: fmt.Errorf("oh well"),
+				WantData: &command.Data{Values: map[string]interface{}{
+					"lf": []string{"ab", ""},
+					"la": []string{"v1", "v2", "other"},
+				}},
+			},
+		},
+		{
+			name: "DeferredCompletion returns error from sub graph",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						ListFlag[string]("lf", 'f', testDesc, 0, command.UnboundedList, DeferredCompleter(
+							SerialNodes(
+								ListArg[string]("la", testDesc, 4, command.UnboundedList, SimpleCompleter[[]string]("un", "deux")),
+							),
+							CompleterFromFunc(func([]string, *command.Data) (*command.Completion, error) {
+								return &command.Completion{Suggestions: []string{"abc", "def"}}, fmt.Errorf("oh well")
+							}))),
+					),
+				),
+				Args:    "cmd v1 v2 other --lf ab ",
+				WantErr: fmt.Errorf(`failed to execute DeferredCompletion graph: Argument "la" requires at least 4 arguments, got 3`),
 				WantData: &command.Data{Values: map[string]interface{}{
-					"greeting": "hey",
-					"names":    []string{"ralph", ""},
+					"lf": []string{"ab", ""},
+					"la": []string{"v1", "v2", "other"},
 				}},
 			},
 		},
+		// Transformer arg tests.
 		{
-			name: "completes last flag",
+			name: "handles nil option",
 			ctc: &commandtest.CompleteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(
-						Flag[string]("greeting", 'h', testDesc, SimpleCompleter[string]("hey", "hi")),
-						ListFlag[string]("names", 'n', testDesc, 1, 2, SimpleDistinctCompleter[[]string]("ralph", "johnny", "renee")),
-						BoolFlag("good", 'g', testDesc),
-						Flag[float64]("float", 'f', testDesc, SimpleCompleter[float64]("1.23", "12.3", "123.4")),
-					),
-					Arg[int]("i", testDesc, SimpleCompleter[int]("1", "2")),
-				),
-				Args: "cmd 1 -h hey other --names ralph renee johnny -f ",
-				Want: &command.Autocompletion{
-					Suggestions: []string{"1.23", "12.3", "123.4"},
+				Node: &SimpleNode{
+					Processor: Arg[string]("strArg", testDesc),
 				},
+				Args: "cmd abc",
 				WantData: &command.Data{Values: map[string]interface{}{
-					"greeting": "hey",
-					"names":    []string{"ralph", "renee", "johnny"},
+					"strArg": "abc",
 				}},
 			},
 		},
 		{
-			name: "completes arg if flag arg isn't at the end",
+			name: "list handles nil option",
 			ctc: &commandtest.CompleteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(
-						Flag[string]("greeting", 'h', testDesc, SimpleCompleter[string]("hey", "hi")),
-						ListFlag[string]("names", 'n', testDesc, 1, 2, SimpleDistinctCompleter[[]string]("ralph", "johnny", "renee")),
-						BoolFlag("good", 'g', testDesc),
-					),
-					ListArg[string]("i", testDesc, 1, 2, SimpleCompleter[[]string]("hey", "ooo")),
-				),
-				Args: "cmd 1 -h hello bravo --names ralph renee johnny ",
-				Want: &command.Autocompletion{
-					Suggestions: []string{"hey", "ooo"},
+				Node: &SimpleNode{
+					Processor: ListArg[string]("slArg", testDesc, 1, 2),
 				},
+				Args: "cmd abc",
 				WantData: &command.Data{Values: map[string]interface{}{
-					"i":        []string{"1", "bravo", ""},
-					"greeting": "hello",
-					"names":    []string{"ralph", "renee", "johnny"},
+					"slArg": []string{"abc"},
 				}},
 			},
 		},
-		// Multi-flag tests
 		{
-			name: "Multi-flags don't get completed",
+			name: "transformer doesn't transform value during completion",
 			ctc: &commandtest.CompleteTestCase{
-				Args: "cmd -qwer",
-				Node: SerialNodes(
-					FlagProcessor(
-						BoolFlag("everyone", 'e', testDesc),
-						BoolFlag("quick", 'q', testDesc),
-						BoolFlag("run", 'r', testDesc),
-						BoolFlag("to", 't', testDesc),
-						BoolFlag("where", 'w', testDesc),
-					),
-				),
+				Node: SerialNodes(Arg[string]("strArg", testDesc,
+					&Transformer[string]{F: func(string, *command.Data) (string, error) {
+						return "newStuff", nil
+					}})),
+				Args: "cmd abc",
+				WantData: &command.Data{Values: map[string]interface{}{
+					"strArg": "abc",
+				}},
 			},
 		},
 		{
-			name: "Args after multi-flags get completed",
+			name:        "FileTransformer doesn't transform",
+			filepathAbs: filepath.Join("abso", "lutely"),
 			ctc: &commandtest.CompleteTestCase{
-				Args: "cmd -qwer ",
-				Node: SerialNodes(
-					FlagProcessor(
-						BoolFlag("everyone", 'e', testDesc),
-						BoolFlag("quick", 'q', testDesc),
-						BoolFlag("run", 'r', testDesc),
-						BoolFlag("to", 't', testDesc),
-						BoolFlag("where", 'w', testDesc),
-					),
-					Arg[string]("s", testDesc, SimpleCompleter[string]("abc", "def", "ghi")),
-				),
-				Want: &command.Autocompletion{
-					Suggestions: []string{"abc", "def", "ghi"},
+				Node: &SimpleNode{
+					Processor: Arg[string]("strArg", testDesc, FileTransformer()),
 				},
+				Args: fmt.Sprintf("cmd %s", filepath.Join("relative", "path.txt")),
 				WantData: &command.Data{Values: map[string]interface{}{
-					"quick":    true,
-					"where":    true,
-					"everyone": true,
-					"run":      true,
-					"s":        "",
+					"strArg": filepath.Join("relative", "path.txt"),
 				}},
 			},
 		},
 		{
-			name: "Args after multi-flags get completed, even if unknown flag included",
+			name:        "FileTransformer for list doesn't transform",
+			filepathAbs: filepath.Join("abso", "lutely"),
 			ctc: &commandtest.CompleteTestCase{
-				Args: "cmd -qwertyuiop ",
-				Node: SerialNodes(
-					FlagProcessor(
-						BoolFlag("everyone", 'e', testDesc),
-						BoolFlag("quick", 'q', testDesc),
-						BoolFlag("run", 'r', testDesc),
-						BoolFlag("to", 't', testDesc),
-						BoolFlag("where", 'w', testDesc),
-					),
-					Arg[string]("s", testDesc, SimpleCompleter[string]("abc", "def", "ghi")),
-				),
-				Want: &command.Autocompletion{
-					Suggestions: []string{"abc", "def", "ghi"},
+				Node: &SimpleNode{
+					Processor: ListArg[string]("strArg", testDesc, 1, 2, TransformerList(FileTransformer())),
 				},
+				Args: fmt.Sprintf("cmd %s", filepath.Join("relative", "path.txt")),
 				WantData: &command.Data{Values: map[string]interface{}{
-					"quick":    true,
-					"where":    true,
-					"everyone": true,
-					"run":      true,
-					"to":       true,
-					"s":        "",
+					"strArg": []string{filepath.Join("relative", "path.txt")},
 				}},
 			},
 		},
 		{
-			name: "Args after multi-flags get completed, even if uncombinable flag is included",
+			name:           "handles transform error",
+			filepathAbsErr: fmt.Errorf("bad news bears"),
 			ctc: &commandtest.CompleteTestCase{
-				Args: "cmd -qwz ",
-				Node: SerialNodes(
-					FlagProcessor(
-						BoolFlag("everyone", 'e', testDesc),
-						BoolFlag("quick", 'q', testDesc),
-						BoolFlag("run", 'r', testDesc),
-						BoolFlag("to", 't', testDesc),
-						Flag[string]("zf", 'z', testDesc),
-						BoolFlag("where", 'w', testDesc),
-					),
-					Arg[string]("s", testDesc, SimpleCompleter[string]("abc", "def", "ghi")),
-				),
-				Want: &command.Autocompletion{
-					Suggestions: []string{"abc", "def", "ghi"},
+				Node: &SimpleNode{
+					Processor: Arg[string]("strArg", testDesc, FileTransformer()),
 				},
+				Args: fmt.Sprintf("cmd %s", filepath.Join("relative", "path.txt")),
 				WantData: &command.Data{Values: map[string]interface{}{
-					"quick": true,
-					"where": true,
-					"s":     "",
+					"strArg": filepath.Join("relative", "path.txt"),
 				}},
 			},
 		},
-		// Duplicate flag tests
 		{
-			name: "Repeated flag still gets completed",
+			name:        "transformer list doesn't transforms values during completion",
+			filepathAbs: filepath.Join("abso", "lutely"),
 			ctc: &commandtest.CompleteTestCase{
-				Args: "cmd -z firstZ -z ",
-				Node: SerialNodes(
-					FlagProcessor(
-						BoolFlag("everyone", 'e', testDesc),
-						BoolFlag("quick", 'q', testDesc),
-						BoolFlag("run", 'r', testDesc),
-						BoolFlag("to", 't', testDesc),
-						Flag[string]("zf", 'z', testDesc, SimpleCompleter[string]("zyx", "wvu", "tsr")),
-						BoolFlag("where", 'w', testDesc),
-					),
-				),
-				Want: &command.Autocompletion{
-					Suggestions: []string{"tsr", "wvu", "zyx"},
+				Node: &SimpleNode{
+					Processor: ListArg[string]("slArg", testDesc, 1, 2, &Transformer[[]string]{F: func(sl []string, d *command.Data) ([]string, error) {
+						var r []string
+						for _, s := range sl {
+							r = append(r, fmt.Sprintf("_%s_", s))
+						}
+						return r, nil
+					}}),
 				},
+				Args: "cmd uno dos",
 				WantData: &command.Data{Values: map[string]interface{}{
-					"zf": "",
+					"slArg": []string{
+						"uno",
+						"dos",
+					},
 				}},
 			},
 		},
 		{
-			name: "Repeated flag still gets completed even if other repetition in multi-flags",
+			name:        "transformer list transforms values that aren't at the end",
+			filepathAbs: filepath.Join("abso", "lutely"),
 			ctc: &commandtest.CompleteTestCase{
-				Args: "cmd --quick -qwrqw --where -z firstZ -z ",
 				Node: SerialNodes(
-					FlagProcessor(
-						BoolFlag("everyone", 'e', testDesc),
-						BoolFlag("quick", 'q', testDesc),
-						BoolFlag("run", 'r', testDesc),
-						BoolFlag("to", 't', testDesc),
-						Flag[string]("zf", 'z', testDesc, SimpleCompleter[string]("zyx", "wvu", "tsr")),
-						BoolFlag("where", 'w', testDesc),
-					),
+					ListArg[string]("slArg", testDesc, 1, 1, &Transformer[[]string]{F: func(sl []string, d *command.Data) ([]string, error) {
+						var r []string
+						for _, s := range sl {
+							r = append(r, fmt.Sprintf("_%s_", s))
+						}
+						return r, nil
+					}}),
+					Arg[string]("sArg", testDesc, &Transformer[string]{F: func(s string, d *command.Data) (string, error) {
+						return s + "!", nil
+					}}),
 				),
-				Want: &command.Autocompletion{
-					Suggestions: []string{"tsr", "wvu", "zyx"},
-				},
+				Args: "cmd uno dos t",
 				WantData: &command.Data{Values: map[string]interface{}{
-					"quick": true,
-					"where": true,
-					"run":   true,
-					"zf":    "",
+					"slArg": []string{
+						"_uno_",
+						"_dos_",
+					},
+					"sArg": "t",
 				}},
 			},
 		},
 		{
-			name: "Don't suggest already seen flag names",
+			name:        "transformer list transforms values on a best effort basis",
+			filepathAbs: filepath.Join("abso", "lutely"),
 			ctc: &commandtest.CompleteTestCase{
-				Args: "cmd -z firstZ --everyone --ilf heyo --run -",
 				Node: SerialNodes(
-					FlagProcessor(
-						BoolFlag("everyone", 'e', testDesc),
-						BoolFlag("quick", 'q', testDesc),
-						BoolFlag("run", 'r', testDesc),
-						BoolFlag("to", 't', testDesc),
-						Flag[string]("zf", 'z', testDesc, SimpleCompleter[string]("zyx", "wvu", "tsr")),
-						ItemizedListFlag[string]("ilf", 'i', testDesc),
-						BoolFlag("where", 'w', testDesc),
-					),
+					ListArg[string]("slArg", testDesc, 1, 1, &Transformer[[]string]{F: func(sl []string, d *command.Data) ([]string, error) {
+						var r []string
+						for _, s := range sl {
+							r = append(r, fmt.Sprintf("_%s_", s))
+						}
+						return r, nil
+					}}),
+					Arg[string]("sArg", testDesc, &Transformer[string]{F: func(s string, d *command.Data) (string, error) {
+						return "oh", fmt.Errorf("Nooooooo")
+					}}),
+					Arg[string]("sArg2", testDesc, &Transformer[string]{F: func(s string, d *command.Data) (string, error) {
+						return "oh yea", fmt.Errorf("nope")
+					}}),
 				),
-				Want: &command.Autocompletion{
-					Suggestions: []string{
-						// ilf still gets completed because it allows multiple.
-						"--ilf",
-						"--quick",
-						"--to",
-						"--where",
-					},
-				},
+				Args: "cmd uno dos tres q",
 				WantData: &command.Data{Values: map[string]interface{}{
-					"everyone": true,
-					"run":      true,
-					"zf":       "firstZ",
+					"slArg": []string{
+						"_uno_",
+						"_dos_",
+					},
+					"sArg":  "tres",
+					"sArg2": "q",
 				}},
 			},
 		},
-		// OptionalFlag tests
 		{
-			name: "OptionalFlag gets completed",
+			name:           "handles transform list error",
+			filepathAbsErr: fmt.Errorf("bad news bears"),
 			ctc: &commandtest.CompleteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(
-						OptionalFlag[string]("of", 'o', testDesc, "dfltValue", SimpleDistinctCompleter[string]("abc", "def", "ghi")),
-					),
-				),
-				Args: "cmd --of",
-				Want: &command.Autocompletion{
-					Suggestions: []string{"--of"},
+				Node: &SimpleNode{
+					Processor: ListArg[string]("slArg", testDesc, 1, 2, TransformerList(FileTransformer())),
 				},
+				Args: fmt.Sprintf("cmd %s %s", filepath.Join("relative", "path.txt"), filepath.Join("other.txt")),
+				WantData: &command.Data{Values: map[string]interface{}{
+					"slArg": []string{
+						filepath.Join("relative", "path.txt"),
+						filepath.Join("other.txt"),
+					},
+				}},
 			},
 		},
 		{
-			name: "OptionalFlag arg gets completed",
+			name: "handles list transformer of incorrect type",
 			ctc: &commandtest.CompleteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(
-						OptionalFlag[string]("of", 'o', testDesc, "dfltValue", SimpleDistinctCompleter[string]("abc", "def", "ghi")),
-					),
-				),
-				Args: "cmd --of ",
-				Want: &command.Autocompletion{
-					Suggestions: []string{"abc", "def", "ghi"},
+				Node: &SimpleNode{
+					Processor: ListArg[string]("slArg", testDesc, 1, 2, TransformerList(FileTransformer())),
 				},
+				Args: "cmd 123",
 				WantData: &command.Data{Values: map[string]interface{}{
-					"of": "",
+					"slArg": []string{"123"},
 				}},
 			},
 		},
+		// FileArgument
 		{
-			name: "Eats partial flag completion",
-			// Eats partial flag completion because there's no great way
-			// to know if the value is for this flag or not.
+			name:        "FileArgument includes a vanilla FileCompleter",
+			filepathAbs: filepath.Join("."),
 			ctc: &commandtest.CompleteTestCase{
 				Node: SerialNodes(
-					FlagProcessor(
-						OptionalFlag[string]("of", 'o', testDesc, "dfltValue", SimpleDistinctCompleter[string]("abc", "def", "ghi")),
-						BoolFlag("bf", 'b', testDesc),
-					),
+					FileArgument("fn", testDesc),
 				),
-				Args: "cmd --of -",
+				Args: "cmd ",
+				WantData: &command.Data{Values: map[string]interface{}{
+					"fn": "",
+				}},
 				Want: &command.Autocompletion{
 					Suggestions: []string{
-						"--bf",
+						filepath.FromSlash(".dot-dir/"),
+						filepath.FromSlash("_testdata_symlink/"),
+						"arg.go",
+						"audit.go",
+						"audit_test.go",
+						"autocomplete.go",
+						"aws.go",
+						"aws_test.go",
+						"branch_help.go",
+						"branch_help_test.go",
+						"branch_node.go",
+						"branch_node_test.go",
+						"cache.go",
+						"cache_test.go",
+						filepath.FromSlash("co2test/"),
+						"completer.go",
+						"completer_test.go",
+						"conditional.go",
+						filepath.FromSlash("cotest/"),
+						"data_transformer.go",
+						"deadline.go",
+						"deadline_test.go",
+						"debug.go",
+						"description.go",
+						"disk_space.go",
+						"disk_space_test.go",
+						"dot_graph.go",
+						"dot_graph_test.go",
+						"echo.go",
+						"error.go",
+						"execute.go",
+						"execute_test.go",
+						"executor.go",
+						"experimental.go",
+						"experimental_test.go",
+						"fake.mod",
+						"fake.sum",
+						"feature_gate.go",
+						"feature_gate_test.go",
+						"fifo.go",
+						"fifo_test.go",
+						"file_functions.go",
+						"file_functions.txt",
+						"file_functions_test.go",
+						"flag.go",
+						"flags_file.go",
+						"flags_file_test.go",
+						"get_processor.go",
+						"git_tree.go",
+						"git_tree_test.go",
+						"json_arg.go",
+						"json_arg_test.go",
+						"keyring.go",
+						"keyring_test.go",
+						"kube.go",
+						"kube_test.go",
+						"layered_config.go",
+						"layered_config_test.go",
+						"list_breaker.go",
+						"macro.go",
+						"macro_test.go",
+						"make_target.go",
+						"make_target_test.go",
+						"map_arg.go",
+						"menu.go",
+						"metrics.go",
+						"metrics_test.go",
+						"mutable_processor.go",
+						"node_repeater.go",
+						"notify.go",
+						"notify_test.go",
+						"option.go",
+						"osenv.go",
+						"piped.go",
+						"piped_test.go",
+						"prompt.go",
+						"prompt_test.go",
+						"quiet.go",
+						"rate_limit.go",
+						"rate_limit_test.go",
+						"registry.go",
+						"registry_test.go",
+						"relative_time.go",
+						"relative_time_test.go",
+						"remember_last.go",
+						"remember_last_test.go",
+						"require_terminal.go",
+						"require_terminal_test.go",
+						"rules.go",
+						"rules_test.go",
+						"run.go",
+						"run_test.go",
+						"runtime_caller.go",
+						"runtime_caller_test.go",
+						"serial_nodes.go",
+						"setup.go",
+						"shell_command_node.go",
+						"shell_command_node_test.go",
+						"shell_vars.go",
+						"shortcut.go",
+						"shortcut_test.go",
+						"simple_node.go",
+						"simple_processor.go",
+						"slow_completer.go",
+						"slow_completer_test.go",
+						"static_cli.go",
+						"static_cli_test.go",
+						"strict_args.go",
+						"system_clipboard.go",
+						"system_clipboard_test.go",
+						filepath.FromSlash("testdata/"),
+						"transformer.go",
+						"usage_test.go",
+						"validator.go",
+						"working_directory.go",
+						"working_directory_test.go",
+						"zip_completer.go",
+						"zip_completer_test.go",
+						"zoneinfo.go",
+						"zoneinfo_test.go",
+						" ",
 					},
 				},
 			},
 		},
 		{
-			name: "Eats optional flag completion",
-			// Eats partial flag completion because there's no great way
-			// to know if the value is for this flag or not.
+			name:        "FileArgument uses provided FileCompleter option",
+			filepathAbs: filepath.Join("."),
 			ctc: &commandtest.CompleteTestCase{
 				Node: SerialNodes(
-					FlagProcessor(
-						OptionalFlag[string]("of", 'o', testDesc, "dfltValue", SimpleDistinctCompleter[string]("abc", "def", "ghi")),
-						BoolFlag("bf", 'b', testDesc),
-					),
+					FileArgument("fn", testDesc, &FileCompleter[string]{
+						FileTypes: []string{".sum", ".mod"},
+					}),
 				),
-				Args: "cmd --of provided -",
+				Args: "cmd ",
 				WantData: &command.Data{Values: map[string]interface{}{
-					"of": "provided",
+					"fn": "",
 				}},
 				Want: &command.Autocompletion{
 					Suggestions: []string{
-						"--bf",
+						filepath.FromSlash(".dot-dir/"),
+						filepath.FromSlash("_testdata_symlink/"),
+						filepath.FromSlash("co2test/"),
+						filepath.FromSlash("cotest/"),
+						"fake.mod",
+						"fake.sum",
+						filepath.FromSlash("testdata/"),
+						" ",
 					},
 				},
 			},
 		},
-		// ItemizedListFlag tests
 		{
-			name: "Itemized list flag gets completed",
+			name:        "FileCompleter works with absolute path",
+			filepathAbs: filepath.Join(),
 			ctc: &commandtest.CompleteTestCase{
 				Node: SerialNodes(
-					FlagProcessor(
-						ItemizedListFlag[string]("ilf", 'i', testDesc, SimpleDistinctCompleter[[]string]("abc", "def", "ghi")),
-					),
+					Arg[string]("fn", testDesc, CompleterFromFunc(func(s string, d *command.Data) (*command.Completion, error) {
+						_, thisFile, _, ok := runtime.Caller(0)
+						if !ok {
+							return nil, fmt.Errorf("failed to get runtime caller")
+						}
+						fc := &FileCompleter[string]{
+							Directory: filepath.Join(filepath.Dir(thisFile), "testdata"),
+						}
+						return fc.Complete(s, d)
+					})),
 				),
-				Args: "cmd --ilf",
+				Args: "cmd ",
+				WantData: &command.Data{Values: map[string]interface{}{
+					"fn": "",
+				}},
 				Want: &command.Autocompletion{
-					Suggestions: []string{"--ilf"},
+					Suggestions: []string{
+						".surprise",
+						filepath.FromSlash("cases/"),
+						filepath.FromSlash("dir1/"),
+						filepath.FromSlash("dir2/"),
+						filepath.FromSlash("dir3/"),
+						filepath.FromSlash("dir4/"),
+						"four.txt",
+						"METADATA",
+						filepath.FromSlash("metadata_/"),
+						filepath.FromSlash("moreCases/"),
+						"one.txt",
+						"three.txt",
+						"two.txt",
+						" ",
+					},
 				},
 			},
 		},
+		// FileCompleter.BaseDir tests
 		{
-			name: "Completes itemized list flag value",
+			name: "FileCompleter completes relative to BaseDir",
 			ctc: &commandtest.CompleteTestCase{
 				Node: SerialNodes(
-					FlagProcessor(
-						ItemizedListFlag[string]("ilf", 'i', testDesc, SimpleDistinctCompleter[[]string]("abc", "def", "ghi")),
-					),
+					Arg[string]("ROOT", testDesc),
+					Arg[string]("fn", testDesc, &FileCompleter[string]{
+						Directory: "dir1",
+						BaseDir: func(d *command.Data) (string, error) {
+							return d.String("ROOT"), nil
+						},
+					}),
 				),
-				Args: "cmd --ilf ",
-				Want: &command.Autocompletion{
-					Suggestions: []string{"abc", "def", "ghi"},
-				},
+				Args: "cmd testdata dir2/f",
 				WantData: &command.Data{Values: map[string]interface{}{
-					"ilf": []string{""},
+					"ROOT": "testdata",
+					"fn":   "dir2/f",
 				}},
+				Want: &command.Autocompletion{
+					Suggestions: []string{
+						filepath.FromSlash("dir2/file"),
+					},
+					SpacelessCompletion: true,
+				},
 			},
 		},
 		{
-			name: "Completes later itemized list flag value",
+			name: "FileCompleter completes single match relative to BaseDir",
 			ctc: &commandtest.CompleteTestCase{
 				Node: SerialNodes(
-					FlagProcessor(
-						ItemizedListFlag[string]("ilf", 'i', testDesc, SimpleDistinctCompleter[[]string]("abc", "def", "ghi")),
-					),
+					Arg[string]("ROOT", testDesc),
+					Arg[string]("fn", testDesc, &FileCompleter[string]{
+						BaseDir: func(d *command.Data) (string, error) {
+							return d.String("ROOT"), nil
+						},
+					}),
 				),
-				Args: "cmd --ilf un -i d",
+				Args: "cmd testdata dir1/fi",
 				WantData: &command.Data{Values: map[string]interface{}{
-					"ilf": []string{"un", "d"},
+					"ROOT": "testdata",
+					"fn":   "dir1/fi",
 				}},
 				Want: &command.Autocompletion{
-					Suggestions: []string{"def"},
+					Suggestions: []string{
+						filepath.FromSlash("dir1/first.txt"),
+					},
 				},
 			},
 		},
 		{
-			name: "Completes distinct itemized list flag value",
+			name: "FileCompleter fails if BaseDir fails",
 			ctc: &commandtest.CompleteTestCase{
 				Node: SerialNodes(
-					FlagProcessor(
-						ItemizedListFlag[string]("ilf", 'i', testDesc, SimpleDistinctCompleter[[]string]("abc", "def", "ghi")),
-					),
+					Arg[string]("fn", testDesc, &FileCompleter[string]{
+						BaseDir: func(d *command.Data) (string, error) {
+							return "", fmt.Errorf("no project root")
+						},
+					}),
 				),
-				Args: "cmd --ilf def -i ",
+				Args:    "cmd dir1/fi",
+				WantErr: fmt.Errorf("failed to get base directory: no project root"),
 				WantData: &command.Data{Values: map[string]interface{}{
-					"ilf": []string{"def", ""},
+					"fn": "dir1/fi",
 				}},
-				Want: &command.Autocompletion{
-					Suggestions: []string{"abc", "ghi"},
-				},
 			},
 		},
-		// Flag completion with list breaker
+		// EnvRefArg tests
 		{
-			name: "completes flag argument when flag processor's list breaker is provided as arg option",
+			name: "EnvRefArg completes environment variable names",
 			ctc: &commandtest.CompleteTestCase{
-				Node: SerialNodes(
-					ListArg[string]("lst", testDesc, 0, command.UnboundedList, breakerFlagProcessor.ListBreaker()),
-					breakerFlagProcessor,
-				),
-				Args: "cmd v1 v2 other --names ",
+				Env: map[string]string{
+					"MY_VAR":    "my value",
+					"MY_OTHER":  "other value",
+					"UNRELATED": "unrelated",
+				},
+				Node: SerialNodes(EnvRefArg("ref", testDesc)),
+				Args: "cmd ",
 				Want: &command.Autocompletion{
-					Suggestions: []string{"johnny", "ralph", "renee"},
+					Suggestions: []string{"MY_OTHER", "MY_VAR", "UNRELATED"},
 				},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"lst":   []string{"v1", "v2", "other"},
-					"names": []string{""},
+					"ref": "",
 				}},
 			},
 		},
 		{
-			name: "second flag is recognized and completed",
+			name: "EnvRefArg completes partial environment variable names",
 			ctc: &commandtest.CompleteTestCase{
-				Node: SerialNodes(
-					ListArg[string]("lst", testDesc, 0, command.UnboundedList, breakerFlagProcessor.ListBreaker()),
-					breakerFlagProcessor,
-				),
-				Args: "cmd v1 v2 other --names un --greeting ",
+				Env: map[string]string{
+					"MY_VAR":    "my value",
+					"MY_OTHER":  "other value",
+					"UNRELATED": "unrelated",
+				},
+				Node: SerialNodes(EnvRefArg("ref", testDesc)),
+				Args: "cmd MY",
 				Want: &command.Autocompletion{
-					Suggestions: []string{"hey", "hi"},
+					Suggestions: []string{"MY_OTHER", "MY_VAR"},
 				},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"lst":      []string{"v1", "v2", "other"},
-					"names":    []string{"un"},
-					"greeting": "",
+					"ref": "MY",
 				}},
 			},
 		},
-		// DeferredCompletion tests
+		// MaxSuggestions tests
 		{
-			name: "DeferredCompletion handles nil graph",
+			name: "completion truncates suggestions at MaxSuggestions",
 			ctc: &commandtest.CompleteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(
-						ListFlag[string]("lf", 'f', testDesc, 0, command.UnboundedList, DeferredCompleter(nil, SimpleCompleter[[]string]("abc", "def"))),
-					),
-				),
-				Args: "cmd --lf ab ",
+				Node: SerialNodes(Arg[string]("s", testDesc, AsCompleter[string](&command.Completion{
+					Suggestions:    []string{"alpha", "bravo", "charlie", "delta", "echo"},
+					MaxSuggestions: 3,
+				}))),
+				Args: "cmd ",
 				Want: &command.Autocompletion{
-					Suggestions: []string{"abc", "def"},
+					Suggestions: []string{"alpha", "bravo", "charlie", "(+2 more)"},
 				},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"lf": []string{"ab", ""},
+					"s": "",
 				}},
 			},
 		},
 		{
-			name: "DeferredCompletion handles error",
+			name: "completion doesn't truncate suggestions when filtered below MaxSuggestions",
 			ctc: &commandtest.CompleteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(
-						ListFlag[string]("lf", 'f', testDesc, 0, command.UnboundedList, DeferredCompleter(nil, CompleterFromFunc(func([]string, *command.Data) (*command.Completion, error) {
-							return &command.Completion{Suggestions: []string{"abc", "def"}}, fmt.Errorf("oh well")
-						}))),
-					),
-				),
-				Args:    "cmd --lf ab ",
-				WantErr: fmt.Errorf("oh well"),
+				Node: SerialNodes(Arg[string]("s", testDesc, AsCompleter[string](&command.Completion{
+					Suggestions:    []string{"alpha", "bravo", "charlie", "delta", "echo"},
+					MaxSuggestions: 3,
+				}))),
+				Args: "cmd b",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"bravo"},
+				},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"lf": []string{"ab", ""},
+					"s": "b",
 				}},
 			},
 		},
 		{
-			name: "DeferredCompletion executes sub graph",
+			name: "completion truncates suggestions at DefaultMaxSuggestions",
 			ctc: &commandtest.CompleteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(
-						ListFlag[string]("lf", 'f', testDesc, 0, command.UnboundedList, DeferredCompleter(
-							SerialNodes(
-								ListArg[string]("la", testDesc, 3, command.UnboundedList, SimpleCompleter[[]string]("un", "deux")),
-							),
-							CompleterFromFunc(func([]string, *command.Data) (*command.Completion, error) {
-								return &command.Completion{Suggestions: []string{"abc", "def"}}, fmt.Errorf("oh well")
-							}))),
-					),
-				),
-				Args:    "cmd v1 v2 other --lf ab ",
-				WantErr: fmt.Errorf("oh well"),
+				Node: SerialNodes(DefaultMaxSuggestions(2), Arg[string]("s", testDesc, SimpleCompleter[string]("alpha", "bravo", "charlie", "delta", "echo"))),
+				Args: "cmd ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"alpha", "bravo", "(+3 more)"},
+				},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"lf": []string{"ab", ""},
-					"la": []string{"v1", "v2", "other"},
+					"s": "",
 				}},
 			},
 		},
 		{
-			name: "DeferredCompletion returns error from sub graph",
+			name: "completion MaxSuggestions overrides DefaultMaxSuggestions",
 			ctc: &commandtest.CompleteTestCase{
-				Node: SerialNodes(
-					FlagProcessor(
-						ListFlag[string]("lf", 'f', testDesc, 0, command.UnboundedList, DeferredCompleter(
-							SerialNodes(
-								ListArg[string]("la", testDesc, 4, command.UnboundedList, SimpleCompleter[[]string]("un", "deux")),
-							),
-							CompleterFromFunc(func([]string, *command.Data) (*command.Completion, error) {
-								return &command.Completion{Suggestions: []string{"abc", "def"}}, fmt.Errorf("oh well")
-							}))),
-					),
-				),
-				Args:    "cmd v1 v2 other --lf ab ",
-				WantErr: fmt.Errorf(`failed to execute DeferredCompletion graph: Argument "la" requires at least 4 arguments, got 3`),
+				Node: SerialNodes(DefaultMaxSuggestions(2), Arg[string]("s", testDesc, AsCompleter[string](&command.Completion{
+					Suggestions:    []string{"alpha", "bravo", "charlie", "delta", "echo"},
+					MaxSuggestions: 3,
+				}))),
+				Args: "cmd ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"alpha", "bravo", "charlie", "(+2 more)"},
+				},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"lf": []string{"ab", ""},
-					"la": []string{"v1", "v2", "other"},
+					"s": "",
 				}},
 			},
 		},
-		// Transformer arg tests.
 		{
-			name: "handles nil option",
+			name: "negative completion MaxSuggestions disables DefaultMaxSuggestions",
 			ctc: &commandtest.CompleteTestCase{
-				Node: &SimpleNode{
-					Processor: Arg[string]("strArg", testDesc),
+				Node: SerialNodes(DefaultMaxSuggestions(2), Arg[string]("s", testDesc, AsCompleter[string](&command.Completion{
+					Suggestions:    []string{"alpha", "bravo", "charlie"},
+					MaxSuggestions: -1,
+				}))),
+				Args: "cmd ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"alpha", "bravo", "charlie"},
 				},
-				Args: "cmd abc",
 				WantData: &command.Data{Values: map[string]interface{}{
-					"strArg": "abc",
+					"s": "",
 				}},
 			},
 		},
+		// RemainingCountHint tests
 		{
-			name: "list handles nil option",
+			name: "RemainingCountHint includes hint for first value",
 			ctc: &commandtest.CompleteTestCase{
-				Node: &SimpleNode{
-					Processor: ListArg[string]("slArg", testDesc, 1, 2),
+				Node: SerialNodes(ListArg[string]("SL", testDesc, 3, 2, SimpleCompleter[[]string]("alpha", "bravo", "charlie", "charlotte"), RemainingCountHint[[]string]())),
+				Args: "cmd ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"alpha", "bravo", "charlie", "charlotte", "(3 more)"},
 				},
-				Args: "cmd abc",
 				WantData: &command.Data{Values: map[string]interface{}{
-					"slArg": []string{"abc"},
+					"SL": []string{""},
 				}},
 			},
 		},
 		{
-			name: "transformer doesn't transform value during completion",
+			name: "RemainingCountHint includes hint for later values",
 			ctc: &commandtest.CompleteTestCase{
-				Node: SerialNodes(Arg[string]("strArg", testDesc,
-					&Transformer[string]{F: func(string, *command.Data) (string, error) {
-						return "newStuff", nil
-					}})),
-				Args: "cmd abc",
+				Node: SerialNodes(ListArg[string]("SL", testDesc, 3, 2, SimpleCompleter[[]string]("alpha", "bravo", "charlie", "charlotte"), RemainingCountHint[[]string]())),
+				Args: "cmd alpha ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"alpha", "bravo", "charlie", "charlotte", "(2 more)"},
+				},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"strArg": "abc",
+					"SL": []string{"alpha", ""},
 				}},
 			},
 		},
 		{
-			name:        "FileTransformer doesn't transform",
-			filepathAbs: filepath.Join("abso", "lutely"),
+			name: "RemainingCountHint includes hint for last required value",
 			ctc: &commandtest.CompleteTestCase{
-				Node: &SimpleNode{
-					Processor: Arg[string]("strArg", testDesc, FileTransformer()),
+				Node: SerialNodes(ListArg[string]("SL", testDesc, 3, 2, SimpleCompleter[[]string]("alpha", "bravo", "charlie", "charlotte"), RemainingCountHint[[]string]())),
+				Args: "cmd alpha bravo ch",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"charlie", "charlotte", "(1 more)"},
 				},
-				Args: fmt.Sprintf("cmd %s", filepath.Join("relative", "path.txt")),
 				WantData: &command.Data{Values: map[string]interface{}{
-					"strArg": filepath.Join("relative", "path.txt"),
+					"SL": []string{"alpha", "bravo", "ch"},
 				}},
 			},
 		},
 		{
-			name:        "FileTransformer for list doesn't transform",
-			filepathAbs: filepath.Join("abso", "lutely"),
+			name: "RemainingCountHint doesn't include hint once minimum is reached",
 			ctc: &commandtest.CompleteTestCase{
-				Node: &SimpleNode{
-					Processor: ListArg[string]("strArg", testDesc, 1, 2, TransformerList(FileTransformer())),
+				Node: SerialNodes(ListArg[string]("SL", testDesc, 3, 2, SimpleCompleter[[]string]("alpha", "bravo", "charlie", "charlotte"), RemainingCountHint[[]string]())),
+				Args: "cmd alpha bravo charlie ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"alpha", "bravo", "charlie", "charlotte"},
 				},
-				Args: fmt.Sprintf("cmd %s", filepath.Join("relative", "path.txt")),
 				WantData: &command.Data{Values: map[string]interface{}{
-					"strArg": []string{filepath.Join("relative", "path.txt")},
+					"SL": []string{"alpha", "bravo", "charlie", ""},
 				}},
 			},
 		},
 		{
-			name:           "handles transform error",
-			filepathAbsErr: fmt.Errorf("bad news bears"),
+			name: "RemainingCountHint doesn't include hint if only one suggestion",
 			ctc: &commandtest.CompleteTestCase{
-				Node: &SimpleNode{
-					Processor: Arg[string]("strArg", testDesc, FileTransformer()),
+				Node: SerialNodes(ListArg[string]("SL", testDesc, 3, 2, SimpleCompleter[[]string]("alpha", "bravo", "charlie", "charlotte"), RemainingCountHint[[]string]())),
+				Args: "cmd alpha b",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"bravo"},
 				},
-				Args: fmt.Sprintf("cmd %s", filepath.Join("relative", "path.txt")),
 				WantData: &command.Data{Values: map[string]interface{}{
-					"strArg": filepath.Join("relative", "path.txt"),
+					"SL": []string{"alpha", "b"},
 				}},
 			},
 		},
 		{
-			name:        "transformer list doesn't transforms values during completion",
-			filepathAbs: filepath.Join("abso", "lutely"),
+			name: "list arg completion doesn't include hint by default",
 			ctc: &commandtest.CompleteTestCase{
-				Node: &SimpleNode{
-					Processor: ListArg[string]("slArg", testDesc, 1, 2, &Transformer[[]string]{F: func(sl []string, d *command.Data) ([]string, error) {
-						var r []string
-						for _, s := range sl {
-							r = append(r, fmt.Sprintf("_%s_", s))
-						}
-						return r, nil
-					}}),
+				Node: SerialNodes(ListArg[string]("SL", testDesc, 3, 2, SimpleCompleter[[]string]("alpha", "bravo", "charlie", "charlotte"))),
+				Args: "cmd alpha ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"alpha", "bravo", "charlie", "charlotte"},
 				},
-				Args: "cmd uno dos",
 				WantData: &command.Data{Values: map[string]interface{}{
-					"slArg": []string{
-						"uno",
-						"dos",
-					},
+					"SL": []string{"alpha", ""},
 				}},
 			},
 		},
+		// TreatEmptyAsUnset tests
 		{
-			name:        "transformer list transforms values that aren't at the end",
-			filepathAbs: filepath.Join("abso", "lutely"),
+			name: "empty optional arg is stored when completing without TreatEmptyAsUnset",
 			ctc: &commandtest.CompleteTestCase{
 				Node: SerialNodes(
-					ListArg[string]("slArg", testDesc, 1, 1, &Transformer[[]string]{F: func(sl []string, d *command.Data) ([]string, error) {
-						var r []string
-						for _, s := range sl {
-							r = append(r, fmt.Sprintf("_%s_", s))
-						}
-						return r, nil
-					}}),
-					Arg[string]("sArg", testDesc, &Transformer[string]{F: func(s string, d *command.Data) (string, error) {
-						return s + "!", nil
-					}}),
+					OptionalArg[string]("name", testDesc, Default("bob")),
+					Arg[string]("greeting", testDesc, SimpleCompleter[string]("hello", "hi", "yo")),
 				),
-				Args: "cmd uno dos t",
+				Args: `cmd "" h`,
+				Want: &command.Autocompletion{
+					Suggestions: []string{"hello", "hi"},
+				},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"slArg": []string{
-						"_uno_",
-						"_dos_",
-					},
-					"sArg": "t",
+					"name":     "",
+					"greeting": "h",
 				}},
 			},
 		},
 		{
-			name:        "transformer list transforms values on a best effort basis",
-			filepathAbs: filepath.Join("abso", "lutely"),
+			name: "empty optional arg uses default when completing with TreatEmptyAsUnset",
 			ctc: &commandtest.CompleteTestCase{
 				Node: SerialNodes(
-					ListArg[string]("slArg", testDesc, 1, 1, &Transformer[[]string]{F: func(sl []string, d *command.Data) ([]string, error) {
-						var r []string
-						for _, s := range sl {
-							r = append(r, fmt.Sprintf("_%s_", s))
-						}
-						return r, nil
-					}}),
-					Arg[string]("sArg", testDesc, &Transformer[string]{F: func(s string, d *command.Data) (string, error) {
-						return "oh", fmt.Errorf("Nooooooo")
-					}}),
-					Arg[string]("sArg2", testDesc, &Transformer[string]{F: func(s string, d *command.Data) (string, error) {
-						return "oh yea", fmt.Errorf("nope")
-					}}),
+					OptionalArg[string]("name", testDesc, Default("bob"), TreatEmptyAsUnset[string]()),
+					Arg[string]("greeting", testDesc, SimpleCompleter[string]("hello", "hi", "yo")),
 				),
-				Args: "cmd uno dos tres q",
+				Args: `cmd "" h`,
+				Want: &command.Autocompletion{
+					Suggestions: []string{"hello", "hi"},
+				},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"slArg": []string{
-						"_uno_",
-						"_dos_",
-					},
-					"sArg":  "tres",
-					"sArg2": "q",
+					"name":     "bob",
+					"greeting": "h",
 				}},
 			},
 		},
 		{
-			name:           "handles transform list error",
-			filepathAbsErr: fmt.Errorf("bad news bears"),
+			name: "empty values are dropped from list args when completing with TreatEmptyAsUnset",
 			ctc: &commandtest.CompleteTestCase{
-				Node: &SimpleNode{
-					Processor: ListArg[string]("slArg", testDesc, 1, 2, TransformerList(FileTransformer())),
+				Node: SerialNodes(
+					ListArg[string]("names", testDesc, 1, 3, TreatEmptyAsUnset[[]string](), SimpleCompleter[[]string]("alice", "bob")),
+				),
+				Args: `cmd alice "" `,
+				Want: &command.Autocompletion{
+					Suggestions: []string{"alice", "bob"},
 				},
-				Args: fmt.Sprintf("cmd %s %s", filepath.Join("relative", "path.txt"), filepath.Join("other.txt")),
 				WantData: &command.Data{Values: map[string]interface{}{
-					"slArg": []string{
-						filepath.Join("relative", "path.txt"),
-						filepath.Join("other.txt"),
-					},
+					"names": []string{"alice", ""},
 				}},
 			},
 		},
+		// AutofillCommonPrefix tests
 		{
-			name: "handles list transformer of incorrect type",
+			name: "completion autofills common prefix",
 			ctc: &commandtest.CompleteTestCase{
-				Node: &SimpleNode{
-					Processor: ListArg[string]("slArg", testDesc, 1, 2, TransformerList(FileTransformer())),
+				Node: SerialNodes(Arg[string]("s", testDesc, AsCompleter[string](&command.Completion{
+					Suggestions:          []string{"zzz-1", "zzz-2"},
+					AutofillCommonPrefix: true,
+				}))),
+				Args: "cmd ",
+				Want: &command.Autocompletion{
+					Suggestions:         []string{"zzz-"},
+					SpacelessCompletion: true,
 				},
-				Args: "cmd 123",
 				WantData: &command.Data{Values: map[string]interface{}{
-					"slArg": []string{"123"},
+					"s": "",
 				}},
 			},
 		},
-		// FileArgument
 		{
-			name:        "FileArgument includes a vanilla FileCompleter",
-			filepathAbs: filepath.Join("."),
+			name: "completion autofills common prefix of filtered suggestions",
 			ctc: &commandtest.CompleteTestCase{
-				Node: SerialNodes(
-					FileArgument("fn", testDesc),
-				),
-				Args: "cmd ",
-				WantData: &command.Data{Values: map[string]interface{}{
-					"fn": "",
-				}},
+				Node: SerialNodes(Arg[string]("s", testDesc, AsCompleter[string](&command.Completion{
+					Suggestions:          []string{"zzz-1", "zzz-2", "other"},
+					AutofillCommonPrefix: true,
+				}))),
+				Args: "cmd z",
 				Want: &command.Autocompletion{
-					Suggestions: []string{
-						filepath.FromSlash(".dot-dir/"),
-						filepath.FromSlash("_testdata_symlink/"),
-						"arg.go",
-						"autocomplete.go",
-						"branch_node.go",
-						"branch_node_test.go",
-						"cache.go",
-						"cache_test.go",
-						filepath.FromSlash("co2test/"),
-						"completer.go",
-						"completer_test.go",
-						"conditional.go",
-						filepath.FromSlash("cotest/"),
-						"data_transformer.go",
-						"debug.go",
-						"description.go",
-						"echo.go",
-						"error.go",
-						"execute.go",
-						"execute_test.go",
-						"executor.go",
-						"fake.mod",
-						"fake.sum",
-						"file_functions.go",
-						"file_functions.txt",
-						"flag.go",
-						"get_processor.go",
-						"list_breaker.go",
-						"map_arg.go",
-						"menu.go",
-						"mutable_processor.go",
-						"node_repeater.go",
-						"option.go",
-						"osenv.go",
-						"prompt.go",
-						"runtime_caller.go",
-						"runtime_caller_test.go",
-						"serial_nodes.go",
-						"setup.go",
-						"shell_command_node.go",
-						"shell_command_node_test.go",
-						"shortcut.go",
-						"shortcut_test.go",
-						"simple_node.go",
-						"simple_processor.go",
-						"static_cli.go",
-						"static_cli_test.go",
-						filepath.FromSlash("testdata/"),
-						"transformer.go",
-						"usage_test.go",
-						"validator.go",
-						"working_directory.go",
-						" ",
-					},
+					Suggestions:         []string{"zzz-"},
+					SpacelessCompletion: true,
 				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"s": "z",
+				}},
 			},
 		},
 		{
-			name:        "FileArgument uses provided FileCompleter option",
-			filepathAbs: filepath.Join("."),
+			name: "completion returns all suggestions if common prefix is already provided",
 			ctc: &commandtest.CompleteTestCase{
-				Node: SerialNodes(
-					FileArgument("fn", testDesc, &FileCompleter[string]{
-						FileTypes: []string{".sum", ".mod"},
-					}),
-				),
-				Args: "cmd ",
+				Node: SerialNodes(Arg[string]("s", testDesc, AsCompleter[string](&command.Completion{
+					Suggestions:          []string{"zzz-1", "zzz-2", "other"},
+					AutofillCommonPrefix: true,
+				}))),
+				Args: "cmd zzz-",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"zzz-1", "zzz-2"},
+				},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"fn": "",
+					"s": "zzz-",
 				}},
+			},
+		},
+		{
+			name: "completion returns all suggestions if there is no common prefix",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(Arg[string]("s", testDesc, AsCompleter[string](&command.Completion{
+					Suggestions:          []string{"zzz-1", "zzz-2", "other"},
+					AutofillCommonPrefix: true,
+				}))),
+				Args: "cmd ",
 				Want: &command.Autocompletion{
-					Suggestions: []string{
-						filepath.FromSlash(".dot-dir/"),
-						filepath.FromSlash("_testdata_symlink/"),
-						filepath.FromSlash("co2test/"),
-						filepath.FromSlash("cotest/"),
-						"fake.mod",
-						"fake.sum",
-						filepath.FromSlash("testdata/"),
-						" ",
-					},
+					Suggestions: []string{"other", "zzz-1", "zzz-2"},
 				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"s": "",
+				}},
 			},
 		},
 		{
-			name:        "FileCompleter works with absolute path",
-			filepathAbs: filepath.Join(),
+			name: "completion doesn't autofill common prefix if not enabled",
 			ctc: &commandtest.CompleteTestCase{
-				Node: SerialNodes(
-					Arg[string]("fn", testDesc, CompleterFromFunc(func(s string, d *command.Data) (*command.Completion, error) {
-						_, thisFile, _, ok := runtime.Caller(0)
-						if !ok {
-							return nil, fmt.Errorf("failed to get runtime caller")
-						}
-						fc := &FileCompleter[string]{
-							Directory: filepath.Join(filepath.Dir(thisFile), "testdata"),
-						}
-						return fc.Complete(s, d)
-					})),
-				),
-				Args: "cmd ",
+				Node: SerialNodes(Arg[string]("s", testDesc, AsCompleter[string](&command.Completion{
+					Suggestions: []string{"zzz-1", "zzz-2", "other"},
+				}))),
+				Args: "cmd z",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"zzz-1", "zzz-2"},
+				},
 				WantData: &command.Data{Values: map[string]interface{}{
-					"fn": "",
+					"s": "z",
 				}},
+			},
+		},
+		{
+			name: "completion completes single suggestion when autofilling common prefix",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(Arg[string]("s", testDesc, AsCompleter[string](&command.Completion{
+					Suggestions:          []string{"zzz-1", "zzz-2", "other"},
+					AutofillCommonPrefix: true,
+				}))),
+				Args: "cmd zzz-1",
 				Want: &command.Autocompletion{
-					Suggestions: []string{
-						".surprise",
-						filepath.FromSlash("cases/"),
-						filepath.FromSlash("dir1/"),
-						filepath.FromSlash("dir2/"),
-						filepath.FromSlash("dir3/"),
-						filepath.FromSlash("dir4/"),
-						"four.txt",
-						"METADATA",
-						filepath.FromSlash("metadata_/"),
-						filepath.FromSlash("moreCases/"),
-						"one.txt",
-						"three.txt",
-						"two.txt",
-						" ",
-					},
+					Suggestions: []string{"zzz-1"},
 				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"s": "zzz-1",
+				}},
 			},
 		},
 		// BranchNode completion tests.
@@ -9265,6 +14192,205 @@ func TestComplete(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "nested BranchNode completes top-level branches",
+			ctc: &commandtest.CompleteTestCase{
+				Node: nestedBranchNode(),
+				Args: "cmd ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"a", "b", "c"},
+				},
+			},
+		},
+		{
+			name: "nested BranchNode completes second-level branches",
+			ctc: &commandtest.CompleteTestCase{
+				Node: nestedBranchNode(),
+				Args: "cmd a ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"b", "bb", "c"},
+				},
+			},
+		},
+		{
+			name: "nested BranchNode completes second-level branches with partial arg",
+			ctc: &commandtest.CompleteTestCase{
+				Node: nestedBranchNode(),
+				Args: "cmd a b",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"b", "bb"},
+				},
+			},
+		},
+		{
+			name: "nested BranchNode completes third-level branches",
+			ctc: &commandtest.CompleteTestCase{
+				Node: nestedBranchNode(),
+				Args: "cmd a b ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"x", "y"},
+				},
+			},
+		},
+		{
+			name: "nested BranchNode completes third-level branches with partial arg",
+			ctc: &commandtest.CompleteTestCase{
+				Node: nestedBranchNode(),
+				Args: "cmd a b x",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"x"},
+				},
+			},
+		},
+		{
+			name: "nested BranchNode completes leaf node",
+			ctc: &commandtest.CompleteTestCase{
+				Node: nestedBranchNode(),
+				Args: "cmd a b x ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"one", "three", "two"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"leaf": "",
+				}},
+			},
+		},
+		{
+			name: "nested BranchNode completes other third-level branch",
+			ctc: &commandtest.CompleteTestCase{
+				Node: nestedBranchNode(),
+				Args: "cmd a b y ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"why", "wye"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"yArg": "",
+				}},
+			},
+		},
+		{
+			name: "nested BranchNode completes branch after first-level synonym",
+			ctc: &commandtest.CompleteTestCase{
+				Node: nestedBranchNode(),
+				Args: "cmd alpha ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"b", "bb", "c"},
+				},
+			},
+		},
+		{
+			name: "nested BranchNode completes branch after branch name synonym",
+			ctc: &commandtest.CompleteTestCase{
+				Node: nestedBranchNode(),
+				Args: "cmd A b ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"x", "y"},
+				},
+			},
+		},
+		{
+			name: "nested BranchNode completes branch after second-level synonyms",
+			ctc: &commandtest.CompleteTestCase{
+				Node: nestedBranchNode(),
+				Args: "cmd alpha beta ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"x", "y"},
+				},
+			},
+		},
+		{
+			name: "nested BranchNode completes leaf after synonyms at every level",
+			ctc: &commandtest.CompleteTestCase{
+				Node: nestedBranchNode(),
+				Args: "cmd A beta ex ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"one", "three", "two"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"leaf": "",
+				}},
+			},
+		},
+		{
+			name: "nested BranchNode completes branch after Synonyms map entry",
+			ctc: &commandtest.CompleteTestCase{
+				Node: nestedBranchNode(),
+				Args: "cmd alpha bee ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"x", "y"},
+				},
+			},
+		},
+		{
+			name: "nested BranchNode completes other nested branch",
+			ctc: &commandtest.CompleteTestCase{
+				Node: nestedBranchNode(),
+				Args: "cmd a c ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"z"},
+				},
+			},
+		},
+		{
+			name: "nested BranchNode completes other top-level branch",
+			ctc: &commandtest.CompleteTestCase{
+				Node: nestedBranchNode(),
+				Args: "cmd b ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"b1", "b2"},
+				},
+			},
+		},
+		{
+			name: "nested BranchNode fails for unknown nested branch",
+			ctc: &commandtest.CompleteTestCase{
+				Node:    nestedBranchNode(),
+				Args:    "cmd a d ",
+				WantErr: fmt.Errorf("Branching argument must be one of [b beta bb c]"),
+			},
+			ictc: &spycommandtest.CompleteTestCase{
+				WantIsBranchingError: true,
+				WantIsUsageError:     true,
+			},
+		},
+		// CompletionSetup tests
+		{
+			name: "CompletionSetup sets data for subsequent completer",
+			ctc: &commandtest.CompleteTestCase{
+				Args: "cmd ",
+				Node: SerialNodes(
+					CompletionSetup(func(i *command.Input, d *command.Data) error {
+						d.Set("choices", []string{"abc", "def"})
+						return nil
+					}),
+					Arg[string]("s", testDesc, CompleterFromFunc(func(s string, d *command.Data) (*command.Completion, error) {
+						return &command.Completion{Suggestions: d.StringList("choices")}, nil
+					})),
+				),
+				Want: &command.Autocompletion{
+					Suggestions: []string{"abc", "def"},
+				},
+				WantData: &command.Data{
+					Values: map[string]interface{}{
+						"choices": []string{"abc", "def"},
+						"s":       "",
+					},
+				},
+			},
+		},
+		{
+			name: "returns error from CompletionSetup",
+			ctc: &commandtest.CompleteTestCase{
+				Args: "cmd ",
+				Node: SerialNodes(
+					CompletionSetup(func(i *command.Input, d *command.Data) error {
+						return fmt.Errorf("failed to load")
+					}),
+					Arg[string]("s", testDesc, SimpleCompleter[string]("abc", "def")),
+				),
+				WantErr: fmt.Errorf("failed to load"),
+			},
+		},
 		// SuperSimpleProcessor tests
 		{
 			name: "sets data with SuperSimpleProcessor",
@@ -10132,6 +15258,38 @@ func TestComplete(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "MapArg completes int keys in numeric order",
+			ctc: &commandtest.CompleteTestCase{
+				Args: "cmd ",
+				Node: SerialNodes(
+					MapArg("m", testDesc, map[int]string{
+						300: "three hundred",
+						5:   "five",
+						40:  "forty",
+					}, true),
+				),
+				Want: &command.Autocompletion{
+					Suggestions: []string{"5", "40", "300"},
+				},
+			},
+		},
+		{
+			name: "MapArg with DisableCompletion doesn't complete keys",
+			ctc: &commandtest.CompleteTestCase{
+				Args: "cmd t",
+				Node: SerialNodes(
+					MapArg("m", testDesc, map[string]int{
+						"one":   1,
+						"two":   2,
+						"three": 3,
+					}, true).DisableCompletion(),
+				),
+				WantData: &command.Data{Values: map[string]interface{}{
+					"m": 0,
+				}},
+			},
+		},
 		// MapFlag test
 		{
 			name: "MapFlag completes some keys",
@@ -10154,6 +15312,24 @@ func TestComplete(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "MapFlag with DisableCompletion doesn't complete keys",
+			ctc: &commandtest.CompleteTestCase{
+				Args: "cmd --m t",
+				Node: SerialNodes(
+					FlagProcessor(
+						MapFlag("m", 'm', testDesc, map[string]int{
+							"one":   1,
+							"two":   2,
+							"three": 3,
+						}, true).DisableCompletion(),
+					),
+				),
+				WantData: &command.Data{Values: map[string]interface{}{
+					"m": 0,
+				}},
+			},
+		},
 		// FlagStop test
 		{
 			name: "Stops processing flags after flag stop",
@@ -10318,6 +15494,44 @@ func sampleRepeaterProcessor(minN, optionalN int) command.Processor {
 	), minN, optionalN)
 }
 
+// nestedBranchNode returns a `BranchNode` with multiple levels of nested
+// branches (and synonyms at each level).
+func nestedBranchNode() command.Node {
+	return &BranchNode{
+		Branches: map[string]command.Node{
+			"a A": &BranchNode{
+				Branches: map[string]command.Node{
+					"b beta": &BranchNode{
+						Branches: map[string]command.Node{
+							"x ex": SerialNodes(Arg[string]("leaf", testDesc, SimpleCompleter[string]("one", "two", "three"))),
+							"y":    SerialNodes(Arg[string]("yArg", testDesc, SimpleCompleter[string]("why", "wye"))),
+						},
+					},
+					"bb": &SimpleNode{},
+					"c": &BranchNode{
+						Branches: map[string]command.Node{
+							"z": &SimpleNode{},
+						},
+					},
+				},
+				Synonyms: BranchSynonyms(map[string][]string{
+					"b": {"bee"},
+				}),
+			},
+			"b": &BranchNode{
+				Branches: map[string]command.Node{
+					"b1": &SimpleNode{},
+					"b2": &SimpleNode{},
+				},
+			},
+			"c": &SimpleNode{},
+		},
+		Synonyms: BranchSynonyms(map[string][]string{
+			"a": {"alpha"},
+		}),
+	}
+}
+
 func branchSynNode() command.Node {
 	return &BranchNode{
 		Branches: map[string]command.Node{
@@ -10409,6 +15623,20 @@ func TestPanics(t *testing.T) {
 			},
 			want: "Short flag name '&' must match regex ^[a-zA-Z0-9]$",
 		},
+		{
+			name: "Required flag can't have a default value",
+			f: func() {
+				Flag[string]("name", 'n', testDesc, Required[string](), Default("alice"))
+			},
+			want: `Flag "name" cannot be required and have a default value`,
+		},
+		{
+			name: "Required option can't be added to flag with a default value",
+			f: func() {
+				Flag[string]("name", 'n', testDesc, Default("alice")).AddOptions(Required[string]())
+			},
+			want: `Flag "name" cannot be required and have a default value`,
+		},
 		{
 			name: "Can't add options to a boolean flag",
 			f: func() {
@@ -58,3 +58,62 @@ func FunctionWrap() command.Processor {
 		return nil
 	}, nil)
 }
+
+// DedupeExecutableOption is an option interface for modifying `DedupeExecutable` processors.
+type DedupeExecutableOption interface {
+	modifyDedupeExecutable(*dedupeExecutable)
+}
+
+// DedupeConsecutive is a `DedupeExecutableOption` that only removes lines that
+// are identical to the line immediately before them (rather than removing all
+// repeated lines).
+func DedupeConsecutive() DedupeExecutableOption {
+	return &dedupeConsecutive{}
+}
+
+type dedupeConsecutive struct{}
+
+func (dc *dedupeConsecutive) modifyDedupeExecutable(de *dedupeExecutable) {
+	de.consecutive = true
+}
+
+// DedupeExecutable returns a `command.Processor` that removes duplicate lines
+// from `command.ExecuteData.Executable` (keeping the first occurrence of each
+// line, in order). The lines are deduplicated after all nodes have been
+// processed, so lines added by downstream processors are also deduplicated.
+// However, lines added by `command.ExecuteData.Executor` functions that
+// are run after this one are not deduplicated.
+func DedupeExecutable(opts ...DedupeExecutableOption) command.Processor {
+	de := &dedupeExecutable{}
+	for _, opt := range opts {
+		opt.modifyDedupeExecutable(de)
+	}
+	return SimpleProcessor(func(i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
+		ed.Executor = append(ed.Executor, func(command.Output, *command.Data) error {
+			ed.Executable = de.dedupe(ed.Executable)
+			return nil
+		})
+		return nil
+	}, nil)
+}
+
+type dedupeExecutable struct {
+	consecutive bool
+}
+
+func (de *dedupeExecutable) dedupe(sl []string) []string {
+	var r []string
+	seen := map[string]bool{}
+	for idx, s := range sl {
+		if de.consecutive {
+			if idx > 0 && sl[idx-1] == s {
+				continue
+			}
+		} else if seen[s] {
+			continue
+		}
+		seen[s] = true
+		r = append(r, s)
+	}
+	return r
+}
@@ -0,0 +1,65 @@
+package commander
+
+import (
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/internal/spycommander"
+)
+
+// ExperimentalOption is an option interface for modifying `Experimental` nodes.
+type ExperimentalOption interface {
+	modifyExperimental(*experimental)
+}
+
+// ExperimentalEnvVar is an `ExperimentalOption` that requires the provided
+// environment variable to be set in order for the experimental node to run.
+// If the environment variable isn't set, execution fails.
+func ExperimentalEnvVar(name string) ExperimentalOption {
+	return &experimentalEnvVar{name}
+}
+
+type experimentalEnvVar struct {
+	name string
+}
+
+func (eev *experimentalEnvVar) modifyExperimental(e *experimental) {
+	e.envVar = eev.name
+}
+
+// Experimental returns a `command.Node` that marks the provided node (and
+// everything after it) as experimental. A warning with the provided message
+// is written to stderr (once) before the inner node is executed. Completion and
+// usage are unaffected.
+func Experimental(message string, inner command.Node, opts ...ExperimentalOption) command.Node {
+	e := &experimental{
+		message: message,
+		n:       inner,
+	}
+	for _, opt := range opts {
+		opt.modifyExperimental(e)
+	}
+	return SerialNodes(e)
+}
+
+type experimental struct {
+	message string
+	n       command.Node
+	envVar  string
+}
+
+func (e *experimental) Execute(i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
+	if e.envVar != "" {
+		if _, ok := command.OSLookupEnv(e.envVar); !ok {
+			return o.Stderrf("[Experimental] %s; set the %q environment variable to proceed\n", e.message, e.envVar)
+		}
+	}
+	o.Stderrf("[Experimental] warning: %s\n", e.message)
+	return spycommander.ProcessGraphExecution(e.n, i, o, d, ed)
+}
+
+func (e *experimental) Complete(i *command.Input, d *command.Data) (*command.Completion, error) {
+	return processOrComplete(e.n, i, d)
+}
+
+func (e *experimental) Usage(i *command.Input, d *command.Data, u *command.Usage) error {
+	return spycommander.ProcessOrUsage(e.n, i, d, u)
+}
@@ -0,0 +1,90 @@
+package commander
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/commandtest"
+	"github.com/leep-frog/command/internal/spycommand"
+	"github.com/leep-frog/command/internal/spycommandtest"
+)
+
+func TestExperimental(t *testing.T) {
+	nameArg := Arg[string]("NAME", testDesc)
+	inner := SerialNodes(
+		nameArg,
+		&ExecutorProcessor{func(o command.Output, d *command.Data) error {
+			o.Stdoutf("hello %s\n", nameArg.Get(d))
+			return nil
+		}},
+	)
+	for _, test := range []struct {
+		name string
+		etc  *commandtest.ExecuteTestCase
+		ietc *spycommandtest.ExecuteTestCase
+	}{
+		{
+			name: "warns and runs inner node",
+			etc: &commandtest.ExecuteTestCase{
+				Node:       Experimental("greetings may change", inner),
+				Args:       []string{"there"},
+				WantStdout: "hello there\n",
+				WantStderr: "[Experimental] warning: greetings may change\n",
+				WantData: &command.Data{Values: map[string]interface{}{
+					"NAME": "there",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "there"},
+					},
+				},
+			},
+		},
+		{
+			name: "fails if env var isn't set",
+			etc: &commandtest.ExecuteTestCase{
+				Node:       Experimental("greetings may change", inner, ExperimentalEnvVar("ENABLE_GREETINGS")),
+				Args:       []string{"there"},
+				WantStderr: "[Experimental] greetings may change; set the \"ENABLE_GREETINGS\" environment variable to proceed\n",
+				WantErr:    fmt.Errorf(`[Experimental] greetings may change; set the "ENABLE_GREETINGS" environment variable to proceed`),
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "there"},
+					},
+					Remaining: []int{0},
+				},
+			},
+		},
+		{
+			name: "warns and runs inner node if env var is set",
+			etc: &commandtest.ExecuteTestCase{
+				Node: Experimental("greetings may change", inner, ExperimentalEnvVar("ENABLE_GREETINGS")),
+				Args: []string{"there"},
+				Env: map[string]string{
+					"ENABLE_GREETINGS": "1",
+				},
+				WantStdout: "hello there\n",
+				WantStderr: "[Experimental] warning: greetings may change\n",
+				WantData: &command.Data{Values: map[string]interface{}{
+					"NAME": "there",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "there"},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			executeTest(t, test.etc, test.ietc)
+		})
+	}
+}
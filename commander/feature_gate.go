@@ -0,0 +1,95 @@
+package commander
+
+import (
+	"strconv"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/internal/spycommander"
+)
+
+// DefaultFeatureFlagEnabled considers a feature flag enabled if the
+// `command.Data` value with the flag's name is `true`. If there is no such
+// `command.Data` value, then the flag is enabled if the environment variable
+// with the flag's name is set to a true value (per `strconv.ParseBool`).
+func DefaultFeatureFlagEnabled(flagName string, d *command.Data) bool {
+	if d.Has(flagName) {
+		b, ok := d.Get(flagName).(bool)
+		return ok && b
+	}
+
+	v, ok := command.OSLookupEnv(flagName)
+	if !ok {
+		return false
+	}
+	b, err := strconv.ParseBool(v)
+	return err == nil && b
+}
+
+// FeatureGateOption is an option interface for modifying `FeatureGate` processors.
+type FeatureGateOption interface {
+	modifyFeatureGate(*featureGate)
+}
+
+// FeatureFlagSource is a `FeatureGateOption` that uses the provided function
+// (e.g. a lookup in a config file or remote service) to determine whether or
+// not the feature flag is enabled (instead of `DefaultFeatureFlagEnabled`).
+func FeatureFlagSource(enabled func(flagName string, d *command.Data) bool) FeatureGateOption {
+	return &featureFlagSource{enabled}
+}
+
+type featureFlagSource struct {
+	enabled func(flagName string, d *command.Data) bool
+}
+
+func (ffs *featureFlagSource) modifyFeatureGate(fg *featureGate) {
+	fg.source = ffs.enabled
+}
+
+// FeatureGate returns a `command.Processor` that only runs the `inner`
+// processor (for execution, completion, and usage) if the provided feature
+// flag is enabled (see `DefaultFeatureFlagEnabled` and `FeatureFlagSource`).
+// Otherwise, the inner processor is silently skipped.
+func FeatureGate(flagName string, inner command.Processor, opts ...FeatureGateOption) command.Processor {
+	fg := &featureGate{
+		flagName: flagName,
+		inner:    inner,
+	}
+	for _, opt := range opts {
+		opt.modifyFeatureGate(fg)
+	}
+	return fg
+}
+
+type featureGate struct {
+	flagName string
+	inner    command.Processor
+	source   func(flagName string, d *command.Data) bool
+}
+
+func (fg *featureGate) enabled(d *command.Data) bool {
+	if fg.source != nil {
+		return fg.source(fg.flagName, d)
+	}
+	return DefaultFeatureFlagEnabled(fg.flagName, d)
+}
+
+func (fg *featureGate) Execute(i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
+	if !fg.enabled(d) {
+		return nil
+	}
+	return spycommander.ProcessOrExecute(fg.inner, i, o, d, ed)
+}
+
+func (fg *featureGate) Complete(i *command.Input, d *command.Data) (*command.Completion, error) {
+	if !fg.enabled(d) {
+		return nil, nil
+	}
+	return processOrComplete(fg.inner, i, d)
+}
+
+func (fg *featureGate) Usage(i *command.Input, d *command.Data, u *command.Usage) error {
+	if !fg.enabled(d) {
+		return nil
+	}
+	return spycommander.ProcessOrUsage(fg.inner, i, d, u)
+}
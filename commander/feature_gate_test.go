@@ -0,0 +1,206 @@
+package commander
+
+import (
+	"testing"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/commandtest"
+	"github.com/leep-frog/command/internal/spycommand"
+	"github.com/leep-frog/command/internal/spycommandtest"
+	"github.com/leep-frog/command/internal/testutil"
+)
+
+func TestFeatureGate(t *testing.T) {
+	nameArg := Arg[string]("NAME", testDesc)
+	greet := &ExecutorProcessor{func(o command.Output, d *command.Data) error {
+		o.Stdoutf("hello %s\n", nameArg.Get(d))
+		return nil
+	}}
+	for _, test := range []struct {
+		name string
+		env  map[string]string
+		// flagValue, if not nil, is set as the feature flag's `command.Data` value.
+		flagValue  interface{}
+		source     func(string, *command.Data) bool
+		wantStdout string
+		wantFlags  []string
+	}{
+		{
+			name: "skips inner processor if flag isn't set",
+		},
+		{
+			name: "skips inner processor if env var is false",
+			env: map[string]string{
+				"NEW_GREETING": "false",
+			},
+		},
+		{
+			name: "skips inner processor if env var isn't a bool",
+			env: map[string]string{
+				"NEW_GREETING": "yes please",
+			},
+		},
+		{
+			name: "runs inner processor if env var is true",
+			env: map[string]string{
+				"NEW_GREETING": "1",
+			},
+			wantStdout: "hello there\n",
+		},
+		{
+			name:       "runs inner processor if data value is true",
+			flagValue:  true,
+			wantStdout: "hello there\n",
+		},
+		{
+			name:      "data value takes precedence over env var",
+			flagValue: false,
+			env: map[string]string{
+				"NEW_GREETING": "true",
+			},
+		},
+		{
+			name:      "skips inner processor if data value isn't a bool",
+			flagValue: "true",
+		},
+		{
+			name:       "runs inner processor if provided flag source is enabled",
+			source:     func(flagName string, d *command.Data) bool { return true },
+			wantStdout: "hello there\n",
+			wantFlags:  []string{"NEW_GREETING"},
+		},
+		{
+			name:   "skips inner processor if provided flag source is disabled",
+			source: func(flagName string, d *command.Data) bool { return false },
+			env: map[string]string{
+				"NEW_GREETING": "true",
+			},
+			wantFlags: []string{"NEW_GREETING"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var gotFlags []string
+			var opts []FeatureGateOption
+			if test.source != nil {
+				opts = append(opts, FeatureFlagSource(func(flagName string, d *command.Data) bool {
+					gotFlags = append(gotFlags, flagName)
+					return test.source(flagName, d)
+				}))
+			}
+
+			wantData := map[string]interface{}{
+				"NAME": "there",
+			}
+			nodes := []command.Processor{nameArg, FeatureGate("NEW_GREETING", greet, opts...)}
+			if test.flagValue != nil {
+				wantData["NEW_GREETING"] = test.flagValue
+				nodes = append([]command.Processor{SuperSimpleProcessor(func(i *command.Input, d *command.Data) error {
+					d.Set("NEW_GREETING", test.flagValue)
+					return nil
+				})}, nodes...)
+			}
+
+			executeTest(t, &commandtest.ExecuteTestCase{
+				Node:       SerialNodes(nodes...),
+				Args:       []string{"there"},
+				Env:        test.env,
+				WantStdout: test.wantStdout,
+				WantData:   &command.Data{Values: wantData},
+			}, &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "there"},
+					},
+				},
+			})
+			testutil.Cmp(t, "FeatureGate checked incorrect flags", test.wantFlags, gotFlags)
+		})
+	}
+}
+
+func TestFeatureGateNode(t *testing.T) {
+	firstArg := Arg[string]("FIRST", testDesc)
+	secondArg := Arg[string]("SECOND", testDesc)
+	executeTest(t, &commandtest.ExecuteTestCase{
+		Node: SerialNodes(FeatureGate("NEW_GREETING", SerialNodes(firstArg, secondArg, &ExecutorProcessor{func(o command.Output, d *command.Data) error {
+			o.Stdoutf("hello %s and %s\n", firstArg.Get(d), secondArg.Get(d))
+			return nil
+		}}))),
+		Args: []string{"alpha", "beta"},
+		Env: map[string]string{
+			"NEW_GREETING": "true",
+		},
+		WantStdout: "hello alpha and beta\n",
+		WantData: &command.Data{Values: map[string]interface{}{
+			"FIRST":  "alpha",
+			"SECOND": "beta",
+		}},
+	}, &spycommandtest.ExecuteTestCase{
+		WantInput: &spycommandtest.SpyInput{
+			Args: []*spycommand.InputArg{
+				{Value: "alpha"},
+				{Value: "beta"},
+			},
+		},
+	})
+}
+
+func TestFeatureGateCompletion(t *testing.T) {
+	nameArg := Arg[string]("NAME", testDesc, SimpleCompleter[string]("alpha", "beta"))
+	otherArg := Arg[string]("OTHER", testDesc, SimpleCompleter[string]("gamma"))
+	for _, test := range []struct {
+		name string
+		ctc  *commandtest.CompleteTestCase
+	}{
+		{
+			name: "skips completion if flag is disabled",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(FeatureGate("NEW_GREETING", nameArg), otherArg),
+				Args: "cmd ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"gamma"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"OTHER": "",
+				}},
+			},
+		},
+		{
+			name: "completes inner processor if flag is enabled",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(FeatureGate("NEW_GREETING", nameArg), otherArg),
+				Args: "cmd ",
+				Env: map[string]string{
+					"NEW_GREETING": "true",
+				},
+				Want: &command.Autocompletion{
+					Suggestions: []string{"alpha", "beta"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"NAME": "",
+				}},
+			},
+		},
+		{
+			name: "completes all of inner node if flag is enabled",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(FeatureGate("NEW_GREETING", SerialNodes(nameArg, otherArg))),
+				Args: "cmd alpha ",
+				Env: map[string]string{
+					"NEW_GREETING": "true",
+				},
+				Want: &command.Autocompletion{
+					Suggestions: []string{"gamma"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"NAME":  "alpha",
+					"OTHER": "",
+				}},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			autocompleteTest(t, test.ctc, nil)
+		})
+	}
+}
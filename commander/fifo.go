@@ -0,0 +1,62 @@
+package commander
+
+import (
+	"bufio"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/internal/stubs"
+)
+
+// FifoCompleter returns a `Completer` that reads newline-separated suggestions
+// from the named pipe (FIFO) at the provided path. The FIFO is only read at
+// completion time. Opening a FIFO (and reading from it) blocks until a writer
+// is present, so at most a short timeout is spent waiting; any suggestions
+// read before the timeout are returned (and none are returned if nothing
+// could be read).
+func FifoCompleter(path string) Completer[string] {
+	return CompleterFromFunc(func(string, *command.Data) (*command.Completion, error) {
+		return &command.Completion{
+			Suggestions: readFifo(path),
+		}, nil
+	})
+}
+
+func readFifo(path string) []string {
+	var mu sync.Mutex
+	var lines []string
+	done := make(chan struct{})
+	open := stubs.FifoOpen
+
+	// Note: if the timeout is reached, this goroutine is left blocked on the
+	// FIFO. That is fine, since completion is run in a short-lived process.
+	go func() {
+		defer close(done)
+		rc, err := open(path)
+		if err != nil {
+			return
+		}
+		defer rc.Close()
+
+		scanner := bufio.NewScanner(rc)
+		for scanner.Scan() {
+			if line := strings.TrimSpace(scanner.Text()); line != "" {
+				mu.Lock()
+				lines = append(lines, line)
+				mu.Unlock()
+			}
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(stubs.FifoTimeout):
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	return slices.Clone(lines)
+}
@@ -0,0 +1,112 @@
+package commander
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/commandtest"
+	"github.com/leep-frog/command/internal/stubs"
+	"github.com/leep-frog/command/internal/testutil"
+)
+
+func TestFifoCompleter(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		// open returns the reader for the FIFO. The provided channel is closed
+		// when the test completes (so blocked readers can be released).
+		open      func(path string, release chan struct{}) (io.ReadCloser, error)
+		args      string
+		want      *command.Autocompletion
+		wantPaths []string
+	}{
+		{
+			name: "suggests lines read from the FIFO",
+			open: func(string, chan struct{}) (io.ReadCloser, error) {
+				return io.NopCloser(strings.NewReader("alpha\n\nbeta\n  gamma  \n")), nil
+			},
+			args: "cmd ",
+			want: &command.Autocompletion{
+				Suggestions: []string{"alpha", "beta", "gamma"},
+			},
+			wantPaths: []string{"suggestions.fifo"},
+		},
+		{
+			name: "filters suggestions read from the FIFO",
+			open: func(string, chan struct{}) (io.ReadCloser, error) {
+				return io.NopCloser(strings.NewReader("alpha\nbeta\nbravo")), nil
+			},
+			args: "cmd b",
+			want: &command.Autocompletion{
+				Suggestions: []string{"beta", "bravo"},
+			},
+			wantPaths: []string{"suggestions.fifo"},
+		},
+		{
+			name: "suggests nothing if the FIFO can't be opened",
+			open: func(string, chan struct{}) (io.ReadCloser, error) {
+				return nil, fmt.Errorf("no such file")
+			},
+			args:      "cmd ",
+			wantPaths: []string{"suggestions.fifo"},
+		},
+		{
+			name: "suggests nothing if opening the FIFO times out",
+			open: func(path string, release chan struct{}) (io.ReadCloser, error) {
+				<-release
+				return nil, fmt.Errorf("released")
+			},
+			args:      "cmd ",
+			wantPaths: []string{"suggestions.fifo"},
+		},
+		{
+			name: "suggests lines read before the timeout",
+			open: func(path string, release chan struct{}) (io.ReadCloser, error) {
+				r, w := io.Pipe()
+				go func() {
+					w.Write([]byte("alpha\nbeta\n"))
+					<-release
+					w.Close()
+				}()
+				return r, nil
+			},
+			args: "cmd ",
+			want: &command.Autocompletion{
+				Suggestions: []string{"alpha", "beta"},
+			},
+			wantPaths: []string{"suggestions.fifo"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			open := test.open
+			release := make(chan struct{})
+			t.Cleanup(func() { close(release) })
+
+			// The FIFO is opened in a separate goroutine.
+			var mu sync.Mutex
+			var gotPaths []string
+			stubs.StubFifo(t, func(path string) (io.ReadCloser, error) {
+				mu.Lock()
+				gotPaths = append(gotPaths, path)
+				mu.Unlock()
+				return open(path, release)
+			}, 50*time.Millisecond)
+
+			autocompleteTest(t, &commandtest.CompleteTestCase{
+				Node: SerialNodes(Arg[string]("S", testDesc, FifoCompleter("suggestions.fifo"))),
+				Args: test.args,
+				Want: test.want,
+				WantData: &command.Data{Values: map[string]interface{}{
+					"S": strings.TrimPrefix(test.args, "cmd "),
+				}},
+			}, nil)
+			mu.Lock()
+			defer mu.Unlock()
+			testutil.Cmp(t, "FifoCompleter opened incorrect paths", test.wantPaths, gotPaths)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package commander
 
 import (
+	"fmt"
 	"io/fs"
 	"io/ioutil"
 	"os"
@@ -54,20 +55,148 @@ func Stat(name string) (os.FileInfo, error) {
 	return fi, nil
 }
 
-// FileContents converts a filename into the file's contents.
-func FileContents(name, desc string, opts ...ArgumentOption[string]) command.Processor {
-	fc := FileArgument(name, desc, opts...)
-	return SimpleProcessor(func(i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
-		if err := spycommander.ProcessOrExecute(fc, i, o, d, ed); err != nil {
-			return err
-		}
-		b, err := os.ReadFile(d.String(name))
-		if err != nil {
-			return o.Annotatef(err, "failed to read fileee")
+func splitOnDelimiter(contents, sep string) []string {
+	r := strings.Split(contents, sep)
+	if r[len(r)-1] == "" {
+		r = r[:len(r)-1]
+	}
+	return r
+}
+
+// CreateIfMissing is an `ArgumentOption` for `FileArgument` that creates an
+// empty file (and any missing parent directories) if the file doesn't already
+// exist. Unlike the default `FileExists` validation, this is useful for
+// output-file arguments. Execution fails if the file can't be opened for
+// writing. Note that the file is only created during execution (not completion
+// or usage).
+func CreateIfMissing() ArgumentOption[string] {
+	return &createIfMissing{}
+}
+
+type createIfMissing struct{}
+
+func (cim *createIfMissing) modifyArgumentOption(ao *argumentOption[string]) {
+	ao.executors = append(ao.executors, func(s string, d *command.Data) error {
+		mode := os.FileMode(0644)
+		if ao.fileMode != nil {
+			mode = *ao.fileMode
 		}
-		d.Set(name, strings.Split(strings.TrimSpace(string(b)), "\n"))
-		return nil
-	}, func(i *command.Input, d *command.Data) (*command.Completion, error) {
-		return processOrComplete(fc, i, d)
+		return createIfMissingFile(s, mode, ao.fileMode != nil)
 	})
 }
+
+func createIfMissingFile(s string, mode os.FileMode, setMode bool) error {
+	if err := os.MkdirAll(filepath.Dir(s), 0755); err != nil {
+		return fmt.Errorf("[CreateIfMissing] failed to create parent directories for %q: %v", s, err)
+	}
+
+	_, statErr := os.Stat(s)
+	created := os.IsNotExist(statErr)
+
+	// Opening in write-only mode (without truncation) verifies the file is
+	// writable without modifying existing files.
+	f, err := os.OpenFile(s, os.O_WRONLY|os.O_CREATE, mode)
+	if err != nil {
+		return fmt.Errorf("[CreateIfMissing] file %q isn't writable: %v", s, err)
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	// The permissions passed to OpenFile are masked by the process's umask,
+	// so explicitly set the requested mode on newly created files.
+	if created && setMode {
+		if err := os.Chmod(s, mode); err != nil {
+			return fmt.Errorf("[WithFileMode] failed to set file mode for %q: %v", s, err)
+		}
+	}
+	return nil
+}
+
+// WithFileMode is an `ArgumentOption` for `FileArgument` that sets the
+// permission bits of files created by the `CreateIfMissing` option (instead of
+// the default 0644). The mode is applied exactly (regardless of the process's
+// umask) and existing files are left unchanged. This panics if the mode
+// contains anything other than permission bits (i.e. it isn't within 0-0777).
+func WithFileMode(mode os.FileMode) ArgumentOption[string] {
+	if mode&^os.ModePerm != 0 {
+		panic(fmt.Sprintf("[WithFileMode] invalid file mode %#o: only permission bits (0-0777) are allowed", uint32(mode)))
+	}
+	return &withFileMode{mode}
+}
+
+type withFileMode struct {
+	mode os.FileMode
+}
+
+func (wfm *withFileMode) modifyArgumentOption(ao *argumentOption[string]) {
+	ao.fileMode = &wfm.mode
+}
+
+// FileContents converts a filename into the file's contents.
+// By default, the contents are trimmed and split on newlines
+// (see the `Delimiter` and `NormalizeLineEndings` methods for alternative behavior).
+func FileContents(name, desc string, opts ...ArgumentOption[string]) *fileContents {
+	return &fileContents{
+		name: name,
+		fa:   FileArgument(name, desc, opts...),
+	}
+}
+
+type fileContents struct {
+	name string
+	fa   *Argument[string]
+	// delimiter is the separator that the contents are split on. If nil, the
+	// trimmed contents are split on newlines.
+	delimiter            *string
+	normalizeLineEndings bool
+}
+
+// Delimiter splits the file's contents on the provided separator (rather than
+// on newlines). When provided, the file's contents are not trimmed and a single
+// trailing empty segment (i.e. from a file that ends with the separator) is
+// dropped. Although chaining isn't conventional in go, it is done here so the
+// option can only be applied to `FileContents`.
+func (fc *fileContents) Delimiter(sep string) *fileContents {
+	fc.delimiter = &sep
+	return fc
+}
+
+// NormalizeLineEndings strips a trailing carriage return (`\r`) from each line,
+// so files with Windows-style (CRLF) line endings produce the same values as
+// files with LF line endings.
+func (fc *fileContents) NormalizeLineEndings() *fileContents {
+	fc.normalizeLineEndings = true
+	return fc
+}
+
+func (fc *fileContents) Execute(i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
+	if err := spycommander.ProcessOrExecute(fc.fa, i, o, d, ed); err != nil {
+		return err
+	}
+	b, err := os.ReadFile(d.String(fc.name))
+	if err != nil {
+		return o.Annotatef(err, "failed to read fileee")
+	}
+	var lines []string
+	if fc.delimiter != nil {
+		lines = splitOnDelimiter(string(b), *fc.delimiter)
+	} else {
+		lines = strings.Split(strings.TrimSpace(string(b)), "\n")
+	}
+	if fc.normalizeLineEndings {
+		for i, line := range lines {
+			lines[i] = strings.TrimSuffix(line, "\r")
+		}
+	}
+	d.Set(fc.name, lines)
+	return nil
+}
+
+func (fc *fileContents) Complete(i *command.Input, d *command.Data) (*command.Completion, error) {
+	return processOrComplete(fc.fa, i, d)
+}
+
+func (fc *fileContents) Usage(i *command.Input, d *command.Data, u *command.Usage) error {
+	return nil
+}
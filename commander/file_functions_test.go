@@ -0,0 +1,343 @@
+package commander
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/commandtest"
+	"github.com/leep-frog/command/internal/spycommand"
+	"github.com/leep-frog/command/internal/spycommandtest"
+	"github.com/leep-frog/command/internal/testutil"
+)
+
+func TestFileContentsDelimiter(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		contents string
+		fc       func(*fileContents) *fileContents
+		want     []string
+	}{
+		{
+			name:     "splits on newlines by default",
+			contents: "one\ntwo\nthree\n",
+			want:     []string{"one", "two", "three"},
+		},
+		{
+			name:     "trims contents by default",
+			contents: "  one\ntwo \n\n",
+			want:     []string{"one", "two"},
+		},
+		{
+			name:     "splits on explicit newline delimiter",
+			contents: "one\ntwo\n\nthree\n",
+			fc: func(fc *fileContents) *fileContents {
+				return fc.Delimiter("\n")
+			},
+			want: []string{"one", "two", "", "three"},
+		},
+		{
+			name:     "splits on null byte",
+			contents: "one\x00two words\x00 three\n\x00",
+			fc: func(fc *fileContents) *fileContents {
+				return fc.Delimiter("\x00")
+			},
+			want: []string{"one", "two words", " three\n"},
+		},
+		{
+			name:     "splits on null byte without trailing delimiter",
+			contents: "one\x00two",
+			fc: func(fc *fileContents) *fileContents {
+				return fc.Delimiter("\x00")
+			},
+			want: []string{"one", "two"},
+		},
+		{
+			name:     "only drops one trailing empty segment",
+			contents: "one\x00\x00",
+			fc: func(fc *fileContents) *fileContents {
+				return fc.Delimiter("\x00")
+			},
+			want: []string{"one", ""},
+		},
+		{
+			name:     "splits on custom string delimiter",
+			contents: "a, b,c, d, ",
+			fc: func(fc *fileContents) *fileContents {
+				return fc.Delimiter(", ")
+			},
+			want: []string{"a", "b,c", "d"},
+		},
+		{
+			name:     "keeps carriage returns by default",
+			contents: "one\r\ntwo\r\nthree\r\n",
+			want:     []string{"one\r", "two\r", "three"},
+		},
+		{
+			name:     "normalizes CRLF line endings",
+			contents: "one\r\ntwo\r\nthree\r\n",
+			fc: func(fc *fileContents) *fileContents {
+				return fc.NormalizeLineEndings()
+			},
+			want: []string{"one", "two", "three"},
+		},
+		{
+			name:     "normalizes mixed line endings",
+			contents: "one\r\ntwo\nthree\r\n",
+			fc: func(fc *fileContents) *fileContents {
+				return fc.NormalizeLineEndings()
+			},
+			want: []string{"one", "two", "three"},
+		},
+		{
+			name:     "only strips trailing carriage returns",
+			contents: "a\rb\r\nc\r\r\nd",
+			fc: func(fc *fileContents) *fileContents {
+				return fc.NormalizeLineEndings()
+			},
+			want: []string{"a\rb", "c\r", "d"},
+		},
+		{
+			name:     "normalizes line endings with explicit newline delimiter",
+			contents: "one\r\n\r\ntwo\r\n",
+			fc: func(fc *fileContents) *fileContents {
+				return fc.Delimiter("\n").NormalizeLineEndings()
+			},
+			want: []string{"one", "", "two"},
+		},
+		{
+			name: "empty file with delimiter",
+			fc: func(fc *fileContents) *fileContents {
+				return fc.Delimiter(",")
+			},
+			want: []string{},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			f := filepath.Join(t.TempDir(), "contents.txt")
+			if err := os.WriteFile(f, []byte(test.contents), 0644); err != nil {
+				t.Fatalf("failed to write test file: %v", err)
+			}
+
+			fc := FileContents("FILE", testDesc)
+			if test.fc != nil {
+				fc = test.fc(fc)
+			}
+
+			executeTest(t, &commandtest.ExecuteTestCase{
+				Node: SerialNodes(fc),
+				Args: []string{f},
+				WantData: &command.Data{
+					Values: map[string]interface{}{
+						"FILE": test.want,
+					},
+				},
+			}, &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: f},
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestCreateIfMissing(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		// existing is the contents of the file to create before running
+		// the test (if nil, the file isn't created).
+		existing []string
+		// path is the path of the file argument relative to the temp directory.
+		path         string
+		wantContents string
+		wantErr      string
+	}{
+		{
+			name:         "leaves existing file unchanged",
+			existing:     []string{"hello", "there"},
+			path:         "out.txt",
+			wantContents: "hello\nthere",
+		},
+		{
+			name: "creates new file",
+			path: "out.txt",
+		},
+		{
+			name: "creates parent directories",
+			path: filepath.Join("some", "nested", "dir", "out.txt"),
+		},
+		{
+			name:    "fails if path is a directory",
+			path:    "some",
+			wantErr: `[CreateIfMissing] file "%s" isn't writable: open %s: is a directory`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			f := filepath.Join(dir, test.path)
+			if test.existing != nil {
+				if err := CreateFile(f, test.existing, 0644); err != nil {
+					t.Fatalf("failed to write test file: %v", err)
+				}
+			}
+			if test.wantErr != "" {
+				if err := os.MkdirAll(f, 0755); err != nil {
+					t.Fatalf("failed to create test directory: %v", err)
+				}
+			}
+
+			etc := &commandtest.ExecuteTestCase{
+				Node: SerialNodes(FileArgument("FILE", testDesc, CreateIfMissing())),
+				Args: []string{f},
+				WantData: &command.Data{
+					Values: map[string]interface{}{
+						"FILE": f,
+					},
+				},
+			}
+			ietc := &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: f},
+					},
+				},
+			}
+			if test.wantErr != "" {
+				wantErr := fmt.Sprintf(test.wantErr, f, f)
+				etc.WantErr = fmt.Errorf(wantErr)
+				etc.WantStderr = wantErr + "\n"
+			}
+			executeTest(t, etc, ietc)
+
+			if test.wantErr != "" {
+				return
+			}
+			b, err := os.ReadFile(f)
+			if err != nil {
+				t.Fatalf("failed to read created file: %v", err)
+			}
+			testutil.Cmp(t, "CreateIfMissing resulted in incorrect file contents", test.wantContents, string(b))
+		})
+	}
+}
+
+func TestCreateIfMissingUsage(t *testing.T) {
+	f := filepath.Join(t.TempDir(), "some", "out.txt")
+	executeTest(t, &commandtest.ExecuteTestCase{
+		Node: SerialNodes(
+			FileArgument("FILE", testDesc, CreateIfMissing()),
+			Arg[string]("NAME", testDesc),
+		),
+		Args: []string{f, "--help"},
+		WantStdout: strings.Join([]string{
+			"NAME",
+			"",
+			"Arguments:",
+			"  NAME: test desc",
+			"",
+		}, "\n"),
+	}, &spycommandtest.ExecuteTestCase{
+		WantInput: &spycommandtest.SpyInput{
+			Args: []*spycommand.InputArg{
+				{Value: f},
+			},
+		},
+	})
+
+	if _, err := os.Stat(filepath.Dir(f)); !os.IsNotExist(err) {
+		t.Errorf("CreateIfMissing created files during usage (stat error: %v)", err)
+	}
+}
+
+func TestWithFileMode(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		mode os.FileMode
+		// existingMode, if set, is the mode of a file to create before running the test.
+		existingMode os.FileMode
+		path         string
+		wantMode     os.FileMode
+	}{
+		{
+			name:     "creates file with requested mode",
+			mode:     0600,
+			path:     "out.txt",
+			wantMode: 0600,
+		},
+		{
+			name:     "creates file with mode that isn't affected by umask",
+			mode:     0777,
+			path:     filepath.Join("some", "nested", "out.sh"),
+			wantMode: 0777,
+		},
+		{
+			name:         "leaves mode of existing file unchanged",
+			mode:         0600,
+			existingMode: 0640,
+			path:         "out.txt",
+			wantMode:     0640,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			f := filepath.Join(t.TempDir(), test.path)
+			if test.existingMode != 0 {
+				if err := CreateFile(f, []string{"hello"}, test.existingMode); err != nil {
+					t.Fatalf("failed to write test file: %v", err)
+				}
+				if err := os.Chmod(f, test.existingMode); err != nil {
+					t.Fatalf("failed to set test file mode: %v", err)
+				}
+			}
+
+			executeTest(t, &commandtest.ExecuteTestCase{
+				Node: SerialNodes(FileArgument("FILE", testDesc, CreateIfMissing(), WithFileMode(test.mode))),
+				Args: []string{f},
+				WantData: &command.Data{
+					Values: map[string]interface{}{
+						"FILE": f,
+					},
+				},
+			}, &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: f},
+					},
+				},
+			})
+
+			fi, err := os.Stat(f)
+			if err != nil {
+				t.Fatalf("failed to stat created file: %v", err)
+			}
+			testutil.Cmp(t, "WithFileMode resulted in incorrect file mode", test.wantMode, fi.Mode().Perm())
+		})
+	}
+}
+
+func TestWithFileModePanics(t *testing.T) {
+	for _, test := range []struct {
+		name      string
+		mode      os.FileMode
+		wantPanic string
+	}{
+		{
+			name:      "panics on sticky bit",
+			mode:      01644,
+			wantPanic: "[WithFileMode] invalid file mode 01644: only permission bits (0-0777) are allowed",
+		},
+		{
+			name:      "panics on non-permission mode bits",
+			mode:      os.ModeDir | 0755,
+			wantPanic: fmt.Sprintf("[WithFileMode] invalid file mode %#o: only permission bits (0-0777) are allowed", uint32(os.ModeDir|0755)),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			testutil.CmpPanic(t, "WithFileMode()", func() ArgumentOption[string] { return WithFileMode(test.mode) }, test.wantPanic)
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/leep-frog/command/command"
 	"github.com/leep-frog/command/internal/constants"
@@ -25,6 +26,10 @@ var (
 	// It explicitly doesn't allow short number flags.
 	MultiFlagRegex = regexp.MustCompile("^-[a-zA-Z]{2,}$")
 	ShortFlagRegex = regexp.MustCompile("^[a-zA-Z0-9]$")
+
+	// partialMultiFlagRegex is the regex used to determine a (potentially
+	// partial) multi-flag when completing (e.g. `-q` or `-qw`).
+	partialMultiFlagRegex = regexp.MustCompile("^-[a-zA-Z]+$")
 )
 
 // FlagInterface defines a flag argument that is parsed regardless of it's position in
@@ -60,6 +65,8 @@ type FlagOptions struct {
 	ProcessMissing func(*command.Data) error
 	// PostProcess runs after the entire flag processor has been processed.
 	PostProcess func(*command.Input, command.Output, *command.Data, *command.ExecuteData) error
+	// Required indicates whether or not the flag must be provided.
+	Required bool
 }
 
 func (fo *FlagOptions) combinable() bool {
@@ -77,6 +84,10 @@ func (fo *FlagOptions) processMissing(d *command.Data) error {
 	return fo.ProcessMissing(d)
 }
 
+func (fo *FlagOptions) required() bool {
+	return fo != nil && fo.Required
+}
+
 func (fo *FlagOptions) postProcess(i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
 	if fo == nil || fo.PostProcess == nil {
 		return nil
@@ -108,6 +119,24 @@ func flagShortName(f FlagInterface) string {
 	return fmt.Sprintf("-%c", f.ShortName())
 }
 
+// FlagCategory assigns the provided flag to a named category. Categorized flags
+// are displayed under their category's header in usage text (uncategorized
+// flags are displayed under the default "Flags" header).
+func FlagCategory(category string, f FlagInterface) FlagInterface {
+	return &categorizedFlag{f, category}
+}
+
+type categorizedFlag struct {
+	FlagInterface
+	category string
+}
+
+func (cf *categorizedFlag) FlagUsage(d *command.Data, u *command.Usage) error {
+	return u.FlagCategory(cf.category, func() error {
+		return cf.FlagInterface.FlagUsage(d, u)
+	})
+}
+
 // FlagProcessor returns a `command.Processor` that iterates over the remaining command line
 // arguments and processes any flags that are present.
 func FlagProcessor(fs ...FlagInterface) *flagProcessor {
@@ -137,6 +166,18 @@ type flagProcessor struct {
 	flagMap map[string]FlagInterface
 	// flagOrder is the order in which the flags were provided
 	flagOrder []FlagInterface
+	// warnUnread is whether or not to warn about provided flags whose values
+	// are never read.
+	warnUnread bool
+}
+
+// WarnUnread configures the flag processor to write a warning to stderr (once
+// execution has completed) for each provided flag whose value was never read
+// (e.g. via `Get`). This is intended as a developer aid, since a flag that is
+// set but never read often indicates a bug.
+func (fn *flagProcessor) WarnUnread() *flagProcessor {
+	fn.warnUnread = true
+	return fn
 }
 
 // ListBreaker returns a `ListBreaker` that breaks a list at any
@@ -188,16 +229,47 @@ func (fn *flagProcessor) Complete(input *command.Input, data *command.Data) (*co
 		unprocessed[f.Name()] = f
 		available[f.Name()] = true
 	}
+
+	// Process flags that aren't provided at all before anything is completed
+	// so that completers for provided flags (and subsequent processors) have
+	// access to the default values of those flags.
+	present := fn.presentFlags(input)
+	for name, f := range unprocessed {
+		if present[name] {
+			continue
+		}
+		if err := f.Options().processMissing(data); err != nil {
+			return nil, err
+		}
+		delete(unprocessed, name)
+	}
+
 	for i := 0; i < input.NumRemaining(); {
 		a, _ := input.PeekAt(i)
 
 		// If it's the last arg.
 		if i == input.NumRemaining()-1 && len(a) > 0 && a[0] == '-' {
+			// If a partial multi-flag, then suggest the remaining combinable flags.
+			if c := fn.multiFlagCompletion(a, available); c != nil {
+				return c, nil
+			}
+
 			k := make([]string, 0, len(fn.flagMap))
 			for n := range available {
 				k = append(k, fmt.Sprintf("--%s", n))
 			}
+
+			// If args were skipped, then downstream processors (e.g. a branch's
+			// own flag processor) may also accept flags here, so let them complete
+			// the flag name (with these flags included).
+			if i > 0 {
+				spycommander.AddInheritedFlagSuggestions(data, k...)
+				break
+			}
+
+			k = append(k, spycommander.PopInheritedFlagSuggestions(data)...)
 			sort.Strings(k)
+			k = slices.Compact(k)
 			return &command.Completion{
 				Suggestions: k,
 			}, nil
@@ -272,6 +344,63 @@ func (fn *flagProcessor) Complete(input *command.Input, data *command.Data) (*co
 	return nil, nil
 }
 
+// presentFlags returns the set of flag names that are referenced (either
+// directly or as part of a multi-flag) in the remaining input.
+func (fn *flagProcessor) presentFlags(input *command.Input) map[string]bool {
+	present := map[string]bool{}
+	for _, a := range input.Remaining() {
+		if a == FlagStop {
+			break
+		}
+
+		if f, ok := fn.flagMap[a]; ok {
+			present[f.Name()] = true
+		} else if MultiFlagRegex.MatchString(a) {
+			for j := 1; j < len(a); j++ {
+				if f, ok := fn.flagMap[fmt.Sprintf("-%s", string(a[j]))]; ok && f.Options().combinable() {
+					present[f.Name()] = true
+				}
+			}
+		}
+	}
+	return present
+}
+
+// multiFlagCompletion returns a `command.Completion` that suggests appending
+// combinable short flags to the provided (partial) multi-flag argument. It
+// returns nil if the argument contains any short flags that aren't combinable
+// flags in this flag processor.
+func (fn *flagProcessor) multiFlagCompletion(a string, available map[string]bool) *command.Completion {
+	if !partialMultiFlagRegex.MatchString(a) {
+		return nil
+	}
+
+	included := map[string]bool{}
+	for j := 1; j < len(a); j++ {
+		f, ok := fn.flagMap[fmt.Sprintf("-%s", string(a[j]))]
+		if !ok || !f.Options().combinable() {
+			return nil
+		}
+		included[f.Name()] = true
+	}
+
+	suggestions := []string{a}
+	for _, f := range fn.flagOrder {
+		if f.ShortName() == FlagNoShortName || !f.Options().combinable() || !available[f.Name()] {
+			continue
+		}
+		if included[f.Name()] && !f.Options().allowsMultiple() {
+			continue
+		}
+		if next := a + string(f.ShortName()); partialMultiFlagRegex.MatchString(next) {
+			suggestions = append(suggestions, next)
+		}
+	}
+	return &command.Completion{
+		Suggestions: suggestions,
+	}
+}
+
 func (fn *flagProcessor) Execute(input *command.Input, output command.Output, data *command.Data, eData *command.ExecuteData) error {
 	return fn.executeOrUsage(input, output, data, eData, nil)
 }
@@ -371,11 +500,23 @@ func (fn *flagProcessor) executeOrUsage(input *command.Input, output command.Out
 		}
 	}
 
+	// Flags files are only loaded on execution.
+	if u == nil {
+		if err := fn.processFlagsFiles(output, data, eData, processed, unprocessed); err != nil {
+			return err
+		}
+	}
+
 	for _, f := range fn.flagOrder {
 		if !unprocessed[f.Name()] {
 			continue
 		}
 
+		// Required flags are only enforced on execution.
+		if u == nil && f.Options().required() {
+			return output.Err(MissingRequiredFlag(f.Name()))
+		}
+
 		if err := f.Options().processMissing(data); err != nil {
 			return output.Annotatef(err, "failed to get default")
 		}
@@ -384,6 +525,10 @@ func (fn *flagProcessor) executeOrUsage(input *command.Input, output command.Out
 		f.Options().postProcess(input, output, data, eData)
 	}
 
+	if u == nil && fn.warnUnread {
+		fn.trackReads(output, data, eData, processed)
+	}
+
 	if u != nil {
 		for _, f := range fn.flagOrder {
 			if !unprocessed[f.Name()] && !needsUsage[f.Name()] {
@@ -398,6 +543,27 @@ func (fn *flagProcessor) executeOrUsage(input *command.Input, output command.Out
 	return nil
 }
 
+// trackReads tracks reads for all of the provided flags and adds a cleanup
+// function that warns about any of those flags whose values weren't read.
+func (fn *flagProcessor) trackReads(output command.Output, data *command.Data, eData *command.ExecuteData, processed map[string]bool) {
+	var names []string
+	for _, f := range fn.flagOrder {
+		if processed[f.Name()] && data.Has(f.Name()) {
+			names = append(names, f.Name())
+		}
+	}
+	data.TrackReads(names...)
+
+	eData.Cleanup = append(eData.Cleanup, func() error {
+		for _, name := range names {
+			if !data.WasRead(name) {
+				output.Stderrf("[WarnUnread] flag %q was provided, but its value was never read\n", name)
+			}
+		}
+		return nil
+	})
+}
+
 func (fn *flagProcessor) Usage(i *command.Input, d *command.Data, u *command.Usage) error {
 	return fn.executeOrUsage(i, command.NewIgnoreAllOutput(), d, &command.ExecuteData{}, u)
 }
@@ -429,11 +595,18 @@ func (f *flag[T]) FlagUsage(d *command.Data, u *command.Usage) error {
 func (f *flag[T]) Options() *FlagOptions {
 	return &FlagOptions{
 		ProcessMissing: func(d *command.Data) error {
-			if dflt, ok := f.argument.getDefault(); ok {
-				f.argument.Set(dflt, d)
-			}
+			f.argument.setDefault(d)
 			return nil
 		},
+		Required:       f.argument.opt != nil && f.argument.opt.required,
+		AllowsMultiple: f.argument.opt != nil && f.argument.opt.lastWins,
+	}
+}
+
+// checkRequired panics if the flag is both required and has a default value.
+func (f *flag[T]) checkRequired() {
+	if opt := f.argument.opt; opt != nil && opt.required && opt._default != nil {
+		panic(fmt.Sprintf("Flag %q cannot be required and have a default value", f.name))
 	}
 }
 
@@ -464,6 +637,7 @@ func (f *flag[T]) AddOptions(opts ...ArgumentOption[T]) FlagWithType[T] {
 	for _, o := range opts {
 		o.modifyArgumentOption(f.argument.opt)
 	}
+	f.checkRequired()
 	return f
 }
 
@@ -472,6 +646,12 @@ func Flag[T any](name string, shortName rune, desc string, opts ...ArgumentOptio
 	return listFlag(name, shortName, desc, 1, 0, opts...) //.AddOptions(ListUntil[T](MatchesRegex("^-")))
 }
 
+// DurationFlag creates a `FlagInterface` whose value is parsed into a `time.Duration`
+// (see `time.ParseDuration` for the accepted formats, e.g. `1h30m`).
+func DurationFlag(name string, shortName rune, desc string, opts ...ArgumentOption[time.Duration]) FlagWithType[time.Duration] {
+	return listFlag(name, shortName, desc, 1, 0, opts...)
+}
+
 // BoolFlag creates a `FlagInterface` for a boolean argument.
 func BoolFlag(name string, shortName rune, desc string) FlagWithType[bool] {
 	return &boolFlag[bool]{
@@ -532,12 +712,19 @@ func (bf *boolFlag[T]) Processor() command.Processor {
 	return bf
 }
 
+// argCount returns the number of values the flag consumes (bool flags don't
+// consume any values).
+func (bf *boolFlag[T]) argCount() int {
+	return 0
+}
+
 func (bf *boolFlag[T]) Options() *FlagOptions {
 	return &FlagOptions{
 		Combinable: true,
 		ProcessMissing: func(d *command.Data) error {
 			if bf.falseValue != nil {
 				d.Set(bf.name, *bf.falseValue)
+				d.MarkDefault(bf.name)
 			}
 			return nil
 		},
@@ -674,6 +861,8 @@ func (ilf *itemizedListFlag[T]) Options() *FlagOptions {
 		func(i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
 			return spycommander.ProcessOrExecute(ilf.flag.Processor(), command.NewInput(ilf.rawArgs, nil), o, d, ed)
 		},
+		// Required
+		ilf.flag.Options().required(),
 	}
 }
 
@@ -719,7 +908,7 @@ func ListFlag[T any](name string, shortName rune, desc string, minN, optionalN i
 }
 
 func listFlag[T any](name string, shortName rune, desc string, minN, optionalN int, opts ...ArgumentOption[T]) *flag[T] {
-	return &flag[T]{
+	f := &flag[T]{
 		name:      name,
 		desc:      desc,
 		shortName: shortName,
@@ -732,4 +921,39 @@ func listFlag[T any](name string, shortName rune, desc string, minN, optionalN i
 			opt:       multiArgumentOptions(opts...),
 		},
 	}
+	f.checkRequired()
+	return f
+}
+
+// PairListFlag creates a flag that accepts an even number of values and stores
+// them in `command.Data` as consecutive pairs (e.g. `--pairs k1 v1 k2 v2` is
+// stored as `[][2]string{{"k1", "v1"}, {"k2", "v2"}}`).
+func PairListFlag(name string, shortName rune, desc string, opts ...ArgumentOption[[][2]string]) FlagWithType[[][2]string] {
+	f := listFlag(name, shortName, desc, 2, command.UnboundedList, opts...)
+	f.argument.op = &pairListOperator{name}
+	return f
+}
+
+// pairListOperator converts an even number of values into consecutive pairs.
+type pairListOperator struct {
+	name string
+}
+
+func (plo *pairListOperator) ToArgs(pairs [][2]string) []string {
+	var sl []string
+	for _, p := range pairs {
+		sl = append(sl, p[0], p[1])
+	}
+	return sl
+}
+
+func (plo *pairListOperator) FromArgs(sl []*string) ([][2]string, error) {
+	if len(sl)%2 != 0 {
+		return nil, &validationErr{plo.name, fmt.Errorf("[PairListFlag] requires an even number of values; got %d", len(sl))}
+	}
+	pairs := make([][2]string, 0, len(sl)/2)
+	for i := 0; i < len(sl); i += 2 {
+		pairs = append(pairs, [2]string{*sl[i], *sl[i+1]})
+	}
+	return pairs, nil
 }
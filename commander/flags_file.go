@@ -0,0 +1,80 @@
+package commander
+
+import (
+	"strings"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/internal/spycommander"
+)
+
+var (
+	// readFlagsFile reads the lines of a flags file.
+	readFlagsFile = ReadFile
+)
+
+// FlagsFile returns a `FlagInterface` whose value is the path to a file
+// containing additional flags. When included in a `FlagProcessor`, each line
+// of the file is split into arguments (with `command.ParseArgs`, so quoted
+// values may contain spaces) and processed as if it were provided on the
+// command line (e.g. `--name "first last"`). Empty lines and lines starting
+// with `#` are ignored. Flags provided on the command line take precedence over
+// flags provided in the file.
+func FlagsFile(name string, shortName rune, desc string) FlagWithType[string] {
+	return &flagsFile{Flag[string](name, shortName, desc, &FileCompleter[string]{})}
+}
+
+type flagsFile struct {
+	FlagWithType[string]
+}
+
+// processFlagsFiles processes the flags in any flags files that were provided.
+// `processed` and `unprocessed` are updated to include the flags set from the files.
+func (fn *flagProcessor) processFlagsFiles(output command.Output, data *command.Data, eData *command.ExecuteData, processed, unprocessed map[string]bool) error {
+	for _, ff := range fn.flagOrder {
+		if _, ok := ff.(*flagsFile); !ok || !processed[ff.Name()] {
+			continue
+		}
+
+		path := data.String(ff.Name())
+		lines, err := readFlagsFile(path)
+		if err != nil {
+			return output.Stderrf("[FlagsFile] failed to read flags file %q: %v\n", path, err)
+		}
+
+		fromFile := map[string]bool{}
+		for _, line := range lines {
+			tokens := command.ParseArgs(line)
+			if len(tokens) == 0 || strings.HasPrefix(tokens[0], "#") {
+				continue
+			}
+
+			f, ok := fn.flagMap[tokens[0]]
+			if _, isFlagsFile := f.(*flagsFile); !ok || isFlagsFile {
+				return output.Stderrf("[FlagsFile] unknown flag in flags file %q: %q\n", path, tokens[0])
+			}
+
+			// Flags provided on the command line take precedence.
+			if processed[f.Name()] {
+				continue
+			}
+			if !f.Options().allowsMultiple() && fromFile[f.Name()] {
+				return output.Stderrf("Flag %q has already been set\n", f.Name())
+			}
+			fromFile[f.Name()] = true
+			delete(unprocessed, f.Name())
+
+			fileInput := command.NewInput(tokens[1:], nil)
+			if err := spycommander.ProcessOrExecute(f.Processor(), fileInput, output, data, eData); err != nil {
+				return err
+			}
+			if !fileInput.FullyProcessed() {
+				return output.Stderrf("[FlagsFile] unprocessed arguments for flag %q in flags file %q: %v\n", f.Name(), path, fileInput.Remaining())
+			}
+		}
+
+		for name := range fromFile {
+			processed[name] = true
+		}
+	}
+	return nil
+}
@@ -0,0 +1,294 @@
+package commander
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/commandtest"
+	"github.com/leep-frog/command/internal/spycommand"
+	"github.com/leep-frog/command/internal/spycommandtest"
+	"github.com/leep-frog/command/internal/testutil"
+)
+
+func TestFlagsFile(t *testing.T) {
+	fp := func() command.Processor {
+		return FlagProcessor(
+			FlagsFile("flags-file", 'f', testDesc),
+			Flag[string]("name", 'n', testDesc),
+			Flag[int]("count", 'c', testDesc, Default(1)),
+			BoolFlag("verbose", 'v', testDesc),
+			ListFlag[string]("tags", 't', testDesc, 0, command.UnboundedList),
+		)
+	}
+
+	for _, test := range []struct {
+		name     string
+		contents []string
+		readErr  error
+		wantPath []string
+		etc      *commandtest.ExecuteTestCase
+		ietc     *spycommandtest.ExecuteTestCase
+	}{
+		{
+			name: "loads flags from file",
+			contents: []string{
+				"# Comment lines are ignored",
+				"--name alice",
+				"",
+				"-c 3",
+				"--verbose",
+				"--tags a b",
+			},
+			wantPath: []string{"some.flags"},
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(fp()),
+				Args: []string{"--flags-file", "some.flags"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"flags-file": "some.flags",
+					"name":       "alice",
+					"count":      3,
+					"verbose":    true,
+					"tags":       []string{"a", "b"},
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "--flags-file"},
+						{Value: "some.flags"},
+					},
+				},
+			},
+		},
+		{
+			name: "loads quoted values from file",
+			contents: []string{
+				`--name "alice bob"`,
+				`--tags 'a b' c\ d ""`,
+			},
+			wantPath: []string{"some.flags"},
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(fp()),
+				Args: []string{"--flags-file", "some.flags"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"flags-file": "some.flags",
+					"name":       "alice bob",
+					"count":      1,
+					"tags":       []string{"a b", "c d", ""},
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "--flags-file"},
+						{Value: "some.flags"},
+					},
+				},
+			},
+		},
+		{
+			name: "command line flags take precedence over file flags",
+			contents: []string{
+				"--name alice",
+				"--count 3",
+			},
+			wantPath: []string{"some.flags"},
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(fp()),
+				Args: []string{"-n", "bob", "-f", "some.flags"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"flags-file": "some.flags",
+					"name":       "bob",
+					"count":      3,
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "-n"},
+						{Value: "bob"},
+						{Value: "-f"},
+						{Value: "some.flags"},
+					},
+				},
+			},
+		},
+		{
+			name: "default values are used for flags not in file or command line",
+			contents: []string{
+				"--name alice",
+			},
+			wantPath: []string{"some.flags"},
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(fp()),
+				Args: []string{"-f", "some.flags"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"flags-file": "some.flags",
+					"name":       "alice",
+					"count":      1,
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "-f"},
+						{Value: "some.flags"},
+					},
+				},
+			},
+		},
+		{
+			name: "file isn't read if flag isn't provided",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(fp()),
+				Args: []string{"-n", "bob"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"name":  "bob",
+					"count": 1,
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "-n"},
+						{Value: "bob"},
+					},
+				},
+			},
+		},
+		{
+			name:     "fails if file can't be read",
+			readErr:  fmt.Errorf("oops"),
+			wantPath: []string{"some.flags"},
+			etc: &commandtest.ExecuteTestCase{
+				Node:       SerialNodes(fp()),
+				Args:       []string{"-f", "some.flags"},
+				WantStderr: "[FlagsFile] failed to read flags file \"some.flags\": oops\n",
+				WantErr:    fmt.Errorf(`[FlagsFile] failed to read flags file "some.flags": oops`),
+				WantData: &command.Data{Values: map[string]interface{}{
+					"flags-file": "some.flags",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "-f"},
+						{Value: "some.flags"},
+					},
+				},
+			},
+		},
+		{
+			name: "fails if unknown flag in file",
+			contents: []string{
+				"--name alice",
+				"--other thing",
+			},
+			wantPath: []string{"some.flags"},
+			etc: &commandtest.ExecuteTestCase{
+				Node:       SerialNodes(fp()),
+				Args:       []string{"-f", "some.flags"},
+				WantStderr: "[FlagsFile] unknown flag in flags file \"some.flags\": \"--other\"\n",
+				WantErr:    fmt.Errorf(`[FlagsFile] unknown flag in flags file "some.flags": "--other"`),
+				WantData: &command.Data{Values: map[string]interface{}{
+					"flags-file": "some.flags",
+					"name":       "alice",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "-f"},
+						{Value: "some.flags"},
+					},
+				},
+			},
+		},
+		{
+			name: "fails if flags file references a flags file",
+			contents: []string{
+				"--flags-file other.flags",
+			},
+			wantPath: []string{"some.flags"},
+			etc: &commandtest.ExecuteTestCase{
+				Node:       SerialNodes(fp()),
+				Args:       []string{"-f", "some.flags"},
+				WantStderr: "[FlagsFile] unknown flag in flags file \"some.flags\": \"--flags-file\"\n",
+				WantErr:    fmt.Errorf(`[FlagsFile] unknown flag in flags file "some.flags": "--flags-file"`),
+				WantData: &command.Data{Values: map[string]interface{}{
+					"flags-file": "some.flags",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "-f"},
+						{Value: "some.flags"},
+					},
+				},
+			},
+		},
+		{
+			name: "fails if flag is set multiple times in file",
+			contents: []string{
+				"--name alice",
+				"--name bob",
+			},
+			wantPath: []string{"some.flags"},
+			etc: &commandtest.ExecuteTestCase{
+				Node:       SerialNodes(fp()),
+				Args:       []string{"-f", "some.flags"},
+				WantStderr: "Flag \"name\" has already been set\n",
+				WantErr:    fmt.Errorf(`Flag "name" has already been set`),
+				WantData: &command.Data{Values: map[string]interface{}{
+					"flags-file": "some.flags",
+					"name":       "alice",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "-f"},
+						{Value: "some.flags"},
+					},
+				},
+			},
+		},
+		{
+			name: "fails if extra arguments for flag in file",
+			contents: []string{
+				"--name alice bob",
+			},
+			wantPath: []string{"some.flags"},
+			etc: &commandtest.ExecuteTestCase{
+				Node:       SerialNodes(fp()),
+				Args:       []string{"-f", "some.flags"},
+				WantStderr: "[FlagsFile] unprocessed arguments for flag \"name\" in flags file \"some.flags\": [bob]\n",
+				WantErr:    fmt.Errorf(`[FlagsFile] unprocessed arguments for flag "name" in flags file "some.flags": [bob]`),
+				WantData: &command.Data{Values: map[string]interface{}{
+					"flags-file": "some.flags",
+					"name":       "alice",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "-f"},
+						{Value: "some.flags"},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var gotPath []string
+			testutil.StubValue(t, &readFlagsFile, func(path string) ([]string, error) {
+				gotPath = append(gotPath, path)
+				return test.contents, test.readErr
+			})
+			executeTest(t, test.etc, test.ietc)
+			testutil.Cmp(t, "FlagsFile read incorrect files", test.wantPath, gotPath)
+		})
+	}
+}
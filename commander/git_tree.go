@@ -0,0 +1,85 @@
+package commander
+
+import (
+	"strings"
+
+	"github.com/leep-frog/command/command"
+)
+
+// CleanGitTreeOption is an option interface for modifying the behavior of
+// `RequireCleanGitTree`.
+type CleanGitTreeOption interface {
+	modifyCleanGitTree(*cleanGitTree)
+}
+
+type cleanGitTree struct {
+	dir            string
+	allowUntracked bool
+}
+
+// GitTreeDir is a `CleanGitTreeOption` that checks the git working tree
+// containing the provided directory (rather than the current directory).
+func GitTreeDir(dir string) CleanGitTreeOption {
+	return &gitTreeDir{dir}
+}
+
+type gitTreeDir struct {
+	dir string
+}
+
+func (gtd *gitTreeDir) modifyCleanGitTree(cgt *cleanGitTree) {
+	cgt.dir = gtd.dir
+}
+
+// AllowUntrackedFiles is a `CleanGitTreeOption` that ignores untracked files
+// when determining if the git working tree is clean.
+func AllowUntrackedFiles() CleanGitTreeOption {
+	return &allowUntrackedFiles{}
+}
+
+type allowUntrackedFiles struct{}
+
+func (*allowUntrackedFiles) modifyCleanGitTree(cgt *cleanGitTree) {
+	cgt.allowUntracked = true
+}
+
+// RequireCleanGitTree returns a `command.Processor` that fails if the git
+// working tree has any uncommitted changes (as reported by
+// `git status --porcelain`). The error lists all of the offending changes.
+// This is useful for release CLIs that should only run against committed code.
+// This processor has no effect on completion or usage.
+func RequireCleanGitTree(opts ...CleanGitTreeOption) command.Processor {
+	cgt := &cleanGitTree{}
+	for _, opt := range opts {
+		opt.modifyCleanGitTree(cgt)
+	}
+
+	return SimpleProcessor(func(i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
+		sc := &ShellCommand[[]string]{
+			CommandName: "git",
+			Args:        []string{"status", "--porcelain"},
+			Dir:         cgt.dir,
+			HideStderr:  true,
+		}
+		lines, err := sc.Run(o, d)
+		if err != nil {
+			return o.Annotatef(err, "[RequireCleanGitTree] failed to get git status")
+		}
+
+		var changes []string
+		for _, line := range lines {
+			line = strings.TrimSpace(line)
+			if line == "" {
+				continue
+			}
+			if cgt.allowUntracked && strings.HasPrefix(line, "??") {
+				continue
+			}
+			changes = append(changes, line)
+		}
+		if len(changes) > 0 {
+			return o.Stderrf("[RequireCleanGitTree] git working tree has uncommitted changes:\n%s\n", strings.Join(changes, "\n"))
+		}
+		return nil
+	}, nil)
+}
@@ -0,0 +1,115 @@
+package commander
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/commandtest"
+)
+
+func TestRequireCleanGitTree(t *testing.T) {
+	release := &ExecutorProcessor{func(o command.Output, d *command.Data) error {
+		o.Stdoutln("releasing")
+		return nil
+	}}
+	gitStatus := []*commandtest.RunContents{{
+		Name: "git",
+		Args: []string{"status", "--porcelain"},
+	}}
+
+	for _, test := range []struct {
+		name string
+		etc  *commandtest.ExecuteTestCase
+	}{
+		{
+			name: "succeeds if the git tree is clean",
+			etc: &commandtest.ExecuteTestCase{
+				Node:            SerialNodes(RequireCleanGitTree(), release),
+				RunResponses:    []*commandtest.FakeRun{{}},
+				WantRunContents: gitStatus,
+				WantStdout:      "releasing\n",
+			},
+		},
+		{
+			name: "fails if the git tree has uncommitted changes",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(RequireCleanGitTree(), release),
+				RunResponses: []*commandtest.FakeRun{{
+					Stdout: []string{
+						" M main.go",
+						"A  new.go",
+						"?? scratch.txt",
+					},
+				}},
+				WantRunContents: gitStatus,
+				WantStderr:      "[RequireCleanGitTree] git working tree has uncommitted changes:\nM main.go\nA  new.go\n?? scratch.txt\n",
+				WantErr:         fmt.Errorf("[RequireCleanGitTree] git working tree has uncommitted changes:\nM main.go\nA  new.go\n?? scratch.txt"),
+			},
+		},
+		{
+			name: "fails if the git tree only has untracked files",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(RequireCleanGitTree(), release),
+				RunResponses: []*commandtest.FakeRun{{
+					Stdout: []string{"?? scratch.txt"},
+				}},
+				WantRunContents: gitStatus,
+				WantStderr:      "[RequireCleanGitTree] git working tree has uncommitted changes:\n?? scratch.txt\n",
+				WantErr:         fmt.Errorf("[RequireCleanGitTree] git working tree has uncommitted changes:\n?? scratch.txt"),
+			},
+		},
+		{
+			name: "succeeds with only untracked files if AllowUntrackedFiles is provided",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(RequireCleanGitTree(AllowUntrackedFiles()), release),
+				RunResponses: []*commandtest.FakeRun{{
+					Stdout: []string{"?? scratch.txt", "?? other.txt"},
+				}},
+				WantRunContents: gitStatus,
+				WantStdout:      "releasing\n",
+			},
+		},
+		{
+			name: "fails with tracked changes even if AllowUntrackedFiles is provided",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(RequireCleanGitTree(AllowUntrackedFiles()), release),
+				RunResponses: []*commandtest.FakeRun{{
+					Stdout: []string{"?? scratch.txt", " D old.go"},
+				}},
+				WantRunContents: gitStatus,
+				WantStderr:      "[RequireCleanGitTree] git working tree has uncommitted changes:\nD old.go\n",
+				WantErr:         fmt.Errorf("[RequireCleanGitTree] git working tree has uncommitted changes:\nD old.go"),
+			},
+		},
+		{
+			name: "runs git status in the provided directory",
+			etc: &commandtest.ExecuteTestCase{
+				Node:         SerialNodes(RequireCleanGitTree(GitTreeDir("some/repo")), release),
+				RunResponses: []*commandtest.FakeRun{{}},
+				WantRunContents: []*commandtest.RunContents{{
+					Name: "git",
+					Args: []string{"status", "--porcelain"},
+					Dir:  "some/repo",
+				}},
+				WantStdout: "releasing\n",
+			},
+		},
+		{
+			name: "fails if git status fails",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(RequireCleanGitTree(), release),
+				RunResponses: []*commandtest.FakeRun{{
+					Err: fmt.Errorf("not a git repository"),
+				}},
+				WantRunContents: gitStatus,
+				WantStderr:      "[RequireCleanGitTree] failed to get git status: failed to execute shell command: not a git repository\n",
+				WantErr:         fmt.Errorf("[RequireCleanGitTree] failed to get git status: failed to execute shell command: not a git repository"),
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			executeTest(t, test.etc, nil)
+		})
+	}
+}
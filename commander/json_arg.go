@@ -0,0 +1,65 @@
+package commander
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/leep-frog/command/command"
+)
+
+// JSONStructArgument is an `Argument` whose JSON value is unmarshaled into
+// (and stored in `command.Data` as) a value of type `T`.
+type JSONStructArgument[T any] struct {
+	*Argument[string]
+}
+
+// JSONStructArg returns an `Argument` that accepts a JSON string and unmarshals
+// it into a value of type `T`. The typed value is what is stored in
+// `command.Data`, so it should be retrieved with `JSONStructArgument.Get`.
+// Validation fails if the JSON can't be unmarshaled into `T`.
+func JSONStructArg[T any](name, desc string, opts ...ArgumentOption[string]) *JSONStructArgument[T] {
+	return &JSONStructArgument[T]{Arg[string](name, desc, append([]ArgumentOption[string]{
+		&jsonStruct[T]{},
+	}, opts...)...)}
+}
+
+// Get returns the unmarshaled value from the `command.Data` object.
+func (ja *JSONStructArgument[T]) Get(d *command.Data) T {
+	return command.GetData[T](d, ja.Name())
+}
+
+// GetOrDefault returns the unmarshaled value from the `command.Data` object,
+// if the argument was provided. Otherwise, it returns the provided value.
+func (ja *JSONStructArgument[T]) GetOrDefault(d *command.Data, dflt T) T {
+	if ja.Provided(d) {
+		return ja.Get(d)
+	}
+	return dflt
+}
+
+type jsonStruct[T any] struct{}
+
+func unmarshalJSONStruct[T any](s string) (T, error) {
+	var t T
+	err := json.Unmarshal([]byte(s), &t)
+	return t, err
+}
+
+func (js *jsonStruct[T]) modifyArgumentOption(ao *argumentOption[string]) {
+	ao.validators = append(ao.validators, &ValidatorOption[string]{
+		func(s string, d *command.Data) error {
+			if _, err := unmarshalJSONStruct[T](s); err != nil {
+				var t T
+				return fmt.Errorf("[JSONStructArg] failed to unmarshal JSON into %T: %v", t, err)
+			}
+			return nil
+		},
+		"JSONStruct()",
+	})
+	ao.namedSet = func(name, s string, d *command.Data) {
+		// Only set the value if it's valid (the validator will catch it otherwise).
+		if t, err := unmarshalJSONStruct[T](s); err == nil {
+			d.Set(name, t)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package commander
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/commandtest"
+	"github.com/leep-frog/command/internal/spycommand"
+	"github.com/leep-frog/command/internal/spycommandtest"
+)
+
+type jsonServerConfig struct {
+	Host string   `json:"host"`
+	Port int      `json:"port"`
+	Tags []string `json:"tags"`
+}
+
+func TestJSONStructArg(t *testing.T) {
+	cfgArg := JSONStructArg[jsonServerConfig]("CFG", testDesc)
+	printer := &ExecutorProcessor{func(o command.Output, d *command.Data) error {
+		cfg := cfgArg.Get(d)
+		o.Stdoutf("%s:%d %v\n", cfg.Host, cfg.Port, cfg.Tags)
+		return nil
+	}}
+
+	for _, test := range []struct {
+		name string
+		etc  *commandtest.ExecuteTestCase
+		ietc *spycommandtest.ExecuteTestCase
+	}{
+		{
+			name: "unmarshals JSON into struct",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(cfgArg, printer),
+				Args: []string{`{"host": "localhost", "port": 8080, "tags": ["a", "b"]}`},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"CFG": jsonServerConfig{"localhost", 8080, []string{"a", "b"}},
+				}},
+				WantStdout: "localhost:8080 [a b]\n",
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: `{"host": "localhost", "port": 8080, "tags": ["a", "b"]}`},
+					},
+				},
+			},
+		},
+		{
+			name: "unmarshals JSON into pointer",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(JSONStructArg[*jsonServerConfig]("CFG", testDesc)),
+				Args: []string{`{"host": "example.com"}`},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"CFG": &jsonServerConfig{Host: "example.com"},
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: `{"host": "example.com"}`},
+					},
+				},
+			},
+		},
+		{
+			name: "fails on type mismatch",
+			etc: &commandtest.ExecuteTestCase{
+				Node:       SerialNodes(cfgArg, printer),
+				Args:       []string{`{"host": "localhost", "port": "8080"}`},
+				WantStderr: "validation for \"CFG\" failed: [JSONStructArg] failed to unmarshal JSON into commander.jsonServerConfig: json: cannot unmarshal string into Go struct field jsonServerConfig.port of type int\n",
+				WantErr:    fmt.Errorf(`validation for "CFG" failed: [JSONStructArg] failed to unmarshal JSON into commander.jsonServerConfig: json: cannot unmarshal string into Go struct field jsonServerConfig.port of type int`),
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: `{"host": "localhost", "port": "8080"}`},
+					},
+				},
+			},
+		},
+		{
+			name: "fails on invalid JSON",
+			etc: &commandtest.ExecuteTestCase{
+				Node:       SerialNodes(cfgArg, printer),
+				Args:       []string{`{"host": `},
+				WantStderr: "validation for \"CFG\" failed: [JSONStructArg] failed to unmarshal JSON into commander.jsonServerConfig: unexpected end of JSON input\n",
+				WantErr:    fmt.Errorf(`validation for "CFG" failed: [JSONStructArg] failed to unmarshal JSON into commander.jsonServerConfig: unexpected end of JSON input`),
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: `{"host": `},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			executeTest(t, test.etc, test.ietc)
+		})
+	}
+}
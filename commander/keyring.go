@@ -0,0 +1,103 @@
+package commander
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/internal/stubs"
+)
+
+// Secret is a string value that hides its contents when formatted (e.g. via
+// `fmt.Sprintf("%v", secret)`), so it isn't accidentally written to output.
+// Use `Secret.Reveal()` to retrieve the actual value.
+type Secret string
+
+// String fulfills the `fmt.Stringer` interface and returns a redacted value.
+func (s Secret) String() string {
+	return "********"
+}
+
+// Reveal returns the actual secret value.
+func (s Secret) Reveal() string {
+	return string(s)
+}
+
+// KeyringSecret returns a `GetProcessor` that fetches a secret from the OS
+// keyring (or credential store) and stores it as a `Secret` in `command.Data`
+// under the provided `name`. The processor only runs during execution (secrets
+// are never fetched during completion).
+func KeyringSecret(name, service, account string) *GetProcessor[Secret] {
+	return &GetProcessor[Secret]{
+		SimpleProcessor(func(i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
+			s, ok, err := keyringGet(service, account)
+			if err != nil {
+				return o.Annotatef(err, "failed to fetch secret %q from keyring", name)
+			}
+			if !ok {
+				return o.Stderrf("secret %q (service=%q, account=%q) not found in keyring; it can be added by running the following:\n%s\n", name, service, account, keyringSetHint(service, account))
+			}
+			d.Set(name, Secret(s))
+			return nil
+		}, nil),
+		name,
+	}
+}
+
+// keyringLookupCommand returns the command that looks up the secret for the
+// provided service and account in the credential store of the current OS, as
+// well as the exit code the command uses if the secret doesn't exist.
+func keyringLookupCommand(service, account string) (*exec.Cmd, int, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		// 44 is errSecItemNotFound
+		return exec.Command("security", "find-generic-password", "-s", service, "-a", account, "-w"), 44, nil
+	case "windows":
+		return nil, 0, fmt.Errorf("keyring lookup is not supported on windows")
+	}
+	return exec.Command("secret-tool", "lookup", "service", service, "account", account), 1, nil
+}
+
+// keyringGet returns the secret for the provided service and account, and
+// whether or not the secret exists.
+func keyringGet(service, account string) (string, bool, error) {
+	cmd, notFoundCode, err := keyringLookupCommand(service, account)
+	if err != nil {
+		return "", false, err
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := stubs.Run(cmd); err != nil {
+		// `secret-tool` also exits with 1 for other failures (e.g. a locked
+		// collection), but only writes to stderr in those cases.
+		var ee *exec.ExitError
+		if errors.As(err, &ee) && ee.ExitCode() == notFoundCode && strings.TrimSpace(stderr.String()) == "" {
+			return "", false, nil
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", false, fmt.Errorf("failed to run %q: %v: %s", cmd.Args[0], err, msg)
+		}
+		return "", false, fmt.Errorf("failed to run %q: %v", cmd.Args[0], err)
+	}
+
+	s := strings.TrimSuffix(stdout.String(), "\n")
+	if s == "" {
+		return "", false, nil
+	}
+	return s, true, nil
+}
+
+// keyringSetHint returns instructions for how a user can add the secret for
+// the provided service and account to the credential store.
+func keyringSetHint(service, account string) string {
+	if runtime.GOOS == "darwin" {
+		return fmt.Sprintf("security add-generic-password -s %q -a %q -w", service, account)
+	}
+	return fmt.Sprintf("secret-tool store --label=%q service %q account %q", service, service, account)
+}
@@ -0,0 +1,168 @@
+package commander
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/commandtest"
+	"github.com/leep-frog/command/internal/stubs"
+	"github.com/leep-frog/command/internal/testutil"
+)
+
+func TestKeyringSecret(t *testing.T) {
+	lookup, notFoundCode, err := keyringLookupCommand("my-service", "me")
+	if err != nil {
+		t.Skipf("keyring lookup isn't supported on %s: %v", runtime.GOOS, err)
+	}
+	wantLookup := []*commandtest.RunContents{{
+		Name: lookup.Args[0],
+		Args: lookup.Args[1:],
+	}}
+
+	ks := KeyringSecret("TOKEN", "my-service", "me")
+	for _, test := range []struct {
+		name string
+		etc  *commandtest.ExecuteTestCase
+		ctc  *commandtest.CompleteTestCase
+	}{
+		{
+			name: "sets secret in data",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					ks,
+					&ExecutorProcessor{func(o command.Output, d *command.Data) error {
+						o.Stdoutf("formatted: %v; revealed: %s\n", ks.Get(d), ks.Get(d).Reveal())
+						return nil
+					}},
+				),
+				RunResponses: []*commandtest.FakeRun{{
+					Stdout: []string{"shh"},
+				}},
+				WantRunContents: wantLookup,
+				WantStdout:      "formatted: ********; revealed: shh\n",
+				WantData: &command.Data{Values: map[string]interface{}{
+					"TOKEN": Secret("shh"),
+				}},
+			},
+		},
+		{
+			name: "fails if secret is not in keyring",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(ks),
+				RunResponses: []*commandtest.FakeRun{{
+					Err: exitErr(notFoundCode),
+				}},
+				WantRunContents: wantLookup,
+				WantStderr: strings.Join([]string{
+					`secret "TOKEN" (service="my-service", account="me") not found in keyring; it can be added by running the following:`,
+					keyringSetHint("my-service", "me"),
+					"",
+				}, "\n"),
+				WantErr: fmt.Errorf("secret \"TOKEN\" (service=\"my-service\", account=\"me\") not found in keyring; it can be added by running the following:\n%s", keyringSetHint("my-service", "me")),
+			},
+		},
+		{
+			name: "fails if keyring returns an error",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(ks),
+				RunResponses: []*commandtest.FakeRun{{
+					Stderr: []string{"locked"},
+					Err:    exitErr(notFoundCode),
+				}},
+				WantRunContents: wantLookup,
+				WantStderr:      fmt.Sprintf("failed to fetch secret \"TOKEN\" from keyring: failed to run %q: exit status %d: locked\n", lookup.Args[0], notFoundCode),
+				WantErr:         fmt.Errorf("failed to fetch secret \"TOKEN\" from keyring: failed to run %q: exit status %d: locked", lookup.Args[0], notFoundCode),
+			},
+		},
+		{
+			// No run responses are provided, so the test fails if the keyring is used.
+			name: "doesn't fetch secret during completion",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(ks, Arg[string]("S", testDesc, SimpleCompleter[string]("abc"))),
+				Args: "cmd a",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"abc"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"S": "a",
+				}},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if test.etc != nil {
+				executeTest(t, test.etc, nil)
+			} else {
+				autocompleteTest(t, test.ctc, nil)
+			}
+		})
+	}
+}
+
+// exitErr returns the error from a process that exits with the provided code.
+func exitErr(code int) error {
+	return exec.Command("sh", "-c", fmt.Sprintf("exit %d", code)).Run()
+}
+
+func TestKeyringGet(t *testing.T) {
+	if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
+		t.Skipf("keyringGet test uses secret-tool, which isn't used on %s", runtime.GOOS)
+	}
+
+	for _, test := range []struct {
+		name    string
+		stdout  string
+		stderr  string
+		err     error
+		want    string
+		wantOK  bool
+		wantErr error
+	}{
+		{
+			name:   "returns secret",
+			stdout: "shh\n",
+			want:   "shh",
+			wantOK: true,
+		},
+		{
+			name: "returns not found for secret-tool's not found status",
+			err:  exitErr(1),
+		},
+		{
+			name:    "returns error if secret-tool fails",
+			stderr:  "Cannot autolaunch D-Bus without X11 $DISPLAY\n",
+			err:     exitErr(1),
+			wantErr: fmt.Errorf(`failed to run "secret-tool": exit status 1: Cannot autolaunch D-Bus without X11 $DISPLAY`),
+		},
+		{
+			name:    "returns error for other exit codes",
+			err:     exitErr(2),
+			wantErr: fmt.Errorf(`failed to run "secret-tool": exit status 2`),
+		},
+		{
+			name:    "returns error if secret-tool can't be run",
+			err:     exec.ErrNotFound,
+			wantErr: fmt.Errorf(`failed to run "secret-tool": %v`, exec.ErrNotFound),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var gotArgs []string
+			stubs.StubRun(t, func(cmd *exec.Cmd) error {
+				gotArgs = cmd.Args
+				cmd.Stdout.Write([]byte(test.stdout))
+				cmd.Stderr.Write([]byte(test.stderr))
+				return test.err
+			})
+
+			got, ok, err := keyringGet("my-service", "me")
+			testutil.CmpError(t, "keyringGet()", test.wantErr, err)
+			testutil.Cmp(t, "keyringGet() returned incorrect secret", test.want, got)
+			testutil.Cmp(t, "keyringGet() returned incorrect ok", test.wantOK, ok)
+			testutil.Cmp(t, "keyringGet() ran incorrect command", []string{"secret-tool", "lookup", "service", "my-service", "account", "me"}, gotArgs)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package commander
+
+import (
+	"strings"
+
+	"github.com/leep-frog/command/command"
+)
+
+var (
+	// kubeContextsArgs are the `kubectl config` arguments that list the
+	// contexts in the current kubeconfig.
+	kubeContextsArgs = []string{"get-contexts", "-o", "name"}
+	// kubeNamespacesArgs are the `kubectl config` arguments that list the
+	// namespaces set in the current kubeconfig.
+	kubeNamespacesArgs = []string{"view", "-o", `jsonpath={range .contexts[*]}{.context.namespace}{"\n"}{end}`}
+)
+
+// KubeContextCompleter returns a `Completer` that suggests the contexts in
+// the current kubeconfig. No suggestions are returned if the contexts
+// can't be fetched.
+func KubeContextCompleter() Completer[string] {
+	return kubeCompleter(kubeContextsArgs...)
+}
+
+// KubeNamespaceCompleter returns a `Completer` that suggests the namespaces
+// set in the current kubeconfig. No suggestions are returned if the
+// namespaces can't be fetched.
+func KubeNamespaceCompleter() Completer[string] {
+	return kubeCompleter(kubeNamespacesArgs...)
+}
+
+func kubeCompleter(args ...string) Completer[string] {
+	return CompleterFromFunc(func(string, *command.Data) (*command.Completion, error) {
+		vs, err := kubeConfigValues(args...)
+		if err != nil {
+			return nil, nil
+		}
+		return &command.Completion{
+			Suggestions: vs,
+		}, nil
+	})
+}
+
+// kubeConfigValues runs `kubectl config <args>` and returns the distinct,
+// non-empty lines of output.
+func kubeConfigValues(args ...string) ([]string, error) {
+	sc := &ShellCommand[[]string]{
+		CommandName: "kubectl",
+		Args:        append([]string{"config"}, args...),
+		HideStderr:  true,
+	}
+	resp, err := sc.Run(nil, &command.Data{})
+	if err != nil {
+		return nil, err
+	}
+	var vs []string
+	seen := map[string]bool{}
+	for _, r := range resp {
+		r = strings.TrimSpace(r)
+		if r == "" || seen[r] {
+			continue
+		}
+		seen[r] = true
+		vs = append(vs, r)
+	}
+	return vs, nil
+}
@@ -0,0 +1,121 @@
+package commander
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/commandtest"
+)
+
+func TestKubeCompleters(t *testing.T) {
+	contexts := []string{"dev-east", "dev-west", "prod"}
+	namespaces := []string{"default", "", "kube-system", "default", "monitoring"}
+	for _, test := range []struct {
+		name string
+		// stdout is the output of the kubectl command.
+		stdout []string
+		// runErr is the error returned by the kubectl command.
+		runErr error
+		// args are the expected `kubectl config` args.
+		args []string
+		ctc  *commandtest.CompleteTestCase
+	}{
+		{
+			name:   "KubeContextCompleter suggests all contexts",
+			stdout: contexts,
+			args:   kubeContextsArgs,
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(Arg[string]("CONTEXT", testDesc, KubeContextCompleter())),
+				Args: "cmd ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"dev-east", "dev-west", "prod"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"CONTEXT": "",
+				}},
+			},
+		},
+		{
+			name:   "KubeContextCompleter suggests contexts matching prefix",
+			stdout: contexts,
+			args:   kubeContextsArgs,
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(Arg[string]("CONTEXT", testDesc, KubeContextCompleter())),
+				Args: "cmd dev",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"dev-east", "dev-west"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"CONTEXT": "dev",
+				}},
+			},
+		},
+		{
+			name:   "KubeContextCompleter returns nothing on provider error",
+			runErr: fmt.Errorf("kubectl not found"),
+			args:   kubeContextsArgs,
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(Arg[string]("CONTEXT", testDesc, KubeContextCompleter())),
+				Args: "cmd ",
+				WantData: &command.Data{Values: map[string]interface{}{
+					"CONTEXT": "",
+				}},
+			},
+		},
+		{
+			name:   "KubeNamespaceCompleter suggests all namespaces",
+			stdout: namespaces,
+			args:   kubeNamespacesArgs,
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(Arg[string]("NAMESPACE", testDesc, KubeNamespaceCompleter())),
+				Args: "cmd ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"default", "kube-system", "monitoring"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"NAMESPACE": "",
+				}},
+			},
+		},
+		{
+			name:   "KubeNamespaceCompleter suggests namespaces matching prefix",
+			stdout: namespaces,
+			args:   kubeNamespacesArgs,
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(Arg[string]("NAMESPACE", testDesc, KubeNamespaceCompleter())),
+				Args: "cmd k",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"kube-system"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"NAMESPACE": "k",
+				}},
+			},
+		},
+		{
+			name:   "KubeNamespaceCompleter returns nothing on provider error",
+			runErr: fmt.Errorf("no kubeconfig"),
+			args:   kubeNamespacesArgs,
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(Arg[string]("NAMESPACE", testDesc, KubeNamespaceCompleter())),
+				Args: "cmd d",
+				WantData: &command.Data{Values: map[string]interface{}{
+					"NAMESPACE": "d",
+				}},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			test.ctc.RunResponses = []*commandtest.FakeRun{{
+				Stdout: test.stdout,
+				Err:    test.runErr,
+			}}
+			test.ctc.WantRunContents = []*commandtest.RunContents{{
+				Name: "kubectl",
+				Args: append([]string{"config"}, test.args...),
+			}}
+			autocompleteTest(t, test.ctc, nil)
+		})
+	}
+}
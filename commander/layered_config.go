@@ -0,0 +1,156 @@
+package commander
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/internal/spycommander"
+)
+
+var (
+	// ConfigFlag is a repeatable flag whose values are the paths of config files
+	// to load with the `LayeredConfig` processor (e.g. `--config base.json --config local.json`).
+	ConfigFlag FlagWithType[[]string] = &configFlag{ListFlag[string]("config", FlagNoShortName, "Config file(s) from which unset argument values are loaded. Later files take precedence", 1, 0, &FileCompleter[[]string]{})}
+
+	// readConfigFile reads the contents of a config file.
+	readConfigFile = os.ReadFile
+)
+
+// configFlag is a list flag that can be provided multiple times. Each
+// occurrence appends to the flag's value (rather than overwriting it).
+type configFlag struct {
+	FlagWithType[[]string]
+}
+
+func (cf *configFlag) Options() *FlagOptions {
+	opts := cf.FlagWithType.Options()
+	opts.AllowsMultiple = true
+	return opts
+}
+
+func (cf *configFlag) Processor() command.Processor {
+	return SimpleProcessor(func(i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
+		prev := cf.GetOrDefault(d, nil)
+		if err := spycommander.ProcessOrExecute(cf.FlagWithType.Processor(), i, o, d, ed); err != nil {
+			return err
+		}
+		d.Set(cf.Name(), append(prev, cf.Get(d)...))
+		return nil
+	}, func(i *command.Input, d *command.Data) (*command.Completion, error) {
+		return processOrComplete(cf.FlagWithType.Processor(), i, d)
+	})
+}
+
+// ConfigTarget is an argument or flag whose value can be set by `LayeredConfig`.
+// Both `*Argument[T]` and `FlagWithType[T]` implement this interface.
+type ConfigTarget interface {
+	Name() string
+}
+
+// LayeredConfig returns a `command.Processor` that loads the JSON config files
+// provided via `ConfigFlag` (which must be included in a preceding
+// `FlagProcessor`). Each file must contain a JSON object and the files are
+// deep-merged in order (i.e. values in later files override values in earlier
+// files, and nested objects are merged recursively).
+//
+// Afterwards, each of the provided targets that wasn't explicitly set (by the
+// command line or otherwise) is set from the merged config value with the same
+// key. String, number, and boolean values are provided as a single argument,
+// arrays are provided as one argument per element, and objects are provided as
+// a single JSON string (see `JSONStructArg`). The resulting precedence is
+// command line values, then config values, then `Default` values.
+// Config keys that don't correspond to a target are ignored.
+func LayeredConfig(targets ...ConfigTarget) command.Processor {
+	return SimpleProcessor(func(i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
+		merged := map[string]interface{}{}
+		for _, path := range ConfigFlag.GetOrDefault(d, nil) {
+			b, err := readConfigFile(path)
+			if err != nil {
+				return o.Stderrf("[LayeredConfig] failed to read config file %q: %v\n", path, err)
+			}
+			var m map[string]interface{}
+			dec := json.NewDecoder(bytes.NewReader(b))
+			dec.UseNumber()
+			if err := dec.Decode(&m); err != nil {
+				return o.Stderrf("[LayeredConfig] failed to parse config file %q: %v\n", path, err)
+			}
+			deepMerge(merged, m)
+		}
+
+		for _, t := range targets {
+			v, ok := merged[t.Name()]
+			// Only values that weren't explicitly provided can be set from config.
+			if !ok || (d.Has(t.Name()) && !d.IsDefault(t.Name())) {
+				continue
+			}
+
+			args, err := configArgs(v)
+			if err != nil {
+				return o.Stderrf("[LayeredConfig] failed to convert config value for %q: %v\n", t.Name(), err)
+			}
+
+			p, ok := t.(command.Processor)
+			if f, isFlag := t.(FlagInterface); isFlag {
+				p, ok = f.Processor(), true
+			}
+			if !ok {
+				return o.Stderrf("[LayeredConfig] config target %q is neither a flag nor a command.Processor\n", t.Name())
+			}
+
+			configInput := command.NewInput(args, nil)
+			if err := spycommander.ProcessOrExecute(p, configInput, o, d, ed); err != nil {
+				return err
+			}
+			if !configInput.FullyProcessed() {
+				return o.Stderrf("[LayeredConfig] unprocessed config values for %q: %v\n", t.Name(), configInput.Remaining())
+			}
+		}
+		return nil
+	}, nil)
+}
+
+// deepMerge merges `from` into `into`. Nested objects are merged recursively
+// and all other values in `from` override those in `into`.
+func deepMerge(into, from map[string]interface{}) {
+	for k, v := range from {
+		fromMap, fromIsMap := v.(map[string]interface{})
+		intoMap, intoIsMap := into[k].(map[string]interface{})
+		if fromIsMap && intoIsMap {
+			deepMerge(intoMap, fromMap)
+		} else {
+			into[k] = v
+		}
+	}
+}
+
+// configArgs converts a JSON value into command line arguments.
+func configArgs(v interface{}) ([]string, error) {
+	switch tv := v.(type) {
+	case []interface{}:
+		var r []string
+		for _, e := range tv {
+			if _, ok := e.([]interface{}); ok {
+				return nil, fmt.Errorf("nested arrays are not supported")
+			}
+			s, err := configArgs(e)
+			if err != nil {
+				return nil, err
+			}
+			r = append(r, s...)
+		}
+		return r, nil
+	case map[string]interface{}:
+		b, err := json.Marshal(tv)
+		if err != nil {
+			return nil, err
+		}
+		return []string{string(b)}, nil
+	case nil:
+		return nil, fmt.Errorf("null values are not supported")
+	default:
+		return []string{fmt.Sprintf("%v", tv)}, nil
+	}
+}
@@ -0,0 +1,290 @@
+package commander
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/commandtest"
+	"github.com/leep-frog/command/internal/spycommand"
+	"github.com/leep-frog/command/internal/spycommandtest"
+	"github.com/leep-frog/command/internal/testutil"
+)
+
+type configServer struct {
+	Host string `json:"host"`
+	Port int    `json:"port"`
+}
+
+func TestLayeredConfig(t *testing.T) {
+	nameFlag := Flag[string]("name", 'n', testDesc)
+	countFlag := Flag[int]("count", 'c', testDesc)
+	tagsFlag := ListFlag[string]("tags", 't', testDesc, 1, command.UnboundedList)
+	envArg := OptionalArg[string]("env", testDesc)
+	serverArg := JSONStructArg[configServer]("server", testDesc)
+	node := SerialNodes(
+		FlagProcessor(ConfigFlag, nameFlag, countFlag, tagsFlag),
+		envArg,
+		LayeredConfig(nameFlag, countFlag, tagsFlag, envArg, serverArg),
+	)
+
+	files := map[string]string{
+		"base.json": `{
+			"name": "base",
+			"count": 1,
+			"tags": ["a", "b"],
+			"env": "dev",
+			"server": {"host": "localhost", "port": 80},
+			"unknown": true
+		}`,
+		"local.json": `{
+			"count": 2,
+			"env": "staging",
+			"server": {"port": 8080}
+		}`,
+		"invalid.json": `["not", "an", "object"]`,
+		"null.json":    `{"name": null}`,
+	}
+
+	for _, test := range []struct {
+		name      string
+		etc       *commandtest.ExecuteTestCase
+		ietc      *spycommandtest.ExecuteTestCase
+		wantReads []string
+	}{
+		{
+			name: "does nothing if no config files are provided",
+			etc: &commandtest.ExecuteTestCase{
+				Node: node,
+			},
+		},
+		{
+			name: "sets values from a single config file",
+			etc: &commandtest.ExecuteTestCase{
+				Node: node,
+				Args: []string{"--config", "base.json"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"config": []string{"base.json"},
+					"name":   "base",
+					"count":  1,
+					"tags":   []string{"a", "b"},
+					"env":    "dev",
+					"server": configServer{"localhost", 80},
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "--config"}, {Value: "base.json"}},
+				},
+			},
+			wantReads: []string{"base.json"},
+		},
+		{
+			name: "deep-merges config files with later files taking precedence",
+			etc: &commandtest.ExecuteTestCase{
+				Node: node,
+				Args: []string{"--config", "base.json", "--config", "local.json"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"config": []string{"base.json", "local.json"},
+					"name":   "base",
+					"count":  2,
+					"tags":   []string{"a", "b"},
+					"env":    "staging",
+					"server": configServer{"localhost", 8080},
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "--config"}, {Value: "base.json"}, {Value: "--config"}, {Value: "local.json"}},
+				},
+			},
+			wantReads: []string{"base.json", "local.json"},
+		},
+		{
+			name: "config file order determines precedence",
+			etc: &commandtest.ExecuteTestCase{
+				Node: node,
+				Args: []string{"--config", "local.json", "--config", "base.json"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"config": []string{"local.json", "base.json"},
+					"name":   "base",
+					"count":  1,
+					"tags":   []string{"a", "b"},
+					"env":    "dev",
+					"server": configServer{"localhost", 80},
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "--config"}, {Value: "local.json"}, {Value: "--config"}, {Value: "base.json"}},
+				},
+			},
+			wantReads: []string{"local.json", "base.json"},
+		},
+		{
+			name: "command line values take precedence over config values",
+			etc: &commandtest.ExecuteTestCase{
+				Node: node,
+				Args: []string{"prod", "--config", "base.json", "-c", "5", "--config", "local.json", "-t", "x"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"config": []string{"base.json", "local.json"},
+					"name":   "base",
+					"count":  5,
+					"tags":   []string{"x"},
+					"env":    "prod",
+					"server": configServer{"localhost", 8080},
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "prod"},
+						{Value: "--config"},
+						{Value: "base.json"},
+						{Value: "-c"},
+						{Value: "5"},
+						{Value: "--config"},
+						{Value: "local.json"},
+						{Value: "-t"},
+						{Value: "x"},
+					},
+				},
+			},
+			wantReads: []string{"base.json", "local.json"},
+		},
+		{
+			name: "fails if config file can't be read",
+			etc: &commandtest.ExecuteTestCase{
+				Node: node,
+				Args: []string{"--config", "missing.json"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"config": []string{"missing.json"},
+				}},
+				WantStderr: "[LayeredConfig] failed to read config file \"missing.json\": file not found\n",
+				WantErr:    fmt.Errorf(`[LayeredConfig] failed to read config file "missing.json": file not found`),
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "--config"}, {Value: "missing.json"}},
+				},
+			},
+			wantReads: []string{"missing.json"},
+		},
+		{
+			name: "fails if config file isn't a JSON object",
+			etc: &commandtest.ExecuteTestCase{
+				Node: node,
+				Args: []string{"--config", "invalid.json"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"config": []string{"invalid.json"},
+				}},
+				WantStderr: "[LayeredConfig] failed to parse config file \"invalid.json\": json: cannot unmarshal array into Go value of type map[string]interface {}\n",
+				WantErr:    fmt.Errorf(`[LayeredConfig] failed to parse config file "invalid.json": json: cannot unmarshal array into Go value of type map[string]interface {}`),
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "--config"}, {Value: "invalid.json"}},
+				},
+			},
+			wantReads: []string{"invalid.json"},
+		},
+		{
+			name: "fails if config value can't be converted",
+			etc: &commandtest.ExecuteTestCase{
+				Node: node,
+				Args: []string{"--config", "null.json"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"config": []string{"null.json"},
+				}},
+				WantStderr: "[LayeredConfig] failed to convert config value for \"name\": null values are not supported\n",
+				WantErr:    fmt.Errorf(`[LayeredConfig] failed to convert config value for "name": null values are not supported`),
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{{Value: "--config"}, {Value: "null.json"}},
+				},
+			},
+			wantReads: []string{"null.json"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var gotReads []string
+			testutil.StubValue(t, &readConfigFile, func(path string) ([]byte, error) {
+				gotReads = append(gotReads, path)
+				contents, ok := files[path]
+				if !ok {
+					return nil, fmt.Errorf("file not found")
+				}
+				return []byte(contents), nil
+			})
+
+			executeTest(t, test.etc, test.ietc)
+			testutil.Cmp(t, "LayeredConfig read incorrect config files", test.wantReads, gotReads)
+		})
+	}
+}
+
+func TestLayeredConfigDefaults(t *testing.T) {
+	regionFlag := Flag[string]("region", 'r', testDesc, Default("us"))
+	sizeArg := OptionalArg[int]("size", testDesc, Default(3))
+	node := SerialNodes(
+		FlagProcessor(ConfigFlag, regionFlag),
+		sizeArg,
+		LayeredConfig(regionFlag, sizeArg),
+	)
+
+	for _, test := range []struct {
+		name string
+		etc  *commandtest.ExecuteTestCase
+	}{
+		{
+			name: "uses defaults if no config is provided",
+			etc: &commandtest.ExecuteTestCase{
+				Node: node,
+				WantData: &command.Data{Values: map[string]interface{}{
+					"region": "us",
+					"size":   3,
+				}},
+			},
+		},
+		{
+			name: "config values take precedence over defaults",
+			etc: &commandtest.ExecuteTestCase{
+				Node: node,
+				Args: []string{"--config", "config.json"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"config": []string{"config.json"},
+					"region": "eu",
+					"size":   7,
+				}},
+			},
+		},
+		{
+			name: "command line values take precedence over config values",
+			etc: &commandtest.ExecuteTestCase{
+				Node: node,
+				Args: []string{"5", "--config", "config.json", "-r", "ap"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"config": []string{"config.json"},
+					"region": "ap",
+					"size":   5,
+				}},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			testutil.StubValue(t, &readConfigFile, func(path string) ([]byte, error) {
+				return []byte(`{"region": "eu", "size": 7}`), nil
+			})
+			var wantInput []*spycommand.InputArg
+			for _, a := range test.etc.Args {
+				wantInput = append(wantInput, &spycommand.InputArg{Value: a})
+			}
+			executeTest(t, test.etc, &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: wantInput,
+				},
+			})
+		})
+	}
+}
@@ -36,6 +36,11 @@ type ListBreaker[T any] struct {
 	Validators []*ValidatorOption[T]
 	// Discard is whether the culprit character should be removed
 	Discard bool
+	// IncludeInFirst is whether the culprit character should be included as the
+	// last element of the broken list (rather than being left for the next
+	// processor). This is mutually exclusive with `Discard`; if both are set,
+	// then the culprit character is discarded.
+	IncludeInFirst bool
 	// UsageFunc modifies the usage doc
 	UsageFunc func(*command.Data, *command.Usage) error
 }
@@ -44,6 +49,10 @@ func (lb *ListBreaker[T]) DiscardBreak(s string, d *command.Data) bool {
 	return lb.Discard
 }
 
+func (lb *ListBreaker[T]) IncludeBreak(s string, d *command.Data) bool {
+	return lb.IncludeInFirst
+}
+
 func (lb *ListBreaker[T]) Break(s string, d *command.Data) bool {
 	for _, v := range lb.Validators {
 		op := operator.GetOperator[T]()
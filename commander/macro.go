@@ -0,0 +1,93 @@
+package commander
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/leep-frog/command/command"
+)
+
+var (
+	// MacroArg is the `Arg` used for macro names.
+	MacroArg = Arg[string]("MACRO", "Name of the macro", MinLength[string, string](1))
+	// MacroValueArg is the `Arg` used for macro expansions. Each value is split
+	// on whitespace, so `"compile --release"` and `compile --release` result in
+	// the same macro.
+	MacroValueArg = ListArg[string]("MACRO_VALUE", "Sequence of arguments that the macro expands to", 1, command.UnboundedList)
+)
+
+// MacroNode wraps the provided node with a macro node. Macros are user-defined
+// tokens that expand to a sequence of arguments before the provided node is
+// processed (e.g. `cmd macro set build "compile --release"` makes `cmd build`
+// equivalent to `cmd compile --release`).
+//
+// Unlike `ShortcutNode`, macro expansions are not validated against the
+// provided node when they are set, and they are only expanded when provided
+// as the first argument. Macros are persisted with the `ShortcutCLI`
+// interface, so `name` should be distinct from any shortcut names used by the
+// same CLI.
+func MacroNode(name string, sc ShortcutCLI, n command.Node) command.Node {
+	return &BranchNode{
+		Branches: map[string]command.Node{
+			"macro": &BranchNode{
+				Branches: map[string]command.Node{
+					"set":    macroSetter(name, sc),
+					"delete": macroDeleter(name, sc),
+					"list":   macroLister(name, sc),
+				},
+			},
+		},
+		// This hides the `macro` branch, but the help doc for it
+		// can still be obtained by running `cmd ... macro --help`.
+		BranchUsageOrder:  []string{},
+		Default:           SerialNodes(shortcutInputTransformer(sc, name, 0), n),
+		DefaultCompletion: true,
+	}
+}
+
+func macroSetter(name string, sc ShortcutCLI) command.Node {
+	return SerialNodes(MacroArg, MacroValueArg, &ExecutorProcessor{func(output command.Output, data *command.Data) error {
+		macro := MacroArg.Get(data)
+		if macro == "macro" {
+			return output.Stderrf("cannot create macro for reserved value (%s)\n", macro)
+		}
+
+		var expansion []string
+		for _, v := range MacroValueArg.Get(data) {
+			expansion = append(expansion, strings.Fields(v)...)
+		}
+		if len(expansion) == 0 {
+			return output.Stderrf("Macro %q must expand to at least one argument\n", macro)
+		}
+		setShortcut(sc, name, macro, expansion)
+		return nil
+	}})
+}
+
+func macroDeleter(name string, sc ShortcutCLI) command.Node {
+	macrosArg := ListArg[string](MacroArg.Name(), MacroArg.Desc(), 1, command.UnboundedList, CompleterList(shortcutCompleter(name, sc)))
+	return SerialNodes(macrosArg, &ExecutorProcessor{func(output command.Output, data *command.Data) error {
+		for _, macro := range macrosArg.Get(data) {
+			if _, ok := getShortcut(sc, name, macro); !ok {
+				output.Stderrf("Macro %q does not exist\n", macro)
+				continue
+			}
+			deleteShortcut(sc, name, macro)
+		}
+		return nil
+	}})
+}
+
+func macroLister(name string, sc ShortcutCLI) command.Node {
+	return SerialNodes(&ExecutorProcessor{func(output command.Output, data *command.Data) error {
+		var r []string
+		for k, v := range getShortcutMap(sc, name) {
+			r = append(r, shortcutStr(k, v))
+		}
+		sort.Strings(r)
+		for _, v := range r {
+			output.Stdoutln(v)
+		}
+		return nil
+	}})
+}
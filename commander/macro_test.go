@@ -0,0 +1,347 @@
+package commander
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/commandtest"
+	"github.com/leep-frog/command/internal/spycommand"
+	"github.com/leep-frog/command/internal/spycommandtest"
+)
+
+func TestMacroExecute(t *testing.T) {
+	sc := &simpleShortcutCLIT{}
+	node := func() command.Node {
+		return MacroNode("bld", sc, SerialNodes(
+			FlagProcessor(
+				BoolFlag("release", 'r', testDesc),
+			),
+			Arg[string]("target", testDesc),
+			ListArg[string]("extra", testDesc, 0, command.UnboundedList),
+		))
+	}
+
+	for _, test := range []struct {
+		name   string
+		am     map[string]map[string][]string
+		etc    *commandtest.ExecuteTestCase
+		ietc   *spycommandtest.ExecuteTestCase
+		wantAC *simpleShortcutCLIT
+	}{
+		{
+			name: "sets a macro from a quoted value",
+			etc: &commandtest.ExecuteTestCase{
+				Node: node(),
+				Args: []string{"macro", "set", "build", "compile --release"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"MACRO":       "build",
+					"MACRO_VALUE": []string{"compile --release"},
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "macro"},
+						{Value: "set"},
+						{Value: "build"},
+						{Value: "compile --release"},
+					},
+				},
+			},
+			wantAC: &simpleShortcutCLIT{
+				changed: true,
+				mp: map[string]map[string][]string{
+					"bld": {
+						"build": {"compile", "--release"},
+					},
+				},
+			},
+		},
+		{
+			name: "sets a macro from multiple values",
+			etc: &commandtest.ExecuteTestCase{
+				Node: node(),
+				Args: []string{"macro", "set", "build", "compile", "--release"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"MACRO":       "build",
+					"MACRO_VALUE": []string{"compile", "--release"},
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "macro"},
+						{Value: "set"},
+						{Value: "build"},
+						{Value: "compile"},
+						{Value: "--release"},
+					},
+				},
+			},
+			wantAC: &simpleShortcutCLIT{
+				changed: true,
+				mp: map[string]map[string][]string{
+					"bld": {
+						"build": {"compile", "--release"},
+					},
+				},
+			},
+		},
+		{
+			name: "overwrites an existing macro",
+			am: map[string]map[string][]string{
+				"bld": {
+					"build": {"compile"},
+				},
+			},
+			etc: &commandtest.ExecuteTestCase{
+				Node: node(),
+				Args: []string{"macro", "set", "build", "compile --release"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"MACRO":       "build",
+					"MACRO_VALUE": []string{"compile --release"},
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "macro"},
+						{Value: "set"},
+						{Value: "build"},
+						{Value: "compile --release"},
+					},
+				},
+			},
+			wantAC: &simpleShortcutCLIT{
+				changed: true,
+				mp: map[string]map[string][]string{
+					"bld": {
+						"build": {"compile", "--release"},
+					},
+				},
+			},
+		},
+		{
+			name: "fails to set a macro with an empty expansion",
+			etc: &commandtest.ExecuteTestCase{
+				Node: node(),
+				Args: []string{"macro", "set", "build", " "},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"MACRO":       "build",
+					"MACRO_VALUE": []string{" "},
+				}},
+				WantStderr: "Macro \"build\" must expand to at least one argument\n",
+				WantErr:    fmt.Errorf(`Macro "build" must expand to at least one argument`),
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "macro"},
+						{Value: "set"},
+						{Value: "build"},
+						{Value: " "},
+					},
+				},
+			},
+		},
+		{
+			name: "fails to set a macro for reserved value",
+			etc: &commandtest.ExecuteTestCase{
+				Node: node(),
+				Args: []string{"macro", "set", "macro", "compile"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"MACRO":       "macro",
+					"MACRO_VALUE": []string{"compile"},
+				}},
+				WantStderr: "cannot create macro for reserved value (macro)\n",
+				WantErr:    fmt.Errorf("cannot create macro for reserved value (macro)"),
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "macro"},
+						{Value: "set"},
+						{Value: "macro"},
+						{Value: "compile"},
+					},
+				},
+			},
+		},
+		{
+			name: "expands a macro during execution",
+			am: map[string]map[string][]string{
+				"bld": {
+					"build": {"compile", "--release"},
+				},
+			},
+			etc: &commandtest.ExecuteTestCase{
+				Node: node(),
+				Args: []string{"build", "more", "args"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"release": true,
+					"target":  "compile",
+					"extra":   []string{"more", "args"},
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "compile"},
+						{Value: "--release"},
+						{Value: "more"},
+						{Value: "args"},
+					},
+				},
+			},
+		},
+		{
+			name: "only expands the first argument",
+			am: map[string]map[string][]string{
+				"bld": {
+					"build": {"compile", "--release"},
+				},
+			},
+			etc: &commandtest.ExecuteTestCase{
+				Node: node(),
+				Args: []string{"lint", "build"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"target": "lint",
+					"extra":  []string{"build"},
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "lint"},
+						{Value: "build"},
+					},
+				},
+			},
+		},
+		{
+			name: "lists macros",
+			am: map[string]map[string][]string{
+				"bld": {
+					"build": {"compile", "--release"},
+					"all":   {"everything"},
+				},
+			},
+			etc: &commandtest.ExecuteTestCase{
+				Node:       node(),
+				Args:       []string{"macro", "list"},
+				WantStdout: "all: everything\nbuild: compile --release\n",
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "macro"},
+						{Value: "list"},
+					},
+				},
+			},
+		},
+		{
+			name: "deletes macros",
+			am: map[string]map[string][]string{
+				"bld": {
+					"build": {"compile", "--release"},
+					"all":   {"everything"},
+				},
+			},
+			etc: &commandtest.ExecuteTestCase{
+				Node: node(),
+				Args: []string{"macro", "delete", "build", "other"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"MACRO": []string{"build", "other"},
+				}},
+				WantStderr: "Macro \"other\" does not exist\n",
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "macro"},
+						{Value: "delete"},
+						{Value: "build"},
+						{Value: "other"},
+					},
+				},
+			},
+			wantAC: &simpleShortcutCLIT{
+				changed: true,
+				mp: map[string]map[string][]string{
+					"bld": {
+						"all": {"everything"},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			sc.changed = false
+			sc.mp = test.am
+
+			executeTest(t, test.etc, test.ietc)
+			changeTest(t, test.wantAC, sc, cmp.AllowUnexported(simpleShortcutCLIT{}))
+		})
+	}
+}
+
+func TestMacroComplete(t *testing.T) {
+	sc := &simpleShortcutCLIT{}
+	for _, test := range []struct {
+		name string
+		mp   map[string]map[string][]string
+		ctc  *commandtest.CompleteTestCase
+	}{
+		{
+			name: "completes after macro expansion",
+			mp: map[string]map[string][]string{
+				"bld": {
+					"build": {"compile"},
+				},
+			},
+			ctc: &commandtest.CompleteTestCase{
+				Node: MacroNode("bld", sc, SerialNodes(
+					Arg[string]("target", testDesc),
+					Arg[string]("mode", testDesc, SimpleCompleter[string]("debug", "release")),
+				)),
+				Args: "cmd build ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"debug", "release"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"target": "compile",
+					"mode":   "",
+				}},
+			},
+		},
+		{
+			name: "completes macro names for delete",
+			mp: map[string]map[string][]string{
+				"bld": {
+					"build": {"compile"},
+					"all":   {"everything"},
+				},
+			},
+			ctc: &commandtest.CompleteTestCase{
+				Node: MacroNode("bld", sc, SerialNodes(
+					Arg[string]("target", testDesc),
+				)),
+				Args: "cmd macro delete ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"all", "build"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"MACRO": []string{""},
+				}},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			sc.mp = test.mp
+			autocompleteTest(t, test.ctc, nil)
+		})
+	}
+}
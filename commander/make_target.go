@@ -0,0 +1,75 @@
+package commander
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/leep-frog/command/command"
+)
+
+var (
+	// readMakefile reads the lines of a Makefile.
+	readMakefile = ReadFile
+)
+
+// MakeTargetCompleter is a completer that suggests the targets defined in
+// the provided Makefile. Pattern rules (e.g. `%.o: %.c`), special targets
+// (e.g. `.PHONY`), and targets defined with variable references are not
+// suggested.
+func MakeTargetCompleter[T any](makefilePath string) Completer[T] {
+	return CompleterFromFunc(func(T, *command.Data) (*command.Completion, error) {
+		lines, err := readMakefile(makefilePath)
+		if err != nil {
+			return nil, fmt.Errorf("[MakeTargetCompleter] failed to read makefile %q: %v", makefilePath, err)
+		}
+		return &command.Completion{
+			Suggestions: parseMakeTargets(lines),
+		}, nil
+	})
+}
+
+// parseMakeTargets returns the (non-special) targets defined in the
+// provided Makefile lines.
+func parseMakeTargets(lines []string) []string {
+	var targets []string
+	seen := map[string]bool{}
+	inDefine := false
+	for _, line := range lines {
+		// Recipe lines start with a tab.
+		if strings.HasPrefix(line, "\t") {
+			continue
+		}
+		if c, _, ok := strings.Cut(line, "#"); ok {
+			line = c
+		}
+
+		// Skip multi-line variable definitions.
+		trimmed := strings.TrimSpace(line)
+		if inDefine {
+			inDefine = trimmed != "endef"
+			continue
+		}
+		if strings.HasPrefix(trimmed, "define ") || trimmed == "define" {
+			inDefine = true
+			continue
+		}
+
+		idx := strings.Index(line, ":")
+		if idx < 0 {
+			continue
+		}
+		// Ignore variable assignments (e.g. `A = b:c`, `A := b`, and `A ::= b`).
+		if strings.Contains(line[:idx], "=") || strings.HasPrefix(strings.TrimLeft(line[idx:], ":"), "=") {
+			continue
+		}
+
+		for _, target := range strings.Fields(line[:idx]) {
+			if seen[target] || strings.HasPrefix(target, ".") || strings.ContainsAny(target, "%$") {
+				continue
+			}
+			seen[target] = true
+			targets = append(targets, target)
+		}
+	}
+	return targets
+}
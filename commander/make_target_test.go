@@ -0,0 +1,132 @@
+package commander
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/commandtest"
+	"github.com/leep-frog/command/internal/testutil"
+)
+
+func TestMakeTargetCompleter(t *testing.T) {
+	makefile := []string{
+		"# Build everything",
+		"CC := gcc",
+		"FLAGS = -o out:file",
+		"OBJ ::= main.o",
+		"SRC_DIR ?= src",
+		"",
+		".PHONY: all build test clean",
+		".DEFAULT_GOAL := all",
+		"",
+		"all: build test # the default",
+		"",
+		"build: main.o util.o",
+		"\t$(CC) $(FLAGS) main.o util.o",
+		"",
+		"%.o: %.c",
+		"\t$(CC) -c $<",
+		"",
+		".c.o:",
+		"\t$(CC) -c $<",
+		"",
+		"$(OBJ): header.h",
+		"",
+		"test: build",
+		"\t./run_tests.sh",
+		"",
+		"test-unit test-integration: build",
+		"\t./run_tests.sh $@",
+		"",
+		"clean::",
+		"\trm -f *.o",
+		"",
+		"clean:: extra",
+		"",
+		"build: FLAGS = -g",
+		"",
+		"define RECIPE",
+		"fake-target: in-define",
+		"endef",
+		"",
+		"deploy: ; ./deploy.sh",
+	}
+
+	for _, test := range []struct {
+		name      string
+		lines     []string
+		err       error
+		ctc       *commandtest.CompleteTestCase
+		wantPaths []string
+	}{
+		{
+			name:  "suggests all targets",
+			lines: makefile,
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(Arg[string]("TARGET", testDesc, MakeTargetCompleter[string]("Makefile"))),
+				Args: "cmd ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"all", "build", "clean", "deploy", "test", "test-integration", "test-unit"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"TARGET": "",
+				}},
+			},
+			wantPaths: []string{"Makefile"},
+		},
+		{
+			name:  "suggests targets with prefix",
+			lines: makefile,
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(Arg[string]("TARGET", testDesc, MakeTargetCompleter[string]("Makefile"))),
+				Args: "cmd test-",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"test-integration", "test-unit"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"TARGET": "test-",
+				}},
+			},
+			wantPaths: []string{"Makefile"},
+		},
+		{
+			name:  "suggests targets for list arguments",
+			lines: makefile,
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(ListArg[string]("TARGETS", testDesc, 1, 2, MakeTargetCompleter[[]string]("some/dir/Makefile"))),
+				Args: "cmd clean b",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"build"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"TARGETS": []string{"clean", "b"},
+				}},
+			},
+			wantPaths: []string{"some/dir/Makefile"},
+		},
+		{
+			name: "fails if makefile can't be read",
+			err:  fmt.Errorf("oops"),
+			ctc: &commandtest.CompleteTestCase{
+				Node:    SerialNodes(Arg[string]("TARGET", testDesc, MakeTargetCompleter[string]("Makefile"))),
+				Args:    "cmd ",
+				WantErr: fmt.Errorf(`[MakeTargetCompleter] failed to read makefile "Makefile": oops`),
+				WantData: &command.Data{Values: map[string]interface{}{
+					"TARGET": "",
+				}},
+			},
+			wantPaths: []string{"Makefile"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var gotPaths []string
+			testutil.StubValue(t, &readMakefile, func(path string) ([]string, error) {
+				gotPaths = append(gotPaths, path)
+				return test.lines, test.err
+			})
+			autocompleteTest(t, test.ctc, nil)
+			testutil.Cmp(t, "MakeTargetCompleter read incorrect files", test.wantPaths, gotPaths)
+		})
+	}
+}
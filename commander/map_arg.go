@@ -16,15 +16,21 @@ func MapArg[K constraints.Ordered, V any](name, desc string, m map[K]V, allowMis
 
 // MapFlag returns a `Flag` that converts an input key into it's value.
 func MapFlag[K constraints.Ordered, V any](name string, shortName rune, desc string, m map[K]V, allowMissing bool) *MapFlargument[K, V] {
+	// Keys are suggested in key order (e.g. numerically for number keys).
+	sortedKeys := maps.Keys(m)
+	slices.Sort(sortedKeys)
 	var keys []string
-	for _, k := range maps.Keys(m) {
+	for _, k := range sortedKeys {
 		keys = append(keys, fmt.Sprintf("%v", k))
 	}
 	ma := &MapFlargument[K, V]{
 		shortName: shortName,
 	}
 	opts := []ArgumentOption[K]{
-		SimpleCompleter[K](keys...),
+		AsCompleter[K](&command.Completion{
+			Suggestions:   keys,
+			PreserveOrder: true,
+		}),
 		&CustomSetter[K]{F: func(key K, d *command.Data) {
 			v, ok := m[key]
 			d.Set(name, v)
@@ -95,6 +101,14 @@ func (man *MapFlargument[K, V]) Options() *FlagOptions {
 	return &FlagOptions{}
 }
 
+// DisableCompletion removes the automatic completion of map keys. Although
+// chaining isn't conventional in go, it is done here because map args are
+// usually declared as package-level variables.
+func (man *MapFlargument[K, V]) DisableCompletion() *MapFlargument[K, V] {
+	man.Argument.opt.completer = nil
+	return man
+}
+
 // Hit returns whether the key provided was actually present in the map.
 func (man *MapFlargument[K, V]) Hit() bool {
 	return man.hit
@@ -0,0 +1,41 @@
+package commander
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/internal/stubs"
+)
+
+// MetricsCollector returns a `command.Processor` that prints a summary of all
+// metrics recorded (via `command.Data.RecordMetric`) once execution has
+// completed, if the provided flag is set. The summary also includes the total
+// time elapsed since this processor was run, so it should be placed at the
+// root of the graph. The clock can be stubbed with `commandtest.StubClock`.
+//
+// Note: the flag can be processed (e.g. by a `FlagProcessor`) anywhere in
+// the graph, as it isn't checked until execution has completed.
+func MetricsCollector(f FlagWithType[bool]) command.Processor {
+	return SimpleProcessor(func(i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
+		start := stubs.TimeNow()
+		ed.Cleanup = append(ed.Cleanup, func() error {
+			if !f.GetOrDefault(d, false) {
+				return nil
+			}
+
+			var sb strings.Builder
+			tw := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(tw, "METRIC\tDURATION")
+			for _, m := range d.Metrics() {
+				fmt.Fprintf(tw, "%s\t%v\n", m.Name, m.Duration)
+			}
+			fmt.Fprintf(tw, "total\t%v\n", stubs.TimeNow().Sub(start))
+			tw.Flush()
+			o.Stdout(sb.String())
+			return nil
+		})
+		return nil
+	}, nil)
+}
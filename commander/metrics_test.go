@@ -0,0 +1,107 @@
+package commander
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/commandtest"
+	"github.com/leep-frog/command/internal/spycommand"
+	"github.com/leep-frog/command/internal/spycommandtest"
+	"github.com/leep-frog/command/internal/stubs"
+)
+
+func TestMetricsCollector(t *testing.T) {
+	metricsFlag := BoolFlag("metrics", 'm', testDesc)
+	node := func(err error) command.Node {
+		return SerialNodes(
+			MetricsCollector(metricsFlag),
+			FlagProcessor(metricsFlag),
+			SimpleProcessor(func(i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
+				d.RecordMetric("load", 1500*time.Millisecond)
+				return nil
+			}, nil),
+			SimpleProcessor(func(i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
+				d.RecordMetric("process-items", 250*time.Millisecond)
+				o.Stdoutln("done")
+				return o.Err(err)
+			}, nil),
+		)
+	}
+
+	for _, test := range []struct {
+		name string
+		etc  *commandtest.ExecuteTestCase
+		ietc *spycommandtest.ExecuteTestCase
+	}{
+		{
+			name: "doesn't print metrics if flag isn't set",
+			etc: &commandtest.ExecuteTestCase{
+				Node:       node(nil),
+				WantStdout: "done\n",
+			},
+		},
+		{
+			name: "prints metrics summary if flag is set",
+			etc: &commandtest.ExecuteTestCase{
+				Node: node(nil),
+				Args: []string{"--metrics"},
+				WantStdout: "done\n" + `METRIC         DURATION
+load           1.5s
+process-items  250ms
+total          2s
+`,
+				WantData: &command.Data{
+					Values: map[string]interface{}{
+						"metrics": true,
+					},
+				},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "--metrics"},
+					},
+				},
+			},
+		},
+		{
+			name: "prints metrics summary if execution fails",
+			etc: &commandtest.ExecuteTestCase{
+				Node: node(fmt.Errorf("oops")),
+				Args: []string{"-m"},
+				WantStdout: "done\n" + `METRIC         DURATION
+load           1.5s
+process-items  250ms
+total          2s
+`,
+				WantStderr: "oops\n",
+				WantErr:    fmt.Errorf("oops"),
+				WantData: &command.Data{
+					Values: map[string]interface{}{
+						"metrics": true,
+					},
+				},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "-m"},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			start := time.Date(2023, 4, 5, 6, 7, 8, 0, time.UTC)
+			times := []time.Time{start, start.Add(2 * time.Second)}
+			stubs.StubClock(t, func() time.Time {
+				r := times[0]
+				times = times[1:]
+				return r
+			}, nil)
+			executeTest(t, test.etc, test.ietc)
+		})
+	}
+}
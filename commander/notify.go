@@ -0,0 +1,75 @@
+package commander
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/leep-frog/command/command"
+)
+
+const (
+	// NotifyEnvVar is the environment variable that enables `NotifyOnComplete`
+	// notifications (when set to any non-empty value).
+	NotifyEnvVar = "COMMAND_CLI_NOTIFY"
+)
+
+var (
+	// NotifyFlag is the flag that enables `NotifyOnComplete` notifications.
+	NotifyFlag = BoolFlag("notify", FlagNoShortName, "Send a notification when the command completes")
+)
+
+// NotifyOnComplete returns a `command.Processor` that sends a desktop
+// notification once execution has completed (including after any
+// `command.ExecuteData.Executor` functions have run). If a desktop notification
+// can't be sent, then a terminal bell (`\a`) is written to stderr instead.
+// Notifications are only sent if the `NotifyFlag` is set or if the
+// `NotifyEnvVar` environment variable is set.
+//
+// Note: the `NotifyFlag` can be processed (e.g. by a `FlagProcessor`) anywhere
+// in the graph, as it isn't checked until execution has completed.
+func NotifyOnComplete() command.Processor {
+	return SimpleProcessor(func(i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
+		ed.Cleanup = append(ed.Cleanup, func() error {
+			if v, _ := command.OSLookupEnv(NotifyEnvVar); v == "" && !NotifyFlag.GetOrDefault(d, false) {
+				return nil
+			}
+
+			if err := notify("Command completed", "Your command has finished running"); err != nil {
+				o.Stderr("\a")
+			}
+			return nil
+		})
+		return nil
+	}, nil)
+}
+
+// notify sends a desktop notification using the notification system of the
+// current OS.
+func notify(title, message string) error {
+	sc, err := notifyCommand(title, message)
+	if err != nil {
+		return err
+	}
+	_, err = sc.Run(nil, &command.Data{})
+	return err
+}
+
+// notifyCommand returns the `ShellCommand` that sends a desktop notification
+// on the current OS.
+func notifyCommand(title, message string) (*ShellCommand[string], error) {
+	switch runtime.GOOS {
+	case "darwin":
+		return &ShellCommand[string]{
+			CommandName: "osascript",
+			Args:        []string{"-e", fmt.Sprintf("display notification %q with title %q", message, title)},
+			HideStderr:  true,
+		}, nil
+	case "windows":
+		return nil, fmt.Errorf("desktop notifications are not supported on windows")
+	}
+	return &ShellCommand[string]{
+		CommandName: "notify-send",
+		Args:        []string{title, message},
+		HideStderr:  true,
+	}, nil
+}
@@ -0,0 +1,124 @@
+package commander
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/commandtest"
+	"github.com/leep-frog/command/internal/spycommand"
+	"github.com/leep-frog/command/internal/spycommandtest"
+)
+
+func TestNotifyOnComplete(t *testing.T) {
+	n := SerialNodes(
+		NotifyOnComplete(),
+		FlagProcessor(NotifyFlag),
+		&ExecutorProcessor{func(o command.Output, d *command.Data) error {
+			o.Stdoutln("done")
+			return nil
+		}},
+	)
+	notifyInput := &spycommandtest.ExecuteTestCase{
+		WantInput: &spycommandtest.SpyInput{
+			Args: []*spycommand.InputArg{
+				{Value: "--notify"},
+			},
+		},
+	}
+
+	for _, test := range []struct {
+		name string
+		etc  *commandtest.ExecuteTestCase
+		ietc *spycommandtest.ExecuteTestCase
+		// notify is whether or not a notification should be sent.
+		notify bool
+		// notifyErr is the error returned by the notification command.
+		notifyErr error
+	}{
+		{
+			name: "does nothing if not enabled",
+			etc: &commandtest.ExecuteTestCase{
+				Node:       n,
+				WantStdout: "done\n",
+			},
+		},
+		{
+			name:      "emits bell if enabled by flag and notification fails",
+			notifyErr: fmt.Errorf("no notifications here"),
+			etc: &commandtest.ExecuteTestCase{
+				Node:       n,
+				Args:       []string{"--notify"},
+				WantStdout: "done\n",
+				WantStderr: "\a",
+				WantData: &command.Data{Values: map[string]interface{}{
+					"notify": true,
+				}},
+			},
+			ietc:   notifyInput,
+			notify: true,
+		},
+		{
+			name:      "emits bell if enabled by env var and notification fails",
+			notifyErr: fmt.Errorf("no notifications here"),
+			etc: &commandtest.ExecuteTestCase{
+				Node: n,
+				Env: map[string]string{
+					NotifyEnvVar: "1",
+				},
+				WantStdout: "done\n",
+				WantStderr: "\a",
+			},
+			notify: true,
+		},
+		{
+			name: "sends desktop notification instead of bell if available",
+			etc: &commandtest.ExecuteTestCase{
+				Node:       n,
+				Args:       []string{"--notify"},
+				WantStdout: "done\n",
+				WantData: &command.Data{Values: map[string]interface{}{
+					"notify": true,
+				}},
+			},
+			ietc:   notifyInput,
+			notify: true,
+		},
+		{
+			name:      "notifies even if execution fails",
+			notifyErr: fmt.Errorf("no notifications here"),
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					NotifyOnComplete(),
+					FlagProcessor(NotifyFlag),
+					&ExecutorProcessor{func(o command.Output, d *command.Data) error {
+						return o.Stderrln("oops")
+					}},
+				),
+				Args:       []string{"--notify"},
+				WantStderr: "oops\n\a",
+				WantErr:    fmt.Errorf("oops"),
+				WantData: &command.Data{Values: map[string]interface{}{
+					"notify": true,
+				}},
+			},
+			ietc:   notifyInput,
+			notify: true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if test.notify {
+				sc, err := notifyCommand("Command completed", "Your command has finished running")
+				if err != nil {
+					t.Skipf("notifications aren't supported on this OS: %v", err)
+				}
+				test.etc.RunResponses = []*commandtest.FakeRun{{Err: test.notifyErr}}
+				test.etc.WantRunContents = []*commandtest.RunContents{{
+					Name: sc.CommandName,
+					Args: sc.Args,
+				}}
+			}
+			executeTest(t, test.etc, test.ietc)
+		})
+	}
+}
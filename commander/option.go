@@ -1,6 +1,10 @@
 package commander
 
-import "github.com/leep-frog/command/command"
+import (
+	"os"
+
+	"github.com/leep-frog/command/command"
+)
 
 // ArgumentOption is an interface for modifying `Argument` objects.
 type ArgumentOption[T any] interface {
@@ -13,10 +17,21 @@ type argumentOption[T any] struct {
 	transformers []*Transformer[T]
 	shortcut     *shortcutOpt[T]
 	customSet    *CustomSetter[T]
+	// namedSet is an internal alternative to `customSet` for options that
+	// need to know the argument's name when setting data.
+	namedSet     func(string, T, *command.Data)
 	_default     *defaultArgumentOption[T]
 	breakers     []*ListBreaker[T]
 	complexecute *Complexecute[T]
 	hideUsage    bool
+	required     bool
+	countHint    bool
+	emptyUnset   bool
+	fileMode     *os.FileMode
+	lastWins     bool
+	// executors are run after validation, but only when the command is
+	// actually being executed (i.e. not during completion or usage).
+	executors []func(T, *command.Data) error
 }
 
 func (ao *argumentOption[T]) inputValidators() []command.InputBreaker {
@@ -85,6 +100,13 @@ type Complexecute[T any] struct {
 	// argument doesn't exactly match one of the completion values and if the number
 	// of completion suggestions isn't exactly one.
 	Lenient bool
+	// Prompt indicates whether the user should be prompted to select one of the
+	// suggestions (rather than failing) when multiple suggestions are returned.
+	// The prompt is only displayed during execution (not when rendering usage)
+	// and if stdin and stdout are terminals (see `commandtest.StubTerminal` and
+	// `commandtest.StubStdin` for testing). This is useful for `FileArgument`
+	// values that match multiple files.
+	Prompt bool
 }
 
 func (c *Complexecute[T]) modifyArgumentOption(ao *argumentOption[T]) {
@@ -140,3 +162,64 @@ type hiddenArg[T any] struct{}
 func (ha *hiddenArg[T]) modifyArgumentOption(ao *argumentOption[T]) {
 	ao.hideUsage = true
 }
+
+// Required is an `ArgumentOption` that makes a flag required. A usage error is
+// returned if a required flag isn't provided. A required flag can't also have
+// a `Default` value. This has no effect on positional arguments (use the
+// `minN` argument count instead).
+func Required[T any]() ArgumentOption[T] {
+	return &requiredOption[T]{}
+}
+
+type requiredOption[T any] struct{}
+
+func (ro *requiredOption[T]) modifyArgumentOption(ao *argumentOption[T]) {
+	ao.required = true
+}
+
+// LastWins is an `ArgumentOption` that allows a flag to be provided multiple
+// times, in which case the last provided value is used (by default, providing
+// a flag more than once results in an error). This is intended for scalar
+// flags (e.g. `--opt a --opt b` results in `b`) and has no effect on
+// positional arguments.
+func LastWins[T any]() ArgumentOption[T] {
+	return &lastWins[T]{}
+}
+
+type lastWins[T any] struct{}
+
+func (lw *lastWins[T]) modifyArgumentOption(ao *argumentOption[T]) {
+	ao.lastWins = true
+}
+
+// TreatEmptyAsUnset is an `ArgumentOption` that ignores empty string
+// arguments (which are commonly produced by shell expansions of unset
+// variables). An optional argument (or flag) whose values are all empty is
+// treated as if it wasn't provided at all (so its `Default` value, if any, is
+// used). Empty values are also dropped from list arguments. When completing,
+// empty values are ignored in the same way (except for the value being
+// completed).
+func TreatEmptyAsUnset[T any]() ArgumentOption[T] {
+	return &treatEmptyAsUnset[T]{}
+}
+
+type treatEmptyAsUnset[T any] struct{}
+
+func (teau *treatEmptyAsUnset[T]) modifyArgumentOption(ao *argumentOption[T]) {
+	ao.emptyUnset = true
+}
+
+// RemainingCountHint is an `ArgumentOption` that, when completing a list
+// argument that hasn't received its minimum number of values, includes a
+// non-insertable hint (e.g. `(2 more)`) indicating how many more values
+// (including the one being completed) are required. See `command.Completion.Hint`
+// for when the hint is displayed.
+func RemainingCountHint[T any]() ArgumentOption[T] {
+	return &remainingCountHint[T]{}
+}
+
+type remainingCountHint[T any] struct{}
+
+func (rch *remainingCountHint[T]) modifyArgumentOption(ao *argumentOption[T]) {
+	ao.countHint = true
+}
@@ -2,6 +2,7 @@ package commander
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/leep-frog/command/command"
 )
@@ -70,6 +71,48 @@ func (ea *EnvArg) run(d *command.Data) error {
 	return nil
 }
 
+// EnvRefArg returns an `Argument` that accepts the *name* of an environment
+// variable and resolves it to that environment variable's value (which is what
+// is stored in `command.Data`). It results in an error if the environment
+// variable is not set (unless `AllowUnset` is called on the returned argument).
+// Environment variable names are suggested for completion.
+func EnvRefArg(name, desc string, opts ...ArgumentOption[string]) *envRefArg {
+	era := &envRefArg{}
+	era.Argument = Arg[string](name, desc, append([]ArgumentOption[string]{
+		CompleterFromFunc(func(string, *command.Data) (*command.Completion, error) {
+			var names []string
+			for _, e := range command.OSEnviron() {
+				if k, _, ok := strings.Cut(e, "="); ok && k != "" {
+					names = append(names, k)
+				}
+			}
+			return &command.Completion{
+				Suggestions: names,
+			}, nil
+		}),
+		&Transformer[string]{F: func(envVar string, d *command.Data) (string, error) {
+			v, ok := command.OSLookupEnv(envVar)
+			if !ok && !era.allowUnset {
+				return "", fmt.Errorf("[EnvRefArg] environment variable %q is not set", envVar)
+			}
+			return v, nil
+		}},
+	}, opts...)...)
+	return era
+}
+
+type envRefArg struct {
+	*Argument[string]
+	allowUnset bool
+}
+
+// AllowUnset resolves unset environment variables to an empty string (rather
+// than failing).
+func (era *envRefArg) AllowUnset() *envRefArg {
+	era.allowUnset = true
+	return era
+}
+
 // SetEnvVarProcessor returns a `command.Processor` that sets the environment variable to the provided value.
 func SetEnvVarProcessor(envVar, value string) command.Processor {
 	return SimpleProcessor(func(i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
@@ -0,0 +1,32 @@
+package commander
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/internal/stubs"
+)
+
+// PipedDefault returns a `command.Processor` that, if the `command.Data` value
+// for `name` isn't set and stdin is piped (i.e. not a terminal), reads a single
+// line from stdin and stores it as the (string) value for `name`. Otherwise,
+// the value is left unset. This should be placed after the (optional) argument
+// it populates, which enables `echo value | mycli` patterns.
+func PipedDefault(name string) command.Processor {
+	return SimpleProcessor(func(i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
+		if d.Has(name) || stubs.StdinIsTerminal() {
+			return nil
+		}
+
+		text, err := bufio.NewReader(stubs.Stdin).ReadString('\n')
+		if err != nil && err != io.EOF {
+			return o.Annotatef(err, "[PipedDefault] failed to read %q from stdin", name)
+		}
+		if text = strings.TrimRight(text, "\r\n"); text != "" {
+			d.Set(name, text)
+		}
+		return nil
+	}, nil)
+}
@@ -0,0 +1,118 @@
+package commander
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/commandtest"
+	"github.com/leep-frog/command/internal/spycommand"
+	"github.com/leep-frog/command/internal/spycommandtest"
+	"github.com/leep-frog/command/internal/stubs"
+)
+
+type errReader struct{}
+
+func (er *errReader) Read([]byte) (int, error) {
+	return 0, fmt.Errorf("broken pipe")
+}
+
+func TestPipedDefault(t *testing.T) {
+	nameArg := OptionalArg[string]("NAME", testDesc)
+	n := SerialNodes(
+		nameArg,
+		PipedDefault("NAME"),
+		&ExecutorProcessor{func(o command.Output, d *command.Data) error {
+			o.Stdoutf("name: %q (provided: %v)\n", nameArg.Get(d), nameArg.Provided(d))
+			return nil
+		}},
+	)
+
+	for _, test := range []struct {
+		name     string
+		terminal bool
+		stdin    string
+		reader   *errReader
+		etc      *commandtest.ExecuteTestCase
+		ietc     *spycommandtest.ExecuteTestCase
+	}{
+		{
+			name:  "reads value from piped stdin",
+			stdin: "alice\nbob\n",
+			etc: &commandtest.ExecuteTestCase{
+				Node:       n,
+				WantStdout: "name: \"alice\" (provided: true)\n",
+				WantData: &command.Data{Values: map[string]interface{}{
+					"NAME": "alice",
+				}},
+			},
+		},
+		{
+			name:  "reads value without trailing newline",
+			stdin: "alice",
+			etc: &commandtest.ExecuteTestCase{
+				Node:       n,
+				WantStdout: "name: \"alice\" (provided: true)\n",
+				WantData: &command.Data{Values: map[string]interface{}{
+					"NAME": "alice",
+				}},
+			},
+		},
+		{
+			name:  "leaves value unset if piped stdin is empty",
+			stdin: "",
+			etc: &commandtest.ExecuteTestCase{
+				Node:       n,
+				WantStdout: "name: \"\" (provided: false)\n",
+			},
+		},
+		{
+			name:  "doesn't read stdin if value is provided",
+			stdin: "alice\n",
+			etc: &commandtest.ExecuteTestCase{
+				Node:       n,
+				Args:       []string{"bob"},
+				WantStdout: "name: \"bob\" (provided: true)\n",
+				WantData: &command.Data{Values: map[string]interface{}{
+					"NAME": "bob",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "bob"},
+					},
+				},
+			},
+		},
+		{
+			name:     "leaves value unset if stdin is a terminal",
+			terminal: true,
+			stdin:    "alice\n",
+			etc: &commandtest.ExecuteTestCase{
+				Node:       n,
+				WantStdout: "name: \"\" (provided: false)\n",
+			},
+		},
+		{
+			name:   "fails if stdin can't be read",
+			reader: &errReader{},
+			etc: &commandtest.ExecuteTestCase{
+				Node:       n,
+				WantStderr: "[PipedDefault] failed to read \"NAME\" from stdin: broken pipe\n",
+				WantErr:    fmt.Errorf(`[PipedDefault] failed to read "NAME" from stdin: broken pipe`),
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			stubs.StubTerminal(t, test.terminal, test.terminal)
+			if test.reader != nil {
+				stubs.StubStdin(t, test.reader)
+			} else {
+				stubs.StubStdin(t, strings.NewReader(test.stdin))
+			}
+			executeTest(t, test.etc, test.ietc)
+		})
+	}
+}
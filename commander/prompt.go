@@ -2,14 +2,18 @@ package commander
 
 import (
 	"bufio"
-	"os"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
 
 	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/internal/stubs"
 )
 
 // Prompt prompts the user for input.
 func Prompt(output command.Output, question string) chan string {
-	reader := bufio.NewReader(os.Stdin)
+	reader := bufio.NewReader(stubs.Stdin)
 	output.Stdoutln(question)
 	c := make(chan string)
 
@@ -26,3 +30,25 @@ func Prompt(output command.Output, question string) chan string {
 
 	return c
 }
+
+// promptSelection lists the provided options and prompts the user to select
+// one of them (by number).
+func promptSelection(output command.Output, question string, options []string) (string, error) {
+	output.Stdoutln(question)
+	for i, opt := range options {
+		output.Stdoutf("  %d) %s\n", i+1, opt)
+	}
+	output.Stdoutf("Select an option [1-%d]: ", len(options))
+
+	text, err := bufio.NewReader(stubs.Stdin).ReadString('\n')
+	if err != nil && (err != io.EOF || text == "") {
+		return "", fmt.Errorf("failed to read selection: %v", err)
+	}
+
+	selection := strings.TrimSpace(text)
+	idx, err := strconv.Atoi(selection)
+	if err != nil || idx < 1 || idx > len(options) {
+		return "", fmt.Errorf("invalid selection %q", selection)
+	}
+	return options[idx-1], nil
+}
@@ -0,0 +1,175 @@
+package commander
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/commandtest"
+	"github.com/leep-frog/command/internal/spycommand"
+	"github.com/leep-frog/command/internal/spycommandtest"
+	"github.com/leep-frog/command/internal/stubs"
+	"github.com/leep-frog/command/internal/testutil"
+)
+
+func TestComplexecutePrompt(t *testing.T) {
+	selectionPrompt := strings.Join([]string{
+		`Multiple matches for "s":`,
+		"  1) " + filepath.FromSlash("testdata/"),
+		"  2) transformer.go",
+		"Select an option [1-2]: ",
+	}, "\n")
+
+	for _, test := range []struct {
+		name     string
+		terminal bool
+		stdin    string
+		etc      *commandtest.ExecuteTestCase
+		ietc     *spycommandtest.ExecuteTestCase
+	}{
+		{
+			name:     "prompts for selection if multiple suggestions",
+			terminal: true,
+			stdin:    "2\n",
+			etc: &commandtest.ExecuteTestCase{
+				OS:         &commandtest.FakeOS{},
+				Node:       SerialNodes(FileArgument("s", testDesc, &Complexecute[string]{Prompt: true})),
+				Args:       []string{"t"},
+				WantStdout: selectionPrompt,
+				WantData: &command.Data{Values: map[string]interface{}{
+					"s": testutil.FilepathAbs(t, "transformer.go"),
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: testutil.FilepathAbs(t, "transformer.go")},
+					},
+				},
+			},
+		},
+		{
+			name:     "prompts for selection without trailing newline",
+			terminal: true,
+			stdin:    " 1 ",
+			etc: &commandtest.ExecuteTestCase{
+				OS:         &commandtest.FakeOS{},
+				Node:       SerialNodes(FileArgument("s", testDesc, &Complexecute[string]{Prompt: true})),
+				Args:       []string{"t"},
+				WantStdout: selectionPrompt,
+				WantData: &command.Data{Values: map[string]interface{}{
+					"s": testutil.FilepathAbs(t, "testdata"),
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: testutil.FilepathAbs(t, "testdata")},
+					},
+				},
+			},
+		},
+		{
+			name:     "doesn't prompt if only one suggestion",
+			terminal: true,
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(FileArgument("s", testDesc, &Complexecute[string]{Prompt: true})),
+				Args: []string{"v"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"s": testutil.FilepathAbs(t, "validator.go"),
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: testutil.FilepathAbs(t, "validator.go")},
+					},
+				},
+			},
+		},
+		{
+			name:  "fails if not a terminal",
+			stdin: "2\n",
+			etc: &commandtest.ExecuteTestCase{
+				OS:         &commandtest.FakeOS{},
+				Node:       SerialNodes(FileArgument("s", testDesc, &Complexecute[string]{Prompt: true})),
+				Args:       []string{"t"},
+				WantStderr: filepath.FromSlash("[Complexecute] requires exactly one suggestion to be returned for \"s\", got 2: [testdata/ transformer.go]\n"),
+				WantErr:    fmt.Errorf(filepath.FromSlash("[Complexecute] requires exactly one suggestion to be returned for \"s\", got 2: [testdata/ transformer.go]")),
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "t"},
+					},
+				},
+			},
+		},
+		{
+			name:     "doesn't prompt when rendering usage",
+			terminal: true,
+			stdin:    "2\n",
+			etc: &commandtest.ExecuteTestCase{
+				OS:         &commandtest.FakeOS{},
+				Node:       SerialNodes(FileArgument("s", testDesc, &Complexecute[string]{Prompt: true})),
+				Args:       []string{"t", "--help"},
+				WantStderr: filepath.FromSlash("[Complexecute] requires exactly one suggestion to be returned for \"s\", got 2: [testdata/ transformer.go]\n"),
+				WantErr:    fmt.Errorf(filepath.FromSlash("[Complexecute] requires exactly one suggestion to be returned for \"s\", got 2: [testdata/ transformer.go]")),
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "t"},
+					},
+				},
+			},
+		},
+		{
+			name:     "fails if invalid selection",
+			terminal: true,
+			stdin:    "3\n",
+			etc: &commandtest.ExecuteTestCase{
+				OS:         &commandtest.FakeOS{},
+				Node:       SerialNodes(FileArgument("s", testDesc, &Complexecute[string]{Prompt: true})),
+				Args:       []string{"t"},
+				WantStdout: selectionPrompt,
+				WantStderr: "[Complexecute] failed to select suggestion for \"s\": invalid selection \"3\"\n",
+				WantErr:    fmt.Errorf("[Complexecute] failed to select suggestion for \"s\": invalid selection \"3\""),
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "t"},
+					},
+				},
+			},
+		},
+		{
+			name:     "fails if no selection is read",
+			terminal: true,
+			etc: &commandtest.ExecuteTestCase{
+				OS:         &commandtest.FakeOS{},
+				Node:       SerialNodes(FileArgument("s", testDesc, &Complexecute[string]{Prompt: true})),
+				Args:       []string{"t"},
+				WantStdout: selectionPrompt,
+				WantStderr: "[Complexecute] failed to select suggestion for \"s\": failed to read selection: EOF\n",
+				WantErr:    fmt.Errorf("[Complexecute] failed to select suggestion for \"s\": failed to read selection: EOF"),
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "t"},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			stubs.StubTerminal(t, test.terminal, test.terminal)
+			stubs.StubStdin(t, strings.NewReader(test.stdin))
+			executeTest(t, test.etc, test.ietc)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package commander
+
+import (
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/internal/spycommander"
+)
+
+// Quiet returns a `command.Processor` that runs the provided processors. If the
+// provided flag is set, then all stdout writes from the processors (and from
+// any `command.ExecuteData.Executor` functions they add) are discarded. Stderr
+// writes and errors are still output as normal.
+//
+// Note: the flag must be processed (e.g. by a `FlagProcessor`) before this
+// processor is run.
+func Quiet(f FlagWithType[bool], ps ...command.Processor) command.Processor {
+	return &quiet{f, ps}
+}
+
+type quiet struct {
+	f  FlagWithType[bool]
+	ps []command.Processor
+}
+
+func (q *quiet) Execute(i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
+	if !q.f.GetOrDefault(d, false) {
+		for _, p := range q.ps {
+			if err := spycommander.ProcessOrExecute(p, i, o, d, ed); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	qo := command.NewQuietOutput(o)
+	startIdx := len(ed.Executor)
+	for _, p := range q.ps {
+		if err := spycommander.ProcessOrExecute(p, i, qo, d, ed); err != nil {
+			return err
+		}
+	}
+
+	// Executor functions are run with the top-level output, so they need to be
+	// wrapped to also be quiet.
+	for idx := startIdx; idx < len(ed.Executor); idx++ {
+		ex := ed.Executor[idx]
+		ed.Executor[idx] = func(o command.Output, d *command.Data) error {
+			return ex(command.NewQuietOutput(o), d)
+		}
+	}
+	return nil
+}
+
+func (q *quiet) Complete(i *command.Input, d *command.Data) (*command.Completion, error) {
+	return completeProcessors(q.ps, i, d)
+}
+
+func (q *quiet) Usage(i *command.Input, d *command.Data, u *command.Usage) error {
+	return usageProcessors(q.ps, i, d, u)
+}
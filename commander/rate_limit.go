@@ -0,0 +1,93 @@
+package commander
+
+import (
+	"time"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/internal/stubs"
+)
+
+// RateLimitCLI is an interface for CLIs that can store rate limit history.
+type RateLimitCLI interface {
+	// RateLimitHistory returns a map from rate limit key to the timestamps of
+	// recent invocations. The returned map must be non-nil.
+	RateLimitHistory() map[string][]time.Time
+	// MarkChanged marks the CLI as changed.
+	MarkChanged()
+}
+
+// RateLimitOption is an option interface for modifying `RateLimit` processors.
+type RateLimitOption interface {
+	modifyRateLimit(*rateLimit)
+}
+
+// RateLimitWait is a `RateLimitOption` that waits until the next invocation
+// is allowed (rather than failing) when the rate limit is exceeded.
+func RateLimitWait() RateLimitOption {
+	return &rateLimitWait{}
+}
+
+type rateLimitWait struct{}
+
+func (rlw *rateLimitWait) modifyRateLimit(rl *rateLimit) {
+	rl.wait = true
+}
+
+// RateLimit returns a `command.Processor` that allows at most `maxPerWindow`
+// executions (for the given `key`) in any `window` length of time. Invocation
+// timestamps are stored in the provided `RateLimitCLI` so the limit applies
+// across separate invocations of the CLI. If the limit is exceeded, then an
+// error is returned (unless the `RateLimitWait` option is provided).
+func RateLimit(key string, maxPerWindow int, window time.Duration, rlc RateLimitCLI, opts ...RateLimitOption) command.Processor {
+	rl := &rateLimit{
+		key:          key,
+		maxPerWindow: maxPerWindow,
+		window:       window,
+		rlc:          rlc,
+	}
+	for _, opt := range opts {
+		opt.modifyRateLimit(rl)
+	}
+	return SimpleProcessor(rl.execute, nil)
+}
+
+type rateLimit struct {
+	key          string
+	maxPerWindow int
+	window       time.Duration
+	rlc          RateLimitCLI
+	wait         bool
+}
+
+// recent returns the timestamps that are still within the window.
+func (rl *rateLimit) recent(now time.Time) []time.Time {
+	var r []time.Time
+	for _, t := range rl.rlc.RateLimitHistory()[rl.key] {
+		if now.Sub(t) < rl.window {
+			r = append(r, t)
+		}
+	}
+	return r
+}
+
+func (rl *rateLimit) execute(i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
+	if rl.maxPerWindow <= 0 {
+		return o.Stderrf("[RateLimit] maxPerWindow must be positive; got %d\n", rl.maxPerWindow)
+	}
+
+	now := stubs.TimeNow()
+	for ts := rl.recent(now); len(ts) >= rl.maxPerWindow; ts = rl.recent(now) {
+		// Timestamps are stored in order, so the earliest relevant one is the
+		// one that needs to expire before another invocation is allowed.
+		retryIn := ts[len(ts)-rl.maxPerWindow].Add(rl.window).Sub(now)
+		if !rl.wait {
+			return o.Stderrf("[RateLimit] rate limit exceeded for %q (%d per %v); try again in %v\n", rl.key, rl.maxPerWindow, rl.window, retryIn)
+		}
+		stubs.TimeSleep(retryIn)
+		now = stubs.TimeNow()
+	}
+
+	rl.rlc.RateLimitHistory()[rl.key] = append(rl.recent(now), now)
+	rl.rlc.MarkChanged()
+	return nil
+}
@@ -0,0 +1,186 @@
+package commander
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/leep-frog/command/commandtest"
+	"github.com/leep-frog/command/internal/stubs"
+	"github.com/leep-frog/command/internal/testutil"
+)
+
+type simpleRateLimitCLI struct {
+	changed bool
+	history map[string][]time.Time
+}
+
+func (srl *simpleRateLimitCLI) MarkChanged() {
+	srl.changed = true
+}
+
+func (srl *simpleRateLimitCLI) RateLimitHistory() map[string][]time.Time {
+	if srl.history == nil {
+		srl.history = map[string][]time.Time{}
+	}
+	return srl.history
+}
+
+func TestRateLimit(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time {
+		return start.Add(time.Duration(seconds) * time.Second)
+	}
+
+	type invocation struct {
+		// seconds is the number of seconds after `start` that the invocation occurs.
+		seconds    int
+		wantStderr string
+		wantErr    error
+	}
+
+	for _, test := range []struct {
+		name          string
+		max           int
+		window        time.Duration
+		opts          []RateLimitOption
+		history       map[string][]time.Time
+		invocations   []*invocation
+		wantHistory   map[string][]time.Time
+		wantSleeps    []time.Duration
+		wantUnchanged bool
+	}{
+		{
+			name:   "allows invocations under the limit",
+			max:    3,
+			window: time.Minute,
+			invocations: []*invocation{
+				{seconds: 0},
+				{seconds: 1},
+				{seconds: 2},
+			},
+			wantHistory: map[string][]time.Time{
+				"api": {at(0), at(1), at(2)},
+			},
+		},
+		{
+			name:   "fails when rapid invocations cross the limit",
+			max:    2,
+			window: time.Minute,
+			invocations: []*invocation{
+				{seconds: 0},
+				{seconds: 1},
+				{
+					seconds:    2,
+					wantStderr: "[RateLimit] rate limit exceeded for \"api\" (2 per 1m0s); try again in 58s\n",
+					wantErr:    fmt.Errorf(`[RateLimit] rate limit exceeded for "api" (2 per 1m0s); try again in 58s`),
+				},
+				{
+					seconds:    59,
+					wantStderr: "[RateLimit] rate limit exceeded for \"api\" (2 per 1m0s); try again in 1s\n",
+					wantErr:    fmt.Errorf(`[RateLimit] rate limit exceeded for "api" (2 per 1m0s); try again in 1s`),
+				},
+				// First invocation is now outside of the window
+				{seconds: 60},
+				{
+					seconds:    60,
+					wantStderr: "[RateLimit] rate limit exceeded for \"api\" (2 per 1m0s); try again in 1s\n",
+					wantErr:    fmt.Errorf(`[RateLimit] rate limit exceeded for "api" (2 per 1m0s); try again in 1s`),
+				},
+			},
+			wantHistory: map[string][]time.Time{
+				"api": {at(1), at(60)},
+			},
+		},
+		{
+			name:   "uses existing history",
+			max:    2,
+			window: time.Hour,
+			history: map[string][]time.Time{
+				"api":   {at(-30 * 60), at(-10)},
+				"other": {at(-10)},
+			},
+			invocations: []*invocation{
+				{
+					seconds:    0,
+					wantStderr: "[RateLimit] rate limit exceeded for \"api\" (2 per 1h0m0s); try again in 30m0s\n",
+					wantErr:    fmt.Errorf(`[RateLimit] rate limit exceeded for "api" (2 per 1h0m0s); try again in 30m0s`),
+				},
+			},
+			wantHistory: map[string][]time.Time{
+				"api":   {at(-30 * 60), at(-10)},
+				"other": {at(-10)},
+			},
+			wantUnchanged: true,
+		},
+		{
+			name:   "prunes expired history",
+			max:    2,
+			window: time.Minute,
+			history: map[string][]time.Time{
+				"api": {at(-120), at(-90), at(-30)},
+			},
+			invocations: []*invocation{
+				{seconds: 0},
+			},
+			wantHistory: map[string][]time.Time{
+				"api": {at(-30), at(0)},
+			},
+		},
+		{
+			name:   "waits when limit is exceeded",
+			max:    2,
+			window: time.Minute,
+			opts:   []RateLimitOption{RateLimitWait()},
+			invocations: []*invocation{
+				{seconds: 0},
+				{seconds: 10},
+				{seconds: 20},
+				{seconds: 20},
+			},
+			wantSleeps: []time.Duration{40 * time.Second, 10 * time.Second},
+			wantHistory: map[string][]time.Time{
+				"api": {at(60), at(70)},
+			},
+		},
+		{
+			name:   "fails if max is not positive",
+			max:    0,
+			window: time.Minute,
+			invocations: []*invocation{
+				{
+					wantStderr: "[RateLimit] maxPerWindow must be positive; got 0\n",
+					wantErr:    fmt.Errorf(`[RateLimit] maxPerWindow must be positive; got 0`),
+				},
+			},
+			wantUnchanged: true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var now time.Time
+			var gotSleeps []time.Duration
+			stubs.StubClock(t, func() time.Time { return now }, func(d time.Duration) {
+				gotSleeps = append(gotSleeps, d)
+				now = now.Add(d)
+			})
+
+			rlc := &simpleRateLimitCLI{history: test.history}
+			n := SerialNodes(RateLimit("api", test.max, test.window, rlc, test.opts...))
+			for _, inv := range test.invocations {
+				// Time never goes backwards (relevant when waiting).
+				if invNow := at(inv.seconds); invNow.After(now) {
+					now = invNow
+				}
+				executeTest(t, &commandtest.ExecuteTestCase{
+					Node:       n,
+					WantStderr: inv.wantStderr,
+					WantErr:    inv.wantErr,
+				}, nil)
+			}
+
+			testutil.Cmp(t, "RateLimit produced incorrect history", test.wantHistory, rlc.history)
+			testutil.Cmp(t, "RateLimit produced incorrect sleeps", test.wantSleeps, gotSleeps)
+			testutil.Cmp(t, "RateLimit produced incorrect changed value", !test.wantUnchanged, rlc.changed)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package commander
+
+import (
+	"fmt"
+	"sync"
+)
+
+var (
+	// completerRegistry is a map from name to registered `Completer`.
+	completerRegistry   = map[string]interface{}{}
+	completerRegistryMu sync.Mutex
+)
+
+// RegisterCompleter registers a named completion source that can be used
+// (via `RegistryCompleter`) without depending on the package that defines it.
+// This is typically called in a package's `init` function. This panics if a
+// completer has already been registered with the provided name.
+func RegisterCompleter[T any](name string, c Completer[T]) {
+	completerRegistryMu.Lock()
+	defer completerRegistryMu.Unlock()
+	if _, ok := completerRegistry[name]; ok {
+		panic(fmt.Sprintf("[RegisterCompleter] a completer is already registered with name %q", name))
+	}
+	completerRegistry[name] = c
+}
+
+// RegistryCompleter returns the `Completer` registered with the provided name
+// (see `RegisterCompleter`). This panics if no completer has been registered
+// with the provided name (or if the registered completer has a different type),
+// so missing registry entries are caught when the command graph is constructed
+// rather than when completion is run.
+func RegistryCompleter[T any](name string) Completer[T] {
+	completerRegistryMu.Lock()
+	defer completerRegistryMu.Unlock()
+	rc, ok := completerRegistry[name]
+	if !ok {
+		panic(fmt.Sprintf("[RegistryCompleter] no completer is registered with name %q", name))
+	}
+	c, ok := rc.(Completer[T])
+	if !ok {
+		var t T
+		panic(fmt.Sprintf("[RegistryCompleter] completer registered with name %q isn't a Completer[%T]", name, t))
+	}
+	return c
+}
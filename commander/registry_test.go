@@ -0,0 +1,87 @@
+package commander
+
+import (
+	"testing"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/commandtest"
+	"github.com/leep-frog/command/internal/testutil"
+)
+
+func TestRegistryCompleter(t *testing.T) {
+	testutil.StubValue(t, &completerRegistry, map[string]interface{}{})
+	RegisterCompleter("colors", SimpleCompleter[string]("red", "green", "blue"))
+	RegisterCompleter("sizes", CompleterFromFunc(func(ss []string, d *command.Data) (*command.Completion, error) {
+		return &command.Completion{
+			Suggestions: []string{"small", "medium", "large"},
+			Distinct:    true,
+		}, nil
+	}))
+
+	for _, test := range []struct {
+		name string
+		ctc  *commandtest.CompleteTestCase
+	}{
+		{
+			name: "completes through registered completer",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(Arg[string]("COLOR", testDesc, RegistryCompleter[string]("colors"))),
+				Args: "cmd ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"blue", "green", "red"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"COLOR": "",
+				}},
+			},
+		},
+		{
+			name: "completes partial value through registered completer",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(Arg[string]("COLOR", testDesc, RegistryCompleter[string]("colors"))),
+				Args: "cmd gr",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"green"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"COLOR": "gr",
+				}},
+			},
+		},
+		{
+			name: "completes list arg through registered completer",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(ListArg[string]("SIZES", testDesc, 1, 2, RegistryCompleter[[]string]("sizes"))),
+				Args: "cmd medium ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"large", "small"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"SIZES": []string{"medium", ""},
+				}},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			autocompleteTest(t, test.ctc, nil)
+		})
+	}
+}
+
+func TestRegistryCompleterPanics(t *testing.T) {
+	testutil.StubValue(t, &completerRegistry, map[string]interface{}{})
+	RegisterCompleter("colors", SimpleCompleter[string]("red", "green", "blue"))
+
+	testutil.CmpPanic(t, "RegistryCompleter()", func() Completer[string] {
+		return RegistryCompleter[string]("unknown")
+	}, `[RegistryCompleter] no completer is registered with name "unknown"`)
+
+	testutil.CmpPanic(t, "RegistryCompleter()", func() Completer[int] {
+		return RegistryCompleter[int]("colors")
+	}, `[RegistryCompleter] completer registered with name "colors" isn't a Completer[int]`)
+
+	testutil.CmpPanic(t, "RegisterCompleter()", func() bool {
+		RegisterCompleter("colors", SimpleCompleter[string]("cyan"))
+		return true
+	}, `[RegisterCompleter] a completer is already registered with name "colors"`)
+}
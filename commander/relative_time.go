@@ -0,0 +1,85 @@
+package commander
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/leep-frog/command/internal/operator"
+	"github.com/leep-frog/command/internal/stubs"
+)
+
+var (
+	// relativeTimeKeywords are the keywords accepted (and suggested) by
+	// `RelativeTimeArg`.
+	relativeTimeKeywords = []string{"now", "today", "tomorrow", "yesterday"}
+)
+
+// RelativeTimeArg returns an `Argument` that accepts a relative time and
+// resolves it to an absolute `time.Time`. Accepted values are:
+//   - `now`: the current time.
+//   - `today`, `tomorrow`, `yesterday`: midnight (local time) of the relevant day.
+//   - An offset from the current time, either as a duration (e.g. `-1h`, `+30m`)
+//     or as a number of days (e.g. `-2d`).
+//   - An absolute time in RFC3339 format (which is also the format the
+//     resolved value is written back to the input in).
+//
+// The current time is determined by the clock stubbed with `commandtest.StubClock`.
+func RelativeTimeArg(name, desc string, opts ...ArgumentOption[time.Time]) *Argument[time.Time] {
+	an := listArgument(name, desc, 1, 0, append([]ArgumentOption[time.Time]{
+		SimpleCompleter[time.Time](relativeTimeKeywords...),
+	}, opts...)...)
+	an.op = &relativeTimeOperator{name, operator.TimeOperator(time.RFC3339)}
+	return an
+}
+
+// relativeTimeOperator resolves relative times when converting from strings.
+// Resolved values are converted back to strings with the wrapped operator.
+type relativeTimeOperator struct {
+	name string
+	op   operator.Operator[time.Time]
+}
+
+func (rto *relativeTimeOperator) ToArgs(t time.Time) []string {
+	return rto.op.ToArgs(t)
+}
+
+func (rto *relativeTimeOperator) FromArgs(sl []*string) (time.Time, error) {
+	if len(sl) == 0 {
+		return time.Time{}, nil
+	}
+	if t, err := rto.op.FromArgs(sl); err == nil {
+		return t, nil
+	}
+	t, err := parseRelativeTime(*sl[0], stubs.TimeNow())
+	if err != nil {
+		return t, &validationErr{rto.name, err}
+	}
+	return t, nil
+}
+
+func parseRelativeTime(s string, now time.Time) (time.Time, error) {
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch strings.ToLower(s) {
+	case "now":
+		return now, nil
+	case "today":
+		return midnight, nil
+	case "tomorrow":
+		return midnight.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return midnight.AddDate(0, 0, -1), nil
+	}
+
+	if strings.HasPrefix(s, "+") || strings.HasPrefix(s, "-") {
+		if days, ok := strings.CutSuffix(s, "d"); ok {
+			if n, err := strconv.Atoi(days); err == nil {
+				return now.AddDate(0, 0, n), nil
+			}
+		} else if dur, err := time.ParseDuration(s); err == nil {
+			return now.Add(dur), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("[RelativeTime] value %q must be one of %v or an offset (e.g. -1h, +30m, -2d)", s, relativeTimeKeywords)
+}
@@ -0,0 +1,189 @@
+package commander
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/commandtest"
+	"github.com/leep-frog/command/internal/spycommand"
+	"github.com/leep-frog/command/internal/spycommandtest"
+	"github.com/leep-frog/command/internal/stubs"
+)
+
+func TestRelativeTimeArg(t *testing.T) {
+	now := time.Date(2023, time.May, 10, 15, 4, 5, 0, time.UTC)
+
+	for _, test := range []struct {
+		name string
+		arg  string
+		want time.Time
+		// wantErr is the error message for invalid values.
+		wantErr string
+	}{
+		{
+			name: "resolves now",
+			arg:  "now",
+			want: now,
+		},
+		{
+			name: "resolves keywords case-insensitively",
+			arg:  "NoW",
+			want: now,
+		},
+		{
+			name: "resolves today",
+			arg:  "today",
+			want: time.Date(2023, time.May, 10, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "resolves tomorrow",
+			arg:  "tomorrow",
+			want: time.Date(2023, time.May, 11, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "resolves yesterday",
+			arg:  "yesterday",
+			want: time.Date(2023, time.May, 9, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "resolves negative duration",
+			arg:  "-2h",
+			want: time.Date(2023, time.May, 10, 13, 4, 5, 0, time.UTC),
+		},
+		{
+			name: "resolves positive duration",
+			arg:  "+1h30m",
+			want: time.Date(2023, time.May, 10, 16, 34, 5, 0, time.UTC),
+		},
+		{
+			name: "resolves days",
+			arg:  "-12d",
+			want: time.Date(2023, time.April, 28, 15, 4, 5, 0, time.UTC),
+		},
+		{
+			name: "resolves RFC3339 time",
+			arg:  "2023-05-12T08:30:00Z",
+			want: time.Date(2023, time.May, 12, 8, 30, 0, 0, time.UTC),
+		},
+		{
+			name:    "fails for unknown keyword",
+			arg:     "soon",
+			wantErr: `validation for "WHEN" failed: [RelativeTime] value "soon" must be one of [now today tomorrow yesterday] or an offset (e.g. -1h, +30m, -2d)`,
+		},
+		{
+			name:    "fails for unsigned duration",
+			arg:     "2h",
+			wantErr: `validation for "WHEN" failed: [RelativeTime] value "2h" must be one of [now today tomorrow yesterday] or an offset (e.g. -1h, +30m, -2d)`,
+		},
+		{
+			name:    "fails for invalid offset",
+			arg:     "-2x",
+			wantErr: `validation for "WHEN" failed: [RelativeTime] value "-2x" must be one of [now today tomorrow yesterday] or an offset (e.g. -1h, +30m, -2d)`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			stubs.StubClock(t, func() time.Time { return now }, nil)
+
+			arg := RelativeTimeArg("WHEN", testDesc)
+			etc := &commandtest.ExecuteTestCase{
+				Node: SerialNodes(arg, &ExecutorProcessor{func(o command.Output, d *command.Data) error {
+					o.Stdoutln(arg.Get(d).Format(time.RFC3339))
+					return nil
+				}}),
+				Args: []string{test.arg},
+			}
+			ietc := &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: test.arg},
+					},
+				},
+			}
+			if test.wantErr == "" {
+				// The input is replaced with the resolved time.
+				ietc.WantInput.Args[0].Value = test.want.Format(time.RFC3339)
+				etc.WantStdout = test.want.Format(time.RFC3339) + "\n"
+				etc.WantData = &command.Data{Values: map[string]interface{}{
+					"WHEN": test.want,
+				}}
+			} else {
+				etc.WantErr = fmt.Errorf(test.wantErr)
+				etc.WantStderr = test.wantErr + "\n"
+				ietc.WantIsValidationError = true
+			}
+			executeTest(t, etc, ietc)
+		})
+	}
+}
+
+func TestRelativeTimeArgCompletion(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		ctc  *commandtest.CompleteTestCase
+	}{
+		{
+			name: "suggests keywords",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(RelativeTimeArg("WHEN", testDesc)),
+				Args: "cmd ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"now", "today", "tomorrow", "yesterday"},
+				},
+			},
+		},
+		{
+			name: "suggests keywords with prefix",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(RelativeTimeArg("WHEN", testDesc)),
+				Args: "cmd t",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"today", "tomorrow"},
+				},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			autocompleteTest(t, test.ctc, nil)
+		})
+	}
+}
+
+func TestRelativeTimeArgReplay(t *testing.T) {
+	now := time.Date(2023, time.May, 10, 15, 4, 5, 0, time.UTC)
+	stubs.StubClock(t, func() time.Time { return now }, nil)
+
+	arg := RelativeTimeArg("WHEN", testDesc)
+	n := SerialNodes(arg)
+	want := &command.Data{Values: map[string]interface{}{
+		"WHEN": time.Date(2023, time.May, 10, 13, 4, 5, 0, time.UTC),
+	}}
+
+	// Execute writes the resolved time back to the input, so replaying those
+	// args should resolve to the same time.
+	executeTest(t, &commandtest.ExecuteTestCase{
+		Node:     n,
+		Args:     []string{"-2h"},
+		WantData: want,
+	}, &spycommandtest.ExecuteTestCase{
+		WantInput: &spycommandtest.SpyInput{
+			Args: []*spycommand.InputArg{
+				{Value: "2023-05-10T13:04:05Z"},
+			},
+		},
+	})
+
+	now = now.Add(time.Hour)
+	executeTest(t, &commandtest.ExecuteTestCase{
+		Node:     n,
+		Args:     []string{"2023-05-10T13:04:05Z"},
+		WantData: want,
+	}, &spycommandtest.ExecuteTestCase{
+		WantInput: &spycommandtest.SpyInput{
+			Args: []*spycommand.InputArg{
+				{Value: "2023-05-10T13:04:05Z"},
+			},
+		},
+	})
+}
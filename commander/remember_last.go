@@ -0,0 +1,162 @@
+package commander
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/internal/operator"
+	"github.com/leep-frog/command/internal/spycommander"
+)
+
+var (
+	rememberLastForgetFlag = BoolFlag("forget", FlagNoShortName, "Forget the values remembered from previous runs")
+	// rememberedTimeOperator is used for `time.Time` values (rather than the
+	// default operator for the type) so values from arguments with a custom
+	// layout (e.g. `TimeArg`) are remembered without losing precision.
+	rememberedTimeOperator = operator.TimeOperator(time.RFC3339Nano)
+)
+
+// RememberLastCLI is an interface for CLIs that can store remembered values.
+type RememberLastCLI interface {
+	// RememberedValues returns a map from `command.Data` key to the value
+	// remembered from the last successful run. The returned map must be non-nil.
+	RememberedValues() map[string]*RememberedValue
+	// MarkChanged marks the CLI as changed.
+	MarkChanged()
+}
+
+// RememberedValue is a value stored by `RememberLast`. The value is stored as
+// its command line arguments (along with its type) so it can be persisted.
+type RememberedValue struct {
+	Type string
+	Args []string
+}
+
+// RememberLast returns a `command.Node` that runs the provided node and, if
+// execution is successful, stores the values for the provided `command.Data`
+// keys in the `RememberLastCLI`. On subsequent runs, the remembered values are
+// used for any of those keys that aren't provided. Note that arguments and
+// flags with their own `Default` option will use that value instead.
+// Remembered values can be cleared with the `--forget` flag.
+func RememberLast(rlc RememberLastCLI, n command.Node, keys ...string) command.Node {
+	return SerialNodes(
+		FlagProcessor(rememberLastForgetFlag),
+		&rememberLast{rlc, n, keys},
+	)
+}
+
+type rememberLast struct {
+	rlc  RememberLastCLI
+	n    command.Node
+	keys []string
+}
+
+func (rl *rememberLast) Execute(i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
+	values := rl.rlc.RememberedValues()
+	if rememberLastForgetFlag.Get(d) {
+		for _, k := range rl.keys {
+			if _, ok := values[k]; ok {
+				delete(values, k)
+				rl.rlc.MarkChanged()
+			}
+		}
+		return spycommander.ProcessGraphExecution(rl.n, i, o, d, ed)
+	}
+
+	for _, k := range rl.keys {
+		rv, ok := values[k]
+		if !ok {
+			continue
+		}
+		v, err := rv.value()
+		if err != nil {
+			return o.Annotatef(err, "[RememberLast] failed to load remembered value for %q", k)
+		}
+		d.Set(k, v)
+	}
+
+	if err := spycommander.ProcessGraphExecution(rl.n, i, o, d, ed); err != nil {
+		return err
+	}
+
+	// Values are only stored if all other executors are successful.
+	ed.Executor = append(ed.Executor, func(o command.Output, d *command.Data) error {
+		for _, k := range rl.keys {
+			if !d.Has(k) {
+				continue
+			}
+			rv, err := newRememberedValue(d.Get(k))
+			if err != nil {
+				return o.Annotatef(err, "[RememberLast] failed to remember value for %q", k)
+			}
+			if prev, ok := values[k]; !ok || prev.Type != rv.Type || !slices.Equal(prev.Args, rv.Args) {
+				values[k] = rv
+				rl.rlc.MarkChanged()
+			}
+		}
+		return nil
+	})
+	return nil
+}
+
+func (rl *rememberLast) Complete(i *command.Input, d *command.Data) (*command.Completion, error) {
+	return processOrComplete(rl.n, i, d)
+}
+
+func (rl *rememberLast) Usage(i *command.Input, d *command.Data, u *command.Usage) error {
+	return spycommander.ProcessOrUsage(rl.n, i, d, u)
+}
+
+func newRememberedValue(v interface{}) (*RememberedValue, error) {
+	switch t := v.(type) {
+	case string:
+		return rememberValue(t), nil
+	case []string:
+		return rememberValue(t), nil
+	case int:
+		return rememberValue(t), nil
+	case []int:
+		return rememberValue(t), nil
+	case float64:
+		return rememberValue(t), nil
+	case []float64:
+		return rememberValue(t), nil
+	case bool:
+		return rememberValue(t), nil
+	case time.Time:
+		return &RememberedValue{"time.Time", rememberedTimeOperator.ToArgs(t)}, nil
+	}
+	return nil, fmt.Errorf("unsupported type %T", v)
+}
+
+func rememberValue[T any](v T) *RememberedValue {
+	return &RememberedValue{fmt.Sprintf("%T", v), operator.GetOperator[T]().ToArgs(v)}
+}
+
+func (rv *RememberedValue) value() (interface{}, error) {
+	switch rv.Type {
+	case "string":
+		return recallValue[string](rv.Args)
+	case "[]string":
+		return recallValue[[]string](rv.Args)
+	case "int":
+		return recallValue[int](rv.Args)
+	case "[]int":
+		return recallValue[[]int](rv.Args)
+	case "float64":
+		return recallValue[float64](rv.Args)
+	case "[]float64":
+		return recallValue[[]float64](rv.Args)
+	case "bool":
+		return recallValue[bool](rv.Args)
+	case "time.Time":
+		return operator.FromArgs(rememberedTimeOperator, rv.Args...)
+	}
+	return nil, fmt.Errorf("unsupported type %q", rv.Type)
+}
+
+func recallValue[T any](args []string) (interface{}, error) {
+	return operator.FromArgs(operator.GetOperator[T](), args...)
+}
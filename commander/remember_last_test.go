@@ -0,0 +1,219 @@
+package commander
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/commandtest"
+	"github.com/leep-frog/command/internal/spycommand"
+	"github.com/leep-frog/command/internal/spycommandtest"
+	"github.com/leep-frog/command/internal/testutil"
+)
+
+type simpleRememberLastCLI struct {
+	changed bool
+	values  map[string]*RememberedValue
+}
+
+func (srl *simpleRememberLastCLI) MarkChanged() {
+	srl.changed = true
+}
+
+func (srl *simpleRememberLastCLI) RememberedValues() map[string]*RememberedValue {
+	if srl.values == nil {
+		srl.values = map[string]*RememberedValue{}
+	}
+	return srl.values
+}
+
+func TestRememberLast(t *testing.T) {
+	nameArg := OptionalArg[string]("NAME", testDesc)
+	countFlag := Flag[int]("count", 'c', testDesc)
+	ratesFlag := ListFlag[float64]("rates", 'r', testDesc, 0, command.UnboundedList)
+
+	type invocation struct {
+		args       []string
+		wantStdout string
+		wantStderr string
+		wantErr    error
+	}
+
+	for _, test := range []struct {
+		name          string
+		values        map[string]*RememberedValue
+		invocations   []*invocation
+		wantValues    map[string]*RememberedValue
+		wantUnchanged bool
+	}{
+		{
+			name: "stores values and uses them on the next run",
+			invocations: []*invocation{
+				{
+					args:       []string{"alice", "-c", "3", "-r", "1.5", "2"},
+					wantStdout: "alice 3 [1.5 2]\n",
+				},
+				{
+					wantStdout: "alice 3 [1.5 2]\n",
+				},
+			},
+			wantValues: map[string]*RememberedValue{
+				"NAME":  {"string", []string{"alice"}},
+				"count": {"int", []string{"3"}},
+				"rates": {"[]float64", []string{"1.5", "2"}},
+			},
+		},
+		{
+			name: "provided values override remembered values",
+			invocations: []*invocation{
+				{
+					args:       []string{"alice", "-c", "3"},
+					wantStdout: "alice 3 []\n",
+				},
+				{
+					args:       []string{"bob"},
+					wantStdout: "bob 3 []\n",
+				},
+				{
+					wantStdout: "bob 3 []\n",
+				},
+			},
+			wantValues: map[string]*RememberedValue{
+				"NAME":  {"string", []string{"bob"}},
+				"count": {"int", []string{"3"}},
+			},
+		},
+		{
+			name: "uses previously persisted values",
+			values: map[string]*RememberedValue{
+				"NAME":  {"string", []string{"carol"}},
+				"count": {"int", []string{"7"}},
+			},
+			invocations: []*invocation{
+				{
+					wantStdout: "carol 7 []\n",
+				},
+			},
+			wantValues: map[string]*RememberedValue{
+				"NAME":  {"string", []string{"carol"}},
+				"count": {"int", []string{"7"}},
+			},
+			wantUnchanged: true,
+		},
+		{
+			name: "forget flag clears remembered values",
+			invocations: []*invocation{
+				{
+					args:       []string{"alice", "-c", "3"},
+					wantStdout: "alice 3 []\n",
+				},
+				{
+					args:       []string{"--forget"},
+					wantStdout: " 0 []\n",
+				},
+				{
+					wantStdout: " 0 []\n",
+				},
+			},
+			wantValues: map[string]*RememberedValue{},
+		},
+		{
+			name: "forget flag doesn't remember provided values",
+			invocations: []*invocation{
+				{
+					args:       []string{"alice", "--forget"},
+					wantStdout: "alice 0 []\n",
+				},
+				{
+					wantStdout: " 0 []\n",
+				},
+			},
+			wantValues:    map[string]*RememberedValue{},
+			wantUnchanged: true,
+		},
+		{
+			name: "doesn't remember values if execution fails",
+			invocations: []*invocation{
+				{
+					args:       []string{"fail", "-c", "3"},
+					wantStderr: "oops\n",
+					wantErr:    fmt.Errorf("oops"),
+				},
+				{
+					wantStdout: " 0 []\n",
+				},
+			},
+			wantValues:    map[string]*RememberedValue{},
+			wantUnchanged: true,
+		},
+		{
+			name: "fails if remembered value has unsupported type",
+			values: map[string]*RememberedValue{
+				"NAME": {"complex128", []string{"1+2i"}},
+			},
+			invocations: []*invocation{
+				{
+					wantStderr: "[RememberLast] failed to load remembered value for \"NAME\": unsupported type \"complex128\"\n",
+					wantErr:    fmt.Errorf(`[RememberLast] failed to load remembered value for "NAME": unsupported type "complex128"`),
+				},
+			},
+			wantValues: map[string]*RememberedValue{
+				"NAME": {"complex128", []string{"1+2i"}},
+			},
+			wantUnchanged: true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			rlc := &simpleRememberLastCLI{values: test.values}
+			n := RememberLast(rlc, SerialNodes(
+				FlagProcessor(countFlag, ratesFlag),
+				nameArg,
+				&ExecutorProcessor{func(o command.Output, d *command.Data) error {
+					if nameArg.Get(d) == "fail" {
+						return o.Stderrln("oops")
+					}
+					o.Stdoutf("%s %d %v\n", nameArg.Get(d), countFlag.Get(d), ratesFlag.Get(d))
+					return nil
+				}},
+			), "NAME", "count", "rates")
+
+			for _, inv := range test.invocations {
+				var inputArgs []*spycommand.InputArg
+				for _, a := range inv.args {
+					inputArgs = append(inputArgs, &spycommand.InputArg{Value: a})
+				}
+				executeTest(t, &commandtest.ExecuteTestCase{
+					Node:          n,
+					Args:          inv.args,
+					WantStdout:    inv.wantStdout,
+					WantStderr:    inv.wantStderr,
+					WantErr:       inv.wantErr,
+					SkipDataCheck: true,
+				}, &spycommandtest.ExecuteTestCase{
+					WantInput: &spycommandtest.SpyInput{
+						Args: inputArgs,
+					},
+				})
+			}
+
+			testutil.Cmp(t, "RememberLast produced incorrect values", test.wantValues, rlc.values)
+			testutil.Cmp(t, "RememberLast produced incorrect changed value", !test.wantUnchanged, rlc.changed)
+		})
+	}
+}
+
+func TestRememberedTimeValue(t *testing.T) {
+	when := time.Date(2024, 4, 5, 6, 7, 8, 9, time.UTC)
+	rv, err := newRememberedValue(when)
+	if err != nil {
+		t.Fatalf("newRememberedValue(%v) returned error: %v", when, err)
+	}
+	testutil.Cmp(t, "newRememberedValue returned incorrect value", &RememberedValue{"time.Time", []string{"2024-04-05T06:07:08.000000009Z"}}, rv)
+
+	got, err := rv.value()
+	if err != nil {
+		t.Fatalf("RememberedValue.value() returned error: %v", err)
+	}
+	testutil.Cmp(t, "RememberedValue.value() returned incorrect value", interface{}(when), got)
+}
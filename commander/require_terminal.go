@@ -0,0 +1,107 @@
+package commander
+
+import (
+	"fmt"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/internal/stubs"
+)
+
+const (
+	// TTYKey is the `command.Data` key used by `RequireTTY`.
+	TTYKey = "IS_TTY"
+	// ColorTerminalKey is the `command.Data` key used by `RequireColorTerminal`.
+	ColorTerminalKey = "IS_COLOR_TERMINAL"
+)
+
+// TerminalOption is an option interface for modifying `RequireTTY` and
+// `RequireColorTerminal` processors.
+type TerminalOption interface {
+	modifyTerminalCheck(*terminalCheck)
+}
+
+// DegradeGracefully is a `TerminalOption` that doesn't fail if the terminal
+// requirement isn't met. Instead, the result is stored in `command.Data` and
+// can be retrieved with `IsTTY` or `IsColorTerminal` so executors can fall back
+// to simpler behavior.
+func DegradeGracefully() TerminalOption {
+	return &degradeGracefully{}
+}
+
+type degradeGracefully struct{}
+
+func (dg *degradeGracefully) modifyTerminalCheck(tc *terminalCheck) {
+	tc.degrade = true
+}
+
+// RequireTTY returns a `command.Processor` that fails if stdin or stdout
+// is not a terminal (e.g. when input or output is piped). This should be
+// used before interactive prompts.
+func RequireTTY(opts ...TerminalOption) command.Processor {
+	return newTerminalCheck("RequireTTY", TTYKey, func() error {
+		if !stubs.StdinIsTerminal() {
+			return fmt.Errorf("stdin is not a terminal")
+		}
+		if !stubs.StdoutIsTerminal() {
+			return fmt.Errorf("stdout is not a terminal")
+		}
+		return nil
+	}, opts...)
+}
+
+// RequireColorTerminal returns a `command.Processor` that fails if stdout is
+// not a terminal that supports color. Specifically, it fails if stdout is not
+// a terminal, if the `NO_COLOR` environment variable is set, or if the `TERM`
+// environment variable is "dumb".
+func RequireColorTerminal(opts ...TerminalOption) command.Processor {
+	return newTerminalCheck("RequireColorTerminal", ColorTerminalKey, func() error {
+		if !stubs.StdoutIsTerminal() {
+			return fmt.Errorf("stdout is not a terminal")
+		}
+		if _, ok := command.OSLookupEnv("NO_COLOR"); ok {
+			return fmt.Errorf("NO_COLOR is set")
+		}
+		if term, _ := command.OSLookupEnv("TERM"); term == "dumb" {
+			return fmt.Errorf("TERM is %q", term)
+		}
+		return nil
+	}, opts...)
+}
+
+// IsTTY returns whether or not the `RequireTTY` check was satisfied.
+func IsTTY(d *command.Data) bool {
+	return d.Has(TTYKey) && d.Bool(TTYKey)
+}
+
+// IsColorTerminal returns whether or not the `RequireColorTerminal` check was satisfied.
+func IsColorTerminal(d *command.Data) bool {
+	return d.Has(ColorTerminalKey) && d.Bool(ColorTerminalKey)
+}
+
+type terminalCheck struct {
+	name    string
+	dataKey string
+	check   func() error
+	degrade bool
+}
+
+func newTerminalCheck(name, dataKey string, check func() error, opts ...TerminalOption) command.Processor {
+	tc := &terminalCheck{
+		name:    name,
+		dataKey: dataKey,
+		check:   check,
+	}
+	for _, opt := range opts {
+		opt.modifyTerminalCheck(tc)
+	}
+	return SimpleProcessor(tc.execute, nil)
+}
+
+func (tc *terminalCheck) execute(i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
+	err := tc.check()
+	d.Set(tc.dataKey, err == nil)
+	if err != nil && !tc.degrade {
+		return o.Stderrf("[%s] %v\n", tc.name, err)
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package commander
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/commandtest"
+	"github.com/leep-frog/command/internal/stubs"
+)
+
+func TestTerminalChecks(t *testing.T) {
+	printer := &ExecutorProcessor{func(o command.Output, d *command.Data) error {
+		o.Stdoutf("tty=%v color=%v\n", IsTTY(d), IsColorTerminal(d))
+		return nil
+	}}
+
+	for _, test := range []struct {
+		name   string
+		stdin  bool
+		stdout bool
+		etc    *commandtest.ExecuteTestCase
+	}{
+		// RequireTTY
+		{
+			name:   "RequireTTY succeeds if stdin and stdout are terminals",
+			stdin:  true,
+			stdout: true,
+			etc: &commandtest.ExecuteTestCase{
+				Node:       SerialNodes(RequireTTY(), printer),
+				WantStdout: "tty=true color=false\n",
+				WantData: &command.Data{Values: map[string]interface{}{
+					TTYKey: true,
+				}},
+			},
+		},
+		{
+			name:   "RequireTTY fails if stdin is not a terminal",
+			stdout: true,
+			etc: &commandtest.ExecuteTestCase{
+				Node:       SerialNodes(RequireTTY(), printer),
+				WantStderr: "[RequireTTY] stdin is not a terminal\n",
+				WantErr:    fmt.Errorf("[RequireTTY] stdin is not a terminal"),
+				WantData: &command.Data{Values: map[string]interface{}{
+					TTYKey: false,
+				}},
+			},
+		},
+		{
+			name:  "RequireTTY fails if stdout is not a terminal",
+			stdin: true,
+			etc: &commandtest.ExecuteTestCase{
+				Node:       SerialNodes(RequireTTY(), printer),
+				WantStderr: "[RequireTTY] stdout is not a terminal\n",
+				WantErr:    fmt.Errorf("[RequireTTY] stdout is not a terminal"),
+				WantData: &command.Data{Values: map[string]interface{}{
+					TTYKey: false,
+				}},
+			},
+		},
+		{
+			name:  "RequireTTY degrades gracefully",
+			stdin: true,
+			etc: &commandtest.ExecuteTestCase{
+				Node:       SerialNodes(RequireTTY(DegradeGracefully()), printer),
+				WantStdout: "tty=false color=false\n",
+				WantData: &command.Data{Values: map[string]interface{}{
+					TTYKey: false,
+				}},
+			},
+		},
+		// RequireColorTerminal
+		{
+			name:   "RequireColorTerminal succeeds if stdout is a terminal",
+			stdout: true,
+			etc: &commandtest.ExecuteTestCase{
+				Env: map[string]string{
+					"TERM": "xterm-256color",
+				},
+				Node:       SerialNodes(RequireColorTerminal(), printer),
+				WantStdout: "tty=false color=true\n",
+				WantData: &command.Data{Values: map[string]interface{}{
+					ColorTerminalKey: true,
+				}},
+			},
+		},
+		{
+			name: "RequireColorTerminal fails if stdout is not a terminal",
+			etc: &commandtest.ExecuteTestCase{
+				Env:        map[string]string{},
+				Node:       SerialNodes(RequireColorTerminal(), printer),
+				WantStderr: "[RequireColorTerminal] stdout is not a terminal\n",
+				WantErr:    fmt.Errorf("[RequireColorTerminal] stdout is not a terminal"),
+				WantData: &command.Data{Values: map[string]interface{}{
+					ColorTerminalKey: false,
+				}},
+			},
+		},
+		{
+			name:   "RequireColorTerminal fails if NO_COLOR is set",
+			stdout: true,
+			etc: &commandtest.ExecuteTestCase{
+				Env: map[string]string{
+					"NO_COLOR": "",
+				},
+				Node:       SerialNodes(RequireColorTerminal(), printer),
+				WantStderr: "[RequireColorTerminal] NO_COLOR is set\n",
+				WantErr:    fmt.Errorf("[RequireColorTerminal] NO_COLOR is set"),
+				WantData: &command.Data{Values: map[string]interface{}{
+					ColorTerminalKey: false,
+				}},
+			},
+		},
+		{
+			name:   "RequireColorTerminal fails if TERM is dumb",
+			stdout: true,
+			etc: &commandtest.ExecuteTestCase{
+				Env: map[string]string{
+					"TERM": "dumb",
+				},
+				Node:       SerialNodes(RequireColorTerminal(), printer),
+				WantStderr: "[RequireColorTerminal] TERM is \"dumb\"\n",
+				WantErr:    fmt.Errorf(`[RequireColorTerminal] TERM is "dumb"`),
+				WantData: &command.Data{Values: map[string]interface{}{
+					ColorTerminalKey: false,
+				}},
+			},
+		},
+		{
+			name:  "RequireColorTerminal degrades gracefully",
+			stdin: true,
+			etc: &commandtest.ExecuteTestCase{
+				Env:        map[string]string{},
+				Node:       SerialNodes(RequireColorTerminal(DegradeGracefully()), printer),
+				WantStdout: "tty=false color=false\n",
+				WantData: &command.Data{Values: map[string]interface{}{
+					ColorTerminalKey: false,
+				}},
+			},
+		},
+		// Both
+		{
+			name:   "RequireTTY and RequireColorTerminal succeed together",
+			stdin:  true,
+			stdout: true,
+			etc: &commandtest.ExecuteTestCase{
+				Env:        map[string]string{},
+				Node:       SerialNodes(RequireTTY(), RequireColorTerminal(), printer),
+				WantStdout: "tty=true color=true\n",
+				WantData: &command.Data{Values: map[string]interface{}{
+					TTYKey:           true,
+					ColorTerminalKey: true,
+				}},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			stubs.StubTerminal(t, test.stdin, test.stdout)
+			executeTest(t, test.etc, nil)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package commander
+
+import (
+	"fmt"
+
+	"github.com/leep-frog/command/command"
+)
+
+// Rule is a requirement over `command.Data` values that is checked by `Rules`.
+type Rule struct {
+	// Description describes the requirement. It is included in the error if
+	// the rule is violated.
+	Description string
+	// When is the condition under which the rule applies. If nil, the rule
+	// always applies.
+	When func(*command.Data) bool
+	// Require returns whether or not the requirement is satisfied.
+	Require func(*command.Data) bool
+}
+
+// RequiredWhen returns a `Rule` that requires the `required` argument (or
+// flag) to be provided whenever the `when` argument (or flag) is provided.
+func RequiredWhen(required, when string) *Rule {
+	return &Rule{
+		Description: fmt.Sprintf("%q is required when %q is provided", required, when),
+		When: func(d *command.Data) bool {
+			return provided(d, when)
+		},
+		Require: func(d *command.Data) bool {
+			return provided(d, required)
+		},
+	}
+}
+
+// MutuallyExclusive returns a `Rule` that allows at most one of the provided
+// arguments (or flags) to be provided.
+func MutuallyExclusive(names ...string) *Rule {
+	return &Rule{
+		Description: fmt.Sprintf("at most one of %q can be provided", names),
+		Require: func(d *command.Data) bool {
+			return countProvided(d, names) <= 1
+		},
+	}
+}
+
+// RequireOneOf returns a `Rule` that requires at least one of the provided
+// arguments (or flags) to be provided.
+func RequireOneOf(names ...string) *Rule {
+	return &Rule{
+		Description: fmt.Sprintf("at least one of %q must be provided", names),
+		Require: func(d *command.Data) bool {
+			return countProvided(d, names) > 0
+		},
+	}
+}
+
+// provided returns whether or not a value was explicitly provided for the
+// argument (or flag). `Default` values don't count as provided.
+func provided(d *command.Data, name string) bool {
+	return d.Has(name) && !d.IsDefault(name)
+}
+
+func countProvided(d *command.Data, names []string) int {
+	var cnt int
+	for _, name := range names {
+		if provided(d, name) {
+			cnt++
+		}
+	}
+	return cnt
+}
+
+// Rules returns a `command.Processor` that checks all of the provided rules
+// against the `command.Data` parsed so far (so it should be placed after the
+// relevant arguments and flags). If any rules are violated, then a single
+// usage error listing all of the violations is returned. Rules are only
+// checked at execution time so partial commands can still be completed.
+func Rules(rules ...*Rule) command.Processor {
+	return SimpleProcessor(func(i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
+		var violations []string
+		for _, r := range rules {
+			if r.When != nil && !r.When(d) {
+				continue
+			}
+			if !r.Require(d) {
+				violations = append(violations, r.Description)
+			}
+		}
+
+		if len(violations) > 0 {
+			return o.Err(&ruleViolations{violations})
+		}
+		return nil
+	}, nil)
+}
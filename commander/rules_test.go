@@ -0,0 +1,287 @@
+package commander
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/commandtest"
+	"github.com/leep-frog/command/internal/spycommand"
+	"github.com/leep-frog/command/internal/spycommandtest"
+)
+
+func TestRules(t *testing.T) {
+	rulesNode := func() command.Node {
+		return SerialNodes(
+			FlagProcessor(
+				Flag[string]("output", 'o', testDesc),
+				Flag[string]("format", 'f', testDesc),
+				BoolFlag("json", 'j', testDesc),
+				BoolFlag("yaml", 'y', testDesc),
+				Flag[string]("region", 'r', testDesc, Default("us")),
+				BoolValuesFlag("color", 'c', testDesc, "always", "never"),
+			),
+			OptionalArg[string]("SRC", testDesc),
+			Rules(
+				RequiredWhen("format", "output"),
+				MutuallyExclusive("json", "yaml"),
+				RequireOneOf("SRC", "output"),
+				RequiredWhen("SRC", "region"),
+				MutuallyExclusive("color", "region"),
+				&Rule{
+					Description: "SRC must not be empty when provided",
+					When: func(d *command.Data) bool {
+						return d.Has("SRC")
+					},
+					Require: func(d *command.Data) bool {
+						return d.String("SRC") != ""
+					},
+				},
+			),
+			&ExecutorProcessor{func(o command.Output, d *command.Data) error {
+				o.Stdoutln("success")
+				return nil
+			}},
+		)
+	}
+
+	for _, test := range []struct {
+		name string
+		etc  *commandtest.ExecuteTestCase
+		ietc *spycommandtest.ExecuteTestCase
+	}{
+		{
+			name: "passes when no rules are violated",
+			etc: &commandtest.ExecuteTestCase{
+				Node:       rulesNode(),
+				Args:       []string{"src.txt", "-j"},
+				WantStdout: "success\n",
+				WantData: &command.Data{Values: map[string]interface{}{
+					"region": "us",
+					"color":  "never",
+					"SRC":    "src.txt",
+					"json":   true,
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "src.txt"},
+						{Value: "-j"},
+					},
+				},
+			},
+		},
+		{
+			name: "passes when conditional rule is satisfied",
+			etc: &commandtest.ExecuteTestCase{
+				Node:       rulesNode(),
+				Args:       []string{"-o", "out.txt", "-f", "csv"},
+				WantStdout: "success\n",
+				WantData: &command.Data{Values: map[string]interface{}{
+					"region": "us",
+					"color":  "never",
+					"output": "out.txt",
+					"format": "csv",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "-o"},
+						{Value: "out.txt"},
+						{Value: "-f"},
+						{Value: "csv"},
+					},
+				},
+			},
+		},
+		{
+			name: "reports a single violation",
+			etc: &commandtest.ExecuteTestCase{
+				Node: rulesNode(),
+				Args: []string{"-o", "out.txt"},
+				WantStderr: strings.Join([]string{
+					"[Rules] 1 rule violation:",
+					`  "format" is required when "output" is provided`,
+					"",
+				}, "\n"),
+				WantErr: fmt.Errorf(strings.Join([]string{
+					"[Rules] 1 rule violation:",
+					`  "format" is required when "output" is provided`,
+				}, "\n")),
+				WantData: &command.Data{Values: map[string]interface{}{
+					"region": "us",
+					"color":  "never",
+					"output": "out.txt",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsUsageError: true,
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "-o"},
+						{Value: "out.txt"},
+					},
+				},
+			},
+		},
+		{
+			name: "reports all violations",
+			etc: &commandtest.ExecuteTestCase{
+				Node: rulesNode(),
+				Args: []string{"-o", "out.txt", "-j", "-y"},
+				WantStderr: strings.Join([]string{
+					"[Rules] 2 rule violations:",
+					`  "format" is required when "output" is provided`,
+					`  at most one of ["json" "yaml"] can be provided`,
+					"",
+				}, "\n"),
+				WantErr: fmt.Errorf(strings.Join([]string{
+					"[Rules] 2 rule violations:",
+					`  "format" is required when "output" is provided`,
+					`  at most one of ["json" "yaml"] can be provided`,
+				}, "\n")),
+				WantData: &command.Data{Values: map[string]interface{}{
+					"region": "us",
+					"color":  "never",
+					"output": "out.txt",
+					"json":   true,
+					"yaml":   true,
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsUsageError: true,
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "-o"},
+						{Value: "out.txt"},
+						{Value: "-j"},
+						{Value: "-y"},
+					},
+				},
+			},
+		},
+		{
+			name: "reports custom rule and unconditional rule violations",
+			etc: &commandtest.ExecuteTestCase{
+				Node: rulesNode(),
+				Args: []string{"", "-y", "-j"},
+				WantStderr: strings.Join([]string{
+					"[Rules] 2 rule violations:",
+					`  at most one of ["json" "yaml"] can be provided`,
+					"  SRC must not be empty when provided",
+					"",
+				}, "\n"),
+				WantErr: fmt.Errorf(strings.Join([]string{
+					"[Rules] 2 rule violations:",
+					`  at most one of ["json" "yaml"] can be provided`,
+					"  SRC must not be empty when provided",
+				}, "\n")),
+				WantData: &command.Data{Values: map[string]interface{}{
+					"region": "us",
+					"color":  "never",
+					"SRC":    "",
+					"json":   true,
+					"yaml":   true,
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsUsageError: true,
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: ""},
+						{Value: "-y"},
+						{Value: "-j"},
+					},
+				},
+			},
+		},
+		{
+			name: "default values aren't considered provided",
+			etc: &commandtest.ExecuteTestCase{
+				Node:       rulesNode(),
+				Args:       []string{"src.txt"},
+				WantStdout: "success\n",
+				WantData: &command.Data{Values: map[string]interface{}{
+					"region": "us",
+					"color":  "never",
+					"SRC":    "src.txt",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "src.txt"},
+					},
+				},
+			},
+		},
+		{
+			name: "flags that override default values are considered provided",
+			etc: &commandtest.ExecuteTestCase{
+				Node: rulesNode(),
+				Args: []string{"-o", "out.txt", "-f", "csv", "-r", "eu", "-c"},
+				WantStderr: strings.Join([]string{
+					"[Rules] 2 rule violations:",
+					`  "SRC" is required when "region" is provided`,
+					`  at most one of ["color" "region"] can be provided`,
+					"",
+				}, "\n"),
+				WantErr: fmt.Errorf(strings.Join([]string{
+					"[Rules] 2 rule violations:",
+					`  "SRC" is required when "region" is provided`,
+					`  at most one of ["color" "region"] can be provided`,
+				}, "\n")),
+				WantData: &command.Data{Values: map[string]interface{}{
+					"region": "eu",
+					"color":  "always",
+					"output": "out.txt",
+					"format": "csv",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsUsageError: true,
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "-o"},
+						{Value: "out.txt"},
+						{Value: "-f"},
+						{Value: "csv"},
+						{Value: "-r"},
+						{Value: "eu"},
+						{Value: "-c"},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			executeTest(t, test.etc, test.ietc)
+		})
+	}
+}
+
+func TestRulesCompletion(t *testing.T) {
+	node := SerialNodes(
+		FlagProcessor(
+			BoolFlag("json", 'j', testDesc),
+			BoolFlag("yaml", 'y', testDesc),
+		),
+		Arg[string]("SRC", testDesc, SimpleCompleter[string]("abc", "def")),
+		Rules(MutuallyExclusive("json", "yaml")),
+	)
+	autocompleteTest(t, &commandtest.CompleteTestCase{
+		Node: node,
+		Args: "cmd -j -y ",
+		Want: &command.Autocompletion{
+			Suggestions: []string{"abc", "def"},
+		},
+		WantData: &command.Data{Values: map[string]interface{}{
+			"SRC":  "",
+			"json": true,
+			"yaml": true,
+		}},
+	}, nil)
+}
@@ -0,0 +1,92 @@
+package commander
+
+import (
+	"strings"
+
+	"github.com/leep-frog/command/command"
+)
+
+// RunResult contains the results of a `Run` invocation.
+type RunResult struct {
+	// Stdout is everything that was written to stdout.
+	Stdout string
+	// Stderr is everything that was written to stderr.
+	Stderr string
+	// Err is the error returned by the execution (if any).
+	Err error
+	// Data is the final `command.Data` object.
+	Data *command.Data
+	// ExecuteData is the final `command.ExecuteData` object. Note that
+	// `ExecuteData.Executable` commands are not run by `Run`.
+	ExecuteData *command.ExecuteData
+}
+
+// RunOption is an option interface for modifying `Run` behavior.
+type RunOption interface {
+	modifyRun(*runOptions)
+}
+
+type runOptions struct {
+	os      command.OS
+	forward command.Output
+}
+
+type simpleRunOption func(*runOptions)
+
+func (sro simpleRunOption) modifyRun(ro *runOptions) {
+	sro(ro)
+}
+
+// RunOS is a `RunOption` that sets the `command.OS` used during execution.
+func RunOS(os command.OS) RunOption {
+	return simpleRunOption(func(ro *runOptions) {
+		ro.os = os
+	})
+}
+
+// RunForwardOutput is a `RunOption` that also forwards all output to the
+// provided `command.Output` (in addition to capturing it in the `RunResult`).
+func RunForwardOutput(o command.Output) RunOption {
+	return simpleRunOption(func(ro *runOptions) {
+		ro.forward = o
+	})
+}
+
+// Run executes the provided node with the provided args and captures the
+// results. Unlike the `commandtest` package, this is intended for use in
+// non-test code that needs to programmatically run a command.
+// The returned error is identical to `RunResult.Err`.
+func Run(n command.Node, args []string, opts ...RunOption) (*RunResult, error) {
+	ro := &runOptions{}
+	for _, opt := range opts {
+		opt.modifyRun(ro)
+	}
+
+	var stdout, stderr strings.Builder
+	o := command.OutputFromFuncs(
+		func(s string) {
+			stdout.WriteString(s)
+			if ro.forward != nil {
+				ro.forward.Stdout(s)
+			}
+		},
+		func(s string) {
+			stderr.WriteString(s)
+			if ro.forward != nil {
+				ro.forward.Stderr(s)
+			}
+		},
+	)
+
+	data := &command.Data{OS: ro.os}
+	eData, err := execute(n, command.ParseExecuteArgs(args), o, data)
+	o.Close()
+
+	return &RunResult{
+		Stdout:      stdout.String(),
+		Stderr:      stderr.String(),
+		Err:         err,
+		Data:        data,
+		ExecuteData: eData,
+	}, err
+}
@@ -0,0 +1,133 @@
+package commander
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/commandtest"
+	"github.com/leep-frog/command/internal/testutil"
+)
+
+func TestRun(t *testing.T) {
+	fos := &commandtest.FakeOS{}
+	node := SerialNodes(
+		Arg[string]("NAME", testDesc),
+		OptionalArg[int]("COUNT", testDesc, Default(1)),
+		&ExecutorProcessor{func(o command.Output, d *command.Data) error {
+			if d.Int("COUNT") < 0 {
+				return o.Stderrf("negative count: %d\n", d.Int("COUNT"))
+			}
+			for i := 0; i < d.Int("COUNT"); i++ {
+				o.Stdoutf("hello %s\n", d.String("NAME"))
+			}
+			return nil
+		}},
+		SimpleProcessor(func(i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
+			if d.OS != nil {
+				ed.Executable = append(ed.Executable, d.OS.SetEnvVar("NAME", d.String("NAME")))
+			}
+			return nil
+		}, nil),
+	)
+
+	for _, test := range []struct {
+		name           string
+		args           []string
+		opts           []RunOption
+		want           *RunResult
+		wantErr        error
+		wantExecutable []string
+		wantForwarded  []string
+	}{
+		{
+			name: "captures stdout and data",
+			args: []string{"there", "2"},
+			want: &RunResult{
+				Stdout: "hello there\nhello there\n",
+				Data: &command.Data{Values: map[string]interface{}{
+					"NAME":  "there",
+					"COUNT": 2,
+				}},
+			},
+		},
+		{
+			name: "captures stderr and error",
+			args: []string{"there", "-1"},
+			want: &RunResult{
+				Stderr: "negative count: -1\n",
+				Err:    fmt.Errorf("negative count: -1"),
+				Data: &command.Data{Values: map[string]interface{}{
+					"NAME":  "there",
+					"COUNT": -1,
+				}},
+			},
+			wantErr: fmt.Errorf("negative count: -1"),
+		},
+		{
+			name: "captures usage errors",
+			want: &RunResult{
+				Stderr: "Argument \"NAME\" requires at least 1 argument, got 0\n",
+				Err:    fmt.Errorf(`Argument "NAME" requires at least 1 argument, got 0`),
+				Data:   &command.Data{},
+			},
+			wantErr: fmt.Errorf(`Argument "NAME" requires at least 1 argument, got 0`),
+		},
+		{
+			name: "uses provided OS",
+			args: []string{"you"},
+			opts: []RunOption{RunOS(fos)},
+			want: &RunResult{
+				Stdout: "hello you\n",
+				Data: &command.Data{
+					Values: map[string]interface{}{
+						"NAME":  "you",
+						"COUNT": 1,
+					},
+					OS: fos,
+				},
+			},
+			wantExecutable: []string{fos.SetEnvVar("NAME", "you")},
+		},
+		{
+			name: "forwards output",
+			args: []string{"there", "-1"},
+			want: &RunResult{
+				Stderr: "negative count: -1\n",
+				Err:    fmt.Errorf("negative count: -1"),
+				Data: &command.Data{Values: map[string]interface{}{
+					"NAME":  "there",
+					"COUNT": -1,
+				}},
+			},
+			wantErr:       fmt.Errorf("negative count: -1"),
+			wantForwarded: []string{"stderr: negative count: -1\n"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var forwarded []string
+			fo := command.OutputFromFuncs(func(s string) {
+				forwarded = append(forwarded, "stdout: "+s)
+			}, func(s string) {
+				forwarded = append(forwarded, "stderr: "+s)
+			})
+			opts := test.opts
+			if test.wantForwarded != nil {
+				opts = append(opts, RunForwardOutput(fo))
+			}
+
+			got, err := Run(node, test.args, opts...)
+			fo.Close()
+
+			testutil.CmpError(t, "Run()", test.wantErr, err)
+			testutil.CmpError(t, "Run() returned incorrect RunResult.Err", test.want.Err, got.Err)
+			// Executors are functions, so only compare the executables.
+			testutil.Cmp(t, "Run() returned incorrect executables", test.wantExecutable, got.ExecuteData.Executable)
+			got.Err, test.want.Err = nil, nil
+			got.ExecuteData = nil
+			testutil.Cmp(t, "Run() returned incorrect result", test.want, got, cmpopts.IgnoreUnexported(command.Data{}))
+			testutil.Cmp(t, "Run() forwarded incorrect output", test.wantForwarded, forwarded)
+		})
+	}
+}
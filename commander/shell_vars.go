@@ -0,0 +1,114 @@
+package commander
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/leep-frog/command/command"
+	"golang.org/x/exp/maps"
+)
+
+var (
+	shellVarNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
+
+// DetectShell returns the `command.ShellType` based on the `SHELL` environment
+// variable. `command.BashShell` is returned if the shell isn't recognized.
+func DetectShell() command.ShellType {
+	if s, ok := command.OSLookupEnv("SHELL"); ok {
+		switch filepath.Base(s) {
+		case "fish":
+			return command.FishShell
+		case "zsh":
+			return command.ZshShell
+		}
+	}
+	return command.BashShell
+}
+
+// EmitShellVarsOption is an option interface for modifying `EmitShellVars` processors.
+type EmitShellVarsOption interface {
+	modifyEmitShellVars(*emitShellVars)
+}
+
+// ForShell is an `EmitShellVarsOption` that produces output for the provided
+// `command.ShellType` (rather than the one detected by `DetectShell`).
+func ForShell(s command.ShellType) EmitShellVarsOption {
+	return &forShell{s}
+}
+
+type forShell struct {
+	shell command.ShellType
+}
+
+func (fs *forShell) modifyEmitShellVars(esv *emitShellVars) {
+	esv.shell = fs.shell
+}
+
+// EmitShellVars returns a `command.Processor` that prints (to stdout) shell
+// statements that set each of the provided variables. This is intended for
+// CLIs whose output is evaluated by the shell (e.g. `eval "$(mycli env)"`).
+// Statements are printed in alphabetical order of variable name and values
+// are quoted so they are interpreted literally.
+//
+// Unlike `SetEnvVarProcessor`, this does not modify `command.ExecuteData.Executable`.
+func EmitShellVars(vars map[string]string, opts ...EmitShellVarsOption) command.Processor {
+	esv := &emitShellVars{vars: vars}
+	for _, opt := range opts {
+		opt.modifyEmitShellVars(esv)
+	}
+	return SimpleProcessor(esv.execute, nil)
+}
+
+type emitShellVars struct {
+	vars  map[string]string
+	shell command.ShellType
+}
+
+func (esv *emitShellVars) execute(i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
+	shell := esv.shell
+	if shell == command.DefaultShell {
+		shell = DetectShell()
+	}
+
+	keys := maps.Keys(esv.vars)
+	slices.Sort(keys)
+
+	var lines []string
+	for _, k := range keys {
+		if !shellVarNameRegex.MatchString(k) {
+			return o.Stderrf("[EmitShellVars] invalid variable name: %q\n", k)
+		}
+
+		v := esv.vars[k]
+		switch shell {
+		case command.BashShell, command.ZshShell:
+			lines = append(lines, fmt.Sprintf("export %s=%s", k, posixQuote(v)))
+		case command.FishShell:
+			lines = append(lines, fmt.Sprintf("set -gx %s %s", k, fishQuote(v)))
+		default:
+			return o.Stderrf("[EmitShellVars] unsupported shell: %q\n", shell)
+		}
+	}
+
+	for _, l := range lines {
+		o.Stdoutln(l)
+	}
+	return nil
+}
+
+// posixQuote wraps the value in single quotes. Nothing is special inside of
+// single quotes, so embedded single quotes are handled by closing the quoted
+// string, adding an escaped quote, and then re-opening it.
+func posixQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// fishQuote wraps the value in single quotes. Inside of fish single quotes,
+// only backslashes and single quotes need to be escaped.
+func fishQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
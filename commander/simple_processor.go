@@ -26,6 +26,17 @@ func SuperSimpleProcessor(f func(*command.Input, *command.Data) error) command.P
 	}
 }
 
+// CompletionSetup returns a processor that runs the provided function only in
+// the completion context. It is useful for loading data into `command.Data`
+// that is only needed by subsequent completers. Execution and usage are no-ops.
+func CompletionSetup(f func(*command.Input, *command.Data) error) command.Processor {
+	return &simpleProcessor{
+		c: func(i *command.Input, d *command.Data) (*command.Completion, error) {
+			return nil, f(i, d)
+		},
+	}
+}
+
 type simpleProcessor struct {
 	e func(*command.Input, command.Output, *command.Data, *command.ExecuteData) error
 	c func(*command.Input, *command.Data) (*command.Completion, error)
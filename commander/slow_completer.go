@@ -0,0 +1,60 @@
+package commander
+
+import (
+	"time"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/internal/operator"
+	"github.com/leep-frog/command/internal/stubs"
+)
+
+// WithCompletionTimeout returns a `Completer` that runs the `inner` completer
+// and, if it doesn't finish within the provided duration, suggests the
+// `fallback` values instead. This keeps tab-completion responsive when the
+// inner completer is backed by a slow source (network, large directory, etc.).
+//
+// Note that a timed-out inner completer is left running in the background,
+// so it should not modify the provided `command.Data` object.
+func WithCompletionTimeout[T any](inner Completer[T], d time.Duration, fallback []T) Completer[T] {
+	return &timeoutCompleter[T]{inner, d, fallback}
+}
+
+type timeoutCompleter[T any] struct {
+	inner    Completer[T]
+	d        time.Duration
+	fallback []T
+}
+
+func (tc *timeoutCompleter[T]) Complete(t T, data *command.Data) (*command.Completion, error) {
+	return tc.completeWithOperator(t, operator.GetOperator[T](), data)
+}
+
+func (tc *timeoutCompleter[T]) completeWithOperator(t T, op operator.Operator[T], data *command.Data) (*command.Completion, error) {
+	type result struct {
+		c   *command.Completion
+		err error
+	}
+
+	// Buffered so the goroutine can always exit, even after a timeout.
+	ch := make(chan *result, 1)
+	timeout := stubs.TimeAfter(tc.d)
+	go func() {
+		c, err := completeWithOperator(tc.inner, op, t, data)
+		ch <- &result{c, err}
+	}()
+
+	select {
+	case r := <-ch:
+		return r.c, r.err
+	case <-timeout:
+		var suggestions []string
+		for _, f := range tc.fallback {
+			suggestions = append(suggestions, op.ToArgs(f)...)
+		}
+		return &command.Completion{Suggestions: suggestions}, nil
+	}
+}
+
+func (tc *timeoutCompleter[T]) modifyArgumentOption(ao *argumentOption[T]) {
+	ao.completer = tc
+}
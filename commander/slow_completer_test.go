@@ -0,0 +1,143 @@
+package commander
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/commandtest"
+	"github.com/leep-frog/command/internal/stubs"
+	"github.com/leep-frog/command/internal/testutil"
+)
+
+func TestWithCompletionTimeout(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		// inner is run as the inner completer. The provided channel is closed
+		// when the test completes (so blocked completers can be released).
+		inner func(release chan struct{}) (*command.Completion, error)
+		// timesOut is whether or not the stubbed timeout should fire.
+		timesOut bool
+		args     string
+		want     *command.Autocompletion
+		wantErr  error
+	}{
+		{
+			name: "uses inner completer if it finishes in time",
+			inner: func(chan struct{}) (*command.Completion, error) {
+				return &command.Completion{Suggestions: []string{"alpha", "beta", "bravo"}}, nil
+			},
+			args: "cmd ",
+			want: &command.Autocompletion{
+				Suggestions: []string{"alpha", "beta", "bravo"},
+			},
+		},
+		{
+			name: "filters inner completer suggestions",
+			inner: func(chan struct{}) (*command.Completion, error) {
+				return &command.Completion{Suggestions: []string{"alpha", "beta", "bravo"}}, nil
+			},
+			args: "cmd b",
+			want: &command.Autocompletion{
+				Suggestions: []string{"beta", "bravo"},
+			},
+		},
+		{
+			name: "returns inner completer error if it finishes in time",
+			inner: func(chan struct{}) (*command.Completion, error) {
+				return nil, fmt.Errorf("oops")
+			},
+			args:    "cmd ",
+			wantErr: fmt.Errorf("oops"),
+		},
+		{
+			name: "uses fallback if inner completer times out",
+			inner: func(release chan struct{}) (*command.Completion, error) {
+				<-release
+				return &command.Completion{Suggestions: []string{"alpha", "beta", "bravo"}}, nil
+			},
+			timesOut: true,
+			args:     "cmd ",
+			want: &command.Autocompletion{
+				Suggestions: []string{"one", "three", "two"},
+			},
+		},
+		{
+			name: "filters fallback suggestions",
+			inner: func(release chan struct{}) (*command.Completion, error) {
+				<-release
+				return &command.Completion{Suggestions: []string{"alpha", "beta", "bravo"}}, nil
+			},
+			timesOut: true,
+			args:     "cmd t",
+			want: &command.Autocompletion{
+				Suggestions: []string{"three", "two"},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			inner := test.inner
+			release := make(chan struct{})
+			t.Cleanup(func() { close(release) })
+
+			var mu sync.Mutex
+			var gotDurations []time.Duration
+			stubs.StubTimeAfter(t, func(d time.Duration) <-chan time.Time {
+				mu.Lock()
+				gotDurations = append(gotDurations, d)
+				mu.Unlock()
+				c := make(chan time.Time, 1)
+				if test.timesOut {
+					c <- time.Time{}
+				}
+				return c
+			})
+
+			cmp := WithCompletionTimeout(CompleterFromFunc(func(string, *command.Data) (*command.Completion, error) {
+				return inner(release)
+			}), 100*time.Millisecond, []string{"one", "two", "three"})
+
+			autocompleteTest(t, &commandtest.CompleteTestCase{
+				Node:    SerialNodes(Arg[string]("S", testDesc, cmp)),
+				Args:    test.args,
+				Want:    test.want,
+				WantErr: test.wantErr,
+				WantData: &command.Data{Values: map[string]interface{}{
+					"S": strings.TrimPrefix(test.args, "cmd "),
+				}},
+			}, nil)
+			mu.Lock()
+			defer mu.Unlock()
+			testutil.Cmp(t, "WithCompletionTimeout used incorrect timeouts", []time.Duration{100 * time.Millisecond}, gotDurations)
+		})
+	}
+}
+
+func TestWithCompletionTimeoutUsesArgumentOperator(t *testing.T) {
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+	stubs.StubTimeAfter(t, func(time.Duration) <-chan time.Time {
+		c := make(chan time.Time, 1)
+		c <- time.Time{}
+		return c
+	})
+
+	cmp := WithCompletionTimeout(CompleterFromFunc(func(time.Time, *command.Data) (*command.Completion, error) {
+		<-release
+		return nil, nil
+	}), time.Second, []time.Time{
+		time.Date(2024, 4, 5, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC),
+	})
+
+	autocompleteTest(t, &commandtest.CompleteTestCase{
+		Node: SerialNodes(TimeArg("when", testDesc, time.DateOnly, cmp)),
+		Args: "cmd ",
+		Want: &command.Autocompletion{
+			Suggestions: []string{"2024-04-05", "2024-05-06"},
+		},
+	}, nil)
+}
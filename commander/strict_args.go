@@ -0,0 +1,91 @@
+package commander
+
+import (
+	"strings"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/internal/spycommander"
+)
+
+// StrictArgs returns a `command.Processor` that runs the provided positional
+// processors in order. If too few arguments are provided to any of them, the
+// resulting error includes the full positional signature of the provided
+// processors along with the arguments that were actually received (rather than
+// only mentioning the specific argument that was missing values).
+func StrictArgs(ps ...command.Processor) command.Processor {
+	return &strictArgs{ps}
+}
+
+type strictArgs struct {
+	ps []command.Processor
+}
+
+func (sa *strictArgs) Execute(i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
+	remaining := i.Remaining()
+	// Not enough args errors are replaced by the consolidated error below.
+	ieo := command.NewIgnoreErrOutput(o, IsNotEnoughArgsError)
+	for _, p := range sa.ps {
+		err := spycommander.ProcessOrExecute(p, i, ieo, d, ed)
+		if err == nil {
+			continue
+		}
+
+		ne, ok := err.(*notEnoughArgs)
+		if !ok {
+			return err
+		}
+
+		signature, sigErr := sa.signature()
+		if sigErr != nil {
+			return o.Annotatef(sigErr, "failed to generate argument signature")
+		}
+		return o.Err(&notEnoughArgs{
+			name:      ne.name,
+			req:       ne.req,
+			got:       ne.got,
+			signature: &signature,
+			received:  remaining[:len(remaining)-i.NumRemaining()],
+		})
+	}
+	return nil
+}
+
+// signature returns the usage line for the positional processors.
+func (sa *strictArgs) signature() (string, error) {
+	u := &command.Usage{}
+	input := command.NewInput(nil, nil)
+	d := &command.Data{}
+	if err := usageProcessors(sa.ps, input, d, u); err != nil {
+		return "", err
+	}
+	return strings.SplitN(u.String(), "\n", 2)[0], nil
+}
+
+func (sa *strictArgs) Complete(i *command.Input, d *command.Data) (*command.Completion, error) {
+	return completeProcessors(sa.ps, i, d)
+}
+
+func (sa *strictArgs) Usage(i *command.Input, d *command.Data, u *command.Usage) error {
+	return usageProcessors(sa.ps, i, d, u)
+}
+
+// completeProcessors runs completion for the provided processors in order,
+// returning the first non-nil completion or error.
+func completeProcessors(ps []command.Processor, i *command.Input, d *command.Data) (*command.Completion, error) {
+	for _, p := range ps {
+		if c, err := processOrComplete(p, i, d); c != nil || err != nil {
+			return c, err
+		}
+	}
+	return nil, nil
+}
+
+// usageProcessors adds the usage of the provided processors in order.
+func usageProcessors(ps []command.Processor, i *command.Input, d *command.Data, u *command.Usage) error {
+	for _, p := range ps {
+		if err := spycommander.ProcessOrUsage(p, i, d, u); err != nil {
+			return err
+		}
+	}
+	return nil
+}
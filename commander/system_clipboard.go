@@ -0,0 +1,61 @@
+package commander
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+
+	"github.com/leep-frog/command/command"
+)
+
+// ClipboardCompleter returns a `Completer` that suggests the current contents
+// of the system clipboard as a single suggestion (useful for pasting tokens,
+// IDs, etc.). Surrounding whitespace is trimmed, and nothing is suggested if
+// the clipboard is empty or contains multiple lines (since those can't be
+// represented as a single suggestion).
+func ClipboardCompleter[T any]() Completer[T] {
+	return CompleterFromFunc(func(T, *command.Data) (*command.Completion, error) {
+		s, err := clipboardContents()
+		if err != nil {
+			return nil, fmt.Errorf("[ClipboardCompleter] failed to read clipboard: %v", err)
+		}
+
+		s = strings.TrimSpace(s)
+		if s == "" || strings.ContainsAny(s, "\r\n") {
+			return nil, nil
+		}
+		return &command.Completion{Suggestions: []string{s}}, nil
+	})
+}
+
+// clipboardContents returns the contents of the system clipboard.
+func clipboardContents() (string, error) {
+	lines, err := clipboardCommand().Run(nil, &command.Data{})
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// clipboardCommand returns the `ShellCommand` that reads the clipboard on the
+// current OS.
+func clipboardCommand() *ShellCommand[[]string] {
+	switch runtime.GOOS {
+	case "darwin":
+		return &ShellCommand[[]string]{
+			CommandName: "pbpaste",
+			HideStderr:  true,
+		}
+	case "windows":
+		return &ShellCommand[[]string]{
+			CommandName: "powershell",
+			Args:        []string{"-NoProfile", "-Command", "Get-Clipboard"},
+			HideStderr:  true,
+		}
+	}
+	return &ShellCommand[[]string]{
+		CommandName: "xclip",
+		Args:        []string{"-selection", "clipboard", "-o"},
+		HideStderr:  true,
+	}
+}
@@ -0,0 +1,103 @@
+package commander
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/commandtest"
+)
+
+func TestClipboardCompleter(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		// clipboard is the output of the clipboard command.
+		clipboard []string
+		// clipboardErr is the error returned by the clipboard command.
+		clipboardErr error
+		ctc          *commandtest.CompleteTestCase
+	}{
+		{
+			name:      "suggests clipboard contents",
+			clipboard: []string{"abc-123"},
+			ctc: &commandtest.CompleteTestCase{
+				Args: "cmd ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"abc-123"},
+				},
+			},
+		},
+		{
+			name:      "trims clipboard contents",
+			clipboard: []string{"  abc-123", ""},
+			ctc: &commandtest.CompleteTestCase{
+				Args: "cmd ",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"abc-123"},
+				},
+			},
+		},
+		{
+			name:      "suggests clipboard contents that match the prefix",
+			clipboard: []string{"abc-123"},
+			ctc: &commandtest.CompleteTestCase{
+				Args: "cmd ab",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"abc-123"},
+				},
+			},
+		},
+		{
+			name:      "doesn't suggest clipboard contents that don't match the prefix",
+			clipboard: []string{"abc-123"},
+			ctc: &commandtest.CompleteTestCase{
+				Args: "cmd xy",
+			},
+		},
+		{
+			name: "suggests nothing if clipboard is empty",
+			ctc: &commandtest.CompleteTestCase{
+				Args: "cmd ",
+			},
+		},
+		{
+			name:      "suggests nothing if clipboard is only whitespace",
+			clipboard: []string{" ", "\t"},
+			ctc: &commandtest.CompleteTestCase{
+				Args: "cmd ",
+			},
+		},
+		{
+			name:      "suggests nothing if clipboard has multiple lines",
+			clipboard: []string{"abc", "123"},
+			ctc: &commandtest.CompleteTestCase{
+				Args: "cmd ",
+			},
+		},
+		{
+			name:         "fails if clipboard can't be read",
+			clipboardErr: fmt.Errorf("no clipboard tool"),
+			ctc: &commandtest.CompleteTestCase{
+				Args:    "cmd ",
+				WantErr: fmt.Errorf("[ClipboardCompleter] failed to read clipboard: failed to execute shell command: no clipboard tool"),
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			sc := clipboardCommand()
+			test.ctc.RunResponses = []*commandtest.FakeRun{{
+				Stdout: test.clipboard,
+				Err:    test.clipboardErr,
+			}}
+			test.ctc.WantRunContents = []*commandtest.RunContents{{
+				Name: sc.CommandName,
+				Args: sc.Args,
+			}}
+			test.ctc.Node = SerialNodes(Arg[string]("TOKEN", testDesc, ClipboardCompleter[string]()))
+			test.ctc.WantData = &command.Data{Values: map[string]interface{}{
+				"TOKEN": test.ctc.Args[len("cmd "):],
+			}}
+			autocompleteTest(t, test.ctc, nil)
+		})
+	}
+}
@@ -1,7 +1,11 @@
 package commander
 
 import (
+	"fmt"
+	"net"
+	"net/url"
 	"strings"
+	"unicode"
 
 	"github.com/leep-frog/command/command"
 )
@@ -18,3 +22,107 @@ func FileNumberInputTransformer(UpToIndexInclusive int) *command.InputTransforme
 		return nil, o.Stderrf("Expected either 1 or 2 parts, got %d\n", len(sl))
 	}, UpToIndexInclusive: UpToIndexInclusive}
 }
+
+// Trim returns a `Transformer` that removes leading and trailing whitespace
+// from a string argument.
+func Trim() *Transformer[string] {
+	return &Transformer[string]{F: func(s string, d *command.Data) (string, error) {
+		return strings.TrimSpace(s), nil
+	}}
+}
+
+// Unquote returns a `Transformer` that removes a balanced pair of surrounding
+// quotes (either single or double) from a string argument. Values with
+// unbalanced or mismatched quotes are left intact.
+func Unquote() *Transformer[string] {
+	return &Transformer[string]{F: func(s string, d *command.Data) (string, error) {
+		if len(s) < 2 {
+			return s, nil
+		}
+		if first, last := s[0], s[len(s)-1]; first == last && (first == '"' || first == '\'') {
+			return s[1 : len(s)-1], nil
+		}
+		return s, nil
+	}}
+}
+
+// URLEncode returns a `Transformer` that query-escapes a string argument
+// (see `url.QueryEscape`).
+func URLEncode() *Transformer[string] {
+	return &Transformer[string]{F: func(s string, d *command.Data) (string, error) {
+		return url.QueryEscape(s), nil
+	}}
+}
+
+// URLDecode returns a `Transformer` that query-unescapes a string argument
+// (see `url.QueryUnescape`). It fails if the argument is not properly encoded.
+func URLDecode() *Transformer[string] {
+	return &Transformer[string]{F: func(s string, d *command.Data) (string, error) {
+		r, err := url.QueryUnescape(s)
+		if err != nil {
+			return "", fmt.Errorf("[URLDecode] failed to decode %q: %v", s, err)
+		}
+		return r, nil
+	}}
+}
+
+// NormalizeMAC returns a `Transformer` that converts a MAC address into its
+// canonical form (lowercase, colon-separated; see `net.HardwareAddr.String`).
+// It fails if the argument isn't a valid MAC address.
+func NormalizeMAC() *Transformer[string] {
+	return &Transformer[string]{F: func(s string, d *command.Data) (string, error) {
+		mac, err := net.ParseMAC(s)
+		if err != nil {
+			return "", fmt.Errorf("[NormalizeMAC] value %q isn't a valid MAC address: %v", s, err)
+		}
+		return mac.String(), nil
+	}}
+}
+
+// ToLower returns a `Transformer` that converts a string argument to lowercase.
+// Since transformers run before validators, this can be combined with
+// validators like `InList` to accept values regardless of case.
+func ToLower() *Transformer[string] {
+	return &Transformer[string]{F: func(s string, d *command.Data) (string, error) {
+		return strings.ToLower(s), nil
+	}}
+}
+
+// ToUpper returns a `Transformer` that converts a string argument to uppercase.
+func ToUpper() *Transformer[string] {
+	return &Transformer[string]{F: func(s string, d *command.Data) (string, error) {
+		return strings.ToUpper(s), nil
+	}}
+}
+
+// ToTitle returns a `Transformer` that converts a string argument to title case
+// (the first letter of each word is uppercased and all other letters are
+// lowercased). Whitespace is left intact.
+func ToTitle() *Transformer[string] {
+	return &Transformer[string]{F: func(s string, d *command.Data) (string, error) {
+		rs := []rune(s)
+		for i, r := range rs {
+			if i == 0 || unicode.IsSpace(rs[i-1]) {
+				rs[i] = unicode.ToTitle(r)
+			} else {
+				rs[i] = unicode.ToLower(r)
+			}
+		}
+		return string(rs), nil
+	}}
+}
+
+// ToLowerList is the list-argument equivalent of `ToLower`.
+func ToLowerList() *Transformer[[]string] {
+	return TransformerList(ToLower())
+}
+
+// ToUpperList is the list-argument equivalent of `ToUpper`.
+func ToUpperList() *Transformer[[]string] {
+	return TransformerList(ToUpper())
+}
+
+// ToTitleList is the list-argument equivalent of `ToTitle`.
+func ToTitleList() *Transformer[[]string] {
+	return TransformerList(ToTitle())
+}
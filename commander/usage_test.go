@@ -345,6 +345,46 @@ func TestUsage(t *testing.T) {
 				}, "\n"),
 			},
 		},
+		{
+			name: "shared flags are included in branch usage",
+			etc: &commandtest.ExecuteTestCase{
+				Args: []string{"deploy"},
+				Node: SerialNodes(
+					FlagProcessor(
+						Flag[string]("env", 'e', "shared env"),
+					),
+					&BranchNode{
+						Branches: map[string]command.Node{
+							"deploy": SerialNodes(
+								FlagProcessor(
+									BoolFlag("force", 'f', "force it"),
+								),
+								Arg[string]("SVC", "service"),
+							),
+							"status": nil,
+						},
+					},
+				),
+				WantStdout: strings.Join([]string{
+					"SVC --env|-e ENV --force|-f",
+					"",
+					"Arguments:",
+					"  SVC: service",
+					"",
+					"Flags:",
+					"  [e] env: shared env",
+					"  [f] force: force it",
+					"",
+				}, "\n"),
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "deploy"},
+					},
+				},
+			},
+		},
 		{
 			name: "works with simple branch node and shortcut node",
 			etc: &commandtest.ExecuteTestCase{
@@ -941,6 +981,56 @@ func TestUsage(t *testing.T) {
 				}, "\n"),
 			},
 		},
+		{
+			name: "works with categorized flags",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					Arg[string]("SARG", "desc"),
+					FlagProcessor(
+						FlagCategory("Network", Flag[int]("port", 'p', "port number")),
+						BoolFlag("verbose", 'v', "verbose output"),
+						FlagCategory("Network", Flag[string]("host", FlagNoShortName, "host name")),
+						FlagCategory("Auth", BoolFlag("anonymous", 'a', "no auth")),
+						Flag[string]("name", 'n', "some name"),
+					),
+				),
+				WantStdout: strings.Join([]string{
+					"SARG --port|-p PORT --verbose|-v --host HOST --anonymous|-a --name|-n NAME",
+					"",
+					"Arguments:",
+					"  SARG: desc",
+					"",
+					"Flags:",
+					"  [n] name: some name",
+					"  [v] verbose: verbose output",
+					"",
+					"Auth Flags:",
+					"  [a] anonymous: no auth",
+					"",
+					"Network Flags:",
+					"      host: host name",
+					"  [p] port: port number",
+					"",
+				}, "\n"),
+			},
+		},
+		{
+			name: "works with only categorized flags",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					FlagProcessor(
+						FlagCategory("Network", Flag[int]("port", 'p', "port number")),
+					),
+				),
+				WantStdout: strings.Join([]string{
+					"--port|-p PORT",
+					"",
+					"Network Flags:",
+					"  [p] port: port number",
+					"",
+				}, "\n"),
+			},
+		},
 		{
 			name: "Fails if validation error",
 			etc: &commandtest.ExecuteTestCase{
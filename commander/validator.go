@@ -2,9 +2,15 @@ package commander
 
 import (
 	"fmt"
+	"net"
 	"os"
+	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
 
 	"github.com/leep-frog/command/command"
 	"golang.org/x/exp/constraints"
@@ -85,6 +91,55 @@ func Not[T any](vo *ValidatorOption[T]) *ValidatorOption[T] {
 	}
 }
 
+// And [`ValidatorOption`] validates an argument satisfies all of the provided
+// validators. Validators are run in order and validation stops at the first failure.
+func And[T any](vos ...*ValidatorOption[T]) *ValidatorOption[T] {
+	usage := combinedUsage("And", vos)
+	return &ValidatorOption[T]{
+		func(t T, d *command.Data) error {
+			for _, vo := range vos {
+				if err := vo.Validate(t, d); err != nil {
+					return fmt.Errorf("[%s] failed: %v", usage, err)
+				}
+			}
+			return nil
+		},
+		usage,
+	}
+}
+
+// Or [`ValidatorOption`] validates an argument satisfies at least one of the
+// provided validators. If none of the validators are satisfied (or if no
+// validators are provided), then the error includes all of the validation failures.
+func Or[T any](vos ...*ValidatorOption[T]) *ValidatorOption[T] {
+	usage := combinedUsage("Or", vos)
+	return &ValidatorOption[T]{
+		func(t T, d *command.Data) error {
+			var errs []string
+			for _, vo := range vos {
+				err := vo.Validate(t, d)
+				if err == nil {
+					return nil
+				}
+				errs = append(errs, err.Error())
+			}
+			if len(errs) == 0 {
+				return fmt.Errorf("[%s] failed: no validators provided", usage)
+			}
+			return fmt.Errorf("[%s] failed: %s", usage, strings.Join(errs, "; "))
+		},
+		usage,
+	}
+}
+
+func combinedUsage[T any](name string, vos []*ValidatorOption[T]) string {
+	var usages []string
+	for _, vo := range vos {
+		usages = append(usages, vo.Usage)
+	}
+	return fmt.Sprintf("%s(%s)", name, strings.Join(usages, ", "))
+}
+
 // Contains [`ValidatorOption`] validates an argument contains the provided string.
 func Contains(s string) *ValidatorOption[string] {
 	return &ValidatorOption[string]{
@@ -130,6 +185,20 @@ func IsRegex() *ValidatorOption[string] {
 	}
 }
 
+// IsGoTemplate [`ValidatorOption`] validates an argument is a valid
+// `text/template` template.
+func IsGoTemplate() *ValidatorOption[string] {
+	return &ValidatorOption[string]{
+		func(s string, d *command.Data) error {
+			if _, err := template.New("").Parse(s); err != nil {
+				return fmt.Errorf("[IsGoTemplate] value %q isn't a valid template: %v", s, err)
+			}
+			return nil
+		},
+		"IsGoTemplate()",
+	}
+}
+
 // InList [`ValidatorOption`] validates an argument is one of the provided choices.
 func InList[T comparable](choices ...T) *ValidatorOption[T] {
 	return &ValidatorOption[T]{
@@ -145,6 +214,58 @@ func InList[T comparable](choices ...T) *ValidatorOption[T] {
 	}
 }
 
+// InRemoteList [`ValidatorOption`] validates an argument is one of the values
+// returned by the provided fetcher. The fetcher is run at validation time
+// (e.g. to retrieve a centrally-managed allowlist), and validation fails if
+// the fetcher returns an error.
+func InRemoteList(fetch func(*command.Data) ([]string, error)) *ValidatorOption[string] {
+	return &ValidatorOption[string]{
+		func(s string, d *command.Data) error {
+			allowed, err := fetch(d)
+			if err != nil {
+				return fmt.Errorf("[InRemoteList] failed to fetch allowed values: %v", err)
+			}
+			if !slices.Contains(allowed, s) {
+				return fmt.Errorf("[InRemoteList] argument must be one of %v", allowed)
+			}
+			return nil
+		},
+		"InRemoteList()",
+	}
+}
+
+// NotIn [`ValidatorOption`] validates an argument is not one of the provided
+// reserved values.
+func NotIn(reserved ...string) *ValidatorOption[string] {
+	return &ValidatorOption[string]{
+		func(s string, d *command.Data) error {
+			for _, r := range reserved {
+				if s == r {
+					return fmt.Errorf("[NotIn] value %q is reserved", r)
+				}
+			}
+			return nil
+		},
+		fmt.Sprintf("NotIn(%v)", reserved),
+	}
+}
+
+// NotInFold [`ValidatorOption`] validates an argument is not one of the provided
+// reserved values (ignoring case).
+func NotInFold(reserved ...string) *ValidatorOption[string] {
+	return &ValidatorOption[string]{
+		func(s string, d *command.Data) error {
+			for _, r := range reserved {
+				if strings.EqualFold(s, r) {
+					return fmt.Errorf("[NotInFold] value %q is reserved (matches %q)", s, r)
+				}
+			}
+			return nil
+		},
+		fmt.Sprintf("NotInFold(%v)", reserved),
+	}
+}
+
 type Lengthable[T any] interface {
 	string | []T
 }
@@ -188,6 +309,80 @@ func Length[K any, T Lengthable[K]](length int) *ValidatorOption[T] {
 	}
 }
 
+// ValidUTF8 [`ValidatorOption`] validates an argument is a valid UTF-8 string.
+func ValidUTF8() *ValidatorOption[string] {
+	return &ValidatorOption[string]{
+		func(s string, d *command.Data) error {
+			if !utf8.ValidString(s) {
+				return fmt.Errorf("[ValidUTF8] value %q isn't valid UTF-8", s)
+			}
+			return nil
+		},
+		"ValidUTF8()",
+	}
+}
+
+// MaxBytes [`ValidatorOption`] validates an argument is at most `n` bytes long.
+// Note that multibyte characters count as multiple bytes.
+func MaxBytes(n int) *ValidatorOption[string] {
+	return &ValidatorOption[string]{
+		func(s string, d *command.Data) error {
+			if len(s) > n {
+				return fmt.Errorf("[MaxBytes] value must be at most %d bytes; got %d bytes", n, len(s))
+			}
+			return nil
+		},
+		fmt.Sprintf("MaxBytes(%d)", n),
+	}
+}
+
+// IsMAC [`ValidatorOption`] validates an argument is a MAC address that can be
+// parsed by `net.ParseMAC` (e.g. `00:00:5e:00:53:01` or `00-00-5E-00-53-01`).
+// Use the `NormalizeMAC` transformer to store the value in canonical form.
+func IsMAC() *ValidatorOption[string] {
+	return &ValidatorOption[string]{
+		func(s string, d *command.Data) error {
+			if _, err := net.ParseMAC(s); err != nil {
+				return fmt.Errorf("[IsMAC] value %q isn't a valid MAC address: %v", s, err)
+			}
+			return nil
+		},
+		"IsMAC()",
+	}
+}
+
+// LuhnValid [`ValidatorOption`] validates an argument is a numeric string with
+// a valid Luhn checksum (as used by credit card numbers, for example).
+func LuhnValid() *ValidatorOption[string] {
+	return &ValidatorOption[string]{
+		func(s string, d *command.Data) error {
+			if s == "" {
+				return fmt.Errorf("[LuhnValid] value must not be empty")
+			}
+			var sum int
+			for i := range s {
+				c := s[len(s)-1-i]
+				if c < '0' || c > '9' {
+					return fmt.Errorf("[LuhnValid] value %q must only contain digits", s)
+				}
+				digit := int(c - '0')
+				// Double every second digit from the right.
+				if i%2 == 1 {
+					if digit *= 2; digit > 9 {
+						digit -= 9
+					}
+				}
+				sum += digit
+			}
+			if sum%10 != 0 {
+				return fmt.Errorf("[LuhnValid] value %q has an invalid Luhn checksum", s)
+			}
+			return nil
+		},
+		"LuhnValid()",
+	}
+}
+
 func fileExistanceValidator(vName, s string, shouldExist bool) (os.FileInfo, error) {
 	fi, err := os.Stat(s)
 
@@ -273,6 +468,89 @@ func IsFile() *ValidatorOption[string] {
 	}
 }
 
+// WithinDir [`ValidatorOption`] validates an argument is a path inside of the
+// provided `root` directory (or is the `root` directory itself). Both paths are
+// converted to absolute paths, so relative paths (and `..` elements) can't be
+// used to escape `root`. Note that the check is lexical, so symbolic links are
+// not resolved (a symlink inside of `root` that points elsewhere is accepted).
+func WithinDir(root string) *ValidatorOption[string] {
+	return &ValidatorOption[string]{
+		func(s string, d *command.Data) error {
+			absRoot, err := filepathAbs(root)
+			if err != nil {
+				return fmt.Errorf("[WithinDir] failed to get absolute path for root: %v", err)
+			}
+			absPath, err := filepathAbs(s)
+			if err != nil {
+				return fmt.Errorf("[WithinDir] failed to get absolute path: %v", err)
+			}
+			rel, err := filepath.Rel(absRoot, absPath)
+			if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				return fmt.Errorf("[WithinDir] path %q is not inside of %q", s, root)
+			}
+			return nil
+		},
+		fmt.Sprintf("WithinDir(%q)", root),
+	}
+}
+
+// IsRelativeSafePath [`ValidatorOption`] validates an argument is a non-empty
+// relative path that doesn't contain any `..` segments, so it can't refer to
+// anything outside of the directory it is resolved against. Unlike `WithinDir`,
+// this check is purely lexical and doesn't depend on a root directory. Both
+// forward slashes and backslashes are treated as path separators.
+func IsRelativeSafePath() *ValidatorOption[string] {
+	return &ValidatorOption[string]{
+		func(s string, d *command.Data) error {
+			if s == "" {
+				return fmt.Errorf("[IsRelativeSafePath] path must not be empty")
+			}
+			if filepath.IsAbs(s) || filepath.VolumeName(s) != "" || strings.HasPrefix(s, "/") || strings.HasPrefix(s, `\`) {
+				return fmt.Errorf("[IsRelativeSafePath] path %q must be relative", s)
+			}
+			for _, segment := range strings.FieldsFunc(s, func(r rune) bool { return r == '/' || r == '\\' }) {
+				if segment == ".." {
+					return fmt.Errorf("[IsRelativeSafePath] path %q must not contain \"..\" segments", s)
+				}
+			}
+			return nil
+		},
+		"IsRelativeSafePath()",
+	}
+}
+
+// HasExtension [`ValidatorOption`] validates an argument ends with one of the
+// provided file extensions. Extensions are case-insensitive and the leading
+// dot is optional (e.g. "go", ".go", and ".GO" are all equivalent). Multi-part
+// extensions (e.g. "tar.gz") are also supported.
+func HasExtension(exts ...string) *ValidatorOption[string] {
+	var normalized []string
+	for _, ext := range exts {
+		normalized = append(normalized, "."+strings.ToLower(strings.TrimPrefix(ext, ".")))
+	}
+	return &ValidatorOption[string]{
+		func(s string, d *command.Data) error {
+			lower := strings.ToLower(s)
+			for _, ext := range normalized {
+				if strings.HasSuffix(lower, ext) {
+					return nil
+				}
+			}
+			if ext := filepath.Ext(s); ext != "" {
+				return fmt.Errorf("[HasExtension] file %q has extension %q; must be one of %v", s, ext, normalized)
+			}
+			return fmt.Errorf("[HasExtension] file %q has no extension; must be one of %v", s, normalized)
+		},
+		fmt.Sprintf("HasExtension(%v)", normalized),
+	}
+}
+
+// AllHaveExtension [`ValidatorOption`] validates every element in a list
+// argument ends with one of the provided file extensions (see `HasExtension`).
+func AllHaveExtension(exts ...string) *ValidatorOption[[]string] {
+	return ListifyValidatorOption(HasExtension(exts...))
+}
+
 // Ordered options
 
 // EQ [`ValidatorOption`] validates an argument equals `n`.
@@ -353,6 +631,32 @@ func GTE[T constraints.Ordered](n T) *ValidatorOption[T] {
 	}
 }
 
+// Before [`ValidatorOption`] validates a time argument is before `t`.
+func Before(t time.Time) *ValidatorOption[time.Time] {
+	return &ValidatorOption[time.Time]{
+		func(v time.Time, d *command.Data) error {
+			if v.Before(t) {
+				return nil
+			}
+			return fmt.Errorf("[Before] value isn't before %v", t)
+		},
+		fmt.Sprintf("Before(%v)", t),
+	}
+}
+
+// After [`ValidatorOption`] validates a time argument is after `t`.
+func After(t time.Time) *ValidatorOption[time.Time] {
+	return &ValidatorOption[time.Time]{
+		func(v time.Time, d *command.Data) error {
+			if v.After(t) {
+				return nil
+			}
+			return fmt.Errorf("[After] value isn't after %v", t)
+		},
+		fmt.Sprintf("After(%v)", t),
+	}
+}
+
 // Positive [`ValidatorOption`] validates an argument is positive.
 func Positive[T constraints.Ordered]() *ValidatorOption[T] {
 	return &ValidatorOption[T]{
@@ -395,6 +699,35 @@ func Negative[T constraints.Ordered]() *ValidatorOption[T] {
 	}
 }
 
+var (
+	// boolishValues are the strings accepted by `IsBoolish` (in true/false pairs).
+	boolishValues = []string{"true", "false", "yes", "no", "on", "off"}
+)
+
+// parseBoolish converts a boolean-ish string (case-insensitive) to a bool.
+func parseBoolish(s string) (bool, bool) {
+	idx := slices.Index(boolishValues, strings.ToLower(s))
+	if idx < 0 {
+		return false, false
+	}
+	return idx%2 == 0, true
+}
+
+// IsBoolish is a `ValidatorOption` that validates a string argument is one of
+// "true", "false", "yes", "no", "on", or "off" (case-insensitive). Use
+// `BoolishArg` to store the corresponding bool value in `command.Data`.
+func IsBoolish() *ValidatorOption[string] {
+	return &ValidatorOption[string]{
+		func(s string, d *command.Data) error {
+			if _, ok := parseBoolish(s); !ok {
+				return fmt.Errorf("[IsBoolish] value must be one of %v", boolishValues)
+			}
+			return nil
+		},
+		"IsBoolish()",
+	}
+}
+
 // Between [`ValidatorOption`] validates an argument is between two numbers.
 func Between[T constraints.Ordered](start, end T, inclusive bool) *ValidatorOption[T] {
 	return &ValidatorOption[T]{
@@ -420,3 +753,83 @@ func Between[T constraints.Ordered](start, end T, inclusive bool) *ValidatorOpti
 		"Negative()",
 	}
 }
+
+// MultipleOf [`ValidatorOption`] validates an argument is a multiple of `n`.
+func MultipleOf[T constraints.Integer](n T) *ValidatorOption[T] {
+	return &ValidatorOption[T]{
+		func(v T, d *command.Data) error {
+			var zero T
+			if n == zero {
+				return fmt.Errorf("[MultipleOf] divisor cannot be zero")
+			}
+			if v%n != zero {
+				return fmt.Errorf("[MultipleOf] value isn't a multiple of %v", n)
+			}
+			return nil
+		},
+		fmt.Sprintf("MultipleOf(%v)", n),
+	}
+}
+
+// Contiguous [`ValidatorOption`] validates that the provided integers form a
+// consecutive sequence (in any order) with no gaps or duplicates.
+func Contiguous() *ValidatorOption[[]int] {
+	return &ValidatorOption[[]int]{
+		func(vs []int, d *command.Data) error {
+			sorted := slices.Clone(vs)
+			slices.Sort(sorted)
+			for i := 1; i < len(sorted); i++ {
+				if sorted[i] == sorted[i-1] {
+					return fmt.Errorf("[Contiguous] value %d is duplicated", sorted[i])
+				}
+				if sorted[i] != sorted[i-1]+1 {
+					return fmt.Errorf("[Contiguous] values aren't contiguous; missing %d", sorted[i-1]+1)
+				}
+			}
+			return nil
+		},
+		"Contiguous()",
+	}
+}
+
+// Numeric is a type constraint for integer and floating-point types.
+type Numeric interface {
+	constraints.Integer | constraints.Float
+}
+
+// InValues [`ValidatorOption`] validates a numeric argument is one of the
+// provided values. See `InValuesCompleter` for the corresponding completer.
+func InValues[T Numeric](values ...T) *ValidatorOption[T] {
+	return &ValidatorOption[T]{
+		func(v T, d *command.Data) error {
+			for _, value := range values {
+				if v == value {
+					return nil
+				}
+			}
+			return fmt.Errorf("[InValues] value must be one of %v", values)
+		},
+		fmt.Sprintf("InValues(%v)", values),
+	}
+}
+
+// SumBetween [`ValidatorOption`] validates the sum of a list of numbers is
+// between `lo` and `hi` (inclusive).
+func SumBetween[T Numeric](lo, hi T) *ValidatorOption[[]T] {
+	return &ValidatorOption[[]T]{
+		func(vs []T, d *command.Data) error {
+			var sum T
+			for _, v := range vs {
+				sum += v
+			}
+			if sum < lo {
+				return fmt.Errorf("[SumBetween] sum (%v) is less than lower bound (%v)", sum, lo)
+			}
+			if sum > hi {
+				return fmt.Errorf("[SumBetween] sum (%v) is greater than upper bound (%v)", sum, hi)
+			}
+			return nil
+		},
+		fmt.Sprintf("SumBetween(%v, %v)", lo, hi),
+	}
+}
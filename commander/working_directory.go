@@ -26,3 +26,33 @@ var (
 		GetwdKey,
 	}
 )
+
+// ChangeDir returns a `command.Processor` that changes the current working
+// directory to `path` for the remainder of the command's execution. The original
+// working directory is restored after execution completes (including after
+// any `command.ExecuteData.Executor` functions have run).
+// This processor has no effect on completion or usage.
+func ChangeDir(path string) command.Processor {
+	return SimpleProcessor(func(i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
+		if err := isDir("ChangeDir", path); err != nil {
+			return o.Err(err)
+		}
+
+		original, err := stubs.OSGetwd()
+		if err != nil {
+			return o.Annotatef(err, "failed to get current directory")
+		}
+
+		if err := stubs.OSChdir(path); err != nil {
+			return o.Annotatef(err, "failed to change directory to %q", path)
+		}
+
+		ed.Cleanup = append(ed.Cleanup, func() error {
+			if err := stubs.OSChdir(original); err != nil {
+				return fmt.Errorf("failed to restore directory to %q: %v", original, err)
+			}
+			return nil
+		})
+		return nil
+	}, nil)
+}
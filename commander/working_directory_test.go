@@ -0,0 +1,129 @@
+package commander
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/commandtest"
+	"github.com/leep-frog/command/internal/spycommandtest"
+	"github.com/leep-frog/command/internal/stubs"
+	"github.com/leep-frog/command/internal/testutil"
+)
+
+func TestChangeDir(t *testing.T) {
+	for _, test := range []struct {
+		name         string
+		etc          *commandtest.ExecuteTestCase
+		ietc         *spycommandtest.ExecuteTestCase
+		chdirErrs    map[string]error
+		wantChdirs   []string
+		wantFinalDir string
+	}{
+		{
+			name: "changes directory and restores it afterward",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					ChangeDir("testdata"),
+					Getwd,
+					&ExecutorProcessor{func(o command.Output, d *command.Data) error {
+						wd, err := stubs.OSGetwd()
+						if err != nil {
+							return err
+						}
+						o.Stdoutf("executor wd: %s\n", wd)
+						return nil
+					}},
+				),
+				WantStdout: "executor wd: testdata\n",
+				WantData: &command.Data{Values: map[string]interface{}{
+					GetwdKey: "testdata",
+				}},
+			},
+			wantChdirs:   []string{"testdata", "/original"},
+			wantFinalDir: "/original",
+		},
+		{
+			name: "restores directory even if a downstream node fails",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(
+					ChangeDir("testdata"),
+					Arg[int]("i", testDesc),
+				),
+				WantStderr: "Argument \"i\" requires at least 1 argument, got 0\n",
+				WantErr:    fmt.Errorf(`Argument "i" requires at least 1 argument, got 0`),
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsNotEnoughArgsError: true,
+				WantIsUsageError:         true,
+			},
+			wantChdirs:   []string{"testdata", "/original"},
+			wantFinalDir: "/original",
+		},
+		{
+			name: "fails if directory does not exist",
+			etc: &commandtest.ExecuteTestCase{
+				Node:       SerialNodes(ChangeDir("not-a-dir")),
+				WantStderr: "[ChangeDir] file \"not-a-dir\" does not exist\n",
+				WantErr:    fmt.Errorf(`[ChangeDir] file "not-a-dir" does not exist`),
+			},
+			wantFinalDir: "/original",
+		},
+		{
+			name: "fails if path is a file",
+			etc: &commandtest.ExecuteTestCase{
+				Node:       SerialNodes(ChangeDir("testdata/one.txt")),
+				WantStderr: "[ChangeDir] argument \"testdata/one.txt\" is a file\n",
+				WantErr:    fmt.Errorf(`[ChangeDir] argument "testdata/one.txt" is a file`),
+			},
+			wantFinalDir: "/original",
+		},
+		{
+			name: "fails if chdir fails",
+			etc: &commandtest.ExecuteTestCase{
+				Node:       SerialNodes(ChangeDir("testdata")),
+				WantStderr: "failed to change directory to \"testdata\": oops\n",
+				WantErr:    fmt.Errorf(`failed to change directory to "testdata": oops`),
+			},
+			chdirErrs: map[string]error{
+				"testdata": fmt.Errorf("oops"),
+			},
+			wantChdirs:   []string{"testdata"},
+			wantFinalDir: "/original",
+		},
+		{
+			name: "fails if directory can't be restored",
+			etc: &commandtest.ExecuteTestCase{
+				Node:       SerialNodes(ChangeDir("testdata")),
+				WantStderr: "failed to restore directory to \"/original\": whoops\n",
+				WantErr:    fmt.Errorf(`failed to restore directory to "/original": whoops`),
+			},
+			chdirErrs: map[string]error{
+				"/original": fmt.Errorf("whoops"),
+			},
+			wantChdirs:   []string{"testdata", "/original"},
+			wantFinalDir: "testdata",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			wd := "/original"
+			var gotChdirs []string
+			testutil.StubValue(t, &stubs.OSGetwd, func() (string, error) {
+				return wd, nil
+			})
+			stubs.StubChdir(t, func(dir string) error {
+				gotChdirs = append(gotChdirs, dir)
+				if err := test.chdirErrs[dir]; err != nil {
+					return err
+				}
+				wd = dir
+				return nil
+			})
+
+			executeTest(t, test.etc, test.ietc)
+
+			testutil.Cmp(t, "ChangeDir made incorrect os.Chdir calls", test.wantChdirs, gotChdirs)
+			testutil.Cmp(t, "ChangeDir resulted in incorrect final directory", test.wantFinalDir, wd)
+		})
+	}
+}
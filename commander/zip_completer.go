@@ -0,0 +1,44 @@
+package commander
+
+import (
+	"archive/zip"
+	"fmt"
+
+	"github.com/leep-frog/command/command"
+)
+
+// ZipEntryCompleter returns a `Completer` that suggests the entries inside of
+// the zip file whose path is stored in `command.Data` under `zipPathArgName`.
+// No suggestions are returned if the zip path argument hasn't been set.
+func ZipEntryCompleter[T any](zipPathArgName string) Completer[T] {
+	return CompleterFromFunc(func(t T, d *command.Data) (*command.Completion, error) {
+		if !d.Has(zipPathArgName) {
+			return nil, nil
+		}
+
+		zipPath := d.String(zipPathArgName)
+		entries, err := zipEntries(zipPath)
+		if err != nil {
+			return nil, fmt.Errorf("[ZipEntryCompleter] failed to read zip file %q: %v", zipPath, err)
+		}
+		return &command.Completion{
+			Suggestions: entries,
+			Distinct:    true,
+		}, nil
+	})
+}
+
+// zipEntries returns the names of all entries in the provided zip file.
+func zipEntries(path string) ([]string, error) {
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	var names []string
+	for _, f := range r.File {
+		names = append(names, f.Name)
+	}
+	return names, nil
+}
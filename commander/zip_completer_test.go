@@ -0,0 +1,137 @@
+package commander
+
+import (
+	"archive/zip"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/commandtest"
+	"github.com/leep-frog/command/internal/spycommandtest"
+)
+
+func TestZipEntryCompleter(t *testing.T) {
+	entries := []string{
+		"README.md",
+		"src/",
+		"src/main.go",
+		"src/util.go",
+	}
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "archive.zip")
+	writeZip(t, archive, entries)
+	missing := filepath.Join(dir, "missing.zip")
+	_, missingErr := zip.OpenReader(missing)
+
+	for _, test := range []struct {
+		name string
+		ctc  *commandtest.CompleteTestCase
+		ictc *spycommandtest.CompleteTestCase
+	}{
+		{
+			name: "completes zip entries",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(
+					Arg[string]("ZIP", testDesc),
+					Arg[string]("ENTRY", testDesc, ZipEntryCompleter[string]("ZIP")),
+				),
+				Args: fmt.Sprintf("cmd %s ", archive),
+				Want: &command.Autocompletion{
+					Suggestions: entries,
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"ZIP":   archive,
+					"ENTRY": "",
+				}},
+			},
+		},
+		{
+			name: "completes partial zip entries",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(
+					Arg[string]("ZIP", testDesc),
+					Arg[string]("ENTRY", testDesc, ZipEntryCompleter[string]("ZIP")),
+				),
+				Args: fmt.Sprintf("cmd %s src/", archive),
+				Want: &command.Autocompletion{
+					Suggestions: []string{"src/", "src/main.go", "src/util.go"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"ZIP":   archive,
+					"ENTRY": "src/",
+				}},
+			},
+		},
+		{
+			name: "completes distinct zip entries for list argument",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(
+					Arg[string]("ZIP", testDesc),
+					ListArg[string]("ENTRIES", testDesc, 1, command.UnboundedList, ZipEntryCompleter[[]string]("ZIP")),
+				),
+				Args: fmt.Sprintf("cmd %s src/main.go src/", archive),
+				Want: &command.Autocompletion{
+					Suggestions: []string{"src/", "src/util.go"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"ZIP":     archive,
+					"ENTRIES": []string{"src/main.go", "src/"},
+				}},
+			},
+		},
+		{
+			name: "doesn't complete if zip path isn't set",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(
+					OptionalArg[string]("ENTRY", testDesc, ZipEntryCompleter[string]("ZIP")),
+				),
+				Args: "cmd ",
+				WantData: &command.Data{Values: map[string]interface{}{
+					"ENTRY": "",
+				}},
+			},
+		},
+		{
+			name: "fails if zip can't be read",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(
+					Arg[string]("ZIP", testDesc),
+					Arg[string]("ENTRY", testDesc, ZipEntryCompleter[string]("ZIP")),
+				),
+				Args:    fmt.Sprintf("cmd %s ", missing),
+				WantErr: fmt.Errorf("[ZipEntryCompleter] failed to read zip file %q: %v", missing, missingErr),
+				WantData: &command.Data{Values: map[string]interface{}{
+					"ZIP":   missing,
+					"ENTRY": "",
+				}},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			autocompleteTest(t, test.ctc, test.ictc)
+		})
+	}
+}
+
+// writeZip writes a zip file with the provided (empty) entries to path.
+func writeZip(t *testing.T, path string, names []string) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("failed to create zip file: %v", err)
+	}
+	zw := zip.NewWriter(f)
+	for _, name := range names {
+		if _, err := zw.Create(name); err != nil {
+			t.Fatalf("failed to create zip entry: %v", err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("failed to close zip writer: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("failed to close zip file: %v", err)
+	}
+}
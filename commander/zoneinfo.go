@@ -0,0 +1,142 @@
+package commander
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/leep-frog/command/command"
+)
+
+var (
+	// timezoneNames is the list of IANA timezone names suggested by
+	// `TimezoneCompleter`.
+	timezoneNames = []string{
+		"UTC",
+		"Africa/Abidjan",
+		"Africa/Accra",
+		"Africa/Addis_Ababa",
+		"Africa/Algiers",
+		"Africa/Cairo",
+		"Africa/Casablanca",
+		"Africa/Dar_es_Salaam",
+		"Africa/Johannesburg",
+		"Africa/Khartoum",
+		"Africa/Kinshasa",
+		"Africa/Lagos",
+		"Africa/Nairobi",
+		"Africa/Tripoli",
+		"Africa/Tunis",
+		"America/Anchorage",
+		"America/Argentina/Buenos_Aires",
+		"America/Bogota",
+		"America/Caracas",
+		"America/Chicago",
+		"America/Denver",
+		"America/Edmonton",
+		"America/Halifax",
+		"America/Havana",
+		"America/Lima",
+		"America/Los_Angeles",
+		"America/Mexico_City",
+		"America/Montevideo",
+		"America/New_York",
+		"America/Panama",
+		"America/Phoenix",
+		"America/Puerto_Rico",
+		"America/Santiago",
+		"America/Sao_Paulo",
+		"America/St_Johns",
+		"America/Toronto",
+		"America/Vancouver",
+		"America/Winnipeg",
+		"Antarctica/McMurdo",
+		"Asia/Almaty",
+		"Asia/Baghdad",
+		"Asia/Bangkok",
+		"Asia/Dhaka",
+		"Asia/Dubai",
+		"Asia/Ho_Chi_Minh",
+		"Asia/Hong_Kong",
+		"Asia/Jakarta",
+		"Asia/Jerusalem",
+		"Asia/Kabul",
+		"Asia/Karachi",
+		"Asia/Kathmandu",
+		"Asia/Kolkata",
+		"Asia/Manila",
+		"Asia/Riyadh",
+		"Asia/Seoul",
+		"Asia/Shanghai",
+		"Asia/Singapore",
+		"Asia/Taipei",
+		"Asia/Tashkent",
+		"Asia/Tehran",
+		"Asia/Tokyo",
+		"Asia/Yangon",
+		"Atlantic/Azores",
+		"Atlantic/Reykjavik",
+		"Australia/Adelaide",
+		"Australia/Brisbane",
+		"Australia/Darwin",
+		"Australia/Hobart",
+		"Australia/Melbourne",
+		"Australia/Perth",
+		"Australia/Sydney",
+		"Europe/Amsterdam",
+		"Europe/Athens",
+		"Europe/Berlin",
+		"Europe/Brussels",
+		"Europe/Bucharest",
+		"Europe/Budapest",
+		"Europe/Dublin",
+		"Europe/Helsinki",
+		"Europe/Istanbul",
+		"Europe/Kyiv",
+		"Europe/Lisbon",
+		"Europe/London",
+		"Europe/Madrid",
+		"Europe/Moscow",
+		"Europe/Oslo",
+		"Europe/Paris",
+		"Europe/Prague",
+		"Europe/Rome",
+		"Europe/Stockholm",
+		"Europe/Vienna",
+		"Europe/Warsaw",
+		"Europe/Zurich",
+		"Indian/Maldives",
+		"Indian/Mauritius",
+		"Pacific/Auckland",
+		"Pacific/Fiji",
+		"Pacific/Guam",
+		"Pacific/Honolulu",
+		"Pacific/Port_Moresby",
+		"Pacific/Tongatapu",
+	}
+)
+
+// TimezoneCompleter returns a `Completer` that suggests IANA timezone names
+// (e.g. `America/New_York`). Only commonly used timezones are suggested,
+// so this should be paired with the `IsTimezone` validator rather than a
+// validator that requires the value to be one of the suggestions.
+func TimezoneCompleter() Completer[string] {
+	return CompleterFromFunc(func(string, *command.Data) (*command.Completion, error) {
+		return &command.Completion{
+			Suggestions: timezoneNames,
+		}, nil
+	})
+}
+
+// IsTimezone [`ValidatorOption`] validates an argument is a timezone name
+// that can be loaded by `time.LoadLocation`.
+func IsTimezone() *ValidatorOption[string] {
+	return &ValidatorOption[string]{
+		func(s string, d *command.Data) error {
+			if _, err := time.LoadLocation(s); err != nil {
+				return fmt.Errorf("[IsTimezone] value %q isn't a valid timezone: %v", s, err)
+			}
+			return nil
+		},
+		"IsTimezone()",
+	}
+}
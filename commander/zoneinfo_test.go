@@ -0,0 +1,137 @@
+package commander
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/commandtest"
+	"github.com/leep-frog/command/internal/spycommand"
+	"github.com/leep-frog/command/internal/spycommandtest"
+)
+
+func TestTimezoneCompleter(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		ctc  *commandtest.CompleteTestCase
+	}{
+		{
+			name: "completes timezone names in a region",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(Arg[string]("TZ", testDesc, TimezoneCompleter())),
+				Args: "cmd Australia/",
+				Want: &command.Autocompletion{
+					Suggestions: []string{
+						"Australia/Adelaide",
+						"Australia/Brisbane",
+						"Australia/Darwin",
+						"Australia/Hobart",
+						"Australia/Melbourne",
+						"Australia/Perth",
+						"Australia/Sydney",
+					},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"TZ": "Australia/",
+				}},
+			},
+		},
+		{
+			name: "completes partial timezone names",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(Arg[string]("TZ", testDesc, TimezoneCompleter())),
+				Args: "cmd America/S",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"America/Santiago", "America/Sao_Paulo", "America/St_Johns"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"TZ": "America/S",
+				}},
+			},
+		},
+		{
+			name: "completes partial timezone names from bundled list",
+			ctc: &commandtest.CompleteTestCase{
+				Node: SerialNodes(Arg[string]("TZ", testDesc, TimezoneCompleter())),
+				Args: "cmd America/New",
+				Want: &command.Autocompletion{
+					Suggestions: []string{"America/New_York"},
+				},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"TZ": "America/New",
+				}},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			autocompleteTest(t, test.ctc, nil)
+		})
+	}
+}
+
+func TestIsTimezone(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		etc  *commandtest.ExecuteTestCase
+		ietc *spycommandtest.ExecuteTestCase
+	}{
+		{
+			name: "IsTimezone succeeds for valid timezone",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(Arg[string]("TZ", testDesc, IsTimezone())),
+				Args: []string{"America/New_York"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"TZ": "America/New_York",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "America/New_York"},
+					},
+				},
+			},
+		},
+		{
+			name: "IsTimezone succeeds for UTC",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(Arg[string]("TZ", testDesc, IsTimezone())),
+				Args: []string{"UTC"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"TZ": "UTC",
+				}},
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "UTC"},
+					},
+				},
+			},
+		},
+		{
+			name: "IsTimezone fails for invalid timezone",
+			etc: &commandtest.ExecuteTestCase{
+				Node: SerialNodes(Arg[string]("TZ", testDesc, IsTimezone())),
+				Args: []string{"Mars/Olympus_Mons"},
+				WantData: &command.Data{Values: map[string]interface{}{
+					"TZ": "Mars/Olympus_Mons",
+				}},
+				WantStderr: "validation for \"TZ\" failed: [IsTimezone] value \"Mars/Olympus_Mons\" isn't a valid timezone: unknown time zone Mars/Olympus_Mons\n",
+				WantErr:    fmt.Errorf(`validation for "TZ" failed: [IsTimezone] value "Mars/Olympus_Mons" isn't a valid timezone: unknown time zone Mars/Olympus_Mons`),
+			},
+			ietc: &spycommandtest.ExecuteTestCase{
+				WantIsValidationError: true,
+				WantInput: &spycommandtest.SpyInput{
+					Args: []*spycommand.InputArg{
+						{Value: "Mars/Olympus_Mons"},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			executeTest(t, test.etc, test.ietc)
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package commandtest
 
 import (
+	"io"
 	"testing"
+	"time"
 
 	"github.com/leep-frog/command/internal/stubs"
 )
@@ -15,3 +17,44 @@ func StubEnv(t *testing.T, m map[string]string) {
 func StubGetwd(t *testing.T, wd string, err error) {
 	stubs.StubGetwd(t, wd, err)
 }
+
+// StubChdir stubs the function used by commander.ChangeDir to change the current working directory.
+func StubChdir(t *testing.T, f func(string) error) {
+	stubs.StubChdir(t, f)
+}
+
+// StubClock stubs the functions used to get the current time and to sleep
+// (e.g. by commander.RateLimit).
+func StubClock(t *testing.T, now func() time.Time, sleep func(time.Duration)) {
+	stubs.StubClock(t, now, sleep)
+}
+
+// StubTerminal stubs whether or not stdin and stdout are considered terminals
+// (e.g. by commander.RequireTTY).
+func StubTerminal(t *testing.T, stdin, stdout bool) {
+	stubs.StubTerminal(t, stdin, stdout)
+}
+
+// StubStdin stubs the reader used for reading user input from stdin (e.g. by
+// commander.Prompt).
+func StubStdin(t *testing.T, r io.Reader) {
+	stubs.StubStdin(t, r)
+}
+
+// StubSpinner stubs whether or not stderr is considered a terminal by any
+// command.Spinner.
+func StubSpinner(t *testing.T, tty bool) {
+	stubs.StubSpinner(t, tty)
+}
+
+// StubFifo stubs the function used to open FIFOs, as well as the amount of
+// time to wait for data to be read from them (e.g. by commander.FifoCompleter).
+func StubFifo(t *testing.T, open func(path string) (io.ReadCloser, error), timeout time.Duration) {
+	stubs.StubFifo(t, open, timeout)
+}
+
+// StubTimeAfter stubs the function used to wait for a timeout (e.g. by
+// commander.WithCompletionTimeout).
+func StubTimeAfter(t *testing.T, after func(time.Duration) <-chan time.Time) {
+	stubs.StubTimeAfter(t, after)
+}
@@ -0,0 +1,41 @@
+package operator
+
+import (
+	"time"
+)
+
+type durationOperator struct{}
+
+func (*durationOperator) ToArgs(d time.Duration) []string {
+	return []string{d.String()}
+}
+
+func (*durationOperator) FromArgs(sl []*string) (time.Duration, error) {
+	if len(sl) == 0 {
+		return 0, nil
+	}
+	return time.ParseDuration(*sl[0])
+}
+
+type durationListOperator struct{}
+
+func (*durationListOperator) ToArgs(ds []time.Duration) []string {
+	sl := make([]string, 0, len(ds))
+	for _, d := range ds {
+		sl = append(sl, d.String())
+	}
+	return sl
+}
+
+func (*durationListOperator) FromArgs(sl []*string) ([]time.Duration, error) {
+	var err error
+	var ds []time.Duration
+	for _, s := range sl {
+		d, e := time.ParseDuration(*s)
+		if e != nil {
+			err = e
+		}
+		ds = append(ds, d)
+	}
+	return ds, err
+}
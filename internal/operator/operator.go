@@ -3,6 +3,7 @@ package operator
 import (
 	"fmt"
 	"strings"
+	"time"
 )
 
 // Operator is an interface for mapping strings to and from specific types
@@ -42,6 +43,12 @@ func GetOperator[T any]() Operator[T] {
 		f = &floatListOperator{}
 	case bool:
 		f = &boolOperator{}
+	case time.Duration:
+		f = &durationOperator{}
+	case []time.Duration:
+		f = &durationListOperator{}
+	case time.Time:
+		f = TimeOperator(time.RFC3339)
 	default:
 		panic(fmt.Sprintf("no operator defined for type %T", t))
 	}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/leep-frog/command/internal/testutil"
 )
@@ -161,6 +162,99 @@ func TestToArgs(t *testing.T) {
 			want:     []int{12, 0},
 			wantErr:  fmt.Errorf(`strconv.Atoi: parsing "thirteen": invalid syntax`),
 		},
+		// duration operator
+		&toArgsTest[time.Duration]{
+			name:     "duration to arg",
+			operator: &durationOperator{},
+			value:    90 * time.Minute,
+			want:     []string{"1h30m0s"},
+		},
+		&toArgsTest[time.Duration]{
+			name:     "zero duration to arg",
+			operator: &durationOperator{},
+			want:     []string{"0s"},
+		},
+		&fromArgsTest[time.Duration]{
+			name:     "duration empty args",
+			operator: &durationOperator{},
+		},
+		&fromArgsTest[time.Duration]{
+			name:     "duration arg to value",
+			operator: &durationOperator{},
+			args:     []string{"1h30m"},
+			want:     90 * time.Minute,
+		},
+		&fromArgsTest[time.Duration]{
+			name:     "negative duration arg to value with extra args",
+			operator: &durationOperator{},
+			args:     []string{"-1.5s", "bleh"},
+			want:     -1500 * time.Millisecond,
+		},
+		&fromArgsTest[time.Duration]{
+			name:     "duration arg error",
+			operator: &durationOperator{},
+			args:     []string{"eleven"},
+			wantErr:  fmt.Errorf(`time: invalid duration "eleven"`),
+		},
+		// duration list operator
+		&toArgsTest[[]time.Duration]{
+			name:     "durationList values to args",
+			operator: &durationListOperator{},
+			value:    []time.Duration{time.Second, 0, 2 * time.Hour},
+			want:     []string{"1s", "0s", "2h0m0s"},
+		},
+		&fromArgsTest[[]time.Duration]{
+			name:     "durationList empty args",
+			operator: &durationListOperator{},
+		},
+		&fromArgsTest[[]time.Duration]{
+			name:     "durationList args to values",
+			operator: &durationListOperator{},
+			args:     []string{"5ms", "1m", "0"},
+			want:     []time.Duration{5 * time.Millisecond, time.Minute, 0},
+		},
+		&fromArgsTest[[]time.Duration]{
+			name:     "durationList arg error",
+			operator: &durationListOperator{},
+			args:     []string{"1s", "thirteen"},
+			want:     []time.Duration{time.Second, 0},
+			wantErr:  fmt.Errorf(`time: invalid duration "thirteen"`),
+		},
+		// time operator
+		&toArgsTest[time.Time]{
+			name:     "time to arg",
+			operator: TimeOperator(time.RFC3339),
+			value:    time.Date(2023, 4, 5, 6, 7, 8, 0, time.UTC),
+			want:     []string{"2023-04-05T06:07:08Z"},
+		},
+		&toArgsTest[time.Time]{
+			name:     "time to arg with custom layout",
+			operator: TimeOperator(time.DateOnly),
+			value:    time.Date(2023, 4, 5, 6, 7, 8, 0, time.UTC),
+			want:     []string{"2023-04-05"},
+		},
+		&fromArgsTest[time.Time]{
+			name:     "time empty args",
+			operator: TimeOperator(time.RFC3339),
+		},
+		&fromArgsTest[time.Time]{
+			name:     "time arg to value",
+			operator: TimeOperator(time.RFC3339),
+			args:     []string{"2023-04-05T06:07:08Z"},
+			want:     time.Date(2023, 4, 5, 6, 7, 8, 0, time.UTC),
+		},
+		&fromArgsTest[time.Time]{
+			name:     "time arg to value with custom layout and extra args",
+			operator: TimeOperator(time.DateOnly),
+			args:     []string{"2023-04-05", "bleh"},
+			want:     time.Date(2023, 4, 5, 0, 0, 0, 0, time.UTC),
+		},
+		&fromArgsTest[time.Time]{
+			name:     "time arg error",
+			operator: TimeOperator(time.DateOnly),
+			args:     []string{"April 5"},
+			wantErr:  fmt.Errorf(`parsing time "April 5" as "2006-01-02": cannot parse "April 5" as "2006"`),
+		},
 		// float64 operator
 		&toArgsTest[float64]{
 			name:     "float negative to arg",
@@ -0,0 +1,26 @@
+package operator
+
+import (
+	"time"
+)
+
+// TimeOperator returns an operator that converts strings to and from
+// `time.Time` values using the provided layout (see `time.Parse`).
+func TimeOperator(layout string) Operator[time.Time] {
+	return &timeOperator{layout}
+}
+
+type timeOperator struct {
+	layout string
+}
+
+func (to *timeOperator) ToArgs(t time.Time) []string {
+	return []string{t.Format(to.layout)}
+}
+
+func (to *timeOperator) FromArgs(sl []*string) (time.Time, error) {
+	if len(sl) == 0 {
+		return time.Time{}, nil
+	}
+	return time.Parse(to.layout, *sl[0])
+}
@@ -2,21 +2,97 @@ package spycommander
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/leep-frog/command/command"
 )
 
+const (
+	// bestEffortCompletionKey is the `command.Data` key used to indicate that
+	// completion should continue past arguments whose values can't be converted
+	// (see `SetBestEffortCompletion`).
+	bestEffortCompletionKey = "COMMAND_BEST_EFFORT_COMPLETION"
+	// inheritedFlagSuggestionsKey is the `command.Data` key used to store flag
+	// names that belong to an upstream flag processor (see
+	// `AddInheritedFlagSuggestions`).
+	inheritedFlagSuggestionsKey = "COMMAND_INHERITED_FLAG_SUGGESTIONS"
+	// defaultMaxSuggestionsKey is the `command.Data` key used to store the
+	// default maximum number of suggestions (see `SetDefaultMaxSuggestions`).
+	defaultMaxSuggestionsKey = "COMMAND_DEFAULT_MAX_SUGGESTIONS"
+)
+
+// SetDefaultMaxSuggestions sets the maximum number of suggestions returned by
+// completions that don't set `command.Completion.MaxSuggestions`.
+func SetDefaultMaxSuggestions(data *command.Data, max int) {
+	data.Set(defaultMaxSuggestionsKey, max)
+}
+
+// SetBestEffortCompletion makes the remainder of the completion continue past
+// arguments whose values can't be converted (rather than stopping with an error).
+func SetBestEffortCompletion(data *command.Data) {
+	data.Set(bestEffortCompletionKey, true)
+}
+
+// BestEffortCompletion returns whether completion should continue past
+// arguments whose values can't be converted (see `SetBestEffortCompletion`).
+func BestEffortCompletion(data *command.Data) bool {
+	return data.Has(bestEffortCompletionKey)
+}
+
+// AddInheritedFlagSuggestions adds flag names (e.g. `--flag`) that can still be
+// provided, but that belong to an upstream flag processor (e.g. shared flags
+// declared before a `commander.BranchNode`). They are included in the
+// suggestions when a flag name is completed further down the graph.
+func AddInheritedFlagSuggestions(data *command.Data, flags ...string) {
+	data.Set(inheritedFlagSuggestionsKey, append(PopInheritedFlagSuggestions(data), flags...))
+}
+
+// PopInheritedFlagSuggestions returns and clears the flag names added with
+// `AddInheritedFlagSuggestions`.
+func PopInheritedFlagSuggestions(data *command.Data) []string {
+	if !data.Has(inheritedFlagSuggestionsKey) {
+		return nil
+	}
+	flags := data.Get(inheritedFlagSuggestionsKey).([]string)
+	delete(data.Values, inheritedFlagSuggestionsKey)
+	return flags
+}
+
 // Separate method for testing purposes (and so Data doesn't need to be
 // constructed by callers).
 func Autocomplete(n command.Node, compLine string, passthroughArgs []string, data *command.Data) (*command.Autocompletion, error) {
-	input := command.ParseCompLine(compLine, passthroughArgs...)
+	return AutocompleteInput(n, command.ParseCompLine(compLine, passthroughArgs...), data)
+}
+
+// AutocompleteInput returns the completion suggestions for the provided
+// (already parsed) input. The last argument in the input is the one being completed.
+func AutocompleteInput(n command.Node, input *command.Input, data *command.Data) (*command.Autocompletion, error) {
 	c, err := ProcessGraphCompletion(n, input, data)
+	delete(data.Values, bestEffortCompletionKey)
+
+	// Include any flag suggestions from upstream flag processors that weren't
+	// already included by a downstream flag processor.
+	if inherited := PopInheritedFlagSuggestions(data); len(inherited) > 0 {
+		if c == nil {
+			c = &command.Completion{}
+		} else {
+			// Clone so completers that return shared `command.Completion` objects
+			// aren't modified.
+			c = c.Clone()
+		}
+		c.Suggestions = append(slices.Clone(c.Suggestions), inherited...)
+	}
+
+	if data.Has(defaultMaxSuggestionsKey) {
+		if c != nil && c.MaxSuggestions == 0 {
+			c = c.Clone()
+			c.MaxSuggestions = data.Get(defaultMaxSuggestionsKey).(int)
+		}
+		delete(data.Values, defaultMaxSuggestionsKey)
+	}
 
 	if c != nil {
-		return &command.Autocompletion{
-			c.ProcessInput(input),
-			c.SpacelessCompletion,
-		}, err
+		return c.Autocompletion(input), err
 	}
 
 	if c == nil && err == nil && !input.FullyProcessed() {
@@ -7,6 +7,14 @@ import (
 
 // Separate method for testing purposes.
 func Execute(n command.Node, input *command.Input, output command.Output, data *command.Data, eData *command.ExecuteData) (retErr error) {
+	defer func() {
+		for i := len(eData.Cleanup) - 1; i >= 0; i-- {
+			if err := eData.Cleanup[i](); err != nil && retErr == nil {
+				retErr = output.Err(err)
+			}
+		}
+	}()
+
 	defer func() {
 		r := recover()
 
@@ -31,7 +39,7 @@ func Execute(n command.Node, input *command.Input, output command.Output, data *
 
 	if !input.FullyProcessed() {
 		retErr = command.ExtraArgsErr(input)
-		output.Stderrln(retErr)
+		output.Err(retErr)
 		ShowUsageAfterError(n, output)
 		return retErr
 	}
@@ -22,7 +22,7 @@ func (et *executeDataTester) check(t *testing.T, tc *testContext) {
 	if tc.eData == nil {
 		tc.eData = &command.ExecuteData{}
 	}
-	if diff := cmp.Diff(et.want, tc.eData, cmpopts.IgnoreFields(command.ExecuteData{}, "Executor")); diff != "" {
+	if diff := cmp.Diff(et.want, tc.eData, cmpopts.IgnoreFields(command.ExecuteData{}, "Executor", "Cleanup")); diff != "" {
 		t.Errorf("%s returned unexpected ExecuteData (-want, +got):\n%s", tc.prefix, diff)
 	}
 }
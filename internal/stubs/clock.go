@@ -0,0 +1,30 @@
+package stubs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/leep-frog/command/internal/testutil"
+)
+
+var (
+	// TimeNow is a stub for time.Now
+	TimeNow = time.Now
+
+	// TimeSleep is a stub for time.Sleep
+	TimeSleep = time.Sleep
+
+	// TimeAfter is a stub for time.After
+	TimeAfter = time.After
+)
+
+// StubClock stubs the functions used to get the current time and to sleep.
+func StubClock(t *testing.T, now func() time.Time, sleep func(time.Duration)) {
+	testutil.StubValue(t, &TimeNow, now)
+	testutil.StubValue(t, &TimeSleep, sleep)
+}
+
+// StubTimeAfter stubs the function used to wait for a timeout.
+func StubTimeAfter(t *testing.T, after func(time.Duration) <-chan time.Time) {
+	testutil.StubValue(t, &TimeAfter, after)
+}
@@ -0,0 +1,28 @@
+package stubs
+
+import (
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/leep-frog/command/internal/testutil"
+)
+
+var (
+	// FifoOpen opens a FIFO for reading.
+	FifoOpen = func(path string) (io.ReadCloser, error) {
+		return os.Open(path)
+	}
+
+	// FifoTimeout is the maximum amount of time to wait for data to be read
+	// from a FIFO.
+	FifoTimeout = 250 * time.Millisecond
+)
+
+// StubFifo stubs the function used to open FIFOs, as well as the amount of
+// time to wait for data to be read from them.
+func StubFifo(t *testing.T, open func(path string) (io.ReadCloser, error), timeout time.Duration) {
+	testutil.StubValue(t, &FifoOpen, open)
+	testutil.StubValue(t, &FifoTimeout, timeout)
+}
@@ -0,0 +1,19 @@
+package stubs
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"github.com/leep-frog/command/internal/testutil"
+)
+
+var (
+	// Stdin is a stub for os.Stdin
+	Stdin io.Reader = os.Stdin
+)
+
+// StubStdin stubs the reader used for reading user input from stdin.
+func StubStdin(t *testing.T, r io.Reader) {
+	testutil.StubValue(t, &Stdin, r)
+}
@@ -1,9 +1,11 @@
 package stubs
 
 import (
+	"fmt"
 	"io"
 	"os"
 	"os/exec"
+	"sort"
 	"testing"
 
 	"github.com/leep-frog/command/command"
@@ -14,6 +16,9 @@ var (
 	// OSGetwd is a stub for os.Getwd
 	OSGetwd = os.Getwd
 
+	// OSChdir is a stub for os.Chdir
+	OSChdir = os.Chdir
+
 	// Run is a wrapper `exec.Cmd` used for stubbing purposes.
 	Run = func(cmd *exec.Cmd) error {
 		return cmd.Run()
@@ -31,6 +36,14 @@ func StubEnv(t *testing.T, m map[string]string) {
 		v, ok := m[key]
 		return v, ok
 	})
+	testutil.StubValue(t, &command.OSEnviron, func() []string {
+		var r []string
+		for k, v := range m {
+			r = append(r, fmt.Sprintf("%s=%s", k, v))
+		}
+		sort.Strings(r)
+		return r
+	})
 }
 
 // StubGetwd uses the provided string and error when calling command.GetwdProcessor.
@@ -40,6 +53,11 @@ func StubGetwd(t *testing.T, wd string, err error) {
 	})
 }
 
+// StubChdir stubs the function used when changing the current working directory.
+func StubChdir(t *testing.T, f func(string) error) {
+	testutil.StubValue(t, &OSChdir, f)
+}
+
 // StubRun stubs the cmd.Run() method with the provided function.
 func StubRun(t *testing.T, f func(cmd *exec.Cmd) error) {
 	testutil.StubValue(t, &Run, f)
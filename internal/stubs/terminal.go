@@ -0,0 +1,29 @@
+package stubs
+
+import (
+	"os"
+	"testing"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/internal/testutil"
+)
+
+var (
+	// StdinIsTerminal returns whether or not stdin is attached to a terminal.
+	StdinIsTerminal = func() bool { return command.IsTerminal(os.Stdin) }
+
+	// StdoutIsTerminal returns whether or not stdout is attached to a terminal.
+	StdoutIsTerminal = func() bool { return command.IsTerminal(os.Stdout) }
+)
+
+// StubTerminal stubs whether or not stdin and stdout are considered terminals.
+func StubTerminal(t *testing.T, stdin, stdout bool) {
+	testutil.StubValue(t, &StdinIsTerminal, func() bool { return stdin })
+	testutil.StubValue(t, &StdoutIsTerminal, func() bool { return stdout })
+}
+
+// StubSpinner stubs whether or not stderr is considered a terminal by any
+// command.Spinner.
+func StubSpinner(t *testing.T, tty bool) {
+	testutil.StubValue(t, &command.StderrIsTerminal, func() bool { return tty })
+}
@@ -0,0 +1,35 @@
+package sourcerer
+
+import (
+	"fmt"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/internal/spycommander"
+)
+
+// Autocomplete returns the completion suggestions for the provided `command.Node`
+// given a pre-split word array (e.g. bash's `COMP_WORDS`) and cursor position.
+// This allows shell integrations to use the completion engine without relying
+// on bash-specific `COMP_LINE` parsing.
+//
+// `words[0]` is the command name (and is ignored), `wordIndex` is the index
+// of the word the cursor is in (e.g. bash's `COMP_CWORD`), and `charIndex` is
+// the cursor's offset within that word. Words after `wordIndex`, and any
+// characters after the cursor in the current word, are ignored. A `wordIndex`
+// of `len(words)` indicates a new (empty) word is being completed.
+func Autocomplete(node command.Node, words []string, wordIndex, charIndex int) (*command.Autocompletion, error) {
+	if wordIndex < 1 || wordIndex > len(words) {
+		return nil, fmt.Errorf("wordIndex must be between 1 and %d (inclusive); got %d", len(words), wordIndex)
+	}
+
+	var current string
+	if wordIndex < len(words) {
+		current = words[wordIndex]
+	}
+	if charIndex < 0 || charIndex > len(current) {
+		return nil, fmt.Errorf("charIndex must be between 0 and %d (inclusive); got %d", len(current), charIndex)
+	}
+
+	args := append(append([]string{}, words[1:wordIndex]...), current[:charIndex])
+	return spycommander.AutocompleteInput(node, command.NewInput(args, nil), &command.Data{OS: CurrentOS})
+}
@@ -0,0 +1,127 @@
+package sourcerer
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/commander"
+	"github.com/leep-frog/command/internal/testutil"
+)
+
+func TestAutocomplete(t *testing.T) {
+	node := commander.SerialNodes(
+		commander.FlagProcessor(
+			commander.Flag[string]("env", 'e', "environment", commander.SimpleCompleter[string]("dev", "prod")),
+		),
+		commander.Arg[string]("FRUIT", "fruit", commander.SimpleCompleter[string]("apple", "apricot", "banana")),
+		commander.Arg[string]("VEGGIE", "veggie", commander.SimpleCompleter[string]("broccoli", "carrot")),
+	)
+
+	for _, test := range []struct {
+		name      string
+		words     []string
+		wordIndex int
+		charIndex int
+		want      *command.Autocompletion
+		wantErr   error
+	}{
+		{
+			name:      "completes empty word",
+			words:     []string{"cmd", ""},
+			wordIndex: 1,
+			want: &command.Autocompletion{
+				Suggestions: []string{"apple", "apricot", "banana"},
+			},
+		},
+		{
+			name:      "completes new word after the last one",
+			words:     []string{"cmd", "banana"},
+			wordIndex: 2,
+			want: &command.Autocompletion{
+				Suggestions: []string{"broccoli", "carrot"},
+			},
+		},
+		{
+			name:      "completes partial word",
+			words:     []string{"cmd", "ap"},
+			wordIndex: 1,
+			charIndex: 2,
+			want: &command.Autocompletion{
+				Suggestions: []string{"apple", "apricot"},
+			},
+		},
+		{
+			name:      "ignores characters after the cursor",
+			words:     []string{"cmd", "bxyz"},
+			wordIndex: 1,
+			charIndex: 1,
+			want: &command.Autocompletion{
+				Suggestions: []string{"banana"},
+			},
+		},
+		{
+			name:      "ignores words after the cursor",
+			words:     []string{"cmd", "a", "carrot", "extra"},
+			wordIndex: 1,
+			charIndex: 1,
+			want: &command.Autocompletion{
+				Suggestions: []string{"apple", "apricot"},
+			},
+		},
+		{
+			name:      "does not parse quotes or whitespace in words",
+			words:     []string{"cmd", "some fruit", ""},
+			wordIndex: 2,
+			want: &command.Autocompletion{
+				Suggestions: []string{"broccoli", "carrot"},
+			},
+		},
+		{
+			name:      "completes flag values",
+			words:     []string{"cmd", "apple", "--env", "p"},
+			wordIndex: 3,
+			charIndex: 1,
+			want: &command.Autocompletion{
+				Suggestions: []string{"prod"},
+			},
+		},
+		{
+			name:      "returns completion errors",
+			words:     []string{"cmd", "apple", "carrot", "extra", ""},
+			wordIndex: 4,
+			wantErr:   fmt.Errorf("Unprocessed extra args: [extra ]"),
+		},
+		{
+			name:    "fails if wordIndex is the command",
+			words:   []string{"cmd", ""},
+			wantErr: fmt.Errorf("wordIndex must be between 1 and 2 (inclusive); got 0"),
+		},
+		{
+			name:      "fails if wordIndex is too large",
+			words:     []string{"cmd", ""},
+			wordIndex: 3,
+			wantErr:   fmt.Errorf("wordIndex must be between 1 and 2 (inclusive); got 3"),
+		},
+		{
+			name:      "fails if charIndex is negative",
+			words:     []string{"cmd", "ap"},
+			wordIndex: 1,
+			charIndex: -1,
+			wantErr:   fmt.Errorf("charIndex must be between 0 and 2 (inclusive); got -1"),
+		},
+		{
+			name:      "fails if charIndex is too large",
+			words:     []string{"cmd", "ap"},
+			wordIndex: 1,
+			charIndex: 3,
+			wantErr:   fmt.Errorf("charIndex must be between 0 and 2 (inclusive); got 3"),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Autocomplete(node, test.words, test.wordIndex, test.charIndex)
+			testutil.CmpError(t, "Autocomplete()", test.wantErr, err)
+			testutil.Cmp(t, "Autocomplete() returned incorrect suggestions", test.want, got)
+		})
+	}
+}
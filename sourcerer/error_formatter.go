@@ -0,0 +1,123 @@
+package sourcerer
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/internal/spycommand"
+)
+
+var (
+	// colorCodeRegex matches the color codes generated by the `color` package.
+	colorCodeRegex = regexp.MustCompile("\033\\[[0-9;]*m")
+)
+
+// WithErrorFormatter is an `Option` that transforms errors before they are
+// written to stderr (e.g. to add a consistent prefix or color across CLIs).
+// The formatter is applied to errors written via `command.Output` error
+// functions (`Err`, `Annotate`, `Terminate`, etc.), which includes usage
+// errors like missing and extra arguments, as well as to errors returned from
+// execution that weren't already written to stderr. Errors that a CLI writes
+// directly with `Stderr` functions are not formatted. The error itself is still
+// returned unchanged, so exit code behavior is unaffected. If the `NO_COLOR`
+// environment variable is set, then color codes are removed from the
+// formatted error.
+func WithErrorFormatter(f func(error) string) Option {
+	so := simpleOption(func(co *compiledOpts) {
+		co.errorFormatter = f
+	})
+	return &so
+}
+
+type errorFormatterOutput struct {
+	command.Output
+	f func(error) string
+	// written contains the errors that have already been written to stderr.
+	written []error
+}
+
+// writeReturned writes the provided error (returned from execution) if it
+// wasn't already written to stderr.
+func (efo *errorFormatterOutput) writeReturned(err error) {
+	if err == nil {
+		return
+	}
+	for _, w := range efo.written {
+		if errors.Is(w, err) {
+			return
+		}
+	}
+	efo.Err(err)
+}
+
+func (efo *errorFormatterOutput) Stderr(s string) error {
+	err := efo.Output.Stderr(s)
+	efo.written = append(efo.written, err)
+	return err
+}
+
+func (efo *errorFormatterOutput) Stderrf(s string, a ...interface{}) error {
+	err := efo.Output.Stderrf(s, a...)
+	efo.written = append(efo.written, err)
+	return err
+}
+
+func (efo *errorFormatterOutput) Stderrln(a ...interface{}) error {
+	err := efo.Output.Stderrln(a...)
+	efo.written = append(efo.written, err)
+	return err
+}
+
+func (efo *errorFormatterOutput) format(err error) string {
+	s := efo.f(err)
+	if _, ok := command.OSLookupEnv("NO_COLOR"); ok {
+		s = colorCodeRegex.ReplaceAllString(s, "")
+	}
+	return s
+}
+
+func (efo *errorFormatterOutput) Err(err error) error {
+	if err != nil {
+		efo.written = append(efo.written, err)
+		efo.Output.Stderrf("%s\n", efo.format(err))
+	}
+	return err
+}
+
+func (efo *errorFormatterOutput) Annotate(err error, s string) error {
+	if err == nil {
+		return nil
+	}
+	return efo.Err(fmt.Errorf("%s: %v", s, err))
+}
+
+func (efo *errorFormatterOutput) Annotatef(err error, s string, a ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	return efo.Err(fmt.Errorf("%s: %v", fmt.Sprintf(s, a...), err))
+}
+
+func (efo *errorFormatterOutput) Terminate(err error) {
+	if err != nil {
+		spycommand.Terminate(efo.Err(err))
+	}
+}
+
+func (efo *errorFormatterOutput) Terminatef(s string, a ...interface{}) {
+	efo.Terminate(fmt.Errorf(s, a...))
+}
+
+func (efo *errorFormatterOutput) Tannotate(err error, s string) {
+	if err != nil {
+		efo.Terminate(fmt.Errorf("%s: %v", s, err))
+	}
+}
+
+func (efo *errorFormatterOutput) Tannotatef(err error, s string, a ...interface{}) {
+	if err != nil {
+		efo.Terminate(fmt.Errorf("%s: %v", fmt.Sprintf(s, a...), err))
+	}
+}
@@ -126,10 +126,20 @@ func (l *linux) autocompleteRegistration(targetName, alias string) string {
 }
 
 func (l *linux) RegisterCLIs(builtin bool, goExecutable, targetName string, clis []CLI) []string {
+	return l.registerCLIs(builtin, goExecutable, targetName, clis, l.autocompleteFunction(false, builtin, goExecutable, targetName), func(alias string) string {
+		// We sort ourselves, hence the no sort.
+		return l.autocompleteRegistration(targetName, alias)
+	})
+}
+
+// registerCLIs generates the execute function and aliases for the provided
+// CLIs. The autocomplete function and registration are provided by the caller
+// so the completion setup can be swapped out for other shells (e.g. zsh).
+func (l *linux) registerCLIs(builtin bool, goExecutable, targetName string, clis []CLI, autocompleteFunction []string, autocompleteRegistration func(alias string) string) []string {
 	// Generate the execute functions
 	r := l.executeFileContents(builtin, goExecutable, targetName)
 	// Generate the autocomplete function
-	r = append(r, l.autocompleteFunction(false, builtin, goExecutable, targetName)...)
+	r = append(r, autocompleteFunction...)
 
 	sort.SliceStable(clis, func(i, j int) bool { return clis[i].Name() < clis[j].Name() })
 	for _, cli := range clis {
@@ -143,9 +153,7 @@ func (l *linux) RegisterCLIs(builtin bool, goExecutable, targetName string, clis
 		}
 
 		r = append(r, aliasCommand)
-
-		// We sort ourselves, hence the no sort.
-		r = append(r, l.autocompleteRegistration(targetName, alias))
+		r = append(r, autocompleteRegistration(alias))
 	}
 	return r
 }
@@ -0,0 +1,24 @@
+package sourcerer
+
+import (
+	"fmt"
+)
+
+// nushellAutocompleteSetup returns the nushell code that registers the
+// `goExecutable` binary (generated with `RunCLI`) as the completer for `alias`.
+//
+// Nushell invokes the custom completer with the command line up to the
+// cursor, so that is passed along as the COMP_LINE argument (and its length in
+// bytes as the COMP_POINT argument).
+func nushellAutocompleteSetup(goExecutable, alias string) []string {
+	completerName := fmt.Sprintf("nu-complete %s", alias)
+	return []string{
+		fmt.Sprintf("# Autocomplete setup for the %q command.", alias),
+		"# Add `source <path-to-this-file>` to your nushell config to enable it.",
+		fmt.Sprintf("def %q [context: string] {", completerName),
+		fmt.Sprintf(`  ^%q %s "0" ($context | encode utf8 | bytes length) $context | lines`, goExecutable, AutocompleteBranchName),
+		"}",
+		"",
+		fmt.Sprintf("extern %q [...args: string@%q]", alias, completerName),
+	}
+}
@@ -0,0 +1,21 @@
+package sourcerer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/leep-frog/command/internal/testutil"
+)
+
+func TestNushellAutocompleteSetup(t *testing.T) {
+	want, err := os.ReadFile(filepath.Join("testdata", "nushell_autocomplete_setup.nu"))
+	if err != nil {
+		t.Fatalf("failed to read golden file: %v", err)
+	}
+
+	// The script is written with `Stdoutln`, hence the trailing newline.
+	got := strings.Join(nushellAutocompleteSetup("/path/to/my cli", "mycli"), "\n") + "\n"
+	testutil.Cmp(t, "nushellAutocompleteSetup() returned incorrect script", string(want), got)
+}
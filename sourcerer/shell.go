@@ -0,0 +1,108 @@
+package sourcerer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/leep-frog/command/command"
+)
+
+var (
+	// sourceShellOS maps the shells supported by `SourceForShell` to the OS
+	// for which they can be generated. Note that PowerShell is only supported
+	// on windows (the generated file relies on windows paths and executables).
+	sourceShellOS = map[command.ShellType]string{
+		command.BashShell:       "linux",
+		command.ZshShell:        "linux",
+		command.PowerShellShell: "windows",
+	}
+
+	// runCLIShellOS maps the shells supported by the `RunCLI` autocomplete setup
+	// to the OS whose code is used.
+	runCLIShellOS = map[command.ShellType]OS{
+		command.BashShell:       Linux(),
+		command.PowerShellShell: Windows(),
+	}
+)
+
+// SourceForShell is identical to `Source` except the generated sourceable
+// file is tailored to the provided shell (`Source` uses `command.DefaultShell`,
+// which generates code for the current OS's default shell: bash for linux and
+// PowerShell for windows).
+//
+// Execution is wired up the same way for all shells; only the autocomplete
+// setup differs. All shells use the same completion protocol (i.e. the
+// COMP_LINE and COMP_POINT equivalents are passed to the binary).
+func SourceForShell(shell command.ShellType, targetName string, clis []CLI, opts ...Option) int {
+	return Source(targetName, clis, append(opts, shellOption(shell))...)
+}
+
+func shellOption(shell command.ShellType) Option {
+	so := simpleOption(func(co *compiledOpts) {
+		co.shell = shell
+	})
+	return &so
+}
+
+func shellNames[T any](m map[command.ShellType]T) []string {
+	var names []string
+	for s := range m {
+		names = append(names, string(s))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// validateShell returns an error if sourceable files can't be generated for
+// the provided shell on the current OS.
+func validateShell(shell command.ShellType) error {
+	if shell == command.DefaultShell {
+		return nil
+	}
+
+	osName, ok := sourceShellOS[shell]
+	if !ok {
+		return fmt.Errorf("unsupported shell %q; expected one of [%s]", shell, strings.Join(shellNames(sourceShellOS), " "))
+	}
+	if osName != CurrentOS.Name() {
+		return fmt.Errorf("shell %q is not supported for os %q", shell, CurrentOS.Name())
+	}
+	return nil
+}
+
+// registerCLIs generates the code for the sourceable file for the provided
+// shell (which should already be validated with `validateShell`).
+func registerCLIs(shell command.ShellType, builtin bool, goExecutable, targetName string, clis []CLI) []string {
+	if shell != command.ZshShell {
+		return CurrentOS.RegisterCLIs(builtin, goExecutable, targetName, clis)
+	}
+
+	l := CurrentOS.(*linux)
+	return l.registerCLIs(builtin, goExecutable, targetName, clis, zshAutocompleteFunction(builtin, goExecutable, targetName), func(alias string) string {
+		return zshAutocompleteRegistration(targetName, alias)
+	})
+}
+
+// runCLIShells returns the shells supported by the `RunCLI` autocomplete setup.
+func runCLIShells() []string {
+	shells := append(shellNames(runCLIShellOS), string(command.NushellShell))
+	sort.Strings(shells)
+	return shells
+}
+
+// runCLIAutocompleteSetup generates the autocomplete setup code for a `RunCLI`
+// binary for the provided shell.
+func runCLIAutocompleteSetup(shell command.ShellType, goExecutable, alias string) string {
+	if shell == command.NushellShell {
+		return strings.Join(nushellAutocompleteSetup(goExecutable, alias), "\n")
+	}
+
+	os := CurrentOS
+	if shell != command.DefaultShell {
+		os = runCLIShellOS[shell]
+	}
+	functionName := fmt.Sprintf("_RunCLI_%s_autocomplete_wrap_function", alias)
+	functionContent := strings.Join(os.RegisterRunCLIAutocomplete(goExecutable, alias), "\n")
+	return os.FunctionWrap(functionName, functionContent)
+}
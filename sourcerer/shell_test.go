@@ -0,0 +1,77 @@
+package sourcerer
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/internal/testutil"
+)
+
+func TestValidateShell(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		os      OS
+		shell   command.ShellType
+		wantErr error
+	}{
+		{
+			name: "default shell is valid for linux",
+			os:   Linux(),
+		},
+		{
+			name: "default shell is valid for windows",
+			os:   Windows(),
+		},
+		{
+			name:  "bash is valid for linux",
+			os:    Linux(),
+			shell: command.BashShell,
+		},
+		{
+			name:    "bash is invalid for windows",
+			os:      Windows(),
+			shell:   command.BashShell,
+			wantErr: fmt.Errorf(`shell "bash" is not supported for os "windows"`),
+		},
+		{
+			name:  "zsh is valid for linux",
+			os:    Linux(),
+			shell: command.ZshShell,
+		},
+		{
+			name:    "zsh is invalid for windows",
+			os:      Windows(),
+			shell:   command.ZshShell,
+			wantErr: fmt.Errorf(`shell "zsh" is not supported for os "windows"`),
+		},
+		{
+			name:  "powershell is valid for windows",
+			os:    Windows(),
+			shell: command.PowerShellShell,
+		},
+		{
+			name:    "powershell is invalid for linux",
+			os:      Linux(),
+			shell:   command.PowerShellShell,
+			wantErr: fmt.Errorf(`shell "powershell" is not supported for os "linux"`),
+		},
+		{
+			name:    "nushell is invalid for sourcing",
+			os:      Linux(),
+			shell:   command.NushellShell,
+			wantErr: fmt.Errorf(`unsupported shell "nushell"; expected one of [bash powershell zsh]`),
+		},
+		{
+			name:    "unknown shell is invalid",
+			os:      Linux(),
+			shell:   "fish",
+			wantErr: fmt.Errorf(`unsupported shell "fish"; expected one of [bash powershell zsh]`),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			testutil.StubValue(t, &CurrentOS, test.os)
+			testutil.CmpError(t, fmt.Sprintf("validateShell(%q)", test.shell), test.wantErr, validateShell(test.shell))
+		})
+	}
+}
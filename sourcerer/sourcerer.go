@@ -41,6 +41,7 @@ var (
 	targetNameRegex = commander.MatchesRegex("^[a-zA-Z0-9]+$")
 	passthroughArgs = commander.ListArg[string]("ARG", "Arguments that get passed through to relevant CLI command", 0, command.UnboundedList)
 	helpFlag        = commander.BoolFlag("help", commander.FlagNoShortName, "Display command's usage doc")
+	showExecFlag    = commander.BoolFlag("show-executable", commander.FlagNoShortName, "Print the shell code generated by the command (to stderr) instead of running it")
 	quietFlag       = commander.BoolFlag("quiet", 'q', "Hide unnecessary output")
 	shadowDirFlag   = commander.Flag("shadow-dir", commander.FlagNoShortName, fmt.Sprintf("Location to use for executable file location in sourceable files (default is path in %s environment variable)", RootDirectoryEnvVar), commander.HiddenArg[string](), commander.IsDir())
 	// See the below link for more details on COMP_* details:
@@ -116,9 +117,20 @@ func (s *sourcerer) executeExecutor(output command.Output, d *command.Data) erro
 		n = commander.SerialNodes(commander.SetupArg, n)
 	}
 
+	// The sourcerer's own execution may already be wrapped in an error formatter.
+	cliOutput := output
+	efo, wrapped := output.(*errorFormatterOutput)
+	if !wrapped && s.opts.errorFormatter != nil {
+		efo = &errorFormatterOutput{Output: output, f: s.opts.errorFormatter}
+		cliOutput = efo
+	}
+
 	// We check this error afer saving. It is up to the user to only mark something as
 	// changed when it should actually be changed (i.e. check for errors in their logic).
-	eData, err := commander.Execute(n, command.ParseExecuteArgs(args), output, CurrentOS)
+	eData, err := commander.Execute(n, command.ParseExecuteArgs(args), cliOutput, CurrentOS)
+	if efo != nil {
+		efo.writeReturned(err)
+	}
 
 	// Save the CLI if it has changed.
 	if cli.Changed() {
@@ -137,6 +149,11 @@ func (s *sourcerer) executeExecutor(output command.Output, d *command.Data) erro
 		return err
 	}
 
+	if showExecFlag.Get(d) {
+		showExecutable(output, eData)
+		return nil
+	}
+
 	// Run the executable file if relevant.
 	if eData == nil || len(eData.Executable) == 0 {
 		return nil
@@ -151,17 +168,34 @@ func (s *sourcerer) executeExecutor(output command.Output, d *command.Data) erro
 		return output.Stderrf("failed to open file: %v\n", err)
 	}
 
-	v := strings.Join(eData.Executable, "\n")
+	if _, err := f.WriteString(executableContents(eData)); err != nil {
+		return output.Stderrf("failed to write to execute file: %v\n", err)
+	}
+
+	return nil
+}
 
+// executableContents returns the shell code to run for the provided `ExecuteData`.
+func executableContents(eData *command.ExecuteData) string {
+	v := strings.Join(eData.Executable, "\n")
 	if eData.FunctionWrap {
 		v = CurrentOS.FunctionWrap(fmt.Sprintf("_leep_execute_data_function_wrap_%s", strings.ReplaceAll(getUuid(), "-", "_")), v)
 	}
+	return v
+}
 
-	if _, err := f.WriteString(v); err != nil {
-		return output.Stderrf("failed to write to execute file: %v\n", err)
+// showExecutable writes the shell code that would be run for the provided
+// `ExecuteData` to stderr (used by the `--show-executable` flag).
+func showExecutable(o command.Output, eData *command.ExecuteData) {
+	if eData == nil || len(eData.Executable) == 0 {
+		o.Stderrln("[show-executable] No executable generated")
+		return
+	}
+	o.Stderrf("[show-executable] FunctionWrap: %v\n", eData.FunctionWrap)
+	o.Stderrln("[show-executable] Executable:")
+	for _, line := range strings.Split(strings.TrimSuffix(executableContents(eData), "\n"), "\n") {
+		o.Stderrf("  %s\n", line)
 	}
-
-	return nil
 }
 
 func (s *sourcerer) autocompleteExecutor(o command.Output, d *command.Data) error {
@@ -267,6 +301,7 @@ func (s *sourcerer) Node() command.Node {
 	// Change if runcli
 	if s.isRunCLI() {
 		aliasFlag := commander.Flag[string]("alias", commander.FlagNoShortName, "")
+		shellFlag := commander.Flag[string]("shell", commander.FlagNoShortName, "Shell to generate the autocomplete setup for (defaults to the current OS's shell)", commander.InList(runCLIShells()...), commander.SimpleCompleter[string](runCLIShells()...))
 		return commander.SerialNodes(
 			// Set the CLI to runCLI
 			commander.SuperSimpleProcessor(func(i *command.Input, d *command.Data) error {
@@ -279,6 +314,7 @@ func (s *sourcerer) Node() command.Node {
 					GenerateAutocompleteSetupBranchName: commander.SerialNodes(
 						commander.FlagProcessor(
 							aliasFlag,
+							shellFlag,
 						),
 						&commander.ExecutorProcessor{func(o command.Output, d *command.Data) error {
 							alias := aliasFlag.GetOrDefault(d, filepath.Base(s.goExecutableFilePath))
@@ -286,9 +322,7 @@ func (s *sourcerer) Node() command.Node {
 								return o.Err(err)
 							}
 
-							functionName := fmt.Sprintf("_RunCLI_%s_autocomplete_wrap_function", alias)
-							functionContent := strings.Join(CurrentOS.RegisterRunCLIAutocomplete(s.goExecutableFilePath, alias), "\n")
-							o.Stdoutln(CurrentOS.FunctionWrap(functionName, functionContent))
+							o.Stdoutln(runCLIAutocompleteSetup(command.ShellType(shellFlag.Get(d)), s.goExecutableFilePath, alias))
 							return nil
 						}},
 					),
@@ -298,6 +332,7 @@ func (s *sourcerer) Node() command.Node {
 					loadCLIArg,
 					commander.FlagProcessor(
 						helpFlag,
+						showExecFlag,
 					),
 					passthroughArgs,
 					&commander.ExecutorProcessor{F: s.executeExecutor},
@@ -329,6 +364,7 @@ func (s *sourcerer) Node() command.Node {
 					fileArg,
 					commander.FlagProcessor(
 						helpFlag,
+						showExecFlag,
 					),
 					passthroughArgs,
 					&commander.ExecutorProcessor{F: s.executeExecutor},
@@ -388,7 +424,10 @@ func multiOpts(opts ...Option) Option {
 }
 
 type compiledOpts struct {
-	aliasers map[string]*Aliaser
+	aliasers       map[string]*Aliaser
+	errorFormatter func(error) string
+	// shell is the shell to generate the sourceable file for (see `SourceForShell`).
+	shell command.ShellType
 }
 
 // RunCLI runs an individual CLI, thus making the go executable file the only
@@ -559,6 +598,10 @@ const (
 func (s *sourcerer) generateFile(o command.Output, d *command.Data) error {
 	loud := !quietFlag.Get(d)
 
+	if err := validateShell(s.opts.shell); err != nil {
+		return o.Err(err)
+	}
+
 	// Create the artifacts directory
 	rootDir := rootDirectoryArg.Get(d)
 	artifactsDir := filepath.Join(rootDir, artifactsDirName)
@@ -591,7 +634,7 @@ func (s *sourcerer) generateFile(o command.Output, d *command.Data) error {
 		o.Stdoutf("Binary file created: %q\n", newExecutableFilePath)
 	}
 
-	fileData := CurrentOS.RegisterCLIs(s.builtin, shadowExecutableFilePath, s.targetName, maps.Values(s.clis))
+	fileData := registerCLIs(s.opts.shell, s.builtin, shadowExecutableFilePath, s.targetName, maps.Values(s.clis))
 
 	fileData = append(fileData, AliasSourcery(shadowExecutableFilePath, maps.Values(s.opts.aliasers)...)...)
 
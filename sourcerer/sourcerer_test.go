@@ -595,6 +595,30 @@ func TestGenerateBinaryNode(t *testing.T) {
 					},
 				},
 			},
+			{
+				name:          "fails for unsupported shell",
+				cliTargetName: "leepFrogSource",
+				env: map[string]string{
+					RootDirectoryEnvVar: "cli-output-dir",
+				},
+				args:    []string{"source"},
+				opts:    []Option{shellOption("fish")},
+				wantErr: fmt.Errorf(`unsupported shell "fish"; expected one of [bash powershell zsh]`),
+				osChecks: map[string]*osCheck{
+					osLinux: {
+						wantStderr: []string{
+							`unsupported shell "fish"; expected one of [bash powershell zsh]`,
+							``,
+						},
+					},
+					osWindows: {
+						wantStderr: []string{
+							`unsupported shell "fish"; expected one of [bash powershell zsh]`,
+							``,
+						},
+					},
+				},
+			},
 			{
 				name:          "hides output when quiet flag is provided",
 				cliTargetName: "leepFrogSource",
@@ -2784,7 +2808,7 @@ func TestGenerateBinaryNode(t *testing.T) {
 							`  }`,
 							`}`,
 							``,
-							fmt.Sprintf(`Register-ArgumentCompleter -CommandName RunCLI%s -ScriptBlock $_custom_autocomplete_%s`, exeBaseName, exeBaseName),
+							fmt.Sprintf(`Register-ArgumentCompleter -CommandName %s -ScriptBlock $_custom_autocomplete_RunCLI%s`, exeBaseName, exeBaseName),
 							`}`,
 							fmt.Sprintf(`. _RunCLI_%s_autocomplete_wrap_function`, exeBaseName),
 							``,
@@ -2831,7 +2855,134 @@ func TestGenerateBinaryNode(t *testing.T) {
 							`  }`,
 							`}`,
 							``,
-							`Register-ArgumentCompleter -CommandName RunCLIabc -ScriptBlock $_custom_autocomplete_abc`,
+							`Register-ArgumentCompleter -CommandName abc -ScriptBlock $_custom_autocomplete_RunCLIabc`,
+							`}`,
+							`. _RunCLI_abc_autocomplete_wrap_function`,
+							``,
+						},
+					},
+				},
+			},
+			{
+				name:          "generates runCLI nushell autocomplete setup",
+				cliTargetName: "leepFrogSource",
+				args:          []string{"generate-autocomplete-setup", "--alias", "abc", "--shell", "nushell"},
+				runCLI:        true,
+				clis: []CLI{
+					&testCLI{name: "basic"},
+				},
+				osChecks: map[string]*osCheck{
+					osLinux: {
+						wantStdout: []string{
+							`# Autocomplete setup for the "abc" command.`,
+							"# Add `source <path-to-this-file>` to your nushell config to enable it.",
+							`def "nu-complete abc" [context: string] {`,
+							fmt.Sprintf(`  ^%q autocomplete "0" ($context | encode utf8 | bytes length) $context | lines`, fakeGoExecutableFilePath.Name()),
+							`}`,
+							``,
+							`extern "abc" [...args: string@"nu-complete abc"]`,
+						},
+					},
+					osWindows: {
+						wantStdout: []string{
+							`# Autocomplete setup for the "abc" command.`,
+							"# Add `source <path-to-this-file>` to your nushell config to enable it.",
+							`def "nu-complete abc" [context: string] {`,
+							fmt.Sprintf(`  ^%q autocomplete "0" ($context | encode utf8 | bytes length) $context | lines`, fakeGoExecutableFilePath.Name()),
+							`}`,
+							``,
+							`extern "abc" [...args: string@"nu-complete abc"]`,
+						},
+					},
+				},
+			},
+			{
+				name:          "generates runCLI bash autocomplete setup regardless of os",
+				cliTargetName: "leepFrogSource",
+				args:          []string{"generate-autocomplete-setup", "--alias", "abc", "--shell", "bash"},
+				runCLI:        true,
+				clis: []CLI{
+					&testCLI{name: "basic"},
+				},
+				osChecks: map[string]*osCheck{
+					osLinux: {
+						wantStdout: []string{
+							`#!/bin/bash`,
+							`function _RunCLI_abc_autocomplete_wrap_function {`,
+							`function _custom_autocomplete_RunCLIabc {`,
+							`  local tFile=$(mktemp)`,
+							fmt.Sprintf(`  %s autocomplete  "$COMP_TYPE" $COMP_POINT "$COMP_LINE" > $tFile`, fakeGoExecutableFilePath.Name()),
+							`  local IFS=$'\n'`,
+							`  COMPREPLY=( $(cat $tFile) )`,
+							`  rm $tFile`,
+							`}`,
+							``,
+							`{ { type complete > /dev/null 2>&1 ; } && complete -F _custom_autocomplete_RunCLIabc -o nosort abc ; } || { echo 'shell function "complete" either failed or does not exist; if using zsh, be sure to set up bashcompinit' 1>&2 ; }`,
+							`}`,
+							`_RunCLI_abc_autocomplete_wrap_function`,
+							``,
+						},
+					},
+					osWindows: {
+						wantStdout: []string{
+							`#!/bin/bash`,
+							`function _RunCLI_abc_autocomplete_wrap_function {`,
+							`function _custom_autocomplete_RunCLIabc {`,
+							`  local tFile=$(mktemp)`,
+							fmt.Sprintf(`  %s autocomplete  "$COMP_TYPE" $COMP_POINT "$COMP_LINE" > $tFile`, fakeGoExecutableFilePath.Name()),
+							`  local IFS=$'\n'`,
+							`  COMPREPLY=( $(cat $tFile) )`,
+							`  rm $tFile`,
+							`}`,
+							``,
+							`{ { type complete > /dev/null 2>&1 ; } && complete -F _custom_autocomplete_RunCLIabc -o nosort abc ; } || { echo 'shell function "complete" either failed or does not exist; if using zsh, be sure to set up bashcompinit' 1>&2 ; }`,
+							`}`,
+							`_RunCLI_abc_autocomplete_wrap_function`,
+							``,
+						},
+					},
+				},
+			},
+			{
+				name:          "generates runCLI powershell autocomplete setup regardless of os",
+				cliTargetName: "leepFrogSource",
+				args:          []string{"generate-autocomplete-setup", "--alias", "abc", "--shell", "powershell"},
+				runCLI:        true,
+				clis: []CLI{
+					&testCLI{name: "basic"},
+				},
+				osChecks: map[string]*osCheck{
+					osLinux: {
+						wantStdout: []string{
+							`function _RunCLI_abc_autocomplete_wrap_function {`,
+							`$_custom_autocomplete_RunCLIabc = {`,
+							`  param($wordToComplete, $commandAst, $compPoint)`,
+							`  $Local:tmpPassthroughArgFile = New-TemporaryFile`,
+							`  [IO.File]::WriteAllText($Local:tmpPassthroughArgFile, $commandAst.ToString())`,
+							fmt.Sprintf(`  (& %s autocomplete  --comp-line-file "0" $compPoint $Local:tmpPassthroughArgFile) | ForEach-Object {`, fakeGoExecutableFilePath.Name()),
+							`    "$_"`,
+							`  }`,
+							`}`,
+							``,
+							`Register-ArgumentCompleter -CommandName abc -ScriptBlock $_custom_autocomplete_RunCLIabc`,
+							`}`,
+							`. _RunCLI_abc_autocomplete_wrap_function`,
+							``,
+						},
+					},
+					osWindows: {
+						wantStdout: []string{
+							`function _RunCLI_abc_autocomplete_wrap_function {`,
+							`$_custom_autocomplete_RunCLIabc = {`,
+							`  param($wordToComplete, $commandAst, $compPoint)`,
+							`  $Local:tmpPassthroughArgFile = New-TemporaryFile`,
+							`  [IO.File]::WriteAllText($Local:tmpPassthroughArgFile, $commandAst.ToString())`,
+							fmt.Sprintf(`  (& %s autocomplete  --comp-line-file "0" $compPoint $Local:tmpPassthroughArgFile) | ForEach-Object {`, fakeGoExecutableFilePath.Name()),
+							`    "$_"`,
+							`  }`,
+							`}`,
+							``,
+							`Register-ArgumentCompleter -CommandName abc -ScriptBlock $_custom_autocomplete_RunCLIabc`,
 							`}`,
 							`. _RunCLI_abc_autocomplete_wrap_function`,
 							``,
@@ -2839,6 +2990,30 @@ func TestGenerateBinaryNode(t *testing.T) {
 					},
 				},
 			},
+			{
+				name:          "generates runCLI autocomplete fails for unsupported shell",
+				cliTargetName: "leepFrogSource",
+				args:          []string{"generate-autocomplete-setup", "--shell", "fish"},
+				runCLI:        true,
+				clis: []CLI{
+					&testCLI{name: "basic"},
+				},
+				wantErr: fmt.Errorf(`validation for "shell" failed: [InList] argument must be one of [bash nushell powershell]`),
+				osChecks: map[string]*osCheck{
+					osLinux: {
+						wantStderr: []string{
+							`validation for "shell" failed: [InList] argument must be one of [bash nushell powershell]`,
+							``,
+						},
+					},
+					osWindows: {
+						wantStderr: []string{
+							`validation for "shell" failed: [InList] argument must be one of [bash nushell powershell]`,
+							``,
+						},
+					},
+				},
+			},
 			{
 				name:          "generates runCLI autocomplete fails if alias doesn't match regex",
 				args:          []string{"generate-autocomplete-setup", "--alias", "ab c"},
@@ -3000,6 +3175,7 @@ func TestSourcerer(t *testing.T) {
 			cacheErrs         []error
 			wantGetCacheCalls []string
 			runCLI            bool
+			opts              []Option
 			wantPanic         any
 			osCheck           *osCheck
 			osChecks          map[string]*osCheck
@@ -3186,6 +3362,201 @@ func TestSourcerer(t *testing.T) {
 					wantErr:    fmt.Errorf("oops"),
 				},
 			},
+			{
+				name:          "applies error formatter to returned errors",
+				cliTargetName: "leepFrogSource",
+				env: map[string]string{
+					RootDirectoryEnvVar: "cli-output-dir",
+				},
+				opts: []Option{
+					WithErrorFormatter(func(err error) string {
+						return color.Apply(fmt.Sprintf("ERROR: %v", err), color.Red)
+					}),
+				},
+				clis: []CLI{
+					&testCLI{
+						name: "basic",
+						f: func(tc *testCLI, i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
+							return o.Err(fmt.Errorf("oops"))
+						},
+					},
+				},
+				args:              []string{"execute", "basic", fakeFile},
+				wantGetCacheCalls: []string{testutil.FilepathAbs(t, "cli-output-dir", "cache")},
+				osCheck: &osCheck{
+					wantStderr: []string{color.Apply("ERROR: oops", color.Red)},
+					wantErr:    fmt.Errorf("oops"),
+				},
+			},
+			{
+				name:          "applies error formatter to returned errors that weren't written",
+				cliTargetName: "leepFrogSource",
+				env: map[string]string{
+					RootDirectoryEnvVar: "cli-output-dir",
+				},
+				opts: []Option{
+					WithErrorFormatter(func(err error) string {
+						return fmt.Sprintf("ERROR: %v", err)
+					}),
+				},
+				clis: []CLI{
+					&testCLI{
+						name: "basic",
+						f: func(tc *testCLI, i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
+							return fmt.Errorf("oops")
+						},
+					},
+				},
+				args:              []string{"execute", "basic", fakeFile},
+				wantGetCacheCalls: []string{testutil.FilepathAbs(t, "cli-output-dir", "cache")},
+				osCheck: &osCheck{
+					wantStderr: []string{"ERROR: oops"},
+					wantErr:    fmt.Errorf("oops"),
+				},
+			},
+			{
+				name:          "applies error formatter to returned executor errors",
+				cliTargetName: "leepFrogSource",
+				env: map[string]string{
+					RootDirectoryEnvVar: "cli-output-dir",
+				},
+				opts: []Option{
+					WithErrorFormatter(func(err error) string {
+						return fmt.Sprintf("ERROR: %v", err)
+					}),
+				},
+				clis: []CLI{
+					&testCLI{
+						name: "basic",
+						f: func(tc *testCLI, i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
+							ed.Executor = append(ed.Executor, func(o command.Output, d *command.Data) error {
+								return fmt.Errorf("oops")
+							})
+							return nil
+						},
+					},
+				},
+				args:              []string{"execute", "basic", fakeFile},
+				wantGetCacheCalls: []string{testutil.FilepathAbs(t, "cli-output-dir", "cache")},
+				osCheck: &osCheck{
+					wantStderr: []string{"ERROR: oops"},
+					wantErr:    fmt.Errorf("oops"),
+				},
+			},
+			{
+				name:          "error formatter doesn't rewrite errors written with Stderr functions",
+				cliTargetName: "leepFrogSource",
+				env: map[string]string{
+					RootDirectoryEnvVar: "cli-output-dir",
+				},
+				opts: []Option{
+					WithErrorFormatter(func(err error) string {
+						return fmt.Sprintf("ERROR: %v", err)
+					}),
+				},
+				clis: []CLI{
+					&testCLI{
+						name: "basic",
+						f: func(tc *testCLI, i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
+							return o.Stderrln("oops")
+						},
+					},
+				},
+				args:              []string{"execute", "basic", fakeFile},
+				wantGetCacheCalls: []string{testutil.FilepathAbs(t, "cli-output-dir", "cache")},
+				osCheck: &osCheck{
+					wantStderr: []string{"oops"},
+					wantErr:    fmt.Errorf("oops"),
+				},
+			},
+			{
+				name:          "applies error formatter to annotated errors",
+				cliTargetName: "leepFrogSource",
+				env: map[string]string{
+					RootDirectoryEnvVar: "cli-output-dir",
+				},
+				opts: []Option{
+					WithErrorFormatter(func(err error) string {
+						return fmt.Sprintf("ERROR: %v", err)
+					}),
+				},
+				clis: []CLI{
+					&testCLI{
+						name: "basic",
+						f: func(tc *testCLI, i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
+							return o.Annotatef(fmt.Errorf("oops"), "failed to do %s", "something")
+						},
+					},
+				},
+				args:              []string{"execute", "basic", fakeFile},
+				wantGetCacheCalls: []string{testutil.FilepathAbs(t, "cli-output-dir", "cache")},
+				osCheck: &osCheck{
+					wantStderr: []string{"ERROR: failed to do something: oops"},
+					wantErr:    fmt.Errorf("failed to do something: oops"),
+				},
+			},
+			{
+				name:          "error formatter respects NO_COLOR",
+				cliTargetName: "leepFrogSource",
+				env: map[string]string{
+					RootDirectoryEnvVar: "cli-output-dir",
+					"NO_COLOR":          "1",
+				},
+				opts: []Option{
+					WithErrorFormatter(func(err error) string {
+						return color.Apply(fmt.Sprintf("ERROR: %v", err), color.Red, color.Bold)
+					}),
+				},
+				clis: []CLI{
+					&testCLI{
+						name: "basic",
+						f: func(tc *testCLI, i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
+							return o.Err(fmt.Errorf("oops"))
+						},
+					},
+				},
+				args:              []string{"execute", "basic", fakeFile},
+				wantGetCacheCalls: []string{testutil.FilepathAbs(t, "cli-output-dir", "cache")},
+				osCheck: &osCheck{
+					wantStderr: []string{"ERROR: oops"},
+					wantErr:    fmt.Errorf("oops"),
+				},
+			},
+			{
+				name:          "applies error formatter to usage errors",
+				cliTargetName: "leepFrogSource",
+				env: map[string]string{
+					RootDirectoryEnvVar: "cli-output-dir",
+				},
+				opts: []Option{
+					WithErrorFormatter(func(err error) string {
+						return fmt.Sprintf("ERROR: %v", err)
+					}),
+				},
+				clis: []CLI{
+					&testCLI{
+						name:       "basic",
+						processors: []command.Processor{commander.ListArg[string]("SL", "test", 1, 1)},
+					},
+				},
+				args:              []string{"execute", "basic", fakeFile, "un", "deux", "trois"},
+				wantGetCacheCalls: []string{testutil.FilepathAbs(t, "cli-output-dir", "cache")},
+				osCheck: &osCheck{
+					wantStderr: []string{
+						"ERROR: Unprocessed extra args: [trois]",
+						strings.Join([]string{
+							usagePrefixString,
+							"SL [ SL ]",
+							"",
+							"Arguments:",
+							"  SL: test",
+							"",
+						}, "\n"),
+					},
+					wantErr:         fmt.Errorf("Unprocessed extra args: [trois]"),
+					noStderrNewline: true,
+				},
+			},
 			{
 				name:          "properly passes arguments to CLI",
 				cliTargetName: "leepFrogSource",
@@ -3461,6 +3832,112 @@ func TestSourcerer(t *testing.T) {
 					},
 				},
 			},
+			{
+				name:          "shows execute data instead of writing it to file",
+				cliTargetName: "leepFrogSource",
+				env: map[string]string{
+					RootDirectoryEnvVar: "cli-output-dir",
+				},
+				clis: []CLI{
+					&testCLI{
+						name: "basic",
+						processors: []command.Processor{
+							commander.SetEnvVarProcessor("FOO", "bar"),
+						},
+						f: func(tc *testCLI, i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
+							ed.Executable = append(ed.Executable, "echo hello")
+							return nil
+						},
+					},
+				},
+				args:              []string{"execute", "basic", f.Name(), "--show-executable"},
+				wantGetCacheCalls: []string{testutil.FilepathAbs(t, "cli-output-dir", "cache")},
+				osChecks: map[string]*osCheck{
+					osLinux: {
+						wantStderr: []string{
+							"[show-executable] FunctionWrap: false",
+							"[show-executable] Executable:",
+							`  export "FOO"="bar"`,
+							"  echo hello",
+						},
+					},
+					osWindows: {
+						wantStderr: []string{
+							"[show-executable] FunctionWrap: false",
+							"[show-executable] Executable:",
+							`  $env:FOO = "bar"`,
+							"  echo hello",
+						},
+					},
+				},
+			},
+			{
+				name:          "shows function wrapped execute data instead of writing it to file",
+				cliTargetName: "leepFrogSource",
+				env: map[string]string{
+					RootDirectoryEnvVar: "cli-output-dir",
+				},
+				clis: []CLI{
+					&testCLI{
+						name: "basic",
+						processors: []command.Processor{
+							commander.SetEnvVarProcessor("FOO", "bar"),
+						},
+						f: func(tc *testCLI, i *command.Input, o command.Output, d *command.Data, ed *command.ExecuteData) error {
+							ed.Executable = append(ed.Executable, "echo hello")
+							ed.FunctionWrap = true
+							return nil
+						},
+					},
+				},
+				args:              []string{"execute", "basic", f.Name(), "--show-executable"},
+				wantGetCacheCalls: []string{testutil.FilepathAbs(t, "cli-output-dir", "cache")},
+				uuids:             []string{"some-uuid"},
+				osChecks: map[string]*osCheck{
+					osLinux: {
+						wantStderr: []string{
+							"[show-executable] FunctionWrap: true",
+							"[show-executable] Executable:",
+							`  #!/bin/bash`,
+							"  function _leep_execute_data_function_wrap_some_uuid {",
+							`  export "FOO"="bar"`,
+							"  echo hello",
+							`  }`,
+							"  _leep_execute_data_function_wrap_some_uuid",
+						},
+					},
+					osWindows: {
+						wantStderr: []string{
+							"[show-executable] FunctionWrap: true",
+							"[show-executable] Executable:",
+							"  function _leep_execute_data_function_wrap_some_uuid {",
+							`  $env:FOO = "bar"`,
+							"  echo hello",
+							`  }`,
+							"  . _leep_execute_data_function_wrap_some_uuid",
+						},
+					},
+				},
+			},
+			{
+				name:          "shows when no execute data is generated",
+				cliTargetName: "leepFrogSource",
+				env: map[string]string{
+					RootDirectoryEnvVar: "cli-output-dir",
+				},
+				clis: []CLI{
+					&testCLI{
+						name: "basic",
+					},
+				},
+				args:              []string{"execute", "basic", f.Name(), "--show-executable"},
+				wantGetCacheCalls: []string{testutil.FilepathAbs(t, "cli-output-dir", "cache")},
+				osCheck: &osCheck{
+					wantStderr: []string{
+						"[show-executable] No executable generated",
+					},
+				},
+			},
 			// Execute with usage tests
 			{
 				name:          "Execute shows usage if help flag included with no other arguments",
@@ -5501,7 +5978,7 @@ func TestSourcerer(t *testing.T) {
 				// Run source command
 				o := commandtest.NewOutput()
 				err = testutil.CmpPanic(t, "source()", func() error {
-					return source(test.runCLI, test.cliTargetName, test.clis, fakeGoExecutableFilePath.Name(), test.args, o)
+					return source(test.runCLI, test.cliTargetName, test.clis, fakeGoExecutableFilePath.Name(), test.args, o, test.opts...)
 				}, test.wantPanic)
 				testutil.CmpError(t, fmt.Sprintf("source(%v)", test.args), oschk.wantErr, err)
 				o.Close()
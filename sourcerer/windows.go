@@ -78,7 +78,7 @@ func (w *windows) RegisterRunCLIAutocomplete(goExecutable, alias string) []strin
 	targetName := fmt.Sprintf("RunCLI%s", alias)
 	return []string{
 		w.autocompleteFunction(true, false, goExecutable, targetName),
-		w.registerArgumentCompleter(false, targetName, alias),
+		w.registerArgumentCompleter(false, alias, targetName),
 	}
 }
 
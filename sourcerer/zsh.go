@@ -0,0 +1,42 @@
+package sourcerer
+
+import (
+	"fmt"
+)
+
+func zshAutocompleteFunctionName(targetName string) string {
+	return fmt.Sprintf("_custom_zsh_autocomplete_%s", targetName)
+}
+
+// zshAutocompleteFunction generates a zsh completion function that passes the
+// command line (up to and including the current word) to the binary's
+// autocomplete branch, just like the bash completion function does.
+func zshAutocompleteFunction(builtin bool, goExecutable, targetName string) []string {
+	branchStr := (&linux{}).getBranchString(builtin, AutocompleteBranchName)
+	return []string{
+		fmt.Sprintf("function %s {", zshAutocompleteFunctionName(targetName)),
+		`  local -a cliWords=( "${(@)words[1,CURRENT]}" )`,
+		`  # If the alias was expanded (i.e. the complete_aliases option isn't set),`,
+		`  # then drop the execute function so the words start with the alias.`,
+		fmt.Sprintf(`  if [[ "${cliWords[1]}" == "_custom_execute_%s" ]]; then`, targetName),
+		`    cliWords=( "${(@)cliWords[2,-1]}" )`,
+		`  fi`,
+		`  local compLine="${(j: :)cliWords}"`,
+		`  local tFile=$(mktemp)`,
+		fmt.Sprintf(`  %s %s "${cliWords[1]}" "0" ${#compLine} "$compLine" > $tFile`, goExecutable, branchStr),
+		`  local -a suggestions=( ${(f)"$(cat $tFile)"} )`,
+		`  rm $tFile`,
+		// -U: suggestions are already filtered by the binary
+		// -Q: suggestions are already escaped by the binary
+		// -V: suggestions are already sorted by the binary
+		fmt.Sprintf(`  compadd -U -Q -V %s -- "${suggestions[@]}"`, targetName),
+		"}",
+		"",
+	}
+}
+
+func zshAutocompleteRegistration(targetName, alias string) string {
+	// Register the execute function as well in case the alias is expanded
+	// before completion (which is zsh's default behavior).
+	return fmt.Sprintf(`{ { type compdef > /dev/null 2>&1 ; } && compdef %s %s _custom_execute_%s ; } || { echo 'shell function "compdef" either failed or does not exist; be sure to run compinit first' 1>&2 ; }`, zshAutocompleteFunctionName(targetName), alias, targetName)
+}
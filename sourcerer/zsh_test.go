@@ -0,0 +1,127 @@
+package sourcerer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/leep-frog/command/command"
+	"github.com/leep-frog/command/internal/testutil"
+)
+
+func TestZshRegisterCLIs(t *testing.T) {
+	testutil.StubValue(t, &CurrentOS, Linux())
+	for _, test := range []struct {
+		name    string
+		builtin bool
+		want    []string
+	}{
+		{
+			name: "generates zsh completion setup",
+			want: []string{
+				`function _custom_execute_myCLIs {`,
+				`  # tmpFile is the file to which we write ExecuteData.Executable`,
+				`  local tmpFile=$(mktemp)`,
+				``,
+				`  # Run the go-only code`,
+				`  /bin/my-clis execute "$1" $tmpFile "${@:2}"`,
+				`  # Return the error code if go code terminated with an error`,
+				`  local errorCode=$?`,
+				`  if [ $errorCode -ne 0 ]; then return $errorCode; fi`,
+				``,
+				`  # Otherwise, run the ExecuteData.Executable data`,
+				`  source $tmpFile`,
+				`  local errorCode=$?`,
+				`  if [ -z "$COMMAND_CLI_DEBUG" ]; then`,
+				`    rm $tmpFile`,
+				`  else`,
+				`    echo $tmpFile`,
+				`  fi`,
+				`  return $errorCode`,
+				`}`,
+				``,
+				`function _custom_zsh_autocomplete_myCLIs {`,
+				`  local -a cliWords=( "${(@)words[1,CURRENT]}" )`,
+				`  # If the alias was expanded (i.e. the complete_aliases option isn't set),`,
+				`  # then drop the execute function so the words start with the alias.`,
+				`  if [[ "${cliWords[1]}" == "_custom_execute_myCLIs" ]]; then`,
+				`    cliWords=( "${(@)cliWords[2,-1]}" )`,
+				`  fi`,
+				`  local compLine="${(j: :)cliWords}"`,
+				`  local tFile=$(mktemp)`,
+				`  /bin/my-clis autocomplete "${cliWords[1]}" "0" ${#compLine} "$compLine" > $tFile`,
+				`  local -a suggestions=( ${(f)"$(cat $tFile)"} )`,
+				`  rm $tFile`,
+				`  compadd -U -Q -V myCLIs -- "${suggestions[@]}"`,
+				`}`,
+				``,
+				`alias alpha='_custom_execute_myCLIs alpha'`,
+				`{ { type compdef > /dev/null 2>&1 ; } && compdef _custom_zsh_autocomplete_myCLIs alpha _custom_execute_myCLIs ; } || { echo 'shell function "compdef" either failed or does not exist; be sure to run compinit first' 1>&2 ; }`,
+				`function _setup_for_beta_cli {`,
+				`  echo setup`,
+				`}`,
+				``,
+				`alias beta='o=$(mktemp) && _setup_for_beta_cli > $o && _custom_execute_myCLIs beta $o'`,
+				`{ { type compdef > /dev/null 2>&1 ; } && compdef _custom_zsh_autocomplete_myCLIs beta _custom_execute_myCLIs ; } || { echo 'shell function "compdef" either failed or does not exist; be sure to run compinit first' 1>&2 ; }`,
+			},
+		},
+		{
+			name:    "generates zsh completion setup for builtin CLIs",
+			builtin: true,
+			want: []string{
+				`function _custom_execute_myCLIs {`,
+				`  # tmpFile is the file to which we write ExecuteData.Executable`,
+				`  local tmpFile=$(mktemp)`,
+				``,
+				`  # Run the go-only code`,
+				`  /bin/my-clis builtin execute "$1" $tmpFile "${@:2}"`,
+				`  # Return the error code if go code terminated with an error`,
+				`  local errorCode=$?`,
+				`  if [ $errorCode -ne 0 ]; then return $errorCode; fi`,
+				``,
+				`  # Otherwise, run the ExecuteData.Executable data`,
+				`  source $tmpFile`,
+				`  local errorCode=$?`,
+				`  if [ -z "$COMMAND_CLI_DEBUG" ]; then`,
+				`    rm $tmpFile`,
+				`  else`,
+				`    echo $tmpFile`,
+				`  fi`,
+				`  return $errorCode`,
+				`}`,
+				``,
+				`function _custom_zsh_autocomplete_myCLIs {`,
+				`  local -a cliWords=( "${(@)words[1,CURRENT]}" )`,
+				`  # If the alias was expanded (i.e. the complete_aliases option isn't set),`,
+				`  # then drop the execute function so the words start with the alias.`,
+				`  if [[ "${cliWords[1]}" == "_custom_execute_myCLIs" ]]; then`,
+				`    cliWords=( "${(@)cliWords[2,-1]}" )`,
+				`  fi`,
+				`  local compLine="${(j: :)cliWords}"`,
+				`  local tFile=$(mktemp)`,
+				`  /bin/my-clis builtin autocomplete "${cliWords[1]}" "0" ${#compLine} "$compLine" > $tFile`,
+				`  local -a suggestions=( ${(f)"$(cat $tFile)"} )`,
+				`  rm $tFile`,
+				`  compadd -U -Q -V myCLIs -- "${suggestions[@]}"`,
+				`}`,
+				``,
+				`alias alpha='_custom_execute_myCLIs alpha'`,
+				`{ { type compdef > /dev/null 2>&1 ; } && compdef _custom_zsh_autocomplete_myCLIs alpha _custom_execute_myCLIs ; } || { echo 'shell function "compdef" either failed or does not exist; be sure to run compinit first' 1>&2 ; }`,
+				`function _setup_for_beta_cli {`,
+				`  echo setup`,
+				`}`,
+				``,
+				`alias beta='o=$(mktemp) && _setup_for_beta_cli > $o && _custom_execute_myCLIs beta $o'`,
+				`{ { type compdef > /dev/null 2>&1 ; } && compdef _custom_zsh_autocomplete_myCLIs beta _custom_execute_myCLIs ; } || { echo 'shell function "compdef" either failed or does not exist; be sure to run compinit first' 1>&2 ; }`,
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			clis := []CLI{
+				&testCLI{name: "beta", setup: []string{"echo setup"}},
+				&testCLI{name: "alpha"},
+			}
+			got := registerCLIs(command.ZshShell, test.builtin, "/bin/my-clis", "myCLIs", clis)
+			testutil.Cmp(t, "registerCLIs(zsh) returned incorrect contents", strings.Join(test.want, "\n"), strings.Join(got, "\n"))
+		})
+	}
+}